@@ -0,0 +1,288 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/corpus"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var (
+	// corpusConfig is the config for corpus command
+	corpusConfig = &config.CorpusConfig{}
+	// corpusCmd represents the corpus command
+	corpusCmd = &cobra.Command{
+		Use:   "corpus",
+		Short: "persistent fingerprint corpus of known components",
+		Long:  "",
+	}
+	// corpusBuildCmd represents the corpus build command
+	corpusBuildCmd = &cobra.Command{
+		Use:   "build",
+		Short: "build a corpus from the source of a known component or a corpus manifest",
+		Long:  "",
+		Run:   runCorpusBuildCmd,
+		Example: config.APPNAME + " corpus build -c /path/to/corpus -s /path/to/zlib --purl pkg:github/madler/zlib --version 1.3.1\n" +
+			config.APPNAME + " corpus build -c /path/to/corpus --manifest /path/to/corpus.json",
+	}
+	// corpusAddCmd represents the corpus add command
+	corpusAddCmd = &cobra.Command{
+		Use:     "add",
+		Short:   "add the source of a known component to a corpus, replacing the component of the same purl",
+		Long:    "",
+		Run:     runCorpusAddCmd,
+		Example: config.APPNAME + " corpus add -c /path/to/corpus -s /path/to/libfoo --purl pkg:generic/acme/libfoo@2.0.0",
+	}
+	// corpusQueryCmd represents the corpus query command
+	corpusQueryCmd = &cobra.Command{
+		Use:   "query [fingerprint...]",
+		Short: "query a corpus for the nearest known files and snippets of fingerprints",
+		Long:  "",
+		Run:   runCorpusQueryCmd,
+		Example: config.APPNAME + " corpus query -c /path/to/corpus e18c8f6f77f60e7d\n" +
+			config.APPNAME + " corpus query -c /path/to/corpus -i source.json -f json -o matches.json",
+	}
+	// corpusStatsCmd represents the corpus stats command
+	corpusStatsCmd = &cobra.Command{
+		Use:     "stats",
+		Short:   "show the components and fingerprints of a corpus",
+		Long:    "",
+		Run:     runCorpusStatsCmd,
+		Example: config.APPNAME + " corpus stats -c /path/to/corpus",
+	}
+)
+
+// runCorpusBuildCmd is the entry of corpus build command
+func runCorpusBuildCmd(_ *cobra.Command, _ []string) {
+	if len(corpusConfig.Manifest) == 0 && len(corpusConfig.PURL) == 0 {
+		log.Fatalf("either purl with source or manifest is required")
+	}
+	if corpus.IsStore(corpusConfig.Corpus) {
+		log.Warnf("corpus exists, rebuild: %s", corpusConfig.Corpus)
+	}
+	idx := corpus.NewIndex(corpusConfig.MaxDistance)
+	if len(corpusConfig.Manifest) > 0 {
+		log.Quietf("loading corpus manifest: %s", corpusConfig.Manifest)
+		if err := idx.AddManifest(corpusConfig.Manifest); err != nil {
+			log.Fatalf("load corpus manifest error: %s", err.Error())
+		}
+	}
+	if len(corpusConfig.PURL) > 0 {
+		addCorpusSource(idx)
+	}
+	saveCorpus(idx)
+}
+
+// runCorpusAddCmd is the entry of corpus add command
+func runCorpusAddCmd(_ *cobra.Command, _ []string) {
+	log.Quietf("loading corpus: %s", corpusConfig.Corpus)
+	idx, err := corpus.Open(corpusConfig.Corpus)
+	if err != nil {
+		log.Fatalf("load corpus error: %s", err.Error())
+	}
+	addCorpusSource(idx)
+	saveCorpus(idx)
+}
+
+// addCorpusSource fingerprints the source of the component and adds it to the index
+func addCorpusSource(idx *corpus.Index) {
+	if !fingerprint.IsValidSnippetMode(corpusConfig.SnippetMode) {
+		log.Fatalf("snippet mode not supported! %s", corpusConfig.SnippetMode)
+	}
+	purl, err := componentPURL(corpusConfig.PURL, corpusConfig.Version)
+	if err != nil {
+		log.Fatalf("%s", err.Error())
+	}
+	c, err := corpus.NewComponent(purl)
+	if err != nil {
+		log.Fatalf("%s", err.Error())
+	}
+	corpusConfig.SrcPath, _ = filepath.Abs(corpusConfig.SrcPath)
+	corpusConfig.Path = corpusConfig.SrcPath
+	corpusConfig.InitIgnoreDirs()
+	log.Quietf("fingerprinting %s: %s", purl, corpusConfig.SrcPath)
+	if err = idx.AddSource(c, &corpusConfig.SourceConfig); err != nil {
+		log.Fatalf("add component error: %s", err.Error())
+	}
+}
+
+// componentPURL returns the purl with the version, the version of the purl is overridden
+func componentPURL(purl, version string) (string, error) {
+	if len(version) == 0 {
+		return purl, nil
+	}
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return "", fmt.Errorf("invalid purl %s: %w", purl, err)
+	}
+	p.Version = version
+	return p.ToString(), nil
+}
+
+// saveCorpus writes the index to the corpus directory
+func saveCorpus(idx *corpus.Index) {
+	output, _ := filepath.Abs(corpusConfig.Corpus)
+	log.Quietf("writing to corpus: %s", output)
+	if err := idx.Save(output); err != nil {
+		log.Fatalf("save corpus error: %s", err.Error())
+	}
+	log.Quietf("saved %d fingerprints of %d components", idx.Size(), len(idx.Components()))
+	log.Quietf("finish")
+}
+
+// runCorpusQueryCmd is the entry of corpus query command
+func runCorpusQueryCmd(_ *cobra.Command, args []string) {
+	if corpusConfig.Format != matchFormatTable && corpusConfig.Format != matchFormatJSON {
+		log.Fatalf("query format not supported! %s", corpusConfig.Format)
+	}
+	if len(corpusConfig.Input) == 0 && len(args) == 0 {
+		log.Fatalf("either input or fingerprints is required")
+	}
+
+	log.Quietf("loading corpus: %s", corpusConfig.Corpus)
+	idx, err := corpus.LoadIndex(corpusConfig.Corpus, corpusConfig.MaxDistance)
+	if err != nil {
+		log.Fatalf("load corpus error: %s", err.Error())
+	}
+	var report *corpus.Report
+	if len(corpusConfig.Input) > 0 {
+		log.Quietf("loading fingerprints: %s", corpusConfig.Input)
+		files, err := corpus.LoadFingerprints(corpusConfig.Input)
+		if err != nil {
+			log.Fatalf("load fingerprints error: %s", err.Error())
+		}
+		report = idx.Match(files, corpusConfig.MaxDistance, corpusConfig.Top)
+	} else {
+		report, err = idx.MatchValues(args, corpusConfig.MaxDistance, corpusConfig.Top)
+		if err != nil {
+			log.Fatalf("%s", err.Error())
+		}
+	}
+	writeMatchReport(corpusConfig.Output, report, corpusConfig.Format)
+}
+
+// runCorpusStatsCmd is the entry of corpus stats command
+func runCorpusStatsCmd(_ *cobra.Command, _ []string) {
+	if corpusConfig.Format != matchFormatTable && corpusConfig.Format != matchFormatJSON {
+		log.Fatalf("stats format not supported! %s", corpusConfig.Format)
+	}
+	idx, err := corpus.Open(corpusConfig.Corpus)
+	if err != nil {
+		log.Fatalf("load corpus error: %s", err.Error())
+	}
+	stats := idx.Stats()
+	stats.Size = corpus.StoreSize(corpusConfig.Corpus)
+
+	writer := bufio.NewWriter(os.Stdout)
+	defer func() {
+		_ = writer.Flush()
+	}()
+	if err = writeCorpusStats(writer, stats, corpusConfig.Format); err != nil {
+		log.Fatalf("write stats error: %s", err.Error())
+	}
+}
+
+// writeCorpusStats renders the totals and one row per component
+func writeCorpusStats(writer io.Writer, stats *corpus.Stats, format string) error {
+	if format == matchFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	tw := table.NewWriter()
+	tw.SetColumnConfigs([]table.ColumnConfig{
+		{Name: "Component", WidthMax: 80},
+	})
+	tw.AppendHeader(table.Row{"#", "Component", "Files", "Fingerprints", "Snippets"})
+	for i, c := range stats.Components {
+		tw.AppendRow(table.Row{i + 1, c.PURL, c.Files, c.Fingerprints, c.Snippets})
+	}
+	tw.AppendFooter(table.Row{"", "Total", stats.Files, stats.Fingerprints, stats.Snippets})
+	tw.SetCaption("%d components, max distance %d, %d blocks, %s on disk.\n",
+		len(stats.Components), stats.MaxDistance, stats.Blocks, formatBytes(stats.Size))
+	_, err := fmt.Fprintln(writer, tw.Render())
+	return err
+}
+
+// formatBytes formats the size in a human-readable unit
+func formatBytes(size int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	value := float64(size)
+	i := 0
+	for value >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d %s", size, units[i])
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + units[i]
+}
+
+func init() {
+	// add flags for corpus build and add command
+	for _, cmd := range []*cobra.Command{corpusBuildCmd, corpusAddCmd} {
+		cmd.PersistentFlags().StringVarP(&corpusConfig.SrcPath, "src", "s", "", "source directory of the known component")
+		cmd.PersistentFlags().StringVar(&corpusConfig.PURL, "purl", "", "purl of the known component")
+		cmd.PersistentFlags().StringVar(&corpusConfig.Version, "version", "", "version of the known component(override the version of purl)")
+		cmd.PersistentFlags().StringVarP(&corpusConfig.Language, "language", "l", "*", "specify language(sample: java,cpp)")
+		cmd.PersistentFlags().StringVar(&corpusConfig.SnippetMode, "snippet-mode", fingerprint.SnippetModeFunction,
+			"granularity of snippet fingerprints, one of none|window|function(function falls back to window)")
+		cmd.PersistentFlags().StringVar(&corpusConfig.CacheDir, "cache-dir", "",
+			"fingerprint cache directory(default ~/sbom-tool/cache/fingerprint)")
+		cmd.PersistentFlags().BoolVar(&corpusConfig.NoCache, "no-cache", false, "fingerprint all files without the cache")
+		cmd.PersistentFlags().StringVar(&corpusConfig.IgnoreDirs, "ignore-dirs", "",
+			"dirs to ignore, skip all dot dirs, split by comma. sample: node_modules,logs")
+		cmd.PersistentFlags().IntVarP(&corpusConfig.Parallelism, "parallelism", "m", config.DefaultParallelism,
+			"number of parallelism")
+	}
+	corpusBuildCmd.PersistentFlags().StringVar(&corpusConfig.Manifest, "manifest", "", "corpus manifest of known components to import")
+	corpusBuildCmd.PersistentFlags().IntVarP(&corpusConfig.MaxDistance, "max-distance", "d", corpus.DefaultMaxDistance,
+		"max hamming distance the corpus is partitioned for(0-31, more blocks are searched for a larger distance)")
+	corpusBuildCmd.MarkFlagsRequiredTogether("src", "purl")
+	_ = corpusAddCmd.MarkPersistentFlagRequired("src")
+	_ = corpusAddCmd.MarkPersistentFlagRequired("purl")
+
+	// add flags for corpus query command
+	corpusQueryCmd.PersistentFlags().StringVarP(&corpusConfig.Input, "input", "i", "",
+		"input fingerprints(output of source command, fingerprint.json, or a sbom document with source)")
+	corpusQueryCmd.PersistentFlags().IntVarP(&corpusConfig.MaxDistance, "max-distance", "d", corpus.DefaultMaxDistance,
+		"max hamming distance of matched fingerprints")
+	corpusQueryCmd.PersistentFlags().IntVar(&corpusConfig.Top, "top", corpus.DefaultTop,
+		"number of the nearest matches reported for each file or snippet")
+	corpusQueryCmd.PersistentFlags().StringVarP(&corpusConfig.Format, "format", "f", matchFormatTable, "output format(table|json)")
+	corpusQueryCmd.PersistentFlags().StringVarP(&corpusConfig.Output, "output", "o", "", "output file(empty for only output to console)")
+
+	corpusStatsCmd.PersistentFlags().StringVarP(&corpusConfig.Format, "format", "f", matchFormatTable, "output format(table|json)")
+
+	corpusCmd.PersistentFlags().StringVarP(&corpusConfig.Corpus, "corpus", "c", "", "corpus directory")
+	_ = corpusCmd.MarkPersistentFlagRequired("corpus")
+
+	corpusCmd.AddCommand(corpusBuildCmd)
+	corpusCmd.AddCommand(corpusAddCmd)
+	corpusCmd.AddCommand(corpusQueryCmd)
+	corpusCmd.AddCommand(corpusStatsCmd)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	diffFormatTable    = "table"
+	diffFormatJSON     = "json"
+	diffFormatMarkdown = "markdown"
+)
+
+var (
+	// diffConfig is the config for diff command
+	diffConfig = &config.DiffConfig{}
+	// diffCmd represents the diff command
+	diffCmd = &cobra.Command{
+		Use:     "diff",
+		Short:   "compare two sbom documents",
+		Long:    "",
+		Run:     runDiffCmd,
+		Example: config.APPNAME + " diff -b /path/to/base-sbom -t /path/to/target-sbom -f markdown -o diff.md --fail-on added",
+	}
+)
+
+func runDiffCmd(_ *cobra.Command, _ []string) {
+	if !util.SliceContains([]string{diffFormatTable, diffFormatJSON, diffFormatMarkdown}, diffConfig.Format) {
+		log.Fatalf("diff format not supported! %s", diffConfig.Format)
+	}
+	failOn := parseFailOn(diffConfig.FailOn)
+
+	log.Quietf("loading base document: %s", diffConfig.Base)
+	base, err := sbom.LoadSBOM(diffConfig.Base)
+	if err != nil {
+		log.Fatalf("load base document error: %s", err.Error())
+	}
+	log.Quietf("loading target document: %s", diffConfig.Target)
+	target, err := sbom.LoadSBOM(diffConfig.Target)
+	if err != nil {
+		log.Fatalf("load target document error: %s", err.Error())
+	}
+	diff := sbom.DiffSBOM(base, target)
+
+	writeDiffResult(diffConfig.Output, diff, diffConfig.Format)
+
+	for _, kind := range failOn {
+		if count := diff.Count(kind); count > 0 {
+			log.Quietf("found %d %s changes", count, kind)
+			os.Exit(diffConfig.ExitCode)
+		}
+	}
+}
+
+// parseFailOn returns the change kinds split by comma, "*" for all kinds
+func parseFailOn(failOn string) []sbom.ChangeKind {
+	failOn = strings.TrimSpace(failOn)
+	if failOn == "*" {
+		return sbom.AllChangeKinds()
+	}
+	kinds := make([]sbom.ChangeKind, 0)
+	for _, kind := range strings.Split(failOn, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		if !util.SliceContains(sbom.AllChangeKinds(), kind) {
+			log.Fatalf("change kind not supported! %s", kind)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+func writeDiffResult(output string, diff *sbom.SBOMDiff, format string) {
+	if len(output) == 0 {
+		if err := writeDiff(os.Stdout, diff, format); err != nil {
+			log.Fatalf("write diff error: %s", err.Error())
+		}
+		return
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	if err = writeDiff(file, diff, format); err != nil {
+		log.Fatalf("save file error: %s", output)
+	}
+	log.Quietf("finish")
+}
+
+func writeDiff(writer io.Writer, diff *sbom.SBOMDiff, format string) error {
+	if format == diffFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+
+	tw := table.NewWriter()
+	tw.SetColumnConfigs([]table.ColumnConfig{
+		{Name: "#", WidthMax: 20},
+		{Name: "Change", WidthMax: 20},
+		{Name: "Name", WidthMax: 80},
+		{Name: "Old", WidthMax: 70},
+		{Name: "New", WidthMax: 70},
+	})
+	tw.AppendHeader(table.Row{"#", "Change", "Name", "Old", "New"})
+	rows := diffRows(diff)
+	for i, row := range rows {
+		tw.AppendRow(append(table.Row{i + 1}, row...))
+	}
+	var err error
+	if format == diffFormatMarkdown {
+		_, err = fmt.Fprintln(writer, tw.RenderMarkdown())
+	} else {
+		tw.SetCaption("Found %d changes.\n", len(rows))
+		_, err = fmt.Fprintln(writer, tw.Render())
+	}
+	return err
+}
+
+// diffRows returns the changes as rows of change, name, old value and new value
+func diffRows(diff *sbom.SBOMDiff) []table.Row {
+	rows := make([]table.Row, 0)
+	for _, c := range diff.Packages {
+		rows = append(rows, table.Row{"package " + c.Kind, c.PURL, c.OldVersion, c.NewVersion})
+	}
+	for _, c := range diff.Licenses {
+		rows = append(rows, table.Row{"license " + c.Field, c.PURL, strings.Join(c.Old, " "), strings.Join(c.New, " ")})
+	}
+	for _, c := range diff.Suppliers {
+		rows = append(rows, table.Row{"supplier", c.PURL, c.Old, c.New})
+	}
+	for _, c := range diff.Dependencies {
+		rows = append(rows, table.Row{"dependency " + c.Kind, c.From + " -> " + c.To, "", ""})
+	}
+	for _, c := range diff.Files {
+		rows = append(rows, table.Row{"file " + c.Kind, c.Name, c.OldChecksum, c.NewChecksum})
+	}
+	return rows
+}
+
+func init() {
+	// add flags for diff command
+	diffCmd.PersistentFlags().StringVarP(&diffConfig.Base, "base", "b", "", "base sbom document")
+	diffCmd.PersistentFlags().StringVarP(&diffConfig.Target, "target", "t", "", "target sbom document")
+	diffCmd.PersistentFlags().StringVarP(&diffConfig.Format, "format", "f", diffFormatTable,
+		"output format(one of table|json|markdown)")
+	diffCmd.PersistentFlags().StringVarP(&diffConfig.Output, "output", "o", "", "output file(empty for only output to console)")
+	diffCmd.PersistentFlags().StringVar(&diffConfig.FailOn, "fail-on", "",
+		"exit with the exit code if any change of the kinds is found, split by comma, * for all kinds"+
+			"(sample: added,upgraded,license,supplier,dependency,file)")
+	diffCmd.PersistentFlags().IntVar(&diffConfig.ExitCode, "exit-code", 1, "exit code when changes of the fail-on kinds are found")
+
+	_ = diffCmd.MarkPersistentFlagRequired("base")
+	_ = diffCmd.MarkPersistentFlagRequired("target")
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
@@ -36,7 +38,8 @@ var (
 		Example: config.APPNAME +
 			" generate -m 4 -p /path/to/project -s /path/to/source -d /path/to/dist " +
 			" -l java -o sbom.spdx.json -f spdx-json --ignore-dirs .git  " +
-			" -n app -v 1.0 -u company -b https://example.com/sbom/xxx",
+			" -n app -v 1.0 -u company -b https://example.com/sbom/xxx\n" +
+			config.APPNAME + " generate --image docker-archive:/path/to/image.tar -n app -v 1.0 -u company -b https://example.com/sbom/xxx",
 		PreRun: func(cmd *cobra.Command, args []string) {
 			// set parallelism
 			generateConfig.SourceConfig.Parallelism = generateConfig.Parallelism
@@ -53,12 +56,30 @@ var (
 )
 
 // runGenerateCmd is the entry of generate command
-func runGenerateCmd(_ *cobra.Command, _ []string) {
+func runGenerateCmd(cmd *cobra.Command, _ []string) {
 	format := spec.GetFormat(generateConfig.Format)
 	if format == nil {
 		log.Warnf("supported formats:", strings.Join(spec.AllFormatNames(), ","))
 		log.Fatalf("format not supported! %s\n", generateConfig.Format)
 	}
+	if !fingerprint.IsValidSnippetMode(generateConfig.SnippetMode) {
+		log.Fatalf("snippet mode not supported! %s", generateConfig.SnippetMode)
+	}
+	if _, err := pckg.GetScopes(generateConfig.Scopes); err != nil {
+		log.Fatalf("%s", err.Error())
+	}
+	if len(generateConfig.Image) > 0 {
+		// the image is the artifact, the source is collected only if the project is given
+		if !cmd.Flags().Changed("path") && !cmd.Flags().Changed("src") {
+			generateConfig.SkipPhases += "," + sbom.SourcePhase
+		}
+	} else {
+		for _, name := range []string{"path", "dist"} {
+			if !cmd.Flags().Changed(name) {
+				log.Fatalf("required flag(s) \"%s\" not set", name)
+			}
+		}
+	}
 	if len(generateConfig.Path) == 0 && len(generateConfig.SrcPath) == 0 {
 		log.Fatalf("project root and source path is blank")
 	}
@@ -70,14 +91,19 @@ func runGenerateCmd(_ *cobra.Command, _ []string) {
 		log.Warnf("source path is blank, use project root: %s", sourceConfig.Path)
 		sourceConfig.SrcPath = sourceConfig.Path
 	}
-	if len(generateConfig.DistPath) == 0 {
-		log.Fatalf("distribution path is blank")
+	if len(generateConfig.Image) == 0 {
+		if len(generateConfig.DistPath) == 0 {
+			log.Fatalf("distribution path is blank")
+		}
+		if _, err := os.Stat(generateConfig.DistPath); err != nil {
+			log.Fatalf("distribution path is invalid")
+		}
 	}
-	_, err := os.Stat(generateConfig.DistPath)
-	if err != nil {
-		log.Fatalf("distribution path is invalid")
+	if len(generateConfig.Image) > 0 {
+		log.Quietf("generating sbom(%s): %s", generateConfig.Format, generateConfig.Image)
+	} else {
+		log.Quietf("generating sbom(%s): %s", generateConfig.Format, generateConfig.Path)
 	}
-	log.Quietf("generating sbom(%s): %s", generateConfig.Format, generateConfig.Path)
 	newSBOM, err := sbom.GenerateSBOM(generateConfig)
 	if err != nil {
 		log.Fatalf("generate sbom error: %s", err.Error())
@@ -113,7 +139,22 @@ func init() {
 		"project source directory(use project root if empty)")
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.Language, "language", "l", "*",
 		"specify language(sample: java,cpp)")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.SnippetMode, "snippet-mode", fingerprint.SnippetModeNone,
+		"granularity of snippet fingerprints, one of none|window|function(function falls back to window)")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.CacheDir, "cache-dir", "",
+		"fingerprint cache directory(default ~/sbom-tool/cache/fingerprint)")
+	generateCmd.PersistentFlags().BoolVar(&generateConfig.NoCache, "no-cache", false, "fingerprint all files without the cache")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.ChangedSince, "changed-since", "",
+		"only fingerprint the files changed since the git revision(e.g. origin/main)")
+	generateCmd.PersistentFlags().BoolVar(&generateConfig.AnonymizeCommitter, "anonymize-committer", false,
+		"record a hash of the email instead of the committer of the source revision")
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.Collectors, "collectors", "c", "*", "enable package collectors")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.Scopes, "scopes", "*",
+		"package scopes to keep, split by comma(one of runtime|dev|test|optional|provided)")
+	generateCmd.PersistentFlags().BoolVar(&generateConfig.Installed, "installed", false,
+		"scan the installed packages(site-packages, node_modules, vendor, gem home, os package databases) instead of the manifests")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.Image, "image", "",
+		"scan a local image instead of the dist, one of oci-dir:/path|oci-archive:/path/img.tar|docker-archive:/path/img.tar")
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.SkipPhases, "skip", "", "", "skip some phases.(one of source|package|artifact)")
 	generateCmd.PersistentFlags().StringVar(&generateConfig.SourceConfig.IgnoreDirs, "ignore-src", "",
 		"dirs to ignore for source, skip all dot dirs, split by comma. sample: node_modules,logs")
@@ -132,11 +173,12 @@ func init() {
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.Path, "path", "p", ".", "project root path")
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.Format, "format", "f", "spdx-json", "sbom document format")
 	generateCmd.PersistentFlags().StringVarP(&generateConfig.Output, "output", "o", "", "output sbom file")
+	generateCmd.PersistentFlags().StringVar(&generateConfig.VulnDB, "vuln-db", "",
+		"local OSV database(a dir of OSV json files or a zip) to match vulnerabilities of packages offline")
 
 	generateCmd.PersistentFlags().BoolVarP(&generateConfig.ExtractFiles, "extract", "x", false, "extract files(only for a single zip,rpm,deb file)")
 
-	_ = generateCmd.MarkPersistentFlagRequired("path")
-	_ = generateCmd.MarkPersistentFlagRequired("dist")
+	// path and dist are required without an image, see runGenerateCmd
 	_ = generateCmd.MarkPersistentFlagRequired("name")
 	_ = generateCmd.MarkPersistentFlagRequired("version")
 	_ = generateCmd.MarkPersistentFlagRequired("supplier")
@@ -55,6 +55,9 @@ func runInfoCmd(_ *cobra.Command, _ []string) {
 	for _, c := range pckg.AllCollectors() {
 		parsers := c.GetParsers()
 		parserDescs := util.SliceMap(parsers, func(p collector.FileParser) string {
+			if collector.ProvidesGraph(p) {
+				return "[graph]" + p.Matcher().Description()
+			}
 			return p.Matcher().Description()
 		})
 		info := fmt.Sprintf("%s\n\t%s", c.GetName(), strings.Join(parserDescs, "\n\t"))
@@ -0,0 +1,168 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/corpus"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	matchFormatTable = "table"
+	matchFormatJSON  = "json"
+)
+
+var (
+	// matchConfig is the config for match command
+	matchConfig = &config.MatchConfig{}
+	// matchCmd represents the match command
+	matchCmd = &cobra.Command{
+		Use:   "match",
+		Short: "match source fingerprints against a local corpus of known components",
+		Long:  "",
+		Run:   runMatchCmd,
+		Example: config.APPNAME + " match -i source.json -c /path/to/corpus -d 3\n" +
+			config.APPNAME + " match -i sbom.xspdx.json -c /path/to/corpus.json -f xspdx-json -o sbom-matched.xspdx.json",
+	}
+)
+
+// runMatchCmd is the entry of match command
+func runMatchCmd(_ *cobra.Command, _ []string) {
+	isReport := matchConfig.Format == matchFormatTable || matchConfig.Format == matchFormatJSON
+	if !isReport && spec.GetFormat(matchConfig.Format) == nil {
+		log.Fatalf("match format not supported! %s", matchConfig.Format)
+	}
+
+	log.Quietf("loading fingerprints: %s", matchConfig.Input)
+	files, err := corpus.LoadFingerprints(matchConfig.Input)
+	if err != nil {
+		log.Fatalf("load fingerprints error: %s", err.Error())
+	}
+	log.Quietf("loading corpus: %s", matchConfig.Corpus)
+	idx, err := corpus.LoadIndex(matchConfig.Corpus, matchConfig.MaxDistance)
+	if err != nil {
+		log.Fatalf("load corpus error: %s", err.Error())
+	}
+	log.Quietf("matching %d files against %d fingerprints", len(files), idx.Size())
+	report := idx.Match(files, matchConfig.MaxDistance, matchConfig.Top)
+
+	if isReport {
+		writeMatchReport(matchConfig.Output, report, matchConfig.Format)
+		return
+	}
+	docPath := matchConfig.SBOM
+	if docPath == "" {
+		docPath = matchConfig.Input
+	}
+	doc, err := sbom.LoadSBOM(docPath)
+	if err != nil {
+		log.Fatalf("load document error(use --sbom for the document to add components): %s", err.Error())
+	}
+	added := corpus.AddComponents(doc, report)
+	log.Quietf("added %d undeclared components", len(added))
+	writeDocument(matchConfig.Output, doc, matchConfig.Format)
+}
+
+// writeMatchReport writes the match result as a table or json report
+func writeMatchReport(output string, report *corpus.Report, format string) {
+	if len(output) == 0 {
+		if err := writeMatches(os.Stdout, report, format); err != nil {
+			log.Fatalf("write matches error: %s", err.Error())
+		}
+		return
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	if err = writeMatches(file, report, format); err != nil {
+		log.Fatalf("save file error: %s", output)
+	}
+	log.Quietf("finish")
+}
+
+// writeMatches renders the matched components and the matches of each file or snippet
+func writeMatches(writer io.Writer, report *corpus.Report, format string) error {
+	if format == matchFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	ctw := table.NewWriter()
+	ctw.AppendHeader(table.Row{"#", "Component", "Files", "Snippets", "Distance"})
+	for i, c := range report.Components {
+		ctw.AppendRow(table.Row{i + 1, c.PURL, c.Files, c.Snippets, c.Distance})
+	}
+	ctw.SetCaption("Matched %d components.\n", len(report.Components))
+	if _, err := fmt.Fprintln(writer, ctw.Render()); err != nil {
+		return err
+	}
+
+	ftw := table.NewWriter()
+	ftw.SetColumnConfigs([]table.ColumnConfig{
+		{Name: "File", WidthMax: 60},
+		{Name: "Component", WidthMax: 60},
+		{Name: "Matched File", WidthMax: 60},
+	})
+	ftw.AppendHeader(table.Row{"#", "File", "Range", "Component", "Matched File", "Matched Range", "Distance"})
+	i := 0
+	for _, f := range report.Files {
+		file := f.File
+		if file == "" {
+			file = f.Fingerprint
+		}
+		for _, m := range f.Matches {
+			i++
+			ftw.AppendRow(table.Row{i, file, f.Range, m.PURL, m.File, m.Range, m.Distance})
+		}
+	}
+	ftw.SetCaption("Matched %d files and snippets.\n", len(report.Files))
+	_, err := fmt.Fprintln(writer, ftw.Render())
+	return err
+}
+
+func init() {
+	// add flags for match command
+	matchCmd.PersistentFlags().StringVarP(&matchConfig.Input, "input", "i", "",
+		"input fingerprints(output of source command, fingerprint.json, or a sbom document with source)")
+	matchCmd.PersistentFlags().StringVarP(&matchConfig.Corpus, "corpus", "c", "",
+		"corpus directory built by corpus command, or a corpus manifest listing the purls and fingerprint outputs of known components")
+	matchCmd.PersistentFlags().IntVarP(&matchConfig.MaxDistance, "max-distance", "d", corpus.DefaultMaxDistance,
+		"max hamming distance of matched fingerprints")
+	matchCmd.PersistentFlags().IntVar(&matchConfig.Top, "top", corpus.DefaultTop,
+		"number of the nearest matches reported for each file or snippet")
+	matchCmd.PersistentFlags().StringVar(&matchConfig.SBOM, "sbom", "",
+		"sbom document to add the undeclared components to(use input if empty)")
+	matchCmd.PersistentFlags().StringVarP(&matchConfig.Format, "format", "f", matchFormatTable,
+		"output format(table|json for a report, or a sbom document format to add the matched components to the document)")
+	matchCmd.PersistentFlags().StringVarP(&matchConfig.Output, "output", "o", "", "output file(empty for only output to console)")
+
+	_ = matchCmd.MarkPersistentFlagRequired("input")
+	_ = matchCmd.MarkPersistentFlagRequired("corpus")
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var (
+	// mergeConfig is the config for merge command
+	mergeConfig = &config.MergeConfig{}
+	// mergeCmd represents the merge command
+	mergeCmd = &cobra.Command{
+		Use:     "merge",
+		Short:   "merge sbom documents into one sbom document",
+		Long:    "",
+		Run:     runMergeCmd,
+		Example: config.APPNAME + " merge -i /path/to/sbom1 -i /path/to/sbom2 -n product -v 1.0.0 -u supplier -f spdx-json -o sbom.spdx.json",
+	}
+)
+
+// runMergeCmd is the entry of merge command
+func runMergeCmd(_ *cobra.Command, _ []string) {
+	if len(mergeConfig.Inputs) < 2 {
+		log.Fatalf("at least two input sbom documents are required")
+	}
+	format := spec.GetFormat(mergeConfig.Format)
+	if format == nil {
+		log.Infof("supported formats: %s", strings.Join(spec.AllFormatNames(), ","))
+		log.Fatalf("format not supported! %s", mergeConfig.Format)
+	}
+
+	docs := make([]*model.SBOM, 0, len(mergeConfig.Inputs))
+	for _, input := range mergeConfig.Inputs {
+		log.Quietf("loading document: %s", input)
+		doc, err := sbom.LoadSBOM(input)
+		if err != nil {
+			log.Fatalf("load document error: %s", err.Error())
+		}
+		docs = append(docs, doc)
+	}
+	log.Quietf("merging %d documents to %s", len(docs), mergeConfig.Format)
+	newSBOM, report, err := sbom.MergeSBOM(mergeConfig, docs)
+	if err != nil {
+		log.Fatalf("merge sbom error: %s", err.Error())
+	}
+	for _, c := range report.Conflicts {
+		log.Warnf("conflicting %s of package %s: %s", c.Field, c.PURL, strings.Join(c.Values, " | "))
+	}
+	if len(mergeConfig.Report) > 0 {
+		writeMergeReport(mergeConfig.Report, report)
+	}
+	// convert to target format from inner model
+	format.Spec().FromModel(newSBOM)
+
+	output := mergeConfig.Output
+	if len(output) == 0 {
+		output = fmt.Sprintf("sbom-%s.%s", format.Spec().Name(), format.Type())
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s\n", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	err = format.Dump(file)
+	if err != nil {
+		log.Fatalf("save file error: %s\n", output)
+	}
+	log.Quietf("finish")
+}
+
+// writeMergeReport writes the merge report as json
+func writeMergeReport(output string, report *collector.MergeReport) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("marshal merge report error: %s", err.Error())
+	}
+	output, _ = filepath.Abs(output)
+	log.Quietf("writing merge report to file: %s (%d conflicts)", output, len(report.Conflicts))
+	if err = os.WriteFile(output, data, 0644); err != nil {
+		log.Fatalf("save file error: %s\n", output)
+	}
+}
+
+func init() {
+	// add flags
+	mergeCmd.PersistentFlags().StringArrayVarP(&mergeConfig.Inputs, "input", "i", nil, "input sbom document(repeatable)")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.PackageName, "name", "n", "", "package name of the merged artifact")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.PackageVersion, "version", "v", "",
+		"package version of the merged artifact")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.PackageSupplier, "supplier", "u", "",
+		"package supplier of the merged artifact")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.NamespaceURI, "namespace", "b", "",
+		"document namespace base uri")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.Format, "format", "f", "spdx-json", "sbom document format")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.Output, "output", "o", "", "output sbom document")
+	mergeCmd.PersistentFlags().StringVarP(&mergeConfig.Report, "report", "r", "",
+		"output file of the merge report(empty for only logging the conflicts)")
+
+	_ = mergeCmd.MarkPersistentFlagRequired("input")
+	_ = mergeCmd.MarkPersistentFlagRequired("name")
+	_ = mergeCmd.MarkPersistentFlagRequired("version")
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/image"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
@@ -32,11 +33,13 @@ var (
 	packageConfig = &config.PackageConfig{Parallelism: config.DefaultParallelism}
 	// packageCmd represents the package command
 	packageCmd = &cobra.Command{
-		Use:     "package",
-		Short:   "collect package dependencies",
-		Long:    "",
-		Run:     runPackageCmd,
-		Example: config.APPNAME + " package -m 4 -p /path/to/project -c maven,npm -o package.json",
+		Use:   "package",
+		Short: "collect package dependencies",
+		Long:  "",
+		Run:   runPackageCmd,
+		Example: config.APPNAME + " package -m 4 -p /path/to/project -c maven,npm --scopes runtime,optional -o package.json\n" +
+			config.APPNAME + " package --installed -p /path/to/venv -o package.json\n" +
+			config.APPNAME + " package --image oci-archive:/path/to/image.tar -o package.json",
 		PreRun: func(cmd *cobra.Command, args []string) {
 			packageConfig.InitIgnoreDirs()
 		},
@@ -44,7 +47,17 @@ var (
 )
 
 // runPackageCmd is the entry of package command
-func runPackageCmd(_ *cobra.Command, _ []string) {
+func runPackageCmd(cmd *cobra.Command, _ []string) {
+	if _, err := pckg.GetScopes(packageConfig.Scopes); err != nil {
+		log.Fatalf("%s", err.Error())
+	}
+	if len(packageConfig.Image) > 0 {
+		runPackageImageCmd()
+		return
+	}
+	if !cmd.Flags().Changed("path") {
+		log.Fatalf("required flag(s) \"path\" not set")
+	}
 	if len(packageConfig.Path) == 0 {
 		log.Fatalf("project root path is blank")
 	}
@@ -62,7 +75,29 @@ func runPackageCmd(_ *cobra.Command, _ []string) {
 	if err != nil {
 		log.Fatalf("collect package error: %s", err.Error())
 	}
+	writePackages(packages)
+}
+
+// runPackageImageCmd collects the packages of a local image
+func runPackageImageCmd() {
+	log.Quietf("collecting package info: %s", packageConfig.Image)
+	packages, err := func() ([]model.Package, error) {
+		img, err := image.Load(packageConfig.Image)
+		if err != nil {
+			return nil, fmt.Errorf("load image error: %w", err)
+		}
+		defer func() {
+			_ = img.Close()
+		}()
+		return pckg.NewCollectorManager(packageConfig).CollectImage(img)
+	}()
+	if err != nil {
+		log.Fatalf("collect package error: %s", err.Error())
+	}
+	writePackages(packages)
+}
 
+func writePackages(packages []model.Package) {
 	output := packageConfig.Output
 
 	printPackages(packages)
@@ -72,7 +107,7 @@ func runPackageCmd(_ *cobra.Command, _ []string) {
 
 	output, _ = filepath.Abs(output)
 	log.Quietf("writing to file: %s\n", output)
-	err = util.WriteToJSONFile(output, packages)
+	err := util.WriteToJSONFile(output, packages)
 	if err != nil {
 		log.Fatalf("save file error: %s", output)
 	}
@@ -85,9 +120,15 @@ func init() {
 		"number of parallelism")
 	packageCmd.PersistentFlags().StringVarP(&packageConfig.Path, "path", "p", ".", "project root path")
 	packageCmd.PersistentFlags().StringVarP(&packageConfig.Collectors, "collectors", "c", "*", "enable package collectors")
+	packageCmd.PersistentFlags().StringVar(&packageConfig.Scopes, "scopes", "*",
+		"package scopes to keep, split by comma(one of runtime|dev|test|optional|provided)")
+	packageCmd.PersistentFlags().BoolVar(&packageConfig.Installed, "installed", false,
+		"scan the installed packages(site-packages, node_modules, vendor, gem home, os package databases) instead of the manifests")
+	packageCmd.PersistentFlags().StringVar(&packageConfig.Image, "image", "",
+		"scan a local image instead of the path, one of oci-dir:/path|oci-archive:/path/img.tar|docker-archive:/path/img.tar")
 	packageCmd.PersistentFlags().StringVarP(&packageConfig.Output, "output", "o", "", "output file(empty for only output to console)")
 
-	_ = packageCmd.MarkPersistentFlagRequired("path")
+	// path is required without an image, see runPackageCmd
 }
 
 func printPackages(pkgs []model.Package) {
@@ -98,13 +139,14 @@ func printPackages(pkgs []model.Package) {
 		{Name: "Type", WidthMax: 20},
 		{Name: "Name", WidthMax: 40},
 		{Name: "Version", WidthMax: 35},
+		{Name: "Scope", WidthMax: 10},
 		{Name: "License", WidthMax: 30},
 		{Name: "Source Location", WidthMax: 80},
 	})
 
-	writer.AppendHeader(table.Row{"#", "Type", "Name", "Version", "License", "Source Location"})
+	writer.AppendHeader(table.Row{"#", "Type", "Name", "Version", "Scope", "License", "Source Location"})
 	for i, pkg := range pkgs {
-		writer.AppendRow(table.Row{i + 1, pkg.Type, pkg.Name, pkg.Version, strings.Join(pkg.LicenseDeclared, " "), pkg.SourceLocation})
+		writer.AppendRow(table.Row{i + 1, pkg.Type, pkg.Name, pkg.Version, pkg.Scope, strings.Join(pkg.LicenseDeclared, " "), pkg.SourceLocation})
 	}
 	writer.SetCaption("Found %d packages.\n", len(pkgs))
 	fmt.Println(writer.Render())
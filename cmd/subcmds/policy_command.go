@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/policy"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	policyFormatJSON  = "json"
+	policyFormatJUnit = "junit"
+)
+
+var (
+	// policyConfig is the config for policy check command
+	policyConfig = &config.PolicyConfig{}
+	// policyCmd represents the policy command
+	policyCmd = &cobra.Command{
+		Use:   "policy",
+		Short: "license policy of sbom documents",
+		Long:  "",
+	}
+	// policyCheckCmd represents the policy check command
+	policyCheckCmd = &cobra.Command{
+		Use:     "check",
+		Short:   "check licenses of a sbom document against a license policy",
+		Long:    "",
+		Run:     runPolicyCheckCmd,
+		Example: config.APPNAME + " policy check -i /path/to/sbom -p /path/to/policy.yaml -f junit -o policy-report.xml",
+	}
+)
+
+// runPolicyCheckCmd is the entry of policy check command
+func runPolicyCheckCmd(_ *cobra.Command, _ []string) {
+	if policyConfig.Format != policyFormatJSON && policyConfig.Format != policyFormatJUnit {
+		log.Fatalf("policy report format not supported! %s", policyConfig.Format)
+	}
+
+	log.Quietf("loading policy: %s", policyConfig.Policy)
+	p, err := policy.LoadPolicy(policyConfig.Policy)
+	if err != nil {
+		log.Fatalf("load policy error: %s", err.Error())
+	}
+	log.Quietf("loading document: %s", policyConfig.Input)
+	doc, err := sbom.LoadSBOM(policyConfig.Input)
+	if err != nil {
+		log.Fatalf("load document error: %s", err.Error())
+	}
+	report := policy.Check(p, doc.Packages)
+
+	writePolicyReport(policyConfig.Output, report, policyConfig.Format)
+
+	summary := report.Summary
+	log.Quietf("checked %d packages: %d allowed, %d require review, %d denied",
+		summary.Packages, summary.Allowed, summary.Review, summary.Denied)
+	if report.Failed(policyConfig.FailOnReview) {
+		os.Exit(policyConfig.ExitCode)
+	}
+}
+
+func writePolicyReport(output string, report *policy.Report, format string) {
+	if len(output) == 0 {
+		if err := writePolicy(os.Stdout, report, format); err != nil {
+			log.Fatalf("write policy report error: %s", err.Error())
+		}
+		return
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	if err = writePolicy(file, report, format); err != nil {
+		log.Fatalf("save file error: %s", output)
+	}
+}
+
+func writePolicy(writer io.Writer, report *policy.Report, format string) error {
+	if format == policyFormatJUnit {
+		return policy.WriteJUnit(writer, report, policyConfig.FailOnReview)
+	}
+	return policy.WriteJSON(writer, report)
+}
+
+func init() {
+	// add flags for policy check command
+	policyCheckCmd.PersistentFlags().StringVarP(&policyConfig.Input, "input", "i", "", "input sbom document")
+	policyCheckCmd.PersistentFlags().StringVarP(&policyConfig.Policy, "policy", "p", "", "license policy file(yaml)")
+	policyCheckCmd.PersistentFlags().StringVarP(&policyConfig.Format, "format", "f", policyFormatJSON,
+		"report format(one of json|junit)")
+	policyCheckCmd.PersistentFlags().StringVarP(&policyConfig.Output, "output", "o", "", "output file(empty for only output to console)")
+	policyCheckCmd.PersistentFlags().BoolVar(&policyConfig.FailOnReview, "fail-on-review", false,
+		"also exit with the exit code if any package requires review")
+	policyCheckCmd.PersistentFlags().IntVar(&policyConfig.ExitCode, "exit-code", 1, "exit code when any package violates the policy")
+
+	_ = policyCheckCmd.MarkPersistentFlagRequired("input")
+	_ = policyCheckCmd.MarkPersistentFlagRequired("policy")
+
+	policyCmd.AddCommand(policyCheckCmd)
+}
@@ -53,6 +53,12 @@ func init() {
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(modifyCmd)
 	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(vulnCmd)
+	rootCmd.AddCommand(matchCmd)
+	rootCmd.AddCommand(policyCmd)
+	rootCmd.AddCommand(corpusCmd)
 }
 
 func Execute() error {
@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/source"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
@@ -42,6 +43,9 @@ func runSourceCmd(_ *cobra.Command, _ []string) {
 	if len(sourceConfig.Path) == 0 && len(sourceConfig.SrcPath) == 0 {
 		log.Fatalf("project root and source path is blank")
 	}
+	if !fingerprint.IsValidSnippetMode(sourceConfig.SnippetMode) {
+		log.Fatalf("snippet mode not supported! %s", sourceConfig.SnippetMode)
+	}
 	if len(sourceConfig.Path) == 0 {
 		log.Warnf("project root is blank, use source path: %s", sourceConfig.SrcPath)
 		sourceConfig.Path = sourceConfig.SrcPath
@@ -84,6 +88,15 @@ func init() {
 		"output mode, singlefile or multiplefile")
 	sourceCmd.PersistentFlags().StringVarP(&sourceConfig.Language, "language", "l", "*",
 		"specify language(sample: java,cpp)")
+	sourceCmd.PersistentFlags().StringVar(&sourceConfig.SnippetMode, "snippet-mode", fingerprint.SnippetModeNone,
+		"granularity of snippet fingerprints, one of none|window|function(function falls back to window)")
+	sourceCmd.PersistentFlags().StringVar(&sourceConfig.CacheDir, "cache-dir", "",
+		"fingerprint cache directory(default ~/sbom-tool/cache/fingerprint)")
+	sourceCmd.PersistentFlags().BoolVar(&sourceConfig.NoCache, "no-cache", false, "fingerprint all files without the cache")
+	sourceCmd.PersistentFlags().StringVar(&sourceConfig.ChangedSince, "changed-since", "",
+		"only fingerprint the files changed since the git revision(e.g. origin/main)")
+	sourceCmd.PersistentFlags().BoolVar(&sourceConfig.AnonymizeCommitter, "anonymize-committer", false,
+		"record a hash of the email instead of the committer of the source revision")
 
 	_ = sourceCmd.MarkPersistentFlagRequired("src")
 }
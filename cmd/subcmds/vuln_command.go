@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package subcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/sbom"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/vuln"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	vulnFormatTable = "table"
+	vulnFormatJSON  = "json"
+)
+
+var (
+	// vulnConfig is the config for vuln command
+	vulnConfig = &config.VulnConfig{}
+	// vulnCmd represents the vuln command
+	vulnCmd = &cobra.Command{
+		Use:     "vuln",
+		Short:   "match packages of a sbom document against a local OSV database",
+		Long:    "",
+		Run:     runVulnCmd,
+		Example: config.APPNAME + " vuln -i /path/to/sbom -d /path/to/osv-db.zip -f cyclonedx-json -o sbom-vuln.cdx.json",
+	}
+)
+
+// runVulnCmd is the entry of vuln command
+func runVulnCmd(_ *cobra.Command, _ []string) {
+	if vulnConfig.Format != vulnFormatTable && vulnConfig.Format != vulnFormatJSON && spec.GetFormat(vulnConfig.Format) == nil {
+		log.Fatalf("vuln format not supported! %s", vulnConfig.Format)
+	}
+
+	log.Quietf("loading document: %s", vulnConfig.Input)
+	doc, err := sbom.LoadSBOM(vulnConfig.Input)
+	if err != nil {
+		log.Fatalf("load document error: %s", err.Error())
+	}
+	log.Quietf("loading vulnerability database: %s", vulnConfig.DB)
+	db, err := vuln.LoadDB(vulnConfig.DB)
+	if err != nil {
+		log.Fatalf("load vulnerability database error: %s", err.Error())
+	}
+	log.Quietf("matching %d packages against %d advisories", len(doc.Packages), db.Size())
+	doc.Vulnerabilities = db.Match(doc.Packages)
+
+	if vulnConfig.Format == vulnFormatTable || vulnConfig.Format == vulnFormatJSON {
+		writeVulnReport(vulnConfig.Output, doc.Vulnerabilities, vulnConfig.Format)
+		return
+	}
+	writeDocument(vulnConfig.Output, doc, vulnConfig.Format)
+}
+
+// writeDocument writes the sbom document in the format
+func writeDocument(output string, doc *model.SBOM, formatName string) {
+	format := spec.GetFormat(formatName)
+	format.Spec().FromModel(doc)
+
+	if len(output) == 0 {
+		output = fmt.Sprintf("sbom-%s.%s", format.Spec().Name(), format.Type())
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s\n", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	if err = format.Dump(file); err != nil {
+		log.Fatalf("save file error: %s\n", output)
+	}
+	log.Quietf("finish")
+}
+
+// writeVulnReport writes the matched vulnerabilities as a table or json report
+func writeVulnReport(output string, vulns []model.Vulnerability, format string) {
+	if len(output) == 0 {
+		if err := writeVulns(os.Stdout, vulns, format); err != nil {
+			log.Fatalf("write vulnerabilities error: %s", err.Error())
+		}
+		return
+	}
+	output, _ = filepath.Abs(output)
+	file, err := os.Create(output)
+	if err != nil {
+		log.Fatalf("create file error: %s", output)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	log.Quietf("writing to file: %s", output)
+	if err = writeVulns(file, vulns, format); err != nil {
+		log.Fatalf("save file error: %s", output)
+	}
+	log.Quietf("finish")
+}
+
+// writeVulns renders the vulnerabilities, one row per affected package for the table format
+func writeVulns(writer io.Writer, vulns []model.Vulnerability, format string) error {
+	if format == vulnFormatJSON {
+		if vulns == nil {
+			vulns = []model.Vulnerability{}
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(vulns)
+	}
+
+	tw := table.NewWriter()
+	tw.SetColumnConfigs([]table.ColumnConfig{
+		{Name: "#", WidthMax: 20},
+		{Name: "ID", WidthMax: 30},
+		{Name: "Severity", WidthMax: 10},
+		{Name: "Package", WidthMax: 80},
+		{Name: "Fixed", WidthMax: 30},
+		{Name: "Summary", WidthMax: 60},
+	})
+	tw.AppendHeader(table.Row{"#", "ID", "Severity", "Package", "Fixed", "Summary"})
+	i := 0
+	for _, v := range vulns {
+		for _, purl := range v.Affects {
+			i++
+			tw.AppendRow(table.Row{i, v.ID, v.Severity, purl, strings.Join(v.Fixed, " "), v.Summary})
+		}
+	}
+	tw.SetCaption("Found %d vulnerabilities.\n", len(vulns))
+	_, err := fmt.Fprintln(writer, tw.Render())
+	return err
+}
+
+func init() {
+	// add flags for vuln command
+	vulnCmd.PersistentFlags().StringVarP(&vulnConfig.Input, "input", "i", "", "input sbom document")
+	vulnCmd.PersistentFlags().StringVarP(&vulnConfig.DB, "db", "d", "",
+		"local OSV database(a dir of OSV json files or a zip)")
+	vulnCmd.PersistentFlags().StringVarP(&vulnConfig.Format, "format", "f", vulnFormatTable,
+		"output format(table|json for a report, or a sbom document format to annotate the input document)")
+	vulnCmd.PersistentFlags().StringVarP(&vulnConfig.Output, "output", "o", "", "output file(empty for only output to console)")
+
+	_ = vulnCmd.MarkPersistentFlagRequired("input")
+	_ = vulnCmd.MarkPersistentFlagRequired("db")
+}
@@ -3,6 +3,7 @@ module gitee.com/JD-opensource/sbom-tool
 go 1.19
 
 require (
+	github.com/CycloneDX/cyclonedx-go v0.7.2
 	github.com/anchore/go-macholibre v0.0.0-20220308212642-53e6d0aaf6fb
 	github.com/blacktop/go-macho v1.1.174
 	github.com/cavaliergopher/rpm v1.2.0
@@ -12,6 +13,7 @@ require (
 	github.com/go-git/go-git/v5 v5.6.1
 	github.com/google/go-cmp v0.5.9
 	github.com/jedib0t/go-pretty/v6 v6.4.9
+	github.com/klauspost/compress v1.16.5
 	github.com/microsoft/go-rustaudit v0.0.0-20220808201409-204dfee52032
 	github.com/mitchellh/mapstructure v1.5.0
 	github.com/pelletier/go-toml v1.9.5
@@ -19,12 +21,13 @@ require (
 	github.com/spaolacci/murmur3 v1.1.0
 	github.com/spdx/tools-golang v0.5.0
 	github.com/spf13/cobra v1.6.1
-	github.com/stretchr/testify v1.8.3
+	github.com/stretchr/testify v1.8.4
 	github.com/tjfoc/gmsm v1.4.1
 	github.com/vifraa/gopom v0.2.1
 	github.com/yalp/jsonpath v0.0.0-20180802001716-5cc68e5049a0
 	go.uber.org/zap v1.24.0
 	golang.org/x/exp v0.0.0-20230304125523-9ff063c70017
+	golang.org/x/mod v0.8.0
 	golang.org/x/net v0.10.0
 	golang.org/x/sys v0.8.0
 	pault.ag/go/debian v0.16.0
@@ -34,7 +37,6 @@ require (
 	github.com/blacktop/go-dwarf v1.0.9 // indirect
 	github.com/go-restruct/restruct v1.2.0-alpha // indirect
 	github.com/kjk/lzma v0.0.0-20161016003348-3fd93898850d // indirect
-	github.com/klauspost/compress v1.16.5 // indirect
 	github.com/mattn/go-runewidth v0.0.13 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/xi2/xz v0.0.0-20171230120015-48954b6210f8 // indirect
@@ -38,20 +38,28 @@ type LogConfig struct {
 
 // SourceConfig is the configuration for source subcommand
 type SourceConfig struct {
-	Path          string
-	Parallelism   int
-	SrcPath       string
-	Output        string
-	Mode          string
-	Language      string
-	IgnoreDirs    string
-	ignoreDirsSet *pattern_set.PatternSet
+	Path               string
+	Parallelism        int
+	SrcPath            string
+	Output             string
+	Mode               string
+	Language           string
+	SnippetMode        string
+	CacheDir           string
+	NoCache            bool
+	ChangedSince       string
+	AnonymizeCommitter bool
+	IgnoreDirs         string
+	ignoreDirsSet      *pattern_set.PatternSet
 }
 
 // PackageConfig is the configuration for package subcommand
 type PackageConfig struct {
 	Parallelism   int
 	Collectors    string
+	Scopes        string
+	Installed     bool
+	Image         string // a local image, e.g. oci-dir:/path or docker-archive:/path/img.tar
 	Path          string
 	Output        string
 	IgnoreDirs    string
@@ -93,6 +101,7 @@ type GenerateConfig struct {
 	Path         string
 	Parallelism  int
 	Output       string
+	VulnDB       string
 }
 
 // ConvertConfig is the configuration for convert subcommand
@@ -118,6 +127,70 @@ type ModifyConfig struct {
 	Update map[string]*[]string
 }
 
+// DiffConfig is the configuration for diff subcommand
+type DiffConfig struct {
+	Base     string
+	Target   string
+	Format   string
+	Output   string
+	FailOn   string
+	ExitCode int
+}
+
+// MergeConfig is the configuration for merge subcommand
+type MergeConfig struct {
+	Inputs          []string
+	Format          string
+	Output          string
+	Report          string
+	NamespaceURI    string
+	PackageName     string
+	PackageVersion  string
+	PackageSupplier string
+}
+
+// VulnConfig is the configuration for vuln subcommand
+type VulnConfig struct {
+	Input  string
+	DB     string
+	Format string
+	Output string
+}
+
+// PolicyConfig is the configuration for policy subcommand
+type PolicyConfig struct {
+	Input        string
+	Policy       string
+	Format       string
+	Output       string
+	FailOnReview bool
+	ExitCode     int
+}
+
+// MatchConfig is the configuration for match subcommand
+type MatchConfig struct {
+	Input       string
+	Corpus      string
+	SBOM        string
+	MaxDistance int
+	Top         int
+	Format      string
+	Output      string
+}
+
+// CorpusConfig is the configuration for corpus subcommand
+type CorpusConfig struct {
+	SourceConfig
+	Corpus      string
+	PURL        string
+	Version     string
+	Manifest    string
+	MaxDistance int
+	Input       string
+	Top         int
+	Format      string
+}
+
 // DefaultParallelism is the default value of parallelism
 const DefaultParallelism = 8
 
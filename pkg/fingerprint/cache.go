@@ -0,0 +1,215 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	// DefaultCacheMaxAge is the age of the cache entries not used since then to be evicted
+	DefaultCacheMaxAge = 30 * 24 * time.Hour
+	// DefaultCacheMaxSize is the max bytes of the cache, the least recently used entries are evicted beyond it
+	DefaultCacheMaxSize int64 = 1 << 30
+	// cacheEvictInterval is the min interval between two evictions of a cache
+	cacheEvictInterval = 24 * time.Hour
+	// cacheEvictMarker is the file whose modification time is the time of the last eviction
+	cacheEvictMarker = "last-evict"
+	cacheEntrySuffix = ".json"
+)
+
+// Cache is a content-addressed cache of file fingerprints, an entry is keyed by the SHA-256 of the file content,
+// the preprocessor, the snippet mode and the algorithm version, so an unchanged file reuses the prior result
+// wherever it is located
+type Cache struct {
+	dir    string
+	hits   int64
+	misses int64
+}
+
+// DefaultCacheDir returns the default directory of the fingerprint cache
+func DefaultCacheDir() string {
+	return filepath.Join(config.UserAppHome(), "cache", "fingerprint")
+}
+
+// OpenCache opens the fingerprint cache in dir, the dir is created if not exists
+func OpenCache(dir string) (*Cache, error) {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir error: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// openCache opens the cache of the source config, nil for no cache
+func openCache(cfg *config.SourceConfig) *Cache {
+	if cfg.NoCache {
+		return nil
+	}
+	cache, err := OpenCache(cfg.CacheDir)
+	if err != nil {
+		log.Warnf("fingerprint cache disabled: %s", err.Error())
+		return nil
+	}
+	return cache
+}
+
+// Dir returns the directory of the cache
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Key returns the cache key of the file content fingerprinted by the preprocessor in the snippet mode
+func (c *Cache) Key(sha256Sum, processor, snippetMode string) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{sha256Sum, processor, snippetMode,
+		Algorithm, AlgorithmVersion}, "\x00")))
+	return hex.EncodeToString(h[:])
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+cacheEntrySuffix)
+}
+
+// Get returns the cached fingerprint of the key, the entry is marked as recently used
+func (c *Cache) Get(key string) (*model.FileFingerprint, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	fp := &model.FileFingerprint{}
+	if err = json.Unmarshal(data, fp); err != nil {
+		log.Warnf("invalid fingerprint cache entry %s: %s", path, err.Error())
+		_ = os.Remove(path)
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	atomic.AddInt64(&c.hits, 1)
+	return fp, true
+}
+
+// Put caches the fingerprint of the key, the path of the file is not cached
+func (c *Cache) Put(key string, fp *model.FileFingerprint) error {
+	entry := *fp
+	entry.File = ""
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Stats returns the number of cache hits and misses
+func (c *Cache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Evict removes the entries not used within maxAge, and then the least recently used entries until
+// the cache is not larger than maxSize, returns the number of removed entries
+func (c *Cache) Evict(maxAge time.Duration, maxSize int64) (int, error) {
+	entries := make([]cacheEntry, 0)
+	var total int64
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, cacheEntrySuffix) {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("walk cache error: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	expired := time.Now().Add(-maxAge)
+	removed := 0
+	for _, e := range entries {
+		if !e.modTime.Before(expired) && total <= maxSize {
+			break
+		}
+		if err = os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		total -= e.size
+		removed++
+	}
+	return removed, nil
+}
+
+// evictIfDue evicts the cache with the default limits at most once per cacheEvictInterval
+func (c *Cache) evictIfDue() {
+	marker := filepath.Join(c.dir, cacheEvictMarker)
+	if info, err := os.Stat(marker); err == nil && time.Since(info.ModTime()) < cacheEvictInterval {
+		return
+	}
+	if err := os.WriteFile(marker, nil, 0o644); err != nil {
+		return
+	}
+	now := time.Now()
+	_ = os.Chtimes(marker, now, now)
+	removed, err := c.Evict(DefaultCacheMaxAge, DefaultCacheMaxSize)
+	if err != nil {
+		log.Warnf("evict fingerprint cache error: %s", err.Error())
+		return
+	}
+	if removed > 0 {
+		log.Infof("evicted %d fingerprint cache entries", removed)
+	}
+}
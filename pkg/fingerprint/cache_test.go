@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/model"
+)
+
+const cacheTestSource = `package demo
+
+func Sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+`
+
+func TestCache_Key(t *testing.T) {
+	cache, err := OpenCache(t.TempDir())
+	assert.NoError(t, err)
+	key := cache.Key("abc", "golang", SnippetModeNone)
+	assert.Equal(t, key, cache.Key("abc", "golang", SnippetModeNone))
+	assert.NotEqual(t, key, cache.Key("abd", "golang", SnippetModeNone))
+	assert.NotEqual(t, key, cache.Key("abc", "java", SnippetModeNone))
+	assert.NotEqual(t, key, cache.Key("abc", "golang", SnippetModeFunction))
+}
+
+func TestCalcDirectoryFingerprint_Cache(t *testing.T) {
+	src := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.go"), []byte(cacheTestSource), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "b.go"), []byte(cacheTestSource), 0o644))
+	cfg := &config.SourceConfig{
+		SrcPath:     src,
+		Parallelism: 2,
+		SnippetMode: SnippetModeFunction,
+		NoCache:     true,
+	}
+	cfg.InitIgnoreDirs()
+	processors := GetPreProcessors("golang")
+	expected, err := CalcDirectoryFingerprint(cfg, processors)
+	assert.NoError(t, err)
+
+	cfg.NoCache = false
+	cfg.CacheDir = t.TempDir()
+	for i := 0; i < 2; i++ {
+		fp, err := CalcDirectoryFingerprint(cfg, processors)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Files, fp.Files)
+	}
+	// the same content of both files is cached once
+	entries, err := filepath.Glob(filepath.Join(cfg.CacheDir, "*", "*"+cacheEntrySuffix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	cache, err := OpenCache(cfg.CacheDir)
+	assert.NoError(t, err)
+	fp, err := generateFileFingerprint(filepath.Join(src, "a.go"), processors, SnippetModeFunction, cache)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Files[0].Fingerprint, fp.Fingerprint)
+	hits, misses := cache.Stats()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(0), misses)
+}
+
+func TestCache_Evict(t *testing.T) {
+	cache, err := OpenCache(t.TempDir())
+	assert.NoError(t, err)
+	now := time.Now()
+	keys := make([]string, 0)
+	for i, sum := range []string{"a", "b", "c", "d"} {
+		key := cache.Key(sum, "golang", SnippetModeNone)
+		keys = append(keys, key)
+		assert.NoError(t, cache.Put(key, &model.FileFingerprint{Language: "golang", Fingerprint: model.FingerprintValue{File: "0123456789abcdef"}}))
+		// a is the least recently used, and not used for 60 days
+		used := now.Add(-time.Duration(3-i) * time.Hour)
+		if i == 0 {
+			used = now.Add(-60 * 24 * time.Hour)
+		}
+		assert.NoError(t, os.Chtimes(cache.path(key), used, used))
+	}
+	info, err := os.Stat(cache.path(keys[0]))
+	assert.NoError(t, err)
+
+	removed, err := cache.Evict(DefaultCacheMaxAge, DefaultCacheMaxSize)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, ok := cache.Get(keys[0])
+	assert.False(t, ok)
+
+	// b is the least recently used of the rest
+	removed, err = cache.Evict(DefaultCacheMaxAge, 2*info.Size())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, ok = cache.Get(keys[1])
+	assert.False(t, ok)
+	_, ok = cache.Get(keys[2])
+	assert.True(t, ok)
+	_, ok = cache.Get(keys[3])
+	assert.True(t, ok)
+}
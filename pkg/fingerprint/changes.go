@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"fmt"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ChangedFiles returns the absolute paths of the files of the git repository containing srcPath, which are changed
+// since the merge base of the base revision and HEAD, including the uncommitted and untracked files
+func ChangedFiles(srcPath string, base string) (map[string]struct{}, error) {
+	repo, err := gogit.PlainOpenWithOptions(srcPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("open git repository error: %w", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("open git worktree error: %w", err)
+	}
+	root := wt.Filesystem.Root()
+
+	baseCommit, err := resolveCommit(repo, base)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := resolveCommit(repo, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if bases, err := baseCommit.MergeBase(headCommit); err == nil && len(bases) > 0 {
+		baseCommit = bases[0]
+	}
+	baseTree, err := baseCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read tree of %s error: %w", base, err)
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read tree of HEAD error: %w", err)
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s with HEAD error: %w", base, err)
+	}
+
+	files := make(map[string]struct{})
+	for _, change := range changes {
+		// a deleted file has no name in the head tree
+		if change.To.Name != "" {
+			files[filepath.Join(root, filepath.FromSlash(change.To.Name))] = struct{}{}
+		}
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("read git status error: %w", err)
+	}
+	for path, s := range status {
+		if s.Worktree != gogit.Unmodified || s.Staging != gogit.Unmodified {
+			files[filepath.Join(root, filepath.FromSlash(path))] = struct{}{}
+		}
+	}
+	return files, nil
+}
+
+func resolveCommit(repo *gogit.Repository, rev string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("resolve revision %s error: %w", rev, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("read commit %s error: %w", rev, err)
+	}
+	return commit, nil
+}
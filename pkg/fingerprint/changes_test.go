@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+)
+
+func commitFiles(t *testing.T, wt *gogit.Worktree, files map[string]string) {
+	root := wt.Filesystem.Root()
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+		_, err := wt.Add(name)
+		assert.NoError(t, err)
+	}
+	_, err := wt.Commit("update", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+}
+
+func TestChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	repo, err := gogit.PlainInit(root, false)
+	assert.NoError(t, err)
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	commitFiles(t, wt, map[string]string{"src/a.go": cacheTestSource, "src/b.go": cacheTestSource})
+	head, err := repo.Head()
+	assert.NoError(t, err)
+	base := head.Hash().String()
+
+	commitFiles(t, wt, map[string]string{"src/b.go": cacheTestSource + "\n// changed\n"})
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "src", "c.go"), []byte(cacheTestSource), 0o644))
+
+	src := filepath.Join(root, "src")
+	changed, err := ChangedFiles(src, base)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		filepath.Join(src, "b.go"): {},
+		filepath.Join(src, "c.go"): {},
+	}, changed)
+
+	cfg := &config.SourceConfig{
+		SrcPath:      src,
+		Parallelism:  1,
+		SnippetMode:  SnippetModeNone,
+		NoCache:      true,
+		ChangedSince: base,
+	}
+	cfg.InitIgnoreDirs()
+	fp, err := CalcDirectoryFingerprint(cfg, GetPreProcessors("golang"))
+	assert.NoError(t, err)
+	files := make([]string, 0)
+	for _, f := range fp.Files {
+		files = append(files, f.File)
+	}
+	assert.Equal(t, []string{"/b.go", "/c.go"}, files)
+
+	_, err = ChangedFiles(src, "no-such-revision")
+	assert.Error(t, err)
+}
@@ -86,6 +86,10 @@ func DistanceHex(a string, b string) (uint8, error) {
 
 // CalcFingerprint calculates the fingerprint of a file or directory
 func CalcFingerprint(cfg *config.SourceConfig) (*model.Fingerprint, error) {
+	if !IsValidSnippetMode(cfg.SnippetMode) {
+		return nil, fmt.Errorf("unsupported snippet mode: %s, one of %s", cfg.SnippetMode,
+			strings.Join(AllSnippetModes(), "|"))
+	}
 	enabledPreProcessors := GetPreProcessors(cfg.Language)
 	preprocessorNames := util.SliceMap(enabledPreProcessors, func(p preprocessor.PreProcessor) string {
 		return p.Name()
@@ -113,6 +117,16 @@ func CalcDirectoryFingerprint(cfg *config.SourceConfig, processors []preprocesso
 	done := make(chan struct{})
 	defer close(done)
 
+	var changed map[string]struct{}
+	if cfg.ChangedSince != "" {
+		var err error
+		if changed, err = ChangedFiles(cfg.SrcPath, cfg.ChangedSince); err != nil {
+			return nil, err
+		}
+		log.Infof("%d files changed since %s", len(changed), cfg.ChangedSince)
+	}
+	cache := openCache(cfg)
+
 	pathChan, errChan := util.WalkFilesWithMatcher(cfg.SrcPath, done, cfg.IgnoreDirsSet(), getSuffixMatcher(processors))
 	resultChan := make(chan result)
 
@@ -123,7 +137,10 @@ func CalcDirectoryFingerprint(cfg *config.SourceConfig, processors []preprocesso
 		go func() {
 			defer wg.Done()
 			for path := range pathChan {
-				fp, err := generateFileFingerprint(path, processors)
+				if changed != nil && !isChanged(changed, path) {
+					continue
+				}
+				fp, err := generateFileFingerprint(path, processors, cfg.SnippetMode, cache)
 				if err != nil {
 					continue
 				}
@@ -140,7 +157,23 @@ func CalcDirectoryFingerprint(cfg *config.SourceConfig, processors []preprocesso
 		close(resultChan)
 	}()
 
-	return processResult(cfg, resultChan, errChan)
+	fp, err := processResult(cfg, resultChan, errChan)
+	if cache != nil {
+		hits, misses := cache.Stats()
+		log.Infof("fingerprint cache %s: %d hits, %d misses", cache.Dir(), hits, misses)
+		cache.evictIfDue()
+	}
+	return fp, err
+}
+
+// isChanged returns true if the file is one of the changed files
+func isChanged(changed map[string]struct{}, path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	_, ok := changed[abs]
+	return ok
 }
 
 func processResult(cfg *config.SourceConfig, resultChan chan result,
@@ -195,7 +228,7 @@ func getSuffixMatcher(processors []preprocessor.PreProcessor) *pattern_set.Patte
 func CalcFileFingerprint(cfg *config.SourceConfig, processors []preprocessor.PreProcessor) (*model.Fingerprint, error) {
 	path := cfg.SrcPath
 
-	fileFp, err := generateFileFingerprint(path, processors)
+	fileFp, err := generateFileFingerprint(path, processors, cfg.SnippetMode, openCache(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +248,10 @@ func CalcFileFingerprint(cfg *config.SourceConfig, processors []preprocessor.Pre
 	return fp, nil
 }
 
-func generateFileFingerprint(path string, processors []preprocessor.PreProcessor) (*model.FileFingerprint, error) {
+// generateFileFingerprint calculates the fingerprint of a file, the cached result of the same content is reused
+// if cache is not nil
+func generateFileFingerprint(path string, processors []preprocessor.PreProcessor,
+	snippetMode string, cache *Cache) (*model.FileFingerprint, error) {
 	stat, err := os.Stat(path)
 	if err != nil {
 		return nil, err
@@ -237,12 +273,21 @@ func generateFileFingerprint(path string, processors []preprocessor.PreProcessor
 		return nil, fmt.Errorf("cannot find matched processor for %s", path)
 	}
 
+	sha256, _ := util.SHA256Sum(bytes.NewReader(data))
+	var key string
+	if cache != nil {
+		key = cache.Key(sha256, processor.Name(), snippetMode)
+		if fp, ok := cache.Get(key); ok {
+			fp.File = path
+			return fp, nil
+		}
+	}
+
 	lines := len(util.SliceFilter(data, func(b byte) bool {
 		return b == '\n'
 	}))
 	md5, _ := util.MD5Sum(bytes.NewReader(data))
 	sha1, _ := util.SHA1Sum(bytes.NewReader(data))
-	sha256, _ := util.SHA256Sum(bytes.NewReader(data))
 	fp := &model.FileFingerprint{
 		File:     path,
 		Lines:    int64(lines),
@@ -252,9 +297,15 @@ func generateFileFingerprint(path string, processors []preprocessor.PreProcessor
 		SHA256:   sha256,
 		Language: processor.Name(),
 		Fingerprint: model.FingerprintValue{
-			File: digester.EnhancedSimHash64([]byte(processor.ProcessContent(string(data)))),
+			File:     digester.EnhancedSimHash64([]byte(processor.ProcessContent(string(data)))),
+			Snippets: snippetFingerprints(string(data), processor, snippetMode),
 		},
 	}
+	if cache != nil {
+		if err = cache.Put(key, fp); err != nil {
+			log.Warnf("cache fingerprint of %s error: %s", path, err.Error())
+		}
+	}
 	return fp, nil
 }
 
@@ -24,11 +24,15 @@ var (
 	regRmCommentBlock *regexp.Regexp
 	regRmCommentLine  *regexp.Regexp
 	regRmBlank        *regexp.Regexp
+	isFunctionHeader  func(line string) bool
 )
 
 var headerPrefixSet *pattern_set.PatternSet
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*(?:(?:[\w:*&<>,~]+\s+)+[*&]*[\w:~]+|[\w:]+::~?\w+)\s*\([^;]*$`),
+		"if", "for", "while", "switch", "catch", "return", "new", "delete", "throw", "else", "case", "do")
 	regRmCommentBlock = regexp.MustCompile(`/\*{1,2}[\s\S]*?\*/`)
 	regRmCommentLine = regexp.MustCompile(`(//[^\n]*)`)
 	regRmBlank = regexp.MustCompile(`[ \t\r\f]+`)
@@ -63,6 +67,10 @@ func (p *Preprocessor) ProcessContent(content string) string {
 	return code
 }
 
+func (p *Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	content = regRmCommentBlock.ReplaceAllString(content, "")
 	content = regRmCommentLine.ReplaceAllString(content, "")
@@ -20,8 +20,9 @@ import (
 )
 
 var (
-	RX_COMMENTS *regexp.Regexp
-	prefixSet   *pattern_set.PatternSet
+	RX_COMMENTS      *regexp.Regexp
+	prefixSet        *pattern_set.PatternSet
+	isFunctionHeader func(line string) bool
 )
 
 type CSharpPreprocessor struct {
@@ -50,6 +51,10 @@ func (g CSharpPreprocessor) SupportedFileTypes() []string {
 	return []string{".cs"}
 }
 
+func (g CSharpPreprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	return RX_COMMENTS.ReplaceAllString(content, "")
 }
@@ -78,6 +83,10 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*(?:[\w<>\[\],.?]+\s+)+\w+\s*(?:<[^>]*>)?\s*\([^;]*$`),
+		"if", "for", "foreach", "while", "switch", "catch", "return", "new", "throw", "else", "case", "using",
+		"lock", "await", "yield")
 	RX_COMMENTS = regexp.MustCompile(`(?:/\*(?:[^*]|(?:\*+[^*/]))*\*+/)|(?://.*)`)
 	prefixSet = pattern_set.NewPrefixPatternMatchSet(
 		"}",
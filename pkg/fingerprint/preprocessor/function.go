@@ -0,0 +1,138 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package preprocessor
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxHeaderLines is the max number of lines between a function header and the opening brace of its body
+const maxHeaderLines = 8
+
+var (
+	regLiterals = regexp.MustCompile(`"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|//.*$`)
+	regWords    = regexp.MustCompile(`\w+`)
+)
+
+// HeaderMatcher returns a function matching the header lines of functions by re,
+// lines that contain any of the keywords before the parameters are not matched, e.g. `} else if (a) {`.
+func HeaderMatcher(re *regexp.Regexp, keywords ...string) func(line string) bool {
+	keywordSet := make(map[string]struct{}, len(keywords))
+	for _, keyword := range keywords {
+		keywordSet[keyword] = struct{}{}
+	}
+	return func(line string) bool {
+		if !re.MatchString(line) {
+			return false
+		}
+		header := line
+		if idx := strings.Index(header, "("); idx >= 0 {
+			header = header[:idx]
+		}
+		for _, word := range regWords.FindAllString(header, -1) {
+			if _, ok := keywordSet[word]; ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// BraceBlocks returns the line ranges of the functions whose bodies are enclosed in braces,
+// a function starts at a header line and ends at the line which closes the body.
+func BraceBlocks(content string, isHeader func(line string) bool) []LineRange {
+	lines := strings.Split(content, "\n")
+	ranges := make([]LineRange, 0)
+	for i := 0; i < len(lines); i++ {
+		if !isHeader(lines[i]) {
+			continue
+		}
+		end := braceBlockEnd(lines, i)
+		if end < 0 {
+			continue
+		}
+		ranges = append(ranges, LineRange{Start: i + 1, End: end + 1})
+		i = end
+	}
+	return ranges
+}
+
+func braceBlockEnd(lines []string, start int) int {
+	depth := 0
+	opened := false
+	for i := start; i < len(lines); i++ {
+		if !opened && i-start >= maxHeaderLines {
+			return -1
+		}
+		for _, c := range regLiterals.ReplaceAllString(lines[i], "") {
+			switch c {
+			case '{':
+				depth++
+				opened = true
+			case '}':
+				depth--
+			case ';':
+				// declaration without body
+				if !opened {
+					return -1
+				}
+			}
+			if opened && depth <= 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// IndentBlocks returns the line ranges of the functions whose bodies are indented under the header lines,
+// the body ends before the next line which is not indented deeper than the header,
+// the line is included if it is the closer, e.g. `end`.
+func IndentBlocks(content string, isHeader func(line string) bool, closer string) []LineRange {
+	lines := strings.Split(content, "\n")
+	ranges := make([]LineRange, 0)
+	for i := 0; i < len(lines); i++ {
+		if !isHeader(lines[i]) {
+			continue
+		}
+		indent := indentation(lines[i])
+		end := i
+		for j := i + 1; j < len(lines); j++ {
+			trimmed := strings.TrimSpace(lines[j])
+			if trimmed == "" {
+				continue
+			}
+			if indentation(lines[j]) > indent {
+				end = j
+				continue
+			}
+			if closer == "" && strings.IndexAny(trimmed[:1], ")]}") == 0 {
+				// the closing bracket of the parameters
+				end = j
+				continue
+			}
+			if closer != "" && (trimmed == closer || strings.HasPrefix(trimmed, closer+" ")) {
+				end = j
+			}
+			break
+		}
+		if end > i {
+			ranges = append(ranges, LineRange{Start: i + 1, End: end + 1})
+			i = end
+		}
+	}
+	return ranges
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
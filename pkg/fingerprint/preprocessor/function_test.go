@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package preprocessor
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderMatcher(t *testing.T) {
+	isHeader := HeaderMatcher(regexp.MustCompile(`^\s*(?:[\w<>\[\],.?]+\s+)+\w+\s*\([^;]*$`), "if", "else", "new")
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{line: "public int add(int a, int b) {", want: true},
+		{line: "  List<String> names(", want: true},
+		{line: "} else if (a > b) {", want: false},
+		{line: "else if (a > b) {", want: false},
+		{line: "return new Foo(a) {", want: false},
+		{line: "public abstract void run();", want: false},
+		{line: "foo(a, b);", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isHeader(tt.line), tt.line)
+	}
+}
+
+func TestBraceBlocks(t *testing.T) {
+	content := `class A {
+    void a() {
+        String s = "}";
+        if (s.isEmpty()) {
+            return;
+        }
+    }
+
+    abstract void b();
+
+    void c(
+            int x)
+    {
+        // }
+    }
+}`
+	isHeader := HeaderMatcher(regexp.MustCompile(`^\s*(?:\w+\s+)+\w+\s*\([^;]*$`), "if")
+	assert.Equal(t, []LineRange{{Start: 2, End: 7}, {Start: 11, End: 15}}, BraceBlocks(content, isHeader))
+}
+
+func TestIndentBlocks(t *testing.T) {
+	python := `def a(x):
+    return x
+
+class B:
+    def b(
+        self,
+    ):
+        y = 1
+
+        return y
+print(a(1))`
+	isHeader := HeaderMatcher(regexp.MustCompile(`^\s*def\s`))
+	assert.Equal(t, []LineRange{{Start: 1, End: 2}, {Start: 5, End: 10}}, IndentBlocks(python, isHeader, ""))
+
+	ruby := `def a(x)
+  if x
+    1
+  end
+end
+def b; end`
+	isHeader = HeaderMatcher(regexp.MustCompile(`^\s*def\s`))
+	assert.Equal(t, []LineRange{{Start: 1, End: 5}}, IndentBlocks(ruby, isHeader, "end"))
+}
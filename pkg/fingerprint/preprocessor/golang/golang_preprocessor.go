@@ -24,6 +24,7 @@ var (
 	regImportWithoutParentheses *regexp.Regexp
 	regImportWithParentheses    *regexp.Regexp
 	prefixSet                   *pattern_set.PatternSet
+	isFunctionHeader            func(line string) bool
 )
 
 type Preprocessor struct{}
@@ -53,6 +54,10 @@ func (g Preprocessor) SupportedFileTypes() []string {
 	return []string{".go"}
 }
 
+func (g Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	return regComments.ReplaceAllString(content, "")
 }
@@ -87,6 +92,7 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(regexp.MustCompile(`^\s*func\s`))
 	regComments = regexp.MustCompile(`(?:/\*(?:[^*]|(?:\*+[^*/]))*\*+/)|(?://.*)`)
 	regImportWithoutParentheses = regexp.MustCompile(`import\s+[\"\']\S+`)
 	regImportWithParentheses = regexp.MustCompile(`import\s*\([\s\S]*?\)`)
@@ -20,8 +20,9 @@ import (
 )
 
 var (
-	regComments *regexp.Regexp
-	prefixSet   *pattern_set.PatternSet
+	regComments      *regexp.Regexp
+	prefixSet        *pattern_set.PatternSet
+	isFunctionHeader func(line string) bool
 )
 
 type Preprocessor struct{}
@@ -50,6 +51,10 @@ func (p *Preprocessor) ProcessContent(content string) string {
 	return code
 }
 
+func (p *Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	return regComments.ReplaceAllString(content, "")
 }
@@ -78,6 +83,9 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*(?:[\w<>\[\],.?]+\s+)+\w+\s*\([^;]*$`),
+		"if", "for", "while", "switch", "catch", "return", "new", "throw", "else", "case", "assert")
 	regComments = regexp.MustCompile(`(?:/\*(?:[^*]|(?:\*+[^*/]))*\*+/)|(?://.*)`)
 
 	prefixSet = pattern_set.NewPrefixPatternMatchSet(
@@ -22,8 +22,9 @@ import (
 )
 
 var (
-	regComments *regexp.Regexp
-	prefixSet   *pattern_set.PatternSet
+	regComments      *regexp.Regexp
+	prefixSet        *pattern_set.PatternSet
+	isFunctionHeader func(line string) bool
 )
 
 type PreProcessor struct{}
@@ -52,6 +53,10 @@ func (PreProcessor) SupportedFileTypes() []string {
 	return []string{".js"}
 }
 
+func (PreProcessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	return regComments.ReplaceAllString(content, "")
 }
@@ -92,6 +97,11 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\b|`+
+			`^\s*(?:export\s+)?(?:const|let|var)\s+\w+\s*=\s*(?:async\s*)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)|`+
+			`^\s*(?:static\s+)?(?:async\s+)?(?:get\s+|set\s+)?\w+\s*\([^)]*\)\s*\{\s*$`),
+		"if", "for", "while", "switch", "catch", "return", "new", "throw", "else", "case")
 	regComments = regexp.MustCompile(`(?:/\*(?:[^*]|(?:\*+[^*/]))*\*+/)|(?://.*)`)
 
 	prefixSet = pattern_set.NewPrefixPatternMatchSet(
@@ -25,6 +25,7 @@ var (
 	remove_out_line_comment_re *regexp.Regexp
 	remove_in_line_comment_re  *regexp.Regexp
 	prefixSet                  *pattern_set.PatternSet
+	isFunctionHeader           func(line string) bool
 )
 
 type Preprocessor struct{}
@@ -53,6 +54,10 @@ func (p *Preprocessor) ProcessContent(content string) string {
 	return code
 }
 
+func (p *Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.IndentBlocks(content, isFunctionHeader, "end")
+}
+
 func removeComments(content string) string {
 	content = remove_block_comment_re.ReplaceAllString(content, "")
 	content = remove_in_line_comment_re.ReplaceAllString(content, "")
@@ -97,6 +102,7 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(regexp.MustCompile(`^\s*(?:local\s+)?function\b|=\s*function\b`))
 	remove_block_comment_re = regexp.MustCompile(`--\[=*\[([\d\D]*?)\]=*\]`)
 	remove_out_line_comment_re = regexp.MustCompile("(-{1,3}.*?)\n")
 	remove_in_line_comment_re = regexp.MustCompile(`(?m)--.*$`)
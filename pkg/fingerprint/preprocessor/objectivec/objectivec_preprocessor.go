@@ -29,6 +29,7 @@ var (
 	remove_out_line_comment_re *regexp.Regexp
 	remove_in_line_comment_re  *regexp.Regexp
 	prefixSet                  *pattern_set.PatternSet
+	isFunctionHeader           func(line string) bool
 )
 
 type Preprocessor struct{}
@@ -57,6 +58,10 @@ func (p *Preprocessor) ProcessContent(content string) string {
 	return code
 }
 
+func (p *Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	content = remove_block_comment_re.ReplaceAllString(content, "")
 	content = remove_in_line_comment_re.ReplaceAllString(content, "")
@@ -101,6 +106,9 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*[-+]\s*\(|^\s*(?:[\w*&<>,]+\s+)+[*&]*\w+\s*\([^;]*$`),
+		"if", "for", "while", "switch", "catch", "return", "else", "case", "do")
 	remove_block_comment_re = regexp.MustCompile(`(/\*{1,2}[\s\S]*?\*/)`)
 	remove_out_line_comment_re = regexp.MustCompile(`(?m)^\s*//.*$`)
 	remove_in_line_comment_re = regexp.MustCompile(`(?m)//.*$`)
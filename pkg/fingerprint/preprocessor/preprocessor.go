@@ -15,3 +15,15 @@ type PreProcessor interface {
 	ProcessContent(content string) string
 	SupportedFileTypes() []string
 }
+
+// LineRange is a range of lines in the source code, the line numbers start from 1 and both ends are included.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// FunctionSplitter is implemented by the preprocessors which can locate the functions of the source code.
+type FunctionSplitter interface {
+	// SplitFunctions returns the line ranges of the functions in content.
+	SplitFunctions(content string) []LineRange
+}
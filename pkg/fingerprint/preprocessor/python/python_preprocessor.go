@@ -25,6 +25,7 @@ var (
 	remove_out_line_comment_re *regexp.Regexp
 	remove_in_line_comment_re  *regexp.Regexp
 	prefixSet                  *pattern_set.PatternSet
+	isFunctionHeader           func(line string) bool
 )
 
 type Preprocessor struct{}
@@ -53,6 +54,10 @@ func (g Preprocessor) SupportedFileTypes() []string {
 	return []string{".py"}
 }
 
+func (g Preprocessor) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.IndentBlocks(content, isFunctionHeader, "")
+}
+
 func removeComments(content string) string {
 	content = remove_block_comment_re.ReplaceAllString(content, "")
 	content = remove_out_line_comment_re.ReplaceAllString(content, "")
@@ -94,6 +99,7 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(regexp.MustCompile(`^\s*(?:async\s+)?def\s`))
 	remove_block_comment_re = regexp.MustCompile(`(?s)('''[^']*'''|"""[^"]*""")`)
 	remove_out_line_comment_re = regexp.MustCompile(`(?m)^\s*#.*$`)
 	remove_in_line_comment_re = regexp.MustCompile(`(?m)#.*$`)
@@ -21,8 +21,9 @@ import (
 )
 
 var (
-	RX_COMMENTS *regexp.Regexp
-	prefixSet   *pattern_set.PatternSet
+	RX_COMMENTS      *regexp.Regexp
+	prefixSet        *pattern_set.PatternSet
+	isFunctionHeader func(line string) bool
 )
 
 type RustPreprocess struct{}
@@ -48,6 +49,10 @@ func (p *RustPreprocess) ProcessContent(content string) string {
 	return code
 }
 
+func (p *RustPreprocess) SplitFunctions(content string) []preprocessor.LineRange {
+	return preprocessor.BraceBlocks(content, isFunctionHeader)
+}
+
 func removeComments(content string) string {
 	return RX_COMMENTS.ReplaceAllString(content, "")
 }
@@ -85,6 +90,8 @@ func removeCommonKeywordLines(content string) string {
 }
 
 func init() {
+	isFunctionHeader = preprocessor.HeaderMatcher(
+		regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:const\s+)?(?:async\s+)?(?:unsafe\s+)?(?:extern\s+"[^"]*"\s+)?fn\s`))
 	RX_COMMENTS = regexp.MustCompile(`(?:/\*(?:[^*]|(?:\*+[^*/]))*\*+/)|(?://.*)`)
 	prefixSet = pattern_set.NewPrefixPatternMatchSet(
 		"break",
@@ -0,0 +1,168 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"fmt"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/digester"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/preprocessor"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+const (
+	SnippetModeNone     = "none"
+	SnippetModeWindow   = "window"
+	SnippetModeFunction = "function"
+)
+
+const (
+	// snippetWindowLines is the number of preprocessed lines of a window snippet
+	snippetWindowLines = 16
+	// snippetWindowStep is the number of preprocessed lines between the starts of two window snippets
+	snippetWindowStep = 8
+	// snippetMinLines is the min number of preprocessed lines of a snippet, shorter snippets are too common to match
+	snippetMinLines = 4
+)
+
+// AllSnippetModes returns all supported snippet modes.
+func AllSnippetModes() []string {
+	return []string{SnippetModeNone, SnippetModeWindow, SnippetModeFunction}
+}
+
+// IsValidSnippetMode reports whether mode is a supported snippet mode, empty is same as none.
+func IsValidSnippetMode(mode string) bool {
+	return mode == "" || util.SliceContains(AllSnippetModes(), mode)
+}
+
+// snippetFingerprints calculates the snippet fingerprints of content, the ranges refer to the lines of content.
+// In function mode, content without functions found is split into windows.
+func snippetFingerprints(content string, processor preprocessor.PreProcessor, mode string) []model.SnippetFingerprint {
+	switch mode {
+	case SnippetModeFunction:
+		if splitter, ok := processor.(preprocessor.FunctionSplitter); ok {
+			if snippets := functionSnippets(content, processor, splitter); len(snippets) > 0 {
+				return snippets
+			}
+		}
+		return windowSnippets(content, processor)
+	case SnippetModeWindow:
+		return windowSnippets(content, processor)
+	default:
+		return nil
+	}
+}
+
+func functionSnippets(content string, processor preprocessor.PreProcessor,
+	splitter preprocessor.FunctionSplitter) []model.SnippetFingerprint {
+	origLines := strings.Split(content, LineSep)
+	snippets := make([]model.SnippetFingerprint, 0)
+	for _, r := range splitter.SplitFunctions(content) {
+		code := strings.Join(origLines[r.Start-1:r.End], LineSep) + LineSep
+		lines := processedLines(processor.ProcessContent(code))
+		if len(lines) < snippetMinLines {
+			continue
+		}
+		snippets = append(snippets, snippetFingerprint(lines, r.Start, r.End))
+	}
+	return snippets
+}
+
+func windowSnippets(content string, processor preprocessor.PreProcessor) []model.SnippetFingerprint {
+	lines := processedLines(processor.ProcessContent(content))
+	if len(lines) < snippetMinLines {
+		return nil
+	}
+	lineNos := mapLines(strings.Split(content, LineSep), lines)
+	snippets := make([]model.SnippetFingerprint, 0)
+	for start := 0; ; start += snippetWindowStep {
+		end := start + snippetWindowLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+		snippets = append(snippets, snippetFingerprint(lines[start:end], lineNos[start], lineNos[end-1]))
+		if end == len(lines) {
+			break
+		}
+	}
+	return snippets
+}
+
+func snippetFingerprint(lines []string, start, end int) model.SnippetFingerprint {
+	return model.SnippetFingerprint{
+		Range: FormatLineRange(start, end),
+		Value: digester.EnhancedSimHash64([]byte(strings.Join(lines, LineSep))),
+	}
+}
+
+// FormatLineRange formats a range of lines(1-based, both ends included) of a snippet, e.g. L10-L25.
+func FormatLineRange(start, end int) string {
+	return fmt.Sprintf("L%d-L%d", start, end)
+}
+
+// ParseLineRange parses a range of lines formatted by FormatLineRange.
+func ParseLineRange(s string) (start int, end int, err error) {
+	if _, err = fmt.Sscanf(s, "L%d-L%d", &start, &end); err != nil {
+		return 0, 0, fmt.Errorf("invalid line range %q: %w", s, err)
+	}
+	if start < 1 || end < start {
+		return 0, 0, fmt.Errorf("invalid line range %q", s)
+	}
+	return start, end, nil
+}
+
+func processedLines(code string) []string {
+	return util.SliceFilter(strings.Split(code, LineSep), func(line string) bool {
+		return strings.TrimSpace(line) != ""
+	})
+}
+
+// mapLines maps the preprocessed lines to the numbers(1-based) of the original lines.
+// The preprocessors only remove comments, lines and whitespaces, so each preprocessed line is searched in order
+// in the original lines with whitespaces ignored. A line which cannot be found, e.g. the code joined by a removed
+// block comment, is mapped to the line containing its first word.
+func mapLines(origLines []string, lines []string) []int {
+	compactOrig := util.SliceMap(origLines, compactLine)
+	lineNos := make([]int, len(lines))
+	next := 0
+	for i, line := range lines {
+		found := searchLine(compactOrig, next, compactLine(line))
+		if found < 0 {
+			found = searchLine(compactOrig, next, strings.Fields(line)[0])
+		}
+		if found < 0 {
+			found = next
+			if found >= len(origLines) {
+				found = len(origLines) - 1
+			}
+			lineNos[i] = found + 1
+			continue
+		}
+		lineNos[i] = found + 1
+		next = found + 1
+	}
+	return lineNos
+}
+
+func searchLine(lines []string, from int, s string) int {
+	for i := from; i < len(lines); i++ {
+		if strings.Contains(lines[i], s) {
+			return i
+		}
+	}
+	return -1
+}
+
+func compactLine(line string) string {
+	return strings.Join(strings.Fields(line), "")
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package fingerprint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/preprocessor/java"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+const snippetJavaCode = `package a;
+
+import java.util.List;
+
+/**
+ * A class.
+ */
+public class A {
+    private int x;
+
+    public int add(int a, int b) {
+        int c = a + b;
+        // comment
+        if (c > 10) {
+            c = c - 10;
+        } else if (c < 0) {
+            c = 0;
+        }
+        return c;
+    }
+
+    public abstract void noBody(int a);
+
+    public static List<String> names(
+            List<String> in) {
+        List<String> out = new ArrayList<>();
+        for (String s : in) {
+            out.add(s.trim());
+        }
+        return out;
+    }
+}
+`
+
+func TestSnippetFingerprints(t *testing.T) {
+	processor := java.NewJavaPreprocessor()
+	assert.Nil(t, snippetFingerprints(snippetJavaCode, processor, SnippetModeNone))
+
+	functions := snippetFingerprints(snippetJavaCode, processor, SnippetModeFunction)
+	assert.Equal(t, []string{"L11-L20", "L24-L31"}, snippetRanges(functions))
+
+	// the snippet of the copied function is same in another file
+	copied := "class B {\n  // copied\n" + strings.Join(strings.Split(snippetJavaCode, "\n")[10:20], "\n") + "\n}\n"
+	copiedFunctions := snippetFingerprints(copied, processor, SnippetModeFunction)
+	assert.Equal(t, []string{"L3-L12"}, snippetRanges(copiedFunctions))
+	assert.Equal(t, functions[0].Value, copiedFunctions[0].Value)
+
+	windows := snippetFingerprints(snippetJavaCode, processor, SnippetModeWindow)
+	assert.Equal(t, []string{"L8-L30"}, snippetRanges(windows))
+}
+
+func TestWindowSnippets(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 40; i++ {
+		if i%2 == 0 {
+			sb.WriteString("// comment\n")
+			continue
+		}
+		sb.WriteString("int v" + strings.Repeat("x", i) + " = 1;\n")
+	}
+	windows := windowSnippets(sb.String(), java.NewJavaPreprocessor())
+	// 20 code lines on the odd lines, windows of 16 lines every 8 lines
+	assert.Equal(t, []string{"L1-L31", "L17-L39"}, snippetRanges(windows))
+}
+
+func TestMapLines(t *testing.T) {
+	orig := []string{"/* header */", "int a = 1;", "  int  b = 2; // b", "int c /* c */ = 3;", "", "int a = 1;"}
+	lines := []string{"int a = 1;", "int  b = 2;", "int c  = 3;", "int a = 1;"}
+	assert.Equal(t, []int{2, 3, 4, 6}, mapLines(orig, lines))
+}
+
+func TestParseLineRange(t *testing.T) {
+	start, end, err := ParseLineRange(FormatLineRange(3, 12))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 12}, []int{start, end})
+	for _, s := range []string{"", "C1-C2", "L5-L2", "L0-L1"} {
+		_, _, err = ParseLineRange(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func snippetRanges(snippets []model.SnippetFingerprint) []string {
+	return util.SliceMap(snippets, func(s model.SnippetFingerprint) string {
+		return s.Range
+	})
+}
@@ -23,6 +23,7 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/env"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/elf"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
@@ -95,7 +96,7 @@ func Collect(cfg *config.ArtifactConfig, projectPath string) (*model.Artifact, e
 	}
 
 	if len(mainPkglicenses) > 0 {
-		mainPkglicenses = license.UniqueStrings(mainPkglicenses)
+		mainPkglicenses = license.NormalizeExpressions(mainPkglicenses)
 	}
 	pkg.LicenseDeclared = mainPkglicenses
 
@@ -158,6 +159,10 @@ func collectDirectory(cfg *config.ArtifactConfig) ([]model.File, error) {
 						{Algorithm: "SM3", Value: sm3},
 					},
 				}
+				if elf.IsELF(path) {
+					file.Type = model.FileTypeBinary
+					file.Compilers = elf.Compilers(path)
+				}
 				resultChan <- &file
 			}
 		}()
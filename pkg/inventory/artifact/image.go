@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package artifact
+
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/image"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// pre-defined labels of the image
+// see: https://github.com/opencontainers/image-spec/blob/main/annotations.md
+const (
+	labelTitle    = "org.opencontainers.image.title"
+	labelVersion  = "org.opencontainers.image.version"
+	labelVendor   = "org.opencontainers.image.vendor"
+	labelLicenses = "org.opencontainers.image.licenses"
+)
+
+// CollectImage collects the artifact information of a container image, the name, version, supplier
+// and licenses are taken from the labels if not given
+func CollectImage(cfg *config.ArtifactConfig, img *image.Image) *model.Artifact {
+	info := img.Info
+	pkg := ArtifactPackage(cfg)
+	if pkg.Name == "" {
+		pkg.Name = info.Labels[labelTitle]
+	}
+	if pkg.Version == "" {
+		pkg.Version = info.Labels[labelVersion]
+	}
+	if pkg.Supplier == "" {
+		pkg.Supplier = info.Labels[labelVendor]
+	}
+	if licenses := strings.TrimSpace(info.Labels[labelLicenses]); licenses != "" {
+		pkg.LicenseDeclared = license.NormalizeExpressions([]string{licenses})
+	}
+	pkg.Type = model.PkgTypeOCI
+	pkg.PURL = imagePURL(pkg.Name, pkg.Version, &info)
+	return &model.Artifact{
+		ID:      info.Config,
+		Package: *pkg,
+		Build: model.Build{
+			OS:   info.OS,
+			Arch: info.Arch,
+		},
+		Image: &info,
+	}
+}
+
+// imagePURL returns the oci purl of the image, the image id is used if the manifest digest is unknown
+// see: https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci
+func imagePURL(name, version string, info *model.Image) string {
+	digest := info.Digest
+	if digest == "" {
+		digest = info.Config
+	}
+	qualifiers := map[string]string{}
+	if info.Arch != "" {
+		qualifiers["arch"] = info.Arch
+	}
+	if version != "" {
+		qualifiers["tag"] = version
+	}
+	return packageurl.NewPackageURL(model.PkgTypeOCI, "", strings.ToLower(name), digest,
+		packageurl.QualifiersFromMap(qualifiers), "").ToString()
+}
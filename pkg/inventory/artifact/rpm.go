@@ -21,6 +21,7 @@ import (
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
 )
 
@@ -53,9 +54,9 @@ func collectRPM(cfg *config.ArtifactConfig) (*model.Artifact, error) {
 		Supplier: rpmMeta.Vendor(),
 		PURL:     artifactPURL(model.PkgTypeDEB, "", rpmMeta.Name(), versionRelease(rpmMeta.Version(), rpmMeta.Release())),
 	}
-	license := strings.TrimSpace(rpmMeta.License())
-	if license != "" {
-		mainPkg.LicenseDeclared = []string{license}
+	licenseStr := strings.TrimSpace(rpmMeta.License())
+	if licenseStr != "" {
+		mainPkg.LicenseDeclared = license.NormalizeExpressions([]string{licenseStr})
 	}
 	files := make([]model.File, 0)
 	rpmMd5, _ := util.MD5SumFile(cfg.DistPath)
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+	fpModel "gitee.com/JD-opensource/sbom-tool/pkg/fingerprint/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/source"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// minFileLines is the min number of lines of a file to be matched by the whole file fingerprint,
+// the fingerprints of tiny files are too common to tell a component
+const minFileLines = 5
+
+// Manifest lists the fingerprint outputs of the known components of a corpus
+type Manifest struct {
+	Components []ManifestComponent `json:"components"`
+}
+
+// ManifestComponent is a known component and its fingerprint output
+type ManifestComponent struct {
+	PURL string `json:"purl"`
+	// Fingerprint is the path of the fingerprint output, relative to the manifest
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NewComponent creates a component of the purl
+func NewComponent(purl string) (Component, error) {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return Component{}, fmt.Errorf("invalid purl %s: %w", purl, err)
+	}
+	name := p.Name
+	if p.Namespace != "" {
+		name = p.Namespace + "/" + p.Name
+	}
+	return Component{PURL: purl, Name: name, Version: p.Version}, nil
+}
+
+// LoadManifest loads the components of a corpus manifest into an index finding all fingerprints within maxDistance
+func LoadManifest(path string, maxDistance int) (*Index, error) {
+	idx := NewIndex(maxDistance)
+	if err := idx.AddManifest(path); err != nil {
+		return nil, err
+	}
+	log.Infof("loaded %d fingerprints of %d components from %s", idx.Size(), len(idx.Components()), path)
+	return idx, nil
+}
+
+// AddManifest adds the components of a corpus manifest
+func (idx *Index) AddManifest(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read corpus manifest error: %w", err)
+	}
+	manifest := &Manifest{}
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return fmt.Errorf("parse corpus manifest error: %w", err)
+	}
+	for _, mc := range manifest.Components {
+		c, err := NewComponent(mc.PURL)
+		if err != nil {
+			return err
+		}
+		fpPath := mc.Fingerprint
+		if !filepath.IsAbs(fpPath) {
+			fpPath = filepath.Join(filepath.Dir(path), fpPath)
+		}
+		files, err := LoadFingerprints(fpPath)
+		if err != nil {
+			return fmt.Errorf("load fingerprints of %s error: %w", mc.PURL, err)
+		}
+		idx.AddFingerprints(c, files)
+	}
+	return nil
+}
+
+// AddSource fingerprints a reference source tree and adds the fingerprints of the component
+func (idx *Index) AddSource(c Component, cfg *config.SourceConfig) error {
+	fp, err := fingerprint.CalcDirectoryFingerprint(cfg, fingerprint.GetPreProcessors(cfg.Language))
+	if err != nil {
+		return fmt.Errorf("fingerprint %s error: %w", cfg.SrcPath, err)
+	}
+	idx.AddFingerprints(c, source.ConvertFingerprint(fp).Fingerprint.Files)
+	return nil
+}
+
+// AddFingerprints adds the file and snippet fingerprints of a component, a component of the same purl is replaced
+func (idx *Index) AddFingerprints(c Component, files []model.FileFingerprint) {
+	idx.RemoveComponent(c.PURL)
+	id := idx.AddComponent(c)
+	for _, f := range files {
+		if f.Lines >= minFileLines {
+			if value, err := ParseValue(f.Fingerprint.File); err == nil {
+				idx.Add(Entry{Component: id, File: f.File, Value: value})
+			}
+		}
+		for _, snippet := range f.Fingerprint.Snippet {
+			if value, err := ParseValue(snippet.Value); err == nil {
+				idx.Add(Entry{Component: id, File: f.File, Range: snippet.Range, Value: value})
+			}
+		}
+	}
+}
+
+// LoadFingerprints loads the file fingerprints of the output of source command, a fingerprint.json,
+// or the source of a sbom document
+func LoadFingerprints(path string) ([]model.FileFingerprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file error: %w", err)
+	}
+	fields := make(map[string]json.RawMessage)
+	if json.Unmarshal(data, &fields) == nil {
+		_, hasMetadata := fields["metadata"]
+		_, hasFiles := fields["files"]
+		if hasMetadata && hasFiles {
+			fp := &fpModel.Fingerprint{}
+			if err = json.Unmarshal(data, fp); err != nil {
+				return nil, fmt.Errorf("parse fingerprint error: %w", err)
+			}
+			return source.ConvertFingerprint(fp).Fingerprint.Files, nil
+		}
+		if _, ok := fields["fingerprint"]; ok {
+			src := &model.Source{}
+			if err = json.Unmarshal(data, src); err != nil {
+				return nil, fmt.Errorf("parse source error: %w", err)
+			}
+			return src.Fingerprint.Files, nil
+		}
+	}
+	format, err := spec.DetectFormat(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("load %s error: %w", path, err)
+	}
+	return format.Spec().ToModel().Source.Fingerprint.Files, nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"sort"
+	"strconv"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+)
+
+const (
+	hashBits = 64
+	// minBlocks keeps the block values in 32 bits
+	minBlocks = 2
+	// DefaultMaxDistance is the default max hamming distance of near-duplicate fingerprints
+	DefaultMaxDistance = 3
+)
+
+// Component is a known component of the corpus
+type Component struct {
+	PURL    string `json:"purl"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Entry is the fingerprint of a file or a snippet of a file of a known component
+type Entry struct {
+	Component int
+	File      string
+	Range     string
+	Value     uint64
+}
+
+// Hit is an entry found by a query
+type Hit struct {
+	*Entry
+	Distance int
+}
+
+// Index is a multi-index of simhash fingerprints. The 64 bits of a fingerprint are split into blocks and each
+// block value is indexed in its own table. By the pigeonhole principle, two fingerprints within a hamming distance
+// smaller than the number of blocks have at least one identical block, so only the entries sharing a block are
+// compared instead of all of them.
+// A table is a sorted array of the block values(high 32 bits) and the entry ids(low 32 bits), searched by binary
+// search, which is also the on-disk format of the table.
+type Index struct {
+	maxDistance int
+	blocks      int
+	components  []Component
+	entries     []Entry
+	tables      [][]uint64
+	sorted      bool
+}
+
+// NewIndex creates an index finding all fingerprints within maxDistance
+func NewIndex(maxDistance int) *Index {
+	if maxDistance < 0 {
+		maxDistance = 0
+	}
+	if maxDistance >= hashBits/minBlocks {
+		maxDistance = hashBits/minBlocks - 1
+	}
+	blocks := maxDistance + 1
+	if blocks < minBlocks {
+		blocks = minBlocks
+	}
+	return &Index{maxDistance: maxDistance, blocks: blocks, tables: make([][]uint64, blocks), sorted: true}
+}
+
+// MaxDistance returns the max hamming distance which can be queried
+func (idx *Index) MaxDistance() int {
+	return idx.maxDistance
+}
+
+// AddComponent adds a component, returns its index for the entries
+func (idx *Index) AddComponent(c Component) int {
+	idx.components = append(idx.components, c)
+	return len(idx.components) - 1
+}
+
+// Component returns the component of an entry
+func (idx *Index) Component(e *Entry) Component {
+	return idx.components[e.Component]
+}
+
+// Components returns all components
+func (idx *Index) Components() []Component {
+	return idx.components
+}
+
+// Add adds a fingerprint entry
+func (idx *Index) Add(e Entry) {
+	id := uint64(len(idx.entries))
+	idx.entries = append(idx.entries, e)
+	for i := 0; i < idx.blocks; i++ {
+		idx.tables[i] = append(idx.tables[i], blockValue(e.Value, i, idx.blocks)<<32|id)
+	}
+	idx.sorted = false
+}
+
+// Size returns the number of entries
+func (idx *Index) Size() int {
+	return len(idx.entries)
+}
+
+// RemoveComponent removes the component of the purl and its entries, returns false if not found
+func (idx *Index) RemoveComponent(purl string) bool {
+	removed := -1
+	for i, c := range idx.components {
+		if c.PURL == purl {
+			removed = i
+			break
+		}
+	}
+	if removed < 0 {
+		return false
+	}
+	entries := idx.entries
+	idx.components = append(idx.components[:removed:removed], idx.components[removed+1:]...)
+	idx.entries = nil
+	idx.tables = make([][]uint64, idx.blocks)
+	for _, e := range entries {
+		if e.Component == removed {
+			continue
+		}
+		if e.Component > removed {
+			e.Component--
+		}
+		idx.Add(e)
+	}
+	return true
+}
+
+// sort sorts the tables after entries added, it must be called before concurrent queries
+func (idx *Index) sort() {
+	if idx.sorted {
+		return
+	}
+	for _, table := range idx.tables {
+		sort.Slice(table, func(i, j int) bool {
+			return table[i] < table[j]
+		})
+	}
+	idx.sorted = true
+}
+
+// Query returns the entries within maxDistance of value, the nearest first,
+// maxDistance is limited to MaxDistance.
+func (idx *Index) Query(value uint64, maxDistance int) []Hit {
+	idx.sort()
+	if maxDistance > idx.MaxDistance() {
+		maxDistance = idx.MaxDistance()
+	}
+	seen := make(map[uint64]struct{})
+	hits := make([]Hit, 0)
+	for i, table := range idx.tables {
+		key := blockValue(value, i, idx.blocks)
+		start := sort.Search(len(table), func(j int) bool {
+			return table[j]>>32 >= key
+		})
+		for j := start; j < len(table) && table[j]>>32 == key; j++ {
+			id := table[j] & 0xffffffff
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			e := &idx.entries[id]
+			if d := int(fingerprint.Distance(value, e.Value)); d <= maxDistance {
+				hits = append(hits, Hit{Entry: e, Distance: d})
+			}
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Distance != hits[j].Distance {
+			return hits[i].Distance < hits[j].Distance
+		}
+		c1, c2 := idx.Component(hits[i].Entry), idx.Component(hits[j].Entry)
+		if c1.PURL != c2.PURL {
+			return c1.PURL < c2.PURL
+		}
+		if hits[i].File != hits[j].File {
+			return hits[i].File < hits[j].File
+		}
+		return hits[i].Range < hits[j].Range
+	})
+	return hits
+}
+
+// blockValue returns the bits of the nth of the blocks
+func blockValue(value uint64, n, blocks int) uint64 {
+	start := n * hashBits / blocks
+	end := (n + 1) * hashBits / blocks
+	return (value >> uint(start)) & (uint64(1)<<uint(end-start) - 1)
+}
+
+// ParseValue parses a hex fingerprint value
+func ParseValue(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flipBits flips n different random bits of value
+func flipBits(r *rand.Rand, value uint64, n int) uint64 {
+	for _, i := range r.Perm(hashBits)[:n] {
+		value ^= 1 << uint(i)
+	}
+	return value
+}
+
+func TestIndex_Query(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, maxDistance := range []int{0, 3, 6} {
+		idx := NewIndex(maxDistance)
+		c := idx.AddComponent(Component{PURL: "pkg:generic/a@1.0"})
+		values := make([]uint64, 0)
+		for i := 0; i < 2000; i++ {
+			value := r.Uint64()
+			values = append(values, value)
+			idx.Add(Entry{Component: c, Value: value})
+			// near duplicates of the value
+			for d := 1; d <= maxDistance+2; d++ {
+				near := flipBits(r, value, d)
+				values = append(values, near)
+				idx.Add(Entry{Component: c, Value: near})
+			}
+		}
+		assert.Equal(t, maxDistance, idx.MaxDistance())
+		for i := 0; i < 200; i++ {
+			query := flipBits(r, values[r.Intn(len(values))], r.Intn(maxDistance+1))
+			// the index finds same entries as a linear scan
+			want := 0
+			for _, value := range values {
+				if bits.OnesCount64(value^query) <= maxDistance {
+					want++
+				}
+			}
+			hits := idx.Query(query, maxDistance)
+			assert.Equal(t, want, len(hits))
+			for j, hit := range hits {
+				assert.Equal(t, bits.OnesCount64(hit.Value^query), hit.Distance)
+				if j > 0 {
+					assert.LessOrEqual(t, hits[j-1].Distance, hit.Distance)
+				}
+			}
+		}
+	}
+}
+
+func TestBlockValue(t *testing.T) {
+	value := uint64(0x0123456789abcdef)
+	assert.Equal(t, []uint64{0xcdef, 0x89ab, 0x4567, 0x0123}, []uint64{
+		blockValue(value, 0, 4), blockValue(value, 1, 4), blockValue(value, 2, 4), blockValue(value, 3, 4),
+	})
+	assert.Equal(t, []uint64{0x89abcdef, 0x01234567}, []uint64{blockValue(value, 0, 2), blockValue(value, 1, 2)})
+}
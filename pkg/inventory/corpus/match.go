@@ -0,0 +1,205 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// DefaultTop is the default number of the nearest matches reported for a file or a snippet
+const DefaultTop = 3
+
+// Match is a known file or snippet matched
+type Match struct {
+	PURL     string `json:"purl"`
+	File     string `json:"file"`
+	Range    string `json:"range,omitempty"`
+	Distance int    `json:"distance"`
+}
+
+// FileMatch is a file or a snippet of the input and its nearest matches
+type FileMatch struct {
+	File        string  `json:"file"`
+	Range       string  `json:"range,omitempty"`
+	Fingerprint string  `json:"fingerprint"`
+	Matches     []Match `json:"matches"`
+}
+
+// ComponentMatch sums up the matches of a component
+type ComponentMatch struct {
+	Component
+	// Files is the number of input files with the whole file or snippets matched
+	Files int `json:"files"`
+	// Snippets is the number of input snippets matched
+	Snippets int `json:"snippets"`
+	// Distance is the distance of the nearest match
+	Distance int `json:"distance"`
+}
+
+// Report is the result of matching fingerprints against a corpus
+type Report struct {
+	Components []ComponentMatch `json:"components"`
+	Files      []FileMatch      `json:"files"`
+}
+
+// Match finds the nearest known files and snippets of the file fingerprints, at most top matches within
+// maxDistance are reported for each file and each snippet
+func (idx *Index) Match(files []model.FileFingerprint, maxDistance, top int) *Report {
+	m := newMatcher(idx, maxDistance, top)
+	for _, f := range files {
+		if f.Lines >= minFileLines {
+			m.match(f.File, "", f.Fingerprint.File)
+		}
+		for _, snippet := range f.Fingerprint.Snippet {
+			m.match(f.File, snippet.Range, snippet.Value)
+		}
+	}
+	return m.report()
+}
+
+// MatchValues finds the nearest known files and snippets of the hex fingerprint values
+func (idx *Index) MatchValues(values []string, maxDistance, top int) (*Report, error) {
+	m := newMatcher(idx, maxDistance, top)
+	for _, value := range values {
+		if _, err := ParseValue(value); err != nil {
+			return nil, fmt.Errorf("invalid fingerprint %s: %w", value, err)
+		}
+		m.match("", "", value)
+	}
+	return m.report(), nil
+}
+
+// matcher collects the matches of the files and snippets, and sums them up by component
+type matcher struct {
+	idx            *Index
+	maxDistance    int
+	top            int
+	files          []FileMatch
+	components     map[string]*ComponentMatch
+	componentFiles map[string]map[string]struct{}
+}
+
+func newMatcher(idx *Index, maxDistance, top int) *matcher {
+	return &matcher{
+		idx:            idx,
+		maxDistance:    maxDistance,
+		top:            top,
+		files:          make([]FileMatch, 0),
+		components:     make(map[string]*ComponentMatch),
+		componentFiles: make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *matcher) match(file, rng, value string) {
+	v, err := ParseValue(value)
+	if err != nil {
+		return
+	}
+	hits := m.idx.Query(v, m.maxDistance)
+	if len(hits) == 0 {
+		return
+	}
+	fm := FileMatch{File: file, Range: rng, Fingerprint: value}
+	counted := make(map[string]struct{})
+	for i, hit := range hits {
+		c := m.idx.Component(hit.Entry)
+		if i < m.top {
+			fm.Matches = append(fm.Matches, Match{PURL: c.PURL, File: hit.File, Range: hit.Range, Distance: hit.Distance})
+		}
+		if _, ok := counted[c.PURL]; ok {
+			continue
+		}
+		counted[c.PURL] = struct{}{}
+		cm, ok := m.components[c.PURL]
+		if !ok {
+			cm = &ComponentMatch{Component: c, Distance: hit.Distance}
+			m.components[c.PURL] = cm
+			m.componentFiles[c.PURL] = make(map[string]struct{})
+		}
+		if hit.Distance < cm.Distance {
+			cm.Distance = hit.Distance
+		}
+		if rng != "" {
+			cm.Snippets++
+		}
+		m.componentFiles[c.PURL][file] = struct{}{}
+	}
+	m.files = append(m.files, fm)
+}
+
+func (m *matcher) report() *Report {
+	report := &Report{Components: make([]ComponentMatch, 0), Files: m.files}
+	for purl, cm := range m.components {
+		cm.Files = len(m.componentFiles[purl])
+		report.Components = append(report.Components, *cm)
+	}
+	sort.Slice(report.Components, func(i, j int) bool {
+		c1, c2 := report.Components[i], report.Components[j]
+		if c1.Files != c2.Files {
+			return c1.Files > c2.Files
+		}
+		return c1.PURL < c2.PURL
+	})
+	return report
+}
+
+// AddComponents adds the matched components which are not declared in the sbom document as packages contained
+// by the artifact, a component is declared if a package has the same purl without version.
+// Returns the added packages.
+func AddComponents(doc *model.SBOM, report *Report) []model.Package {
+	declared := make(map[string]struct{})
+	declared[purlKey(doc.Artifact.PURL)] = struct{}{}
+	for _, pkg := range doc.Packages {
+		declared[purlKey(pkg.PURL)] = struct{}{}
+	}
+	added := make([]model.Package, 0)
+	for _, cm := range report.Components {
+		key := purlKey(cm.PURL)
+		if _, ok := declared[key]; ok {
+			continue
+		}
+		declared[key] = struct{}{}
+		pkg := model.Package{
+			Name:    cm.Name,
+			Version: cm.Version,
+			Type:    model.PkgTypeGeneric,
+			PURL:    cm.PURL,
+		}
+		if p, err := packageurl.FromString(cm.PURL); err == nil {
+			pkg.Type = p.Type
+		}
+		added = append(added, pkg)
+		doc.Packages = append(doc.Packages, pkg)
+		if doc.Artifact.PURL != "" {
+			doc.Relationships = append(doc.Relationships, model.Relationship{
+				Type:   model.Contains,
+				FromID: doc.Artifact.PURL,
+				ToID:   pkg.PURL,
+			})
+		}
+	}
+	return added
+}
+
+// purlKey returns the purl without version
+func purlKey(purl string) string {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return purl
+	}
+	p.Version = ""
+	return p.ToString()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestIndex_Match(t *testing.T) {
+	idx, err := LoadManifest("test_material/corpus.json", DefaultMaxDistance)
+	assert.NoError(t, err)
+	assert.Equal(t, []Component{{PURL: "pkg:github/madler/zlib@1.3.1", Name: "madler/zlib", Version: "1.3.1"}},
+		idx.Components())
+
+	files, err := LoadFingerprints("test_material/proj/source.json")
+	assert.NoError(t, err)
+	report := idx.Match(files, DefaultMaxDistance, DefaultTop)
+	// the function copied into a larger file is matched by its snippet
+	assert.Equal(t, []FileMatch{{
+		File:        "/util.c",
+		Range:       "L14-L28",
+		Fingerprint: "e18c8f6f77f60e7d",
+		Matches: []Match{
+			{PURL: "pkg:github/madler/zlib@1.3.1", File: "/adler32.c", Range: "L35-L49", Distance: 0},
+		},
+	}}, report.Files)
+	assert.Equal(t, []ComponentMatch{{Component: idx.Components()[0], Files: 1, Snippets: 1, Distance: 0}},
+		report.Components)
+
+	// the whole known file matches itself
+	known, err := LoadFingerprints("test_material/known/source.json")
+	assert.NoError(t, err)
+	report = idx.Match(known, 0, 1)
+	assert.Equal(t, 3, len(report.Files))
+	assert.Equal(t, "", report.Files[0].Range)
+	assert.Equal(t, []ComponentMatch{{Component: idx.Components()[0], Files: 1, Snippets: 2, Distance: 0}},
+		report.Components)
+}
+
+func TestAddComponents(t *testing.T) {
+	report := &Report{Components: []ComponentMatch{
+		{Component: Component{PURL: "pkg:github/madler/zlib@1.3.1", Name: "madler/zlib", Version: "1.3.1"}},
+		{Component: Component{PURL: "pkg:npm/lodash@4.17.21", Name: "lodash", Version: "4.17.21"}},
+	}}
+	doc := &model.SBOM{
+		Artifact: model.Artifact{Package: model.Package{Name: "app", PURL: "pkg:generic/app@1.0"}},
+		Packages: []model.Package{{Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20"}},
+	}
+	added := AddComponents(doc, report)
+	assert.Equal(t, []model.Package{
+		{Name: "madler/zlib", Version: "1.3.1", Type: "github", PURL: "pkg:github/madler/zlib@1.3.1"},
+	}, added)
+	assert.Equal(t, 2, len(doc.Packages))
+	assert.Equal(t, []model.Relationship{
+		{Type: model.Contains, FromID: "pkg:generic/app@1.0", ToID: "pkg:github/madler/zlib@1.3.1"},
+	}, doc.Relationships)
+}
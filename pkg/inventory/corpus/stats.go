@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import "sort"
+
+// ComponentStats is the number of files and fingerprints of a component
+type ComponentStats struct {
+	Component
+	Files        int `json:"files"`
+	Fingerprints int `json:"fingerprints"`
+	Snippets     int `json:"snippets"`
+}
+
+// Stats is the statistics of a corpus
+type Stats struct {
+	MaxDistance  int              `json:"maxDistance"`
+	Blocks       int              `json:"blocks"`
+	Files        int              `json:"files"`
+	Fingerprints int              `json:"fingerprints"`
+	Snippets     int              `json:"snippets"`
+	Size         int64            `json:"size,omitempty"`
+	Components   []ComponentStats `json:"components"`
+}
+
+// Stats counts the files and fingerprints of the corpus
+func (idx *Index) Stats() *Stats {
+	stats := &Stats{
+		MaxDistance:  idx.maxDistance,
+		Blocks:       idx.blocks,
+		Fingerprints: len(idx.entries),
+		Components:   make([]ComponentStats, len(idx.components)),
+	}
+	files := make([]map[string]struct{}, len(idx.components))
+	for i, c := range idx.components {
+		stats.Components[i].Component = c
+		files[i] = make(map[string]struct{})
+	}
+	for _, e := range idx.entries {
+		cs := &stats.Components[e.Component]
+		cs.Fingerprints++
+		if e.Range != "" {
+			cs.Snippets++
+			stats.Snippets++
+		}
+		files[e.Component][e.File] = struct{}{}
+	}
+	for i := range stats.Components {
+		stats.Components[i].Files = len(files[i])
+		stats.Files += len(files[i])
+	}
+	sort.Slice(stats.Components, func(i, j int) bool {
+		return stats.Components[i].PURL < stats.Components[j].PURL
+	})
+	return stats
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// The corpus is stored in a directory:
+//
+//	corpus.json   metadata and the components with their file names
+//	entries.bin   fingerprint entries, 24 bytes each: value(8), component(4), file(4), start line(4), end line(4)
+//	block-N.bin   sorted table of the Nth block, 8 bytes each: block value(high 4) and entry id(low 4)
+//
+// All numbers are little endian.
+const (
+	storeFormat    = 1
+	metaFileName   = "corpus.json"
+	entriesName    = "entries.bin"
+	blockFileName  = "block-%d.bin"
+	entryRecordLen = 24
+	tableRecordLen = 8
+)
+
+// storeMeta is the metadata of a stored corpus
+type storeMeta struct {
+	Format      int              `json:"format"`
+	Algorithm   string           `json:"algorithm"`
+	MaxDistance int              `json:"maxDistance"`
+	Blocks      int              `json:"blocks"`
+	Entries     int              `json:"entries"`
+	Components  []storeComponent `json:"components"`
+}
+
+// storeComponent is a component and the names of its files referred by the entries
+type storeComponent struct {
+	Component
+	Files []string `json:"files"`
+}
+
+func algorithm() string {
+	return fingerprint.Algorithm + " " + fingerprint.AlgorithmVersion
+}
+
+// IsStore returns true if path is a directory of a stored corpus
+func IsStore(path string) bool {
+	_, err := os.Stat(filepath.Join(path, metaFileName))
+	return err == nil
+}
+
+// LoadIndex loads a stored corpus, or the components of a corpus manifest into an index finding all
+// fingerprints within maxDistance
+func LoadIndex(path string, maxDistance int) (*Index, error) {
+	if !IsStore(path) {
+		return LoadManifest(path, maxDistance)
+	}
+	idx, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if maxDistance > idx.MaxDistance() {
+		log.Warnf("max distance %d exceeds %d of the corpus, use %d", maxDistance, idx.MaxDistance(), idx.MaxDistance())
+	}
+	return idx, nil
+}
+
+// Open loads a stored corpus
+func Open(dir string) (*Index, error) {
+	data, err := os.ReadFile(filepath.Join(dir, metaFileName))
+	if err != nil {
+		return nil, fmt.Errorf("read corpus error: %w", err)
+	}
+	meta := &storeMeta{}
+	if err = json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("parse corpus metadata error: %w", err)
+	}
+	if meta.Format != storeFormat {
+		return nil, fmt.Errorf("unsupported corpus format: %d", meta.Format)
+	}
+	if meta.Algorithm != algorithm() {
+		return nil, fmt.Errorf("corpus fingerprints of %s are not comparable with %s, rebuild the corpus",
+			meta.Algorithm, algorithm())
+	}
+	idx := NewIndex(meta.MaxDistance)
+	if idx.blocks != meta.Blocks {
+		return nil, fmt.Errorf("invalid corpus blocks: %d", meta.Blocks)
+	}
+	for _, c := range meta.Components {
+		idx.AddComponent(c.Component)
+	}
+	if idx.entries, err = readEntries(filepath.Join(dir, entriesName), meta); err != nil {
+		return nil, err
+	}
+	for i := range idx.tables {
+		if idx.tables[i], err = readTable(filepath.Join(dir, fmt.Sprintf(blockFileName, i)), meta.Entries); err != nil {
+			return nil, err
+		}
+	}
+	log.Infof("loaded %d fingerprints of %d components from %s", idx.Size(), len(idx.Components()), dir)
+	return idx, nil
+}
+
+func readEntries(path string, meta *storeMeta) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read corpus entries error: %w", err)
+	}
+	if len(data) != meta.Entries*entryRecordLen {
+		return nil, fmt.Errorf("corrupted corpus entries: %s", path)
+	}
+	entries := make([]Entry, meta.Entries)
+	for i := range entries {
+		record := data[i*entryRecordLen : (i+1)*entryRecordLen]
+		component := int(binary.LittleEndian.Uint32(record[8:]))
+		file := int(binary.LittleEndian.Uint32(record[12:]))
+		if component >= len(meta.Components) || file >= len(meta.Components[component].Files) {
+			return nil, fmt.Errorf("corrupted corpus entries: %s", path)
+		}
+		entries[i] = Entry{
+			Component: component,
+			File:      meta.Components[component].Files[file],
+			Value:     binary.LittleEndian.Uint64(record),
+		}
+		if start, end := binary.LittleEndian.Uint32(record[16:]), binary.LittleEndian.Uint32(record[20:]); start > 0 {
+			entries[i].Range = fingerprint.FormatLineRange(int(start), int(end))
+		}
+	}
+	return entries, nil
+}
+
+func readTable(path string, size int) ([]uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read corpus table error: %w", err)
+	}
+	if len(data) != size*tableRecordLen {
+		return nil, fmt.Errorf("corrupted corpus table: %s", path)
+	}
+	table := make([]uint64, size)
+	for i := range table {
+		table[i] = binary.LittleEndian.Uint64(data[i*tableRecordLen:])
+	}
+	return table, nil
+}
+
+// Save stores the corpus into a directory, the files of a corpus stored before are replaced
+func (idx *Index) Save(dir string) error {
+	idx.sort()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create corpus dir error: %w", err)
+	}
+	meta := &storeMeta{
+		Format:      storeFormat,
+		Algorithm:   algorithm(),
+		MaxDistance: idx.maxDistance,
+		Blocks:      idx.blocks,
+		Entries:     len(idx.entries),
+		Components:  make([]storeComponent, len(idx.components)),
+	}
+	fileIDs := make([]map[string]uint32, len(idx.components))
+	for i, c := range idx.components {
+		meta.Components[i] = storeComponent{Component: c, Files: make([]string, 0)}
+		fileIDs[i] = make(map[string]uint32)
+	}
+	record := make([]byte, entryRecordLen)
+	err := writeFile(filepath.Join(dir, entriesName), func(w io.Writer) error {
+		for _, e := range idx.entries {
+			c := &meta.Components[e.Component]
+			fileID, ok := fileIDs[e.Component][e.File]
+			if !ok {
+				fileID = uint32(len(c.Files))
+				fileIDs[e.Component][e.File] = fileID
+				c.Files = append(c.Files, e.File)
+			}
+			var start, end int
+			if e.Range != "" {
+				start, end, _ = fingerprint.ParseLineRange(e.Range)
+			}
+			binary.LittleEndian.PutUint64(record, e.Value)
+			binary.LittleEndian.PutUint32(record[8:], uint32(e.Component))
+			binary.LittleEndian.PutUint32(record[12:], fileID)
+			binary.LittleEndian.PutUint32(record[16:], uint32(start))
+			binary.LittleEndian.PutUint32(record[20:], uint32(end))
+			if _, err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, table := range idx.tables {
+		err = writeFile(filepath.Join(dir, fmt.Sprintf(blockFileName, i)), func(w io.Writer) error {
+			return binary.Write(w, binary.LittleEndian, table)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// the metadata is written at last, a partly written corpus is detected by the sizes of the files
+	return writeFile(filepath.Join(dir, metaFileName), func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(meta)
+	})
+}
+
+// writeFile writes a file by a temporary file renamed after written
+func writeFile(path string, write func(w io.Writer) error) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create file error: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp)
+	}()
+	w := bufio.NewWriter(file)
+	if err = write(w); err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write %s error: %w", path, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// StoreSize returns the bytes of the files of a stored corpus
+func StoreSize(dir string) int64 {
+	var size int64
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && !e.IsDir() {
+			size += info.Size()
+		}
+	}
+	return size
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package corpus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
+)
+
+func TestIndex_Save(t *testing.T) {
+	idx, err := LoadManifest("test_material/corpus.json", DefaultMaxDistance)
+	assert.NoError(t, err)
+	dir := filepath.Join(t.TempDir(), "corpus")
+	assert.NoError(t, idx.Save(dir))
+	assert.True(t, IsStore(dir))
+	assert.False(t, IsStore("test_material/corpus.json"))
+
+	stored, err := Open(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, idx.Components(), stored.Components())
+	assert.Equal(t, idx.Stats(), stored.Stats())
+
+	files, err := LoadFingerprints("test_material/proj/source.json")
+	assert.NoError(t, err)
+	assert.Equal(t, idx.Match(files, DefaultMaxDistance, DefaultTop), stored.Match(files, DefaultMaxDistance, DefaultTop))
+
+	// a truncated corpus is rejected
+	assert.NoError(t, os.Truncate(filepath.Join(dir, "block-1.bin"), 8))
+	_, err = Open(dir)
+	assert.Error(t, err)
+}
+
+func TestIndex_AddSource(t *testing.T) {
+	cfg := &config.SourceConfig{
+		Path:        "test_material/lib",
+		SrcPath:     "test_material/lib",
+		Parallelism: 1,
+		Language:    "cpp",
+		SnippetMode: fingerprint.SnippetModeFunction,
+		NoCache:     true,
+	}
+	cfg.InitIgnoreDirs()
+	c, err := NewComponent("pkg:generic/checksum@1.0")
+	assert.NoError(t, err)
+	idx := NewIndex(DefaultMaxDistance)
+	assert.NoError(t, idx.AddSource(c, cfg))
+	stats := idx.Stats()
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, 3, stats.Fingerprints)
+	assert.Equal(t, 2, stats.Snippets)
+
+	dir := t.TempDir()
+	assert.NoError(t, idx.Save(dir))
+	stored, err := LoadIndex(dir, DefaultMaxDistance)
+	assert.NoError(t, err)
+
+	// adding the same purl again replaces the component
+	assert.NoError(t, stored.AddSource(c, cfg))
+	c2, err := NewComponent("pkg:generic/checksum@2.0")
+	assert.NoError(t, err)
+	assert.NoError(t, stored.AddSource(c2, cfg))
+	stats = stored.Stats()
+	assert.Equal(t, 6, stats.Fingerprints)
+	assert.Equal(t, []ComponentStats{
+		{Component: c, Files: 1, Fingerprints: 3, Snippets: 2},
+		{Component: c2, Files: 1, Fingerprints: 3, Snippets: 2},
+	}, stats.Components)
+
+	value := stored.entries[0].Value
+	report, err := stored.MatchValues([]string{fmt.Sprintf("%x", value)}, 0, DefaultTop)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(report.Components))
+	_, err = stored.MatchValues([]string{"not a fingerprint"}, 0, DefaultTop)
+	assert.Error(t, err)
+}
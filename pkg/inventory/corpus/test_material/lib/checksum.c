@@ -0,0 +1,27 @@
+#include <stddef.h>
+
+#define MOD_ADLER 65521
+
+unsigned long adler32(const unsigned char *data, size_t len)
+{
+    unsigned long a = 1, b = 0;
+    size_t index;
+
+    for (index = 0; index < len; ++index) {
+        a = (a + data[index]) % MOD_ADLER;
+        b = (b + a) % MOD_ADLER;
+    }
+    return (b << 16) | a;
+}
+
+unsigned long fletcher16(const unsigned char *data, size_t len)
+{
+    unsigned long sum1 = 0, sum2 = 0;
+    size_t index;
+
+    for (index = 0; index < len; ++index) {
+        sum1 = (sum1 + data[index]) % 255;
+        sum2 = (sum2 + sum1) % 255;
+    }
+    return (sum2 << 8) | sum1;
+}
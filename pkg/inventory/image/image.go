@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package image
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// Image is a local container image squashed into a root filesystem, no registry is accessed
+type Image struct {
+	Info   model.Image
+	Root   string         // the squashed root filesystem
+	owners map[string]int // slash path relative to the root -> index of the layer
+	tmpDir string
+}
+
+// Load unpacks the image and squashes its layers in order into a temporary root filesystem, which is removed by Close
+func Load(ref string) (*Image, error) {
+	reference, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(reference.Path); err != nil {
+		return nil, fmt.Errorf("image path is invalid: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp("", "sbom-tool-image-")
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{
+		Info:   model.Image{Reference: reference.String()},
+		Root:   filepath.Join(tmpDir, "rootfs"),
+		tmpDir: tmpDir,
+	}
+	if err = img.load(reference); err != nil {
+		_ = img.Close()
+		return nil, err
+	}
+	return img, nil
+}
+
+func (img *Image) load(reference *Reference) error {
+	dir := reference.Path
+	if reference.IsArchive() {
+		dir = filepath.Join(img.tmpDir, "layout")
+		log.Infof("unpacking image: %s", reference.Path)
+		if err := unpack(reference.Path, dir); err != nil {
+			return fmt.Errorf("unpack image error: %w", err)
+		}
+	}
+	var layers []layerBlob
+	var err error
+	if reference.Transport == TransportDockerArchive {
+		layers, err = readDockerArchive(dir, &img.Info)
+	} else {
+		layers, err = readOCILayout(dir, &img.Info)
+	}
+	if err != nil {
+		return fmt.Errorf("read image error: %w", err)
+	}
+
+	s := newSquasher(img.Root)
+	if err = os.MkdirAll(img.Root, 0o755); err != nil {
+		return err
+	}
+	img.Info.Layers = make([]model.Layer, 0, len(layers))
+	for i, l := range layers {
+		log.Infof("applying image layer: %s", l.layer.DiffID)
+		if err = s.apply(l.path, i); err != nil {
+			return fmt.Errorf("apply layer %s error: %w", l.layer.Digest, err)
+		}
+		img.Info.Layers = append(img.Info.Layers, l.layer)
+	}
+	img.owners = s.owners
+	return nil
+}
+
+// Close removes the unpacked image
+func (img *Image) Close() error {
+	return os.RemoveAll(img.tmpDir)
+}
+
+// LayerOf returns the diff id of the layer the file is taken from, the path is relative to the root
+func (img *Image) LayerOf(path string) string {
+	if idx, ok := img.layerIndex(path); ok {
+		return img.Info.Layers[idx].DiffID
+	}
+	return ""
+}
+
+func (img *Image) layerIndex(path string) (int, bool) {
+	name, ok := cleanName(path)
+	if !ok {
+		return 0, false
+	}
+	idx, ok := img.owners[name]
+	return idx, ok && idx < len(img.Info.Layers)
+}
+
+// AssignLayers records the layers introducing the packages, whose paths are relative to the root.
+// A package is introduced by the lowest layer of its owned files, since an upgrade rewrites all of them,
+// the layer of the manifest the package is found in is used if the files are unknown
+func (img *Image) AssignLayers(pkgs []model.Package) {
+	for i := range pkgs {
+		lowest := -1
+		for _, file := range pkgs[i].Files {
+			if idx, ok := img.layerIndex(file); ok && (lowest < 0 || idx < lowest) {
+				lowest = idx
+			}
+		}
+		if lowest < 0 {
+			location := strings.SplitN(pkgs[i].SourceLocation, "!", 2)[0]
+			if idx, ok := img.layerIndex(location); ok {
+				lowest = idx
+			}
+		}
+		if lowest >= 0 {
+			pkgs[i].Layer = img.Info.Layers[lowest].DiffID
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package image
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+const (
+	baseLayer  = "sha256:6e33346e1d4621a676e8eefbe31f60171c46f08282657269a0e28dab7f1e8943"
+	curlLayer  = "sha256:dd1aacd44dc6aebd995a9a707f4452859b03b460aab072fb51f85bc52cc863eb"
+	appLayer   = "sha256:94662d835f642db26328f76b18c6990fa503164b2be8d7628a33c5cb50193db4"
+	imageID    = "sha256:eca06e1bd2abc8be43253e26b7b6af4ce3466eaaee5b85eb942926f78a70d860"
+	manifestID = "sha256:67a8f32ef16a1b61ef3b9e18016afda6caf1fbe7b972bd81f0ea456a6ed46e1f"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("docker-archive:/path/to/img.tar")
+	assert.NoError(t, err)
+	assert.Equal(t, &Reference{Transport: TransportDockerArchive, Path: "/path/to/img.tar"}, ref)
+	assert.True(t, ref.IsArchive())
+
+	ref, err = ParseReference("oci-dir:C:/path/to/layout")
+	assert.NoError(t, err)
+	assert.Equal(t, &Reference{Transport: TransportOCIDir, Path: "C:/path/to/layout"}, ref)
+	assert.False(t, ref.IsArchive())
+
+	for _, invalid := range []string{"/path/to/img.tar", "oci-dir:", "docker://debian:12"} {
+		_, err = ParseReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// the blobs of the OCI layout are compressed by gzip, none and zstd
+	ociLayerBlobs := []string{
+		"sha256:cc1f16537a040a9d8e1146d014970443b5d6f7d9d0b5703890f2e22af36349b4",
+		curlLayer,
+		"sha256:e5d45a1be87bc51df3a352005fd1cc9f4babf59d13b9c9e5d2b66cff77190318",
+	}
+	tests := []struct {
+		ref        string
+		digest     string
+		baseName   string
+		layerBlobs []string
+	}{
+		{
+			ref:        "oci-dir:test_material/oci",
+			digest:     manifestID,
+			baseName:   "docker.io/library/debian:bookworm",
+			layerBlobs: ociLayerBlobs,
+		},
+		{
+			ref:        "oci-archive:test_material/oci-archive.tar",
+			digest:     manifestID,
+			baseName:   "docker.io/library/debian:bookworm",
+			layerBlobs: ociLayerBlobs,
+		},
+		{
+			ref:        "docker-archive:test_material/docker-archive.tar.gz",
+			layerBlobs: []string{baseLayer, curlLayer, appLayer},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			img, err := Load(tt.ref)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer func() {
+				_ = img.Close()
+			}()
+
+			info := img.Info
+			assert.Equal(t, tt.ref, info.Reference)
+			assert.Equal(t, tt.digest, info.Digest)
+			assert.Equal(t, imageID, info.Config)
+			assert.Equal(t, "linux", info.OS)
+			assert.Equal(t, "amd64", info.Arch)
+			assert.Equal(t, "2024-03-01T10:00:00Z", info.Created)
+			assert.Equal(t, "Example Inc.", info.Labels["org.opencontainers.image.vendor"])
+			assert.Equal(t, tt.baseName, info.BaseName)
+			assert.Equal(t, []string{baseLayer, curlLayer, appLayer}, util.SliceMap(info.Layers, func(l model.Layer) string {
+				return l.DiffID
+			}))
+			for i, layer := range info.Layers {
+				assert.Equal(t, tt.layerBlobs[i], layer.Digest)
+			}
+			assert.Equal(t, "RUN /bin/sh -c apt-get install -y curl # buildkit", info.Layers[1].CreatedBy)
+
+			exists := func(name string) bool {
+				_, err := os.Lstat(filepath.Join(img.Root, filepath.FromSlash(name)))
+				return err == nil
+			}
+			for _, name := range []string{"etc/os-release", "usr/bin/curl", "usr/bin/curl-hardlink", "opt/app/new.txt",
+				"app/package.json", "escape", "outside"} {
+				assert.True(t, exists(name), name)
+			}
+			// removed by the whiteouts and the opaque dir
+			for _, name := range []string{"etc/removed.conf", "usr/share/zoneinfo", "var/lib/dpkg/info/tzdata.list",
+				"opt/app/old.txt", "opt/app/lib/keep.txt", "escape/pwned"} {
+				assert.False(t, exists(name), name)
+			}
+
+			assert.Equal(t, baseLayer, img.LayerOf("etc/os-release"))
+			assert.Equal(t, curlLayer, img.LayerOf("var/lib/dpkg/status"))
+			assert.Equal(t, curlLayer, img.LayerOf("/usr/bin/curl"))
+			assert.Equal(t, appLayer, img.LayerOf("app/package.json"))
+			assert.Equal(t, "", img.LayerOf("etc/removed.conf"))
+
+			root := img.Root
+			assert.NoError(t, img.Close())
+			_, err = os.Stat(root)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestImage_AssignLayers(t *testing.T) {
+	img, err := Load("oci-dir:test_material/oci")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() {
+		_ = img.Close()
+	}()
+	pkgs := []model.Package{
+		{Name: "libc6", SourceLocation: "var/lib/dpkg/status", Files: []string{"usr/lib/x86_64-linux-gnu/libc.so.6"}},
+		{Name: "curl", SourceLocation: "var/lib/dpkg/status", Files: []string{"usr/bin/curl"}},
+		{Name: "demo-app", SourceLocation: "app/package.json"},
+		{Name: "unknown", SourceLocation: "etc/removed.conf"},
+	}
+	img.AssignLayers(pkgs)
+	assert.Equal(t, []string{baseLayer, curlLayer, appLayer, ""}, util.SliceMap(pkgs, func(p model.Package) string {
+		return p.Layer
+	}))
+}
+
+func TestUnpack_ChainedSymlinks(t *testing.T) {
+	parent := t.TempDir()
+	archive := filepath.Join(parent, "img.tar")
+	f, err := os.Create(archive)
+	if !assert.NoError(t, err) {
+		return
+	}
+	tw := tar.NewWriter(f)
+	entries := []tar.Header{
+		{Name: "a", Typeflag: tar.TypeSymlink, Linkname: "."},
+		// each link stays inside by its text, but passes through the link written before
+		{Name: "a/l", Typeflag: tar.TypeSymlink, Linkname: "../outside"},
+		{Name: "b", Typeflag: tar.TypeSymlink, Linkname: "a/../outside"},
+		{Name: "a/l/evil", Typeflag: tar.TypeReg, Size: 4, Mode: 0o644},
+		{Name: "b", Typeflag: tar.TypeReg, Size: 4, Mode: 0o644},
+	}
+	for i := range entries {
+		assert.NoError(t, tw.WriteHeader(&entries[i]))
+		if entries[i].Typeflag == tar.TypeReg {
+			_, err = tw.Write([]byte("evil"))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, f.Close())
+	assert.NoError(t, os.Mkdir(filepath.Join(parent, "outside"), 0o755))
+
+	dir := filepath.Join(parent, "unpack")
+	assert.NoError(t, unpack(archive, dir))
+	_, err = os.Stat(filepath.Join(parent, "outside", "evil"))
+	assert.True(t, os.IsNotExist(err), "file written out of the unpack dir")
+	entriesOutside, err := os.ReadDir(filepath.Join(parent, "outside"))
+	assert.NoError(t, err)
+	assert.Empty(t, entriesOutside)
+	_, err = os.Lstat(filepath.Join(dir, "a", "l"))
+	assert.True(t, os.IsNotExist(err), "symlink written through a symlink")
+	stat, err := os.Lstat(filepath.Join(dir, "b"))
+	if assert.NoError(t, err) {
+		assert.True(t, stat.Mode().IsRegular())
+	}
+}
+
+func TestSquasher_AbsoluteSymlinks(t *testing.T) {
+	parent := t.TempDir()
+	layer := filepath.Join(parent, "layer.tar")
+	f, err := os.Create(layer)
+	if !assert.NoError(t, err) {
+		return
+	}
+	release := []byte("ID=alpine\nVERSION_ID=3.18.4\n")
+	tw := tar.NewWriter(f)
+	entries := []tar.Header{
+		{Name: "usr/lib/os-release", Typeflag: tar.TypeReg, Size: int64(len(release)), Mode: 0o644},
+		{Name: "etc/os-release", Typeflag: tar.TypeSymlink, Linkname: "/usr/lib/os-release"},
+		{Name: "etc/release", Typeflag: tar.TypeSymlink, Linkname: "../../../usr/lib/os-release"},
+	}
+	for i := range entries {
+		assert.NoError(t, tw.WriteHeader(&entries[i]))
+		if entries[i].Typeflag == tar.TypeReg {
+			_, err = tw.Write(release)
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, f.Close())
+
+	root := filepath.Join(parent, "rootfs")
+	assert.NoError(t, os.Mkdir(root, 0o755))
+	assert.NoError(t, newSquasher(root).apply(layer, 0))
+	for _, name := range []string{"etc/os-release", "etc/release"} {
+		link, err := os.Readlink(filepath.Join(root, name))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "../usr/lib/os-release", link)
+		}
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if assert.NoError(t, err) {
+			assert.Equal(t, release, data)
+		}
+	}
+}
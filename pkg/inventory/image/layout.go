@@ -0,0 +1,308 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// media types of the image indexes
+const (
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// annotations of the base image
+// see: https://github.com/opencontainers/image-spec/blob/main/annotations.md
+const (
+	annotationBaseName   = "org.opencontainers.image.base.name"
+	annotationBaseDigest = "org.opencontainers.image.base.digest"
+)
+
+var digestReg = regexp.MustCompile(`^([a-z0-9]+):([a-f0-9]+)$`)
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant"`
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *platform         `json:"platform"`
+}
+
+type index struct {
+	MediaType string       `json:"mediaType"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+type manifest struct {
+	Config      descriptor        `json:"config"`
+	Layers      []descriptor      `json:"layers"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant"`
+	Created      string `json:"created"`
+	Config       struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+	RootFS struct {
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// dockerManifest is an entry of the manifest.json written by docker save
+type dockerManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// layerBlob is a layer to apply and the path of its blob
+type layerBlob struct {
+	path  string
+	layer model.Layer
+}
+
+// readOCILayout reads the image of an OCI image layout dir, the manifest for the current arch is picked
+// from a multi-platform index
+// see: https://github.com/opencontainers/image-spec/blob/main/image-layout.md
+func readOCILayout(dir string, info *model.Image) ([]layerBlob, error) {
+	idx := index{}
+	if err := readJSON(filepath.Join(dir, "index.json"), &idx); err != nil {
+		return nil, err
+	}
+	desc, err := selectManifest(dir, idx.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	m := manifest{}
+	if err = readBlobJSON(dir, desc.Digest, &m); err != nil {
+		return nil, err
+	}
+	configPath, err := blobPath(dir, m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	config := imageConfig{}
+	if err = readJSON(configPath, &config); err != nil {
+		return nil, err
+	}
+	info.Digest = desc.Digest
+	info.Config = m.Config.Digest
+	fillConfig(info, &config)
+	if name := m.Annotations[annotationBaseName]; name != "" {
+		info.BaseName = name
+		info.BaseDigest = m.Annotations[annotationBaseDigest]
+	}
+	if len(config.RootFS.DiffIDs) != len(m.Layers) {
+		return nil, fmt.Errorf("image has %d layers but %d diff ids", len(m.Layers), len(config.RootFS.DiffIDs))
+	}
+
+	layers := make([]layerBlob, 0, len(m.Layers))
+	for i, l := range m.Layers {
+		path, err := blobPath(dir, l.Digest)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layerBlob{path: path, layer: model.Layer{
+			Digest:    l.Digest,
+			DiffID:    config.RootFS.DiffIDs[i],
+			MediaType: l.MediaType,
+			Size:      l.Size,
+		}})
+	}
+	fillHistory(layers, &config)
+	return layers, nil
+}
+
+// readDockerArchive reads the image of a tarball unpacked into dir, which is saved by docker save,
+// the first image is read if several images are saved together
+func readDockerArchive(dir string, info *model.Image) ([]layerBlob, error) {
+	var manifests []dockerManifest
+	if err := readJSON(filepath.Join(dir, "manifest.json"), &manifests); err != nil {
+		return nil, err
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no image in manifest.json")
+	}
+	m := manifests[0]
+	configPath, err := archivePath(dir, m.Config)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := imageConfig{}
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse image config error: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	info.Config = "sha256:" + hex.EncodeToString(sum[:])
+	fillConfig(info, &config)
+	// the archives of docker 25 and later are OCI image layouts as well, which keep the manifest digest
+	if _, err := os.Stat(filepath.Join(dir, "index.json")); err == nil {
+		idx := index{}
+		if err := readJSON(filepath.Join(dir, "index.json"), &idx); err == nil {
+			if desc, err := selectManifest(dir, idx.Manifests); err == nil {
+				info.Digest = desc.Digest
+			}
+		}
+	}
+	if len(config.RootFS.DiffIDs) != len(m.Layers) {
+		return nil, fmt.Errorf("image has %d layers but %d diff ids", len(m.Layers), len(config.RootFS.DiffIDs))
+	}
+
+	layers := make([]layerBlob, 0, len(m.Layers))
+	for i, l := range m.Layers {
+		path, err := archivePath(dir, l)
+		if err != nil {
+			return nil, err
+		}
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		digest := config.RootFS.DiffIDs[i]
+		if strings.HasPrefix(filepath.ToSlash(l), "blobs/") {
+			digest = strings.Replace(strings.TrimPrefix(filepath.ToSlash(l), "blobs/"), "/", ":", 1)
+		}
+		layers = append(layers, layerBlob{path: path, layer: model.Layer{
+			Digest: digest,
+			DiffID: config.RootFS.DiffIDs[i],
+			Size:   stat.Size(),
+		}})
+	}
+	fillHistory(layers, &config)
+	return layers, nil
+}
+
+// fillConfig fills the platform, labels and the base image of the config into the image info
+func fillConfig(info *model.Image, config *imageConfig) {
+	info.OS = config.OS
+	info.Arch = config.Architecture
+	info.Variant = config.Variant
+	info.Created = config.Created
+	info.Labels = config.Config.Labels
+	if name := config.Config.Labels[annotationBaseName]; name != "" {
+		info.BaseName = name
+		info.BaseDigest = config.Config.Labels[annotationBaseDigest]
+	}
+}
+
+// fillHistory fills the commands creating the layers, the history of the empty layers is skipped
+func fillHistory(layers []layerBlob, config *imageConfig) {
+	i := 0
+	for _, h := range config.History {
+		if h.EmptyLayer {
+			continue
+		}
+		if i >= len(layers) {
+			return
+		}
+		layers[i].layer.CreatedBy = h.CreatedBy
+		i++
+	}
+}
+
+// selectManifest selects the image manifest from the descriptors, the nested indexes are walked,
+// the manifest of linux and the current arch is preferred, the attestation manifests of unknown platforms are skipped
+func selectManifest(dir string, descs []descriptor) (*descriptor, error) {
+	var candidates []descriptor
+	for _, desc := range descs {
+		if desc.Platform != nil && desc.Platform.OS == "unknown" {
+			continue
+		}
+		if desc.MediaType == mediaTypeOCIIndex || desc.MediaType == mediaTypeDockerManifestList {
+			idx := index{}
+			if err := readBlobJSON(dir, desc.Digest, &idx); err != nil {
+				return nil, err
+			}
+			nested, err := selectManifest(dir, idx.Manifests)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, *nested)
+			continue
+		}
+		candidates = append(candidates, desc)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no image manifest found")
+	}
+	for i := range candidates {
+		if p := candidates[i].Platform; p != nil && p.OS == "linux" && p.Architecture == runtime.GOARCH {
+			return &candidates[i], nil
+		}
+	}
+	return &candidates[0], nil
+}
+
+// blobPath returns the path of the blob in the OCI image layout
+func blobPath(dir, digest string) (string, error) {
+	groups := digestReg.FindStringSubmatch(digest)
+	if groups == nil {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	return filepath.Join(dir, "blobs", groups[1], groups[2]), nil
+}
+
+// archivePath returns the path of a file referenced by manifest.json, which must not escape the dir
+func archivePath(dir, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path %q in manifest.json", name)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+func readBlobJSON(dir, digest string, v interface{}) error {
+	path, err := blobPath(dir, digest)
+	if err != nil {
+		return err
+	}
+	return readJSON(path, v)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s error: %w", filepath.Base(path), err)
+	}
+	return nil
+}
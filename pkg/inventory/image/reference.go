@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package image
+
+import (
+	"fmt"
+	"strings"
+)
+
+// transports of the local image references
+const (
+	TransportOCIDir        = "oci-dir"        // a dir of the OCI image layout
+	TransportOCIArchive    = "oci-archive"    // a tarball of the OCI image layout
+	TransportDockerArchive = "docker-archive" // a tarball saved by docker save
+)
+
+var transports = []string{TransportOCIDir, TransportOCIArchive, TransportDockerArchive}
+
+// Reference is a reference to a local image, e.g. oci-dir:/path/to/layout or docker-archive:/path/to/image.tar
+type Reference struct {
+	Transport string
+	Path      string
+}
+
+// ParseReference parses the image reference in the form of <transport>:<path>
+func ParseReference(ref string) (*Reference, error) {
+	transport, path, ok := strings.Cut(ref, ":")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("invalid image reference %q, expect <transport>:<path>", ref)
+	}
+	for _, t := range transports {
+		if t == transport {
+			return &Reference{Transport: transport, Path: path}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported image transport %q, expect one of %s", transport, strings.Join(transports, "|"))
+}
+
+func (r *Reference) String() string {
+	return r.Transport + ":" + r.Path
+}
+
+// IsArchive returns true if the image is a tarball to unpack
+func (r *Reference) IsArchive() bool {
+	return r.Transport != TransportOCIDir
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// whiteouts of the layer changesets
+// see: https://github.com/opencontainers/image-spec/blob/main/layer.md#whiteouts
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// tarReadCloser closes the decompressor and the file of a tarball
+type tarReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *tarReadCloser) Close() error {
+	var err error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if e := r.closers[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// openTar opens a tarball, the gzip and zstd compressed tarballs are detected by the magic
+func openTar(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &tarReadCloser{Reader: gr, closers: []io.Closer{f, gr}}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &tarReadCloser{Reader: zr, closers: []io.Closer{f, zr.IOReadCloser()}}, nil
+	}
+	return &tarReadCloser{Reader: br, closers: []io.Closer{f}}, nil
+}
+
+// cleanName returns the slash path of a tar entry relative to the root, the entries escaping the root are rejected
+func cleanName(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	return name, name != ""
+}
+
+// unpack extracts the files, dirs and the symlinks inside dir of a tarball into dir
+func unpack(archive, dir string) error {
+	r, err := openTar(archive)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, ok := cleanName(hdr.Name)
+		if !ok {
+			continue
+		}
+		// nothing is written through a symlink written before, which may point out of dir
+		if underSymlink(dir, name) {
+			log.Warnf("skip archive entry %s under a symlink", hdr.Name)
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0o755)
+		case tar.TypeReg:
+			// a symlink in place of the file is replaced rather than followed
+			if isSymlink(dir, name) {
+				err = os.Remove(target)
+			}
+			if err == nil {
+				err = writeFile(target, tr, 0o644)
+			}
+		case tar.TypeSymlink:
+			// docker save links the duplicate layers to each other
+			if escapes(dir, name, hdr.Linkname) {
+				log.Warnf("skip symlink %s escaping the archive", hdr.Name)
+				continue
+			}
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err == nil {
+				err = os.Symlink(hdr.Linkname, target)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// underSymlink reports whether a parent dir of the slash path under root is a symlink
+func underSymlink(root, name string) bool {
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if isSymlink(root, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSymlink(root, name string) bool {
+	stat, err := os.Lstat(filepath.Join(root, filepath.FromSlash(name)))
+	return err == nil && stat.Mode()&fs.ModeSymlink != 0
+}
+
+// escapes reports whether the link of the symlink name leaves root, either by its text or by passing through
+// a symlink under root, e.g. b -> a/../x where a -> .
+func escapes(root, name, link string) bool {
+	link = filepath.ToSlash(link)
+	if path.IsAbs(link) {
+		return true
+	}
+	var parts []string
+	segments := strings.Split(path.Dir(name)+"/"+link, "/")
+	for i, segment := range segments {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(parts) == 0 {
+				return true
+			}
+			parts = parts[:len(parts)-1]
+		default:
+			parts = append(parts, segment)
+			if i < len(segments)-1 && isSymlink(root, path.Join(parts...)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeFile(target string, r io.Reader, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// squasher applies the layers in order to a root filesystem, and records the layer every file is taken from
+type squasher struct {
+	root   string
+	owners map[string]int // slash path relative to the root -> index of the layer
+}
+
+func newSquasher(root string) *squasher {
+	return &squasher{root: root, owners: make(map[string]int)}
+}
+
+// apply applies the changeset of a layer, the whiteouts remove the files of the lower layers
+func (s *squasher) apply(layerPath string, layer int) error {
+	r, err := openTar(layerPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if err = os.MkdirAll(s.root, 0o755); err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, ok := cleanName(hdr.Name)
+		if !ok {
+			continue
+		}
+		// nothing is written through a symlink, which may point out of the root
+		if s.underSymlink(name) {
+			log.Debugf("skip layer entry %s under a symlink", hdr.Name)
+			continue
+		}
+		dir, base := path.Split(name)
+		if base == whiteoutOpaque {
+			s.opaque(strings.TrimSuffix(dir, "/"), layer)
+			continue
+		}
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			s.remove(dir + strings.TrimPrefix(base, whiteoutPrefix))
+			continue
+		}
+		if err = s.write(name, hdr, tr, layer); err != nil {
+			log.Warnf("write layer entry %s error: %s", hdr.Name, err.Error())
+		}
+	}
+}
+
+func (s *squasher) write(name string, hdr *tar.Header, r io.Reader, layer int) error {
+	target := filepath.Join(s.root, filepath.FromSlash(name))
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if stat, err := os.Lstat(target); err == nil && !stat.IsDir() {
+			s.remove(name)
+		}
+		// the dirs are kept writable for the upper layers
+		return os.MkdirAll(target, 0o755)
+	case tar.TypeReg:
+		s.remove(name)
+		// the files are kept readable for the collectors
+		if err := writeFile(target, r, hdr.FileInfo().Mode().Perm()|0o600); err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		s.remove(name)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		// the link is resolved inside the root rather than on the host
+		if err := os.Symlink(rootedLink(name, hdr.Linkname), target); err != nil {
+			return err
+		}
+	case tar.TypeLink:
+		linkName, ok := cleanName(hdr.Linkname)
+		if !ok || s.underSymlink(linkName) {
+			return nil
+		}
+		s.remove(name)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.Link(filepath.Join(s.root, filepath.FromSlash(linkName)), target); err != nil {
+			return err
+		}
+	default:
+		// devices and fifos are not needed to collect the packages
+		return nil
+	}
+	s.owners[name] = layer
+	return nil
+}
+
+// rootedLink returns the link of the symlink name relative to its dir, with the absolute links and the leading ".."
+// resolved against the root of the image, e.g. etc/os-release -> /usr/lib/os-release becomes ../usr/lib/os-release
+func rootedLink(name, link string) string {
+	link = filepath.ToSlash(link)
+	if !path.IsAbs(link) {
+		link = path.Join(path.Dir(name), link)
+	}
+	rel, err := filepath.Rel("/"+path.Dir(name), path.Join("/", link))
+	if err != nil {
+		return "."
+	}
+	return rel
+}
+
+// underSymlink returns true if any parent dir of the path is a symlink
+func (s *squasher) underSymlink(name string) bool {
+	return underSymlink(s.root, name)
+}
+
+// remove removes the path and all files under it
+func (s *squasher) remove(name string) {
+	target := filepath.Join(s.root, filepath.FromSlash(name))
+	stat, err := os.Lstat(target)
+	if err != nil {
+		return
+	}
+	if err = os.RemoveAll(target); err != nil {
+		log.Warnf("remove %s error: %s", name, err.Error())
+	}
+	delete(s.owners, name)
+	if stat.IsDir() {
+		for owned := range s.owners {
+			if strings.HasPrefix(owned, name+"/") {
+				delete(s.owners, owned)
+			}
+		}
+	}
+}
+
+// opaque removes the files of the lower layers under the dir, the files of the current layer are kept
+func (s *squasher) opaque(dir string, layer int) {
+	root := filepath.Join(s.root, filepath.FromSlash(dir))
+	if stat, err := os.Lstat(root); err != nil || !stat.IsDir() {
+		return
+	}
+	_ = filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(s.root, p)
+		rel = filepath.ToSlash(rel)
+		if owner, ok := s.owners[rel]; !ok || owner != layer {
+			_ = os.Remove(p)
+			delete(s.owners, rel)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package apk
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const installedDBPath = "lib/apk/db/installed"
+
+// installedEntry is a package in the installed database
+type installedEntry struct {
+	name       string
+	version    string
+	arch       string
+	license    string
+	maintainer string
+	depends    []string
+	provides   []string
+	files      []string
+}
+
+// InstalledDBParser is a parser for lib/apk/db/installed, the database of the packages installed by apk,
+// a package owns the files listed in the F: and R: lines
+// see: https://wiki.alpinelinux.org/wiki/Apk_spec#Installed_Database_V2
+type InstalledDBParser struct{}
+
+func NewInstalledDBParser() *InstalledDBParser {
+	return &InstalledDBParser{}
+}
+
+func (p *InstalledDBParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/lib/apk/db/installed$`),
+	}}
+}
+
+func (p *InstalledDBParser) ProvidesGraph() bool {
+	return true
+}
+
+func (p *InstalledDBParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("parse path %s", path)
+	entries, err := readInstalledDB(path)
+	if err != nil {
+		return nil, err
+	}
+	root := collector.RootOf(path, installedDBPath)
+	release := collector.ReadOSRelease(root)
+
+	// the installed packages and the names, shared objects and commands provided by them
+	installed := make(map[string]string)
+	for _, e := range entries {
+		purl := packageURL(e.name, e.version, e.arch, release)
+		installed[e.name] = purl
+		for _, provide := range e.provides {
+			if _, ok := installed[provide]; !ok {
+				installed[provide] = purl
+			}
+		}
+	}
+
+	pkgs := make([]model.Package, 0, len(entries))
+	for _, e := range entries {
+		pkg := newPackage(e.name, e.version, e.arch, path, release)
+		pkg.Supplier = e.maintainer
+		if e.license != "" {
+			pkg.LicenseDeclared = license.NormalizeExpressions([]string{e.license})
+		}
+		for _, depend := range e.depends {
+			if purl, ok := installed[depend]; ok && purl != pkg.PURL {
+				pkg.Dependencies = append(pkg.Dependencies, purl)
+			}
+		}
+		pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+		for _, file := range e.files {
+			pkg.Files = append(pkg.Files, filepath.Join(root, filepath.FromSlash(file)))
+		}
+		pkgs = append(pkgs, *pkg)
+	}
+	return pkgs, nil
+}
+
+func readInstalledDB(path string) ([]installedEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make([]installedEntry, 0)
+	var entry installedEntry
+	var dir string
+	flush := func() {
+		if entry.name != "" {
+			entries = append(entries, entry)
+		}
+		entry = installedEntry{}
+		dir = ""
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "P":
+			entry.name = value
+		case "V":
+			entry.version = value
+		case "A":
+			entry.arch = value
+		case "L":
+			entry.license = value
+		case "m":
+			entry.maintainer = value
+		case "D":
+			for _, depend := range strings.Fields(value) {
+				if strings.HasPrefix(depend, "!") {
+					// conflict
+					continue
+				}
+				entry.depends = append(entry.depends, dependName(depend))
+			}
+		case "p":
+			for _, provide := range strings.Fields(value) {
+				entry.provides = append(entry.provides, dependName(provide))
+			}
+		case "F":
+			dir = value
+		case "R":
+			entry.files = append(entry.files, dir+"/"+value)
+		}
+	}
+	flush()
+	return entries, scanner.Err()
+}
+
+// dependName returns the name of a dependency or a provided name without the version constraint,
+// e.g. so:libc.musl-x86_64.so.1=1 or openssl>3
+func dependName(depend string) string {
+	if i := strings.IndexAny(depend, "<>=~"); i > 0 {
+		return depend[:i]
+	}
+	return depend
+}
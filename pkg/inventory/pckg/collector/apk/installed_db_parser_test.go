@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package apk
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestInstalledDBParser_Parse(t *testing.T) {
+	root := "test_material/rootfs"
+	want := []model.Package{
+		{Name: "musl", Version: "1.2.4-r2", PURL: "pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.18.4",
+			Supplier: "Timo Teräs <timo.teras@iki.fi>", LicenseDeclared: []string{"MIT"},
+			Files: []string{"lib/ld-musl-x86_64.so.1", "lib/libc.musl-x86_64.so.1"}},
+		{Name: "busybox", Version: "1.36.1-r2", PURL: "pkg:apk/alpine/busybox@1.36.1-r2?arch=x86_64&distro=alpine-3.18.4",
+			Supplier:        "Sören Tempel <soeren+alpine@soeren-tempel.net>",
+			LicenseDeclared: []string{"GPL-2.0-only"},
+			Dependencies:    []string{"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.18.4"},
+			Files:           []string{"bin/busybox", "bin/sh", "etc/securetty", "etc/udhcpd.conf"}},
+		{Name: "libcrypto3", Version: "3.1.4-r0", PURL: "pkg:apk/alpine/libcrypto3@3.1.4-r0?arch=x86_64&distro=alpine-3.18.4",
+			Supplier:        "Ariadne Conill <ariadne@dereferenced.org>",
+			LicenseDeclared: []string{"Apache-2.0"},
+			Dependencies:    []string{"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.18.4"},
+			Files:           []string{"lib/libcrypto.so.3"}},
+		{Name: "alpine-baselayout", Version: "3.4.3-r1",
+			PURL:            "pkg:apk/alpine/alpine-baselayout@3.4.3-r1?arch=x86_64&distro=alpine-3.18.4",
+			LicenseDeclared: []string{"GPL-2.0-only"},
+			Dependencies:    []string{"pkg:apk/alpine/busybox@1.36.1-r2?arch=x86_64&distro=alpine-3.18.4"},
+			Files:           []string{"etc/motd"}},
+	}
+	got, err := NewInstalledDBParser().Parse(filepath.Join(root, "lib/apk/db/installed"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for i := range got {
+		for j, file := range got[i].Files {
+			rel, _ := filepath.Rel(root, file)
+			got[i].Files[j] = filepath.ToSlash(rel)
+		}
+	}
+	if !slices.EqualFunc(got, want, func(p1, p2 model.Package) bool {
+		return model.PackageEqual(&p1, &p2) && p1.PURL == p2.PURL && p1.Supplier == p2.Supplier &&
+			slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
+			slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.Files, p2.Files)
+	}) {
+		t.Errorf("Parse() got = %v, want %v", got, want)
+	}
+}
@@ -41,6 +41,10 @@ func (m *RustCargoFileParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"Cargo.lock"}}
 }
 
+func (m *RustCargoFileParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *RustCargoFileParser) Parse(filePath string) ([]model.Package, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -70,20 +70,40 @@ func parseCargoTomlFile(reader io.Reader, filePath string) ([]model.Package, err
 
 	pkgs = append(pkgs, *mainPackage)
 
-	dependencies := tree.Values()["dependencies"]
+	for _, table := range cargoDependencyTables {
+		pkgs = append(pkgs, parseDependencyTable(tree, table.name, table.scope, filePath)...)
+	}
+	pkgs = collector.SortPackage(pkgs)
+	return pkgs, nil
+}
+
+// cargoDependencyTables are the dependency tables of Cargo.toml and their scopes
+var cargoDependencyTables = []struct {
+	name  string
+	scope model.Scope
+}{
+	{name: "dependencies", scope: model.ScopeRuntime},
+	{name: "dev-dependencies", scope: model.ScopeDev},
+	{name: "build-dependencies", scope: model.ScopeDev},
+}
+
+func parseDependencyTable(tree *toml.Tree, name string, scope model.Scope, filePath string) []model.Package {
+	pkgs := make([]model.Package, 0)
+	dependencies := tree.Values()[name]
 	if _, ok := dependencies.(*toml.Tree); !ok {
-		return pkgs, nil
+		return pkgs
 	}
 
 	tomlTree := dependencies.(*toml.Tree)
 
 	if tomlTree == nil || tomlTree.Values() == nil {
-		return pkgs, nil
+		return pkgs
 	}
 
 	waitParsePkgMap := tomlTree.Values()
 	for pkgName, pkg := range waitParsePkgMap {
 		version := ""
+		pkgScope := scope
 
 		if _, ok := pkg.(*toml.Tree); ok {
 			tomlTree := pkg.(*toml.Tree)
@@ -106,6 +126,10 @@ func parseCargoTomlFile(reader io.Reader, filePath string) ([]model.Package, err
 					version = value.(string)
 				}
 			}
+
+			if optional, ok := tomlTree.Get("optional").(bool); ok && optional && scope == model.ScopeRuntime {
+				pkgScope = model.ScopeOptional
+			}
 		}
 
 		if _, ok := pkg.(*toml.PubTOMLValue); ok {
@@ -117,10 +141,10 @@ func parseCargoTomlFile(reader io.Reader, filePath string) ([]model.Package, err
 		}
 		pkgVersion := removeSpecialCharacters(version)
 		p := newPackage(pkgName, pkgVersion, filePath)
+		p.Scope = pkgScope
 		pkgs = append(pkgs, *p)
 	}
-	pkgs = collector.SortPackage(pkgs)
-	return pkgs, nil
+	return pkgs
 }
 
 func removeSpecialCharacters(input string) string {
@@ -20,13 +20,23 @@ import (
 
 func TestParseCargoToml(t *testing.T) {
 	var expectStr string = `[
+    {
+        "name": "cc",
+        "version": "1.0",
+        "type": "cargo",
+        "purl": "pkg:cargo/cc@1.0",
+        "dependencies": null,
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "dev"
+    },
     {
         "name": "core",
         "version": "",
         "type": "cargo",
         "purl": "pkg:cargo/core",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
     },
     {
         "name": "crossbeam",
@@ -34,7 +44,8 @@ func TestParseCargoToml(t *testing.T) {
         "type": "cargo",
         "purl": "pkg:cargo/crossbeam",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
     },
     {
         "name": "itertools",
@@ -42,7 +53,17 @@ func TestParseCargoToml(t *testing.T) {
         "type": "cargo",
         "purl": "pkg:cargo/itertools@0.10",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
+    },
+    {
+        "name": "pretty_assertions",
+        "version": "1.4",
+        "type": "cargo",
+        "purl": "pkg:cargo/pretty_assertions@1.4",
+        "dependencies": null,
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "dev"
     },
     {
         "name": "rustorm-derive",
@@ -50,7 +71,17 @@ func TestParseCargoToml(t *testing.T) {
         "type": "cargo",
         "purl": "pkg:cargo/rustorm-derive@0.1",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
+    },
+    {
+        "name": "serde",
+        "version": "1.0",
+        "type": "cargo",
+        "purl": "pkg:cargo/serde@1.0",
+        "dependencies": null,
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "optional"
     },
     {
         "name": "suspicious-pods-lib",
@@ -58,7 +89,8 @@ func TestParseCargoToml(t *testing.T) {
         "type": "cargo",
         "purl": "pkg:cargo/suspicious-pods-lib@1.2.0",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
     },
     {
         "name": "suspicious-pods",
@@ -74,7 +106,8 @@ func TestParseCargoToml(t *testing.T) {
         "type": "cargo",
         "purl": "pkg:cargo/xi-core-lib@65911d9",
         "dependencies": null,
-        "sourceLocation": "test_material/Cargo.toml"
+        "sourceLocation": "test_material/Cargo.toml",
+        "scope": "runtime"
     }
 ]
 `
@@ -32,7 +32,7 @@ func TestPriorityResolutionCargoFile_Parse(t *testing.T) {
 			files: []collector.File{
 				collector.NewFileMeta("test_material/Cargo.toml"),
 			},
-			wantResult: `[{"name":"core","version":"","type":"cargo","purl":"pkg:cargo/core","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"crossbeam","version":"","type":"cargo","purl":"pkg:cargo/crossbeam","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"itertools","version":"0.10","type":"cargo","purl":"pkg:cargo/itertools@0.10","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"rustorm-derive","version":"0.1","type":"cargo","purl":"pkg:cargo/rustorm-derive@0.1","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"suspicious-pods-lib","version":"1.2.0","type":"cargo","purl":"pkg:cargo/suspicious-pods-lib@1.2.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"suspicious-pods","version":"1.2.0","type":"cargo","purl":"pkg:cargo/suspicious-pods@1.2.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"xi-core-lib","version":"65911d9","type":"cargo","purl":"pkg:cargo/xi-core-lib@65911d9","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"}]`,
+			wantResult: `[{"name":"cc","version":"1.0","type":"cargo","purl":"pkg:cargo/cc@1.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"dev"},{"name":"core","version":"","type":"cargo","purl":"pkg:cargo/core","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"},{"name":"crossbeam","version":"","type":"cargo","purl":"pkg:cargo/crossbeam","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"},{"name":"itertools","version":"0.10","type":"cargo","purl":"pkg:cargo/itertools@0.10","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"},{"name":"pretty_assertions","version":"1.4","type":"cargo","purl":"pkg:cargo/pretty_assertions@1.4","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"dev"},{"name":"rustorm-derive","version":"0.1","type":"cargo","purl":"pkg:cargo/rustorm-derive@0.1","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"},{"name":"serde","version":"1.0","type":"cargo","purl":"pkg:cargo/serde@1.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"optional"},{"name":"suspicious-pods-lib","version":"1.2.0","type":"cargo","purl":"pkg:cargo/suspicious-pods-lib@1.2.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"},{"name":"suspicious-pods","version":"1.2.0","type":"cargo","purl":"pkg:cargo/suspicious-pods@1.2.0","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml"},{"name":"xi-core-lib","version":"65911d9","type":"cargo","purl":"pkg:cargo/xi-core-lib@65911d9","supplier":"","filesAnalyzed":false,"verificationCode":"","licenseConcluded":null,"licenseDeclared":null,"dependencies":null,"sourceLocation":"test_material/Cargo.toml","scope":"runtime"}]`,
 			wantErr:    false,
 		},
 		{
@@ -0,0 +1,196 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cmake
+
+import (
+	"regexp"
+	"strings"
+)
+
+// command is a command invocation of a cmake script, e.g. set(FOO bar)
+type command struct {
+	name string
+	args []string
+}
+
+// parseScript parses the command invocations of a cmake script, the arguments are split but not evaluated
+// see: https://cmake.org/cmake/help/latest/manual/cmake-language.7.html
+func parseScript(content string) []command {
+	s := &scanner{src: content}
+	cmds := make([]command, 0)
+	for {
+		s.skipSpaceAndComments()
+		if s.eof() {
+			return cmds
+		}
+		start := s.pos
+		for !s.eof() && isIdentChar(s.peek()) {
+			s.pos++
+		}
+		name := s.src[start:s.pos]
+		if name == "" {
+			s.pos++
+			continue
+		}
+		s.skipSpace()
+		if s.eof() || s.peek() != '(' {
+			continue
+		}
+		s.pos++
+		cmds = append(cmds, command{name: strings.ToLower(name), args: s.arguments()})
+	}
+}
+
+type scanner struct {
+	src string
+	pos int
+}
+
+func (s *scanner) eof() bool {
+	return s.pos >= len(s.src)
+}
+
+func (s *scanner) peek() byte {
+	return s.src[s.pos]
+}
+
+func (s *scanner) skipSpace() {
+	for !s.eof() && strings.IndexByte(" \t\r\n", s.peek()) >= 0 {
+		s.pos++
+	}
+}
+
+func (s *scanner) skipSpaceAndComments() {
+	for {
+		s.skipSpace()
+		if s.eof() || s.peek() != '#' {
+			return
+		}
+		s.pos++
+		if _, ok := s.bracket(); ok {
+			continue
+		}
+		for !s.eof() && s.peek() != '\n' {
+			s.pos++
+		}
+	}
+}
+
+// bracket reads a bracket argument or comment [==[...]==] at the position
+func (s *scanner) bracket() (string, bool) {
+	rest := s.src[s.pos:]
+	if !strings.HasPrefix(rest, "[") {
+		return "", false
+	}
+	level := 1
+	for level < len(rest) && rest[level] == '=' {
+		level++
+	}
+	if level >= len(rest) || rest[level] != '[' {
+		return "", false
+	}
+	closing := "]" + strings.Repeat("=", level-1) + "]"
+	end := strings.Index(rest[level+1:], closing)
+	if end < 0 {
+		s.pos = len(s.src)
+		return rest[level+1:], true
+	}
+	s.pos += level + 1 + end + len(closing)
+	return rest[level+1 : level+1+end], true
+}
+
+// arguments reads the arguments until the closing parenthesis
+func (s *scanner) arguments() []string {
+	args := make([]string, 0)
+	depth := 0
+	for {
+		s.skipSpaceAndComments()
+		if s.eof() {
+			return args
+		}
+		switch c := s.peek(); {
+		case c == '(':
+			depth++
+			s.pos++
+		case c == ')':
+			s.pos++
+			if depth == 0 {
+				return args
+			}
+			depth--
+		case c == '"':
+			args = append(args, s.quoted())
+		default:
+			if arg, ok := s.bracket(); ok {
+				args = append(args, arg)
+				continue
+			}
+			start := s.pos
+			for !s.eof() && strings.IndexByte(" \t\r\n()#\"", s.peek()) < 0 {
+				if s.peek() == '\\' {
+					s.pos++
+				}
+				s.pos++
+			}
+			args = append(args, s.src[start:min(s.pos, len(s.src))])
+		}
+	}
+}
+
+// quoted reads a quoted argument
+func (s *scanner) quoted() string {
+	var b strings.Builder
+	s.pos++
+	for !s.eof() {
+		c := s.peek()
+		s.pos++
+		switch {
+		case c == '"':
+			return b.String()
+		case c == '\\' && !s.eof():
+			b.WriteByte(s.peek())
+			s.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+var variableRefRe = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// expand expands the references of the variables, the references of unknown variables are kept
+func expand(value string, vars map[string]string) string {
+	for i := 0; i < 10 && strings.Contains(value, "${"); i++ {
+		expanded := variableRefRe.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := vars[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+		if expanded == value {
+			break
+		}
+		value = expanded
+	}
+	return value
+}
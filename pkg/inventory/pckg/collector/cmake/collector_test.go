@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cmake
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestCmakeCollector_Collect(t *testing.T) {
+	c := NewCollector()
+	c.TryToAccept(collector.NewFileMeta("test_material/project/CMakeLists.txt"))
+	c.TryToAccept(collector.NewFileMeta("test_material/project/cmake/deps.cmake"))
+	got, err := c.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	want := []model.Package{
+		{Name: "mylib", Version: "release-2.3",
+			PURL: "pkg:generic/mylib@release-2.3?vcs_url=git+https://gitlab.example.com/team/mylib.git%40release-2.3"},
+		{Name: "openssl", Version: "3.1.4",
+			PURL: "pkg:generic/openssl@3.1.4?checksum=sha256:840af5366ab9b522bde525826be3ef0fb0af81c6a9ebd84caa600fea1731eee3&download_url=https://www.openssl.org/source/openssl-3.1.4.tar.gz"},
+		{Name: "fmtlib/fmt", Version: "10.2.1", PURL: "pkg:github/fmtlib/fmt@10.2.1"},
+		{Name: "google/googletest", Version: "v1.14.0", PURL: "pkg:github/google/googletest@v1.14.0"},
+		{Name: "nlohmann/json", Version: "v3.11.3", PURL: "pkg:github/nlohmann/json@v3.11.3"},
+	}
+	if !slices.EqualFunc(got, want, func(p1 model.Package, p2 model.Package) bool {
+		return model.PackageEqual(&p1, &p2) && p1.PURL == p2.PURL
+	}) {
+		t.Errorf("Collect() got = %v, want %v", got, want)
+	}
+}
+
+func TestParseScript(t *testing.T) {
+	cmds := parseScript(`
+set(A "x;y" [=[z]=]) # comment
+#[[ if(FALSE) ]]
+message(STATUS "a \"quoted\" (text)" $<$<CONFIG:Debug>:d>)
+`)
+	want := []command{
+		{name: "set", args: []string{"A", "x;y", "z"}},
+		{name: "message", args: []string{"STATUS", `a "quoted" (text)`, "$<$<CONFIG:Debug>:d>"}},
+	}
+	if !slices.EqualFunc(cmds, want, func(c1, c2 command) bool {
+		return c1.name == c2.name && slices.Equal(c1.args, c2.args)
+	}) {
+		t.Errorf("parseScript() got = %v, want %v", cmds, want)
+	}
+}
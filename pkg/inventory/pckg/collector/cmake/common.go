@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cmake
+
+import (
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func newPackage(name, version string, path string, qualifiers packageurl.Qualifiers) *model.Package {
+	return &model.Package{
+		Name:           name,
+		Version:        version,
+		Type:           PkgType(),
+		PURL:           packageurl.NewPackageURL(PkgType(), "", name, version, qualifiers, "").ToString(),
+		SourceLocation: path,
+	}
+}
+
+// newGithubPackage returns a package hosted on github, named by the owner and the repository
+func newGithubPackage(owner, repo, version string, path string) *model.Package {
+	return &model.Package{
+		Name:           owner + "/" + repo,
+		Version:        version,
+		Type:           packageurl.TypeGithub,
+		PURL:           packageurl.NewPackageURL(packageurl.TypeGithub, owner, repo, version, nil, "").ToString(),
+		SourceLocation: path,
+	}
+}
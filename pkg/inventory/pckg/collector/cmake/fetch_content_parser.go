@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cmake
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// declareCommands are the commands declaring the content downloaded at configure or build time
+var declareCommands = []string{"fetchcontent_declare", "externalproject_add"}
+
+// declareKeywords are the keywords of the arguments of the declare commands
+var declareKeywords = []string{"URL", "URL_HASH", "URL_MD5", "GIT_REPOSITORY", "GIT_TAG", "GIT_SHALLOW",
+	"GIT_PROGRESS", "GIT_SUBMODULES", "SVN_REPOSITORY", "SVN_REVISION", "HG_REPOSITORY", "HG_TAG",
+	"SOURCE_DIR", "SOURCE_SUBDIR", "BINARY_DIR", "PREFIX", "DOWNLOAD_DIR", "DOWNLOAD_NAME",
+	"DOWNLOAD_EXTRACT_TIMESTAMP", "DOWNLOAD_NO_EXTRACT", "PATCH_COMMAND", "UPDATE_COMMAND",
+	"UPDATE_DISCONNECTED", "CONFIGURE_COMMAND", "BUILD_COMMAND", "INSTALL_COMMAND", "TEST_COMMAND",
+	"CMAKE_ARGS", "CMAKE_CACHE_ARGS", "DEPENDS", "EXCLUDE_FROM_ALL", "FIND_PACKAGE_ARGS",
+	"OVERRIDE_FIND_PACKAGE", "SYSTEM", "LOG_DOWNLOAD", "LOG_BUILD", "BUILD_BYPRODUCTS", "TLS_VERIFY"}
+
+var (
+	githubRepoRe    = regexp.MustCompile(`^(?:git\+)?(?:https?://|ssh://git@|git@)github\.com[/:]([\w.-]+)/([\w.-]+?)(?:\.git)?/?$`)
+	githubArchiveRe = regexp.MustCompile(`^https?://github\.com/([\w.-]+)/([\w.-]+)/(?:archive/(?:refs/(?:tags|heads)/)?(.+?)\.(?:tar\.gz|tgz|tar\.bz2|tar\.xz|zip)|releases/download/([^/]+)/.+)$`)
+	fileVersionRe   = regexp.MustCompile(`[-_]v?(\d+(?:\.\d+)+[\w.+-]*?)\.(?:tar\.gz|tgz|tar\.bz2|tbz2|tar\.xz|txz|tar|zip|7z)$`)
+)
+
+// FetchContentParser is a parser for the FetchContent_Declare and ExternalProject_Add calls of cmake scripts,
+// the content hosted on github is reported as a github package, otherwise a generic package
+// see: https://cmake.org/cmake/help/latest/module/FetchContent.html
+type FetchContentParser struct{}
+
+// NewFetchContentParser returns a new FetchContentParser
+func NewFetchContentParser() *FetchContentParser {
+	return &FetchContentParser{}
+}
+
+func (m *FetchContentParser) Matcher() collector.FileMatcher {
+	return &collector.FilePatternMatcher{Patterns: []string{"CMakeLists.txt", "*.cmake"}}
+}
+
+func (m *FetchContentParser) Parse(filePath string) ([]model.Package, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return parseFetchContent(string(content), filePath), nil
+}
+
+// parseFetchContent returns the packages declared by the script, the variables set by the script are expanded
+func parseFetchContent(content string, filePath string) []model.Package {
+	vars := make(map[string]string)
+	pkgs := make([]model.Package, 0)
+	for _, cmd := range parseScript(content) {
+		switch {
+		case cmd.name == "set" && len(cmd.args) >= 2:
+			vars[cmd.args[0]] = expand(cmd.args[1], vars)
+		case util.SliceContains(declareCommands, cmd.name) && len(cmd.args) > 0:
+			if pkg := declaredPackage(cmd.args, vars, filePath); pkg != nil {
+				pkgs = append(pkgs, *pkg)
+			}
+		}
+	}
+	return pkgs
+}
+
+// declaredPackage returns the package of the arguments of a declare command, nil if nothing is downloaded
+func declaredPackage(args []string, vars map[string]string, filePath string) *model.Package {
+	name := expand(args[0], vars)
+	options := make(map[string][]string)
+	keyword := ""
+	for _, arg := range args[1:] {
+		if util.SliceContains(declareKeywords, arg) {
+			keyword = arg
+			continue
+		}
+		if keyword != "" {
+			options[keyword] = append(options[keyword], expand(arg, vars))
+		}
+	}
+	repo, tag, url := first(options["GIT_REPOSITORY"]), first(options["GIT_TAG"]), first(options["URL"])
+	if strings.Contains(tag, "${") {
+		tag = ""
+	}
+
+	if repo != "" {
+		if m := githubRepoRe.FindStringSubmatch(repo); m != nil {
+			return newGithubPackage(m[1], m[2], tag, filePath)
+		}
+		var qualifiers packageurl.Qualifiers
+		if tag != "" {
+			qualifiers = packageurl.QualifiersFromMap(map[string]string{"vcs_url": "git+" + repo + "@" + tag})
+		} else {
+			qualifiers = packageurl.QualifiersFromMap(map[string]string{"vcs_url": "git+" + repo})
+		}
+		return newPackage(name, tag, filePath, qualifiers)
+	}
+	if url != "" && !strings.Contains(url, "${") {
+		if m := githubArchiveRe.FindStringSubmatch(url); m != nil {
+			version := m[3]
+			if version == "" {
+				version = m[4]
+			}
+			return newGithubPackage(m[1], m[2], version, filePath)
+		}
+		version := ""
+		if m := fileVersionRe.FindStringSubmatch(path.Base(url)); m != nil {
+			version = m[1]
+		}
+		q := map[string]string{"download_url": url}
+		if checksum := urlChecksum(options); checksum != "" {
+			q["checksum"] = checksum
+		}
+		return newPackage(name, version, filePath, packageurl.QualifiersFromMap(q))
+	}
+	return nil
+}
+
+// urlChecksum returns the checksum of the URL_HASH or URL_MD5 as algorithm:hex, e.g. sha256:0af1...
+func urlChecksum(options map[string][]string) string {
+	if hash := first(options["URL_HASH"]); hash != "" {
+		if algorithm, value, ok := strings.Cut(hash, "="); ok {
+			return strings.ToLower(algorithm) + ":" + strings.ToLower(value)
+		}
+	}
+	if md5 := first(options["URL_MD5"]); md5 != "" {
+		return "md5:" + strings.ToLower(md5)
+	}
+	return ""
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
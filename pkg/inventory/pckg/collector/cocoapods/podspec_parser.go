@@ -19,6 +19,7 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 const _PodSpecNewStr = "Pod::Spec.new"
@@ -88,7 +89,7 @@ func (p *PodSpecParser) Parse(path string) ([]model.Package, error) {
 	if name != "" && version != "" {
 		pkg := newPackage(name, version, path)
 		if len(licenses) > 0 {
-			pkg.LicenseDeclared = licenses
+			pkg.LicenseDeclared = license.NormalizeExpressions(licenses)
 		}
 		pkgs = append(pkgs, *pkg)
 	}
@@ -46,6 +46,13 @@ type Collector interface {
 	Collect() (pkgs []model.Package, err error)
 }
 
+// RootAware is implemented by the collectors which need the root directory of the scan,
+// e.g. to tell the paths inside the project from the paths above it
+type RootAware interface {
+	// SetRoot sets the root directory of the scan before any file is accepted
+	SetRoot(root string)
+}
+
 // BaseCollector provide common properties and behaviors inherited by other collectors
 type BaseCollector struct {
 	Name     string
@@ -112,6 +119,8 @@ func acquirePackageURL(purlType, name, version string) string {
 func SortPackage(pkgs []model.Package) []model.Package {
 	for i := 0; i < len(pkgs); i++ {
 		sort.Strings(pkgs[i].Dependencies)
+		sort.Strings(pkgs[i].Contains)
+		sort.Strings(pkgs[i].Files)
 	}
 	return util.SliceSort(pkgs, func(p1, p2 model.Package) bool {
 		return strings.Compare(p1.PURL, p2.PURL) <= -1
@@ -125,6 +134,12 @@ func StrictMode() bool {
 
 // OrganizePackage remove and merge duplicate packages
 func OrganizePackage(pkgs []model.Package) []model.Package {
+	return OrganizePackageWithReport(pkgs, nil)
+}
+
+// OrganizePackageWithReport remove and merge duplicate packages like OrganizePackage,
+// the conflicting metadata of the merged packages is recorded to the report if it is not nil
+func OrganizePackageWithReport(pkgs []model.Package, report *MergeReport) []model.Package {
 	pkgs = util.SliceFilter(pkgs, func(p model.Package) bool {
 		if strictMode {
 			return p.Name != "" && p.Version != ""
@@ -138,7 +153,7 @@ func OrganizePackage(pkgs []model.Package) []model.Package {
 		}
 	}
 	pkgs = util.SliceUniqueFunc(pkgs, func(p1 model.Package, p2 model.Package) model.Package {
-		return *CombinePackage(&p1, &p2)
+		return *CombinePackageWithReport(&p1, &p2, report)
 	}, func(p1 model.Package, p2 model.Package) bool {
 		return (p1.Type == p2.Type && p1.Name == p2.Name && p1.Version == p2.Version) || // same type,name,version
 			(p1.Type == p2.Type && p1.Name == p2.Name && (p1.Version == "" || p2.Version == "")) // same type,name and empty version
@@ -147,8 +162,47 @@ func OrganizePackage(pkgs []model.Package) []model.Package {
 	return pkgs
 }
 
+// MergeConflict is the metadata of a package which differs between the merged packages
+type MergeConflict struct {
+	PURL   string   `json:"purl"`
+	Field  string   `json:"field"`
+	Values []string `json:"values"`
+}
+
+// MergeReport records the conflicts found when merging packages
+type MergeReport struct {
+	Conflicts []MergeConflict `json:"conflicts"`
+}
+
+// add records a conflict if the two values of the field are both present and differ
+func (r *MergeReport) add(purl, field string, v1, v2 []string) {
+	if r == nil || len(v1) == 0 || len(v2) == 0 || sameValues(v1, v2) {
+		return
+	}
+	r.Conflicts = append(r.Conflicts, MergeConflict{
+		PURL:   purl,
+		Field:  field,
+		Values: []string{strings.Join(v1, ","), strings.Join(v2, ",")},
+	})
+}
+
+// sameValues checks two slices contain the same values regardless of order and duplication
+func sameValues(v1, v2 []string) bool {
+	s1 := util.SliceUnique(v1)
+	s2 := util.SliceUnique(v2)
+	sort.Strings(s1)
+	sort.Strings(s2)
+	return slices.Equal(s1, s2)
+}
+
 // CombinePackage combines two packages into one package
 func CombinePackage(p1, p2 *model.Package) *model.Package {
+	return CombinePackageWithReport(p1, p2, nil)
+}
+
+// CombinePackageWithReport combines two packages into one package like CombinePackage,
+// the differing licenses and suppliers of the same package are recorded to the report if it is not nil
+func CombinePackageWithReport(p1, p2 *model.Package, report *MergeReport) *model.Package {
 	if p1.PURL != p2.PURL {
 		if p1.Type != p2.Type || p1.Name != p2.Name {
 			// different type and name, cannot combine
@@ -165,12 +219,24 @@ func CombinePackage(p1, p2 *model.Package) *model.Package {
 		}
 	}
 
+	if p1.Supplier != "" && p2.Supplier != "" && p1.Supplier != p2.Supplier {
+		report.add(p1.PURL, "supplier", []string{p1.Supplier}, []string{p2.Supplier})
+	}
+	report.add(p1.PURL, "licenseDeclared", p1.LicenseDeclared, p2.LicenseDeclared)
+	report.add(p1.PURL, "licenseConcluded", p1.LicenseConcluded, p2.LicenseConcluded)
+
 	if p1.Supplier == "" {
 		p1.Supplier = p2.Supplier
 	}
+	if p1.Copyright == "" {
+		p1.Copyright = p2.Copyright
+	}
+	p1.Scope = model.MergeScope(p1.Scope, p2.Scope)
 	p1.LicenseDeclared = util.SliceUnique(append(p1.LicenseDeclared, p2.LicenseDeclared...))
 	p1.LicenseConcluded = util.SliceUnique(append(p1.LicenseConcluded, p2.LicenseConcluded...))
 	p1.Dependencies = util.SliceUnique(append(p1.Dependencies, p2.Dependencies...))
+	p1.Contains = util.SliceUnique(append(p1.Contains, p2.Contains...))
+	p1.Files = util.SliceUnique(append(p1.Files, p2.Files...))
 	return p1
 }
 
@@ -180,7 +246,39 @@ func EqualPackage(p1, p2 *model.Package) bool {
 		p1.Version == p2.Version &&
 		p1.Type == p2.Type &&
 		p1.PURL == p2.PURL &&
+		p1.Scope == p2.Scope &&
 		slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
 		slices.Equal(p1.LicenseConcluded, p2.LicenseConcluded) &&
 		slices.Equal(p1.Dependencies, p2.Dependencies)
 }
+
+// FilterPackageByScope keeps the packages of the given scopes, a package with unknown scope is regarded as a runtime package.
+// Dependencies and nested packages referring to the removed packages are removed as well
+func FilterPackageByScope(pkgs []model.Package, scopes []model.Scope) []model.Package {
+	pkgs = util.SliceFilter(pkgs, func(p model.Package) bool {
+		scope := p.Scope
+		if scope == "" {
+			scope = model.ScopeRuntime
+		}
+		return slices.Contains(scopes, scope)
+	})
+	purls := make(map[string]struct{}, len(pkgs))
+	for i := range pkgs {
+		purls[pkgs[i].PURL] = struct{}{}
+	}
+	for i := range pkgs {
+		if len(pkgs[i].Dependencies) > 0 {
+			pkgs[i].Dependencies = util.SliceFilter(pkgs[i].Dependencies, func(dep string) bool {
+				_, ok := purls[dep]
+				return ok
+			})
+		}
+		if len(pkgs[i].Contains) > 0 {
+			pkgs[i].Contains = util.SliceFilter(pkgs[i].Contains, func(purl string) bool {
+				_, ok := purls[purl]
+				return ok
+			})
+		}
+	}
+	return pkgs
+}
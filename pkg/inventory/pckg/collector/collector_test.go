@@ -13,6 +13,8 @@ package collector
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 )
@@ -80,3 +82,64 @@ func TestOrganizePackage(t *testing.T) {
 		t.Errorf("OrganizePackage() got = %v, \nwant %v", result, expect)
 	}
 }
+
+func TestOrganizePackageWithReport(t *testing.T) {
+	data := []model.Package{
+		{Name: "gson", Type: model.PkgTypeMaven, Version: "1.0", LicenseDeclared: []string{"MIT"}, Supplier: "Google"},
+		{Name: "gson", Type: model.PkgTypeMaven, Version: "1.0", LicenseDeclared: []string{"Apache-2.0"}},
+		{Name: "gson", Type: model.PkgTypeMaven, Version: "1.0", Supplier: "Alphabet"},
+		{Name: "guava", Type: model.PkgTypeMaven, Version: "32.0", LicenseDeclared: []string{"MIT", "Apache-2.0"}},
+		{Name: "guava", Type: model.PkgTypeMaven, Version: "32.0", LicenseDeclared: []string{"Apache-2.0", "MIT"}},
+	}
+	report := &MergeReport{}
+	result := OrganizePackageWithReport(data, report)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, []string{"MIT", "Apache-2.0"}, result[0].LicenseDeclared)
+	assert.Equal(t, "Google", result[0].Supplier)
+	assert.Equal(t, []MergeConflict{
+		{PURL: "pkg:maven/gson@1.0", Field: "licenseDeclared", Values: []string{"MIT", "Apache-2.0"}},
+		{PURL: "pkg:maven/gson@1.0", Field: "supplier", Values: []string{"Google", "Alphabet"}},
+	}, report.Conflicts)
+}
+
+func TestPropagateScope(t *testing.T) {
+	pkgs := []model.Package{
+		{Name: "app", PURL: "pkg:npm/app", Dependencies: []string{"pkg:npm/react", "pkg:npm/jest"}},
+		{Name: "react", PURL: "pkg:npm/react", Scope: model.ScopeRuntime, Dependencies: []string{"pkg:npm/loose-envify"}},
+		{Name: "jest", PURL: "pkg:npm/jest", Scope: model.ScopeDev, Dependencies: []string{"pkg:npm/loose-envify", "pkg:npm/chalk"}},
+		{Name: "loose-envify", PURL: "pkg:npm/loose-envify"},
+		{Name: "chalk", PURL: "pkg:npm/chalk"},
+	}
+	PropagateScope(pkgs)
+
+	scopes := util.SliceMap(pkgs, func(p model.Package) model.Scope {
+		return p.Scope
+	})
+	expect := []model.Scope{"", model.ScopeRuntime, model.ScopeDev, model.ScopeRuntime, model.ScopeDev}
+	if !util.SliceEqual(scopes, expect, func(s1, s2 model.Scope) bool { return s1 == s2 }) {
+		t.Errorf("PropagateScope() got = %v, want %v", scopes, expect)
+	}
+}
+
+func TestFilterPackageByScope(t *testing.T) {
+	pkgs := []model.Package{
+		{Name: "react", PURL: "pkg:npm/react", Scope: model.ScopeRuntime, Dependencies: []string{"pkg:npm/loose-envify"}},
+		{Name: "jest", PURL: "pkg:npm/jest", Scope: model.ScopeDev, Dependencies: []string{"pkg:npm/loose-envify", "pkg:npm/chalk"}},
+		{Name: "loose-envify", PURL: "pkg:npm/loose-envify", Scope: model.ScopeRuntime},
+		{Name: "chalk", PURL: "pkg:npm/chalk", Scope: model.ScopeDev},
+		{Name: "lodash", PURL: "pkg:npm/lodash"},
+	}
+	result := FilterPackageByScope(pkgs, []model.Scope{model.ScopeRuntime})
+	expect := []model.Package{pkgs[0], pkgs[2], pkgs[4]}
+	if !util.SliceEqual(result, expect, func(p1 model.Package, p2 model.Package) bool {
+		return EqualPackage(&p1, &p2)
+	}) {
+		t.Errorf("FilterPackageByScope() got = %v, \nwant %v", result, expect)
+	}
+
+	result = FilterPackageByScope(pkgs, []model.Scope{model.ScopeDev})
+	if len(result) != 2 || len(result[0].Dependencies) != 1 || result[0].Dependencies[0] != "pkg:npm/chalk" {
+		t.Errorf("FilterPackageByScope() got = %v", result)
+	}
+}
@@ -20,9 +20,14 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the packages installed into vendor dir
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewComposerJsonFileParser())
 	parsers = append(parsers, NewComposerLockFileParser())
+
+	installedParsers = append(installedParsers, NewInstalledJSONParser())
 }
 
 func NewCollector() *Collector {
@@ -32,3 +37,12 @@ func NewCollector() *Collector {
 	c.Parsers = parsers
 	return &c
 }
+
+// NewInstalledCollector returns the collector of the packages installed into vendor dir
+func NewInstalledCollector() *Collector {
+	c := Collector{}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = installedParsers
+	return &c
+}
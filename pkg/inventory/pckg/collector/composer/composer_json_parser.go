@@ -27,9 +27,10 @@ var (
 )
 
 type ComposerInfo struct {
-	Name      string            `json:"name"`
-	License   string            `json:"license"`
-	DepPkgMap map[string]string `json:"require"`
+	Name         string            `json:"name"`
+	License      string            `json:"license"`
+	DepPkgMap    map[string]string `json:"require"`
+	DevDepPkgMap map[string]string `json:"require-dev"`
 }
 
 // ComposerJsonFileParser is a parser for composer.json file
@@ -65,7 +66,19 @@ func parseComposerJsonFile(reader io.Reader, filePath string) ([]model.Package,
 		return pkgs, err
 	}
 
-	for pkgName, pkgVersion := range composerInfo.DepPkgMap {
+	for scope, depPkgMap := range map[model.Scope]map[string]string{
+		model.ScopeRuntime: composerInfo.DepPkgMap,
+		model.ScopeDev:     composerInfo.DevDepPkgMap,
+	} {
+		pkgs = append(pkgs, parseComposerRequire(depPkgMap, scope, filePath)...)
+	}
+	pkgs = collector.SortPackage(pkgs)
+	return pkgs, nil
+}
+
+func parseComposerRequire(depPkgMap map[string]string, scope model.Scope, filePath string) []model.Package {
+	pkgs := make([]model.Package, 0)
+	for pkgName, pkgVersion := range depPkgMap {
 		pkgName = strings.TrimSpace(pkgName)
 		pkgVersion = strings.TrimSpace(pkgVersion)
 
@@ -84,11 +97,11 @@ func parseComposerJsonFile(reader io.Reader, filePath string) ([]model.Package,
 		}
 
 		pkg := newPackage(pkgName, pkgVersion, filePath)
+		pkg.Scope = scope
 		pkgs = append(pkgs, *pkg)
 
 	}
-	pkgs = collector.SortPackage(pkgs)
-	return pkgs, nil
+	return pkgs
 }
 
 func composerVersionParser(versionStr string) string {
@@ -28,13 +28,14 @@ var composerJsonTestdata = []testComposerJsonItem{
 		title:    "testComposerJson test",
 		filePath: "test_material/composer.json",
 		expected: []model.Package{
-			{Name: "fakerphp/faker1", Version: "1.29.1", Type: model.PkgTypeComposer},
-			{Name: "fakerphp/faker2", Version: "1.0.0", Type: model.PkgTypeComposer},
-			{Name: "fakerphp/faker3", Version: "4.4.1", Type: model.PkgTypeComposer},
-			{Name: "fakerphp/faker4", Version: "4.4.2", Type: model.PkgTypeComposer},
-			{Name: "guzzlehttp/guzzle", Version: "7.2", Type: model.PkgTypeComposer},
-			{Name: "myclabs/php-enum", Version: "1.2.2", Type: model.PkgTypeComposer},
-			{Name: "phan/phan", Version: "2.7.1", Type: model.PkgTypeComposer},
+			{Name: "fakerphp/faker1", Version: "1.29.1", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "fakerphp/faker2", Version: "1.0.0", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "fakerphp/faker3", Version: "4.4.1", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "fakerphp/faker4", Version: "4.4.2", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "guzzlehttp/guzzle", Version: "7.2", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "mockery/mockery", Version: "1.6.2", Type: model.PkgTypeComposer, Scope: model.ScopeDev},
+			{Name: "myclabs/php-enum", Version: "1.2.2", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
+			{Name: "phan/phan", Version: "2.7.1", Type: model.PkgTypeComposer, Scope: model.ScopeRuntime},
 		},
 	},
 }
@@ -48,7 +49,7 @@ func TestParseComposerJsonFile(t *testing.T) {
 		}
 
 		if !util.SliceEqual(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
@@ -21,7 +21,8 @@ import (
 )
 
 type PhpComposerLockInfo struct {
-	Packages []PhpComposerLockMetadata `json:"packages"`
+	Packages    []PhpComposerLockMetadata `json:"packages"`
+	PackagesDev []PhpComposerLockMetadata `json:"packages-dev"`
 }
 
 type PhpComposerLockMetadata struct {
@@ -61,19 +62,26 @@ func parseComposerLockFile(reader io.Reader, filePath string) ([]model.Package,
 		return pkgs, err
 	}
 
-	if len(composerLockInfo.Packages) == 0 {
+	if len(composerLockInfo.Packages) == 0 && len(composerLockInfo.PackagesDev) == 0 {
 		log.Warnf("composerLockInfo.Packages list is nil!")
 		return pkgs, err
 	}
 
-	for _, info := range composerLockInfo.Packages {
-		if info.Name == "" {
-			continue
-		}
-		pkg := newPackage(info.Name, info.Version, filePath)
+	for scope, infos := range map[model.Scope][]PhpComposerLockMetadata{
+		model.ScopeRuntime: composerLockInfo.Packages,
+		model.ScopeDev:     composerLockInfo.PackagesDev,
+	} {
+		for _, info := range infos {
+			if info.Name == "" {
+				continue
+			}
+			pkg := newPackage(info.Name, info.Version, filePath)
+			pkg.Scope = scope
 
-		pkgs = append(pkgs, *pkg)
+			pkgs = append(pkgs, *pkg)
+		}
 	}
+	pkgs = collector.SortPackage(pkgs)
 
 	return pkgs, nil
 }
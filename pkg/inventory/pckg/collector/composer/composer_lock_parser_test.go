@@ -28,8 +28,10 @@ var composerLockTestdata = []testComposerLockItem{
 		title:    "testComposerJson test",
 		filePath: "test_material/composer.lock",
 		expected: []model.Package{
-			{Name: "adoy/fastcgi-client", Version: "1.0.2", Type: model.PkgTypeComposer, LicenseDeclared: []string{"MIT"}},
-			{Name: "alcaeus/mongo-php-adapter", Version: "1.1.11", Type: model.PkgTypeComposer, LicenseDeclared: []string{"MIT"}},
+			{Name: "adoy/fastcgi-client", Version: "1.0.2", Type: model.PkgTypeComposer, LicenseDeclared: []string{"MIT"}, Scope: model.ScopeRuntime},
+			{Name: "alcaeus/mongo-php-adapter", Version: "1.1.11", Type: model.PkgTypeComposer, LicenseDeclared: []string{"MIT"}, Scope: model.ScopeRuntime},
+			{Name: "behat/gherkin", Version: "v4.6.2", Type: model.PkgTypeComposer, Scope: model.ScopeDev},
+			{Name: "codeception/codeception", Version: "4.1.6", Type: model.PkgTypeComposer, Scope: model.ScopeDev},
 		},
 	},
 }
@@ -43,7 +45,7 @@ func TestParseComposerLockFile(t *testing.T) {
 		}
 
 		if !util.SliceEqual(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
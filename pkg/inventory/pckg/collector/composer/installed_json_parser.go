@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// installedJSON is the content of vendor/composer/installed.json of composer 2,
+// composer 1 writes the packages array only
+type installedJSON struct {
+	Packages        []installedPackage `json:"packages"`
+	DevPackageNames []string           `json:"dev-package-names"`
+}
+
+type installedPackage struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	License     []string          `json:"license"`
+	Require     map[string]string `json:"require"`
+	InstallPath string            `json:"install-path"`
+}
+
+// InstalledJSONParser is a parser for vendor/composer/installed.json, the packages installed by composer,
+// the package owns the files of its install path
+// see: https://getcomposer.org/doc/07-runtime.md#installed-versions
+type InstalledJSONParser struct{}
+
+func NewInstalledJSONParser() *InstalledJSONParser {
+	return &InstalledJSONParser{}
+}
+
+func (m *InstalledJSONParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/composer/installed\.json$`),
+	}}
+}
+
+func (m *InstalledJSONParser) ProvidesGraph() bool {
+	return true
+}
+
+func (m *InstalledJSONParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("parse path %s", filePath)
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var installed installedJSON
+	if err = json.Unmarshal(data, &installed); err != nil {
+		// composer 1
+		if err = json.Unmarshal(data, &installed.Packages); err != nil {
+			log.Errorf("failed to decode installed.json: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	composerDir := filepath.Dir(filePath)
+	versions := make(map[string]string)
+	for _, info := range installed.Packages {
+		versions[info.Name] = info.Version
+	}
+	pkgs := make([]model.Package, 0, len(installed.Packages))
+	for _, info := range installed.Packages {
+		if info.Name == "" {
+			continue
+		}
+		pkg := newPackage(info.Name, info.Version, filePath)
+		pkg.Scope = model.ScopeRuntime
+		if util.SliceContains(installed.DevPackageNames, info.Name) {
+			pkg.Scope = model.ScopeDev
+		}
+		pkg.LicenseDeclared = license.NormalizeExpressions(info.License)
+		for name := range info.Require {
+			// the platform packages like php and ext-json are not installed
+			if version, ok := versions[name]; ok {
+				pkg.Dependencies = append(pkg.Dependencies, packageURL(name, version))
+			}
+		}
+		installPath := filepath.Join(composerDir, "..", filepath.FromSlash(info.Name))
+		if info.InstallPath != "" {
+			installPath = filepath.Join(composerDir, filepath.FromSlash(info.InstallPath))
+		}
+		pkg.Files = collector.ListFiles(installPath, nil)
+		pkgs = append(pkgs, *pkg)
+	}
+	return collector.SortPackage(pkgs), nil
+}
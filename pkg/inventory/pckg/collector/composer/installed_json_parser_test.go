@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package composer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestInstalledJSONParser_Parse(t *testing.T) {
+	tests := []struct {
+		root string
+		want []model.Package
+	}{
+		{
+			root: "test_material/installed",
+			want: []model.Package{
+				{Name: "monolog/monolog", Version: "3.5.0", Scope: model.ScopeRuntime, LicenseDeclared: []string{"MIT"},
+					Dependencies: []string{"pkg:composer/psr/log@3.0.0"},
+					Files:        []string{"vendor/monolog/monolog/composer.json", "vendor/monolog/monolog/src/Logger.php"}},
+				{Name: "phpunit/phpunit", Version: "10.5.3", Scope: model.ScopeDev, LicenseDeclared: []string{"BSD-3-Clause"},
+					Files: []string{"vendor/phpunit/phpunit/src/TestCase.php"}},
+				{Name: "psr/log", Version: "3.0.0", Scope: model.ScopeRuntime, LicenseDeclared: []string{"MIT"},
+					Files: []string{"vendor/psr/log/src/LoggerInterface.php"}},
+			},
+		},
+		{
+			root: "test_material/installed-v1",
+			want: []model.Package{
+				{Name: "psr/log", Version: "1.1.4", Scope: model.ScopeRuntime, LicenseDeclared: []string{"MIT"},
+					Files: []string{"vendor/psr/log/src/LoggerInterface.php"}},
+			},
+		},
+	}
+	parser := NewInstalledJSONParser()
+	for _, tt := range tests {
+		t.Run(tt.root, func(t *testing.T) {
+			got, err := parser.Parse(filepath.Join(tt.root, "vendor/composer/installed.json"))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for i := range got {
+				for j, file := range got[i].Files {
+					rel, _ := filepath.Rel(tt.root, file)
+					got[i].Files[j] = filepath.ToSlash(rel)
+				}
+			}
+			if !slices.EqualFunc(got, tt.want, func(p1, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope &&
+					slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
+					slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.Files, p2.Files)
+			}) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
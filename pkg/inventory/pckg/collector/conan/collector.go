@@ -24,6 +24,8 @@ func init() {
 	parsers = append(parsers, NewConanFileParser())
 	parsers = append(parsers, NewConanLockParser())
 	parsers = append(parsers, NewConanGraphParser())
+	parsers = append(parsers, NewConanPyParser())
+	parsers = append(parsers, NewConanDataParser())
 }
 
 func NewCollector() *Collector {
@@ -11,6 +11,9 @@
 package conan
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/anchore/packageurl-go"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
@@ -25,6 +28,7 @@ func newPackage(name, version string, path string) *model.Package {
 		SourceLocation: path,
 	}
 }
+
 func packageURL(name, version string) string {
 	return packageurl.NewPackageURL(
 		PkgType(),
@@ -35,3 +39,23 @@ func packageURL(name, version string) string {
 		"",
 	).ToString()
 }
+
+// rangeVersionRe matches the first version of a version range, e.g. [>=1.2.11 <2]
+var rangeVersionRe = regexp.MustCompile(`\d+(\.[\w-]+)*`)
+
+// parseReference parses a conan reference name/version@user/channel#revision%timestamp,
+// the lower bound is taken as the version of a version range
+func parseReference(ref string) (name, version string) {
+	ref = strings.TrimSpace(ref)
+	ref = strings.Split(ref, "#")[0]
+	ref = strings.Split(ref, "@")[0]
+	fields := strings.SplitN(ref, "/", 2)
+	name = strings.TrimSpace(fields[0])
+	if len(fields) == 2 {
+		version = strings.TrimSpace(fields[1])
+		if strings.HasPrefix(version, "[") {
+			version = rangeVersionRe.FindString(version)
+		}
+	}
+	return name, version
+}
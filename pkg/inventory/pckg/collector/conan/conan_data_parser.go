@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package conan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+type conanData struct {
+	Sources map[string]yaml.Node `yaml:"sources"`
+}
+
+// ConanDataParser is a parser for conandata.yml, the recipe is named by the conanfile.py beside or the dir of the recipe,
+// and takes the version pinned by the conanfile.py
+// see: https://docs.conan.io/2/tutorial/creating_packages/handle_sources_in_packages.html
+type ConanDataParser struct{}
+
+// NewConanDataParser returns a new ConanDataParser
+func NewConanDataParser() *ConanDataParser {
+	return &ConanDataParser{}
+}
+
+func (m *ConanDataParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"conandata.yml"}}
+}
+
+func (m *ConanDataParser) Parse(filePath string) ([]model.Package, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var data conanData
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse conandata.yml file: %w", err)
+	}
+
+	r := &recipe{}
+	dir := filepath.Dir(filePath)
+	if py, err := os.ReadFile(filepath.Join(dir, "conanfile.py")); err == nil {
+		r = parseRecipe(string(py))
+	}
+	name := r.name
+	if name == "" {
+		// conan-center-index layout: recipes/<name>/all/conandata.yml
+		name = filepath.Base(dir)
+		if name == "all" {
+			name = filepath.Base(filepath.Dir(dir))
+		}
+	}
+
+	// the sources list all versions the recipe can build, only the version pinned by the recipe is built,
+	// a recipe of no fixed version is taken without version
+	if len(data.Sources) == 0 && r.version == "" {
+		return nil, nil
+	}
+	pkg := newPackage(name, r.version, filePath)
+	pkg.LicenseDeclared = license.NormalizeExpressions(r.licenses)
+	return []model.Package{*pkg}, nil
+}
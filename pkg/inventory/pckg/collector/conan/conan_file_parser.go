@@ -80,16 +80,7 @@ func parseConanfile(reader io.Reader, filePath string, licenses map[string]strin
 			continue
 		}
 
-		ref := strings.TrimSpace(line)
-		fields := strings.Split(ref, "/")
-
-		var name, version string
-		if len(fields) >= 2 {
-			name = strings.TrimSpace(fields[0])
-			version = strings.TrimSpace(fields[1])
-		} else {
-			name = strings.TrimSpace(fields[0])
-		}
+		name, version := parseReference(line)
 		if name == "" {
 			continue
 		}
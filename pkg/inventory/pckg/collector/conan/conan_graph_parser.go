@@ -18,6 +18,7 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
 )
 
@@ -52,6 +53,10 @@ func (m *ConanGraphParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"conan-graph-info.json"}}
 }
 
+func (m *ConanGraphParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *ConanGraphParser) Parse(filePath string) ([]model.Package, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -85,7 +90,7 @@ func parseConanGraphJsonFile(reader io.Reader, path string) ([]model.Package, er
 		pkg := newPackage(node.Name, node.Version, path)
 		//如果license不为空，赋值
 		if IsEmptyOrNull(node.License) {
-			pkg.LicenseConcluded = []string{node.License}
+			pkg.LicenseConcluded = license.NormalizeExpressions([]string{node.License})
 		}
 
 		// 如果依赖对象不为空，解析依赖
@@ -16,6 +16,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
 )
 
@@ -67,5 +68,5 @@ func resolveLicense(name string, version string, licenses map[string]string) []s
 	if _, ok := licenses[key]; ok {
 		licenseList = append(licenseList, licenses[key])
 	}
-	return licenseList
+	return license.NormalizeExpressions(licenseList)
 }
@@ -15,7 +15,7 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
+	"sort"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
@@ -26,9 +26,21 @@ type conanLock struct {
 	Requires       []string `json:"requires"`
 	BuildRequires  []string `json:"build_requires"`
 	PythonRequires []string `json:"python_requires"`
+	ConfigRequires []string `json:"config_requires"`
+	// GraphLock is the graph of conan 1 lockfiles
+	GraphLock struct {
+		Nodes map[string]conanLockNode `json:"nodes"`
+	} `json:"graph_lock"`
 }
 
-// ConanLockParser is a parser for conan.lock
+type conanLockNode struct {
+	Ref     string `json:"ref"`
+	Context string `json:"context"`
+}
+
+// ConanLockParser is a parser for conan.lock, both the conan 2 lockfile(version 0.5)
+// and the conan 1 lockfile(version 0.4) with a graph_lock are supported
+// see: https://docs.conan.io/2/tutorial/versioning/lockfiles.html
 type ConanLockParser struct{}
 
 // NewConanLockParser returns a new ConanLockParser
@@ -58,19 +70,44 @@ func parseConanlock(reader io.Reader, filePath string) ([]model.Package, error)
 	if err := json.NewDecoder(reader).Decode(&cl); err != nil {
 		return nil, fmt.Errorf("decode error: %w", err)
 	}
-	for _, req := range cl.Requires {
-		ref := strings.Split(req, "#")[0]
-		fields := strings.Split(ref, "/")
-
-		var name, version string
-		if len(fields) >= 2 {
-			name = fields[0]
-			version = fields[1]
-		} else {
-			name = fields[0]
+	scopedRequires := []struct {
+		scope    model.Scope
+		requires []string
+	}{
+		{model.ScopeRuntime, cl.Requires},
+		{model.ScopeDev, cl.BuildRequires},
+		{model.ScopeDev, cl.PythonRequires},
+		{model.ScopeDev, cl.ConfigRequires},
+	}
+	for _, item := range scopedRequires {
+		for _, req := range item.requires {
+			name, version := parseReference(req)
+			if name == "" {
+				continue
+			}
+			pkg := newPackage(name, version, filePath)
+			pkg.Scope = item.scope
+			pkgs = append(pkgs, *pkg)
 		}
+	}
 
+	// the nodes of conan 1 lockfiles, the node without a ref is the consumer
+	ids := make([]string, 0, len(cl.GraphLock.Nodes))
+	for id := range cl.GraphLock.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		node := cl.GraphLock.Nodes[id]
+		name, version := parseReference(node.Ref)
+		if name == "" || version == "" {
+			continue
+		}
 		pkg := newPackage(name, version, filePath)
+		pkg.Scope = model.ScopeRuntime
+		if node.Context == "build" {
+			pkg.Scope = model.ScopeDev
+		}
 		pkgs = append(pkgs, *pkg)
 	}
 
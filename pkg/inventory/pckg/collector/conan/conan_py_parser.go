@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package conan
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var (
+	// recipeAttrRe matches the class attributes of a recipe, e.g. name = "zlib"
+	recipeAttrRe = regexp.MustCompile(`(?m)^\s*(name|version|license|requires|tool_requires|build_requires|test_requires)\s*=\s*`)
+	// requireCallRe matches the requirements declared by methods, e.g. self.requires("zlib/1.3")
+	requireCallRe = regexp.MustCompile(`\bself\.(requires|tool_requires|build_requires|test_requires)\(\s*["']([^"']+)["']`)
+	quotedRe      = regexp.MustCompile(`["']([^"']*)["']`)
+)
+
+// requireScopes are the scopes of the requirements of a recipe
+var requireScopes = map[string]model.Scope{
+	"requires":       model.ScopeRuntime,
+	"tool_requires":  model.ScopeDev,
+	"build_requires": model.ScopeDev,
+	"test_requires":  model.ScopeTest,
+}
+
+// ConanPyParser is a parser for conanfile.py, the requirements declared by literal references
+// in the class attributes and the requirements() and build_requirements() methods are taken
+// see: https://docs.conan.io/2/reference/conanfile/methods/requirements.html
+type ConanPyParser struct{}
+
+// NewConanPyParser returns a new ConanPyParser
+func NewConanPyParser() *ConanPyParser {
+	return &ConanPyParser{}
+}
+
+func (m *ConanPyParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"conanfile.py"}}
+}
+
+func (m *ConanPyParser) Parse(filePath string) ([]model.Package, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses, err := getConanPkgLicense(filePath)
+	if err != nil {
+		log.Warnf("conanfile get license fail:" + err.Error())
+	}
+
+	return parseConanPy(string(content), filePath, licenses), nil
+}
+
+// recipe is the info of a conanfile.py
+type recipe struct {
+	name     string
+	version  string
+	licenses []string
+	// requires are the references of the requirements by kind
+	requires map[string][]string
+}
+
+// parseConanPy parses conanfile.py
+func parseConanPy(content string, filePath string, licenses map[string]string) []model.Package {
+	r := parseRecipe(content)
+	pkgs := make([]model.Package, 0)
+	var mainPkg *model.Package
+	if r.name != "" {
+		mainPkg = newPackage(r.name, r.version, filePath)
+		mainPkg.LicenseDeclared = license.NormalizeExpressions(r.licenses)
+	}
+	seen := make(map[string]struct{})
+	for _, kind := range []string{"requires", "tool_requires", "build_requires", "test_requires"} {
+		for _, ref := range r.requires[kind] {
+			name, version := parseReference(ref)
+			if name == "" || strings.ContainsAny(name+version, "{}") {
+				continue
+			}
+			pkg := newPackage(name, version, filePath)
+			if _, ok := seen[pkg.PURL]; ok {
+				continue
+			}
+			seen[pkg.PURL] = struct{}{}
+			pkg.Scope = requireScopes[kind]
+			pkg.LicenseConcluded = resolveLicense(name, version, licenses)
+			pkgs = append(pkgs, *pkg)
+			if mainPkg != nil && pkg.Scope == model.ScopeRuntime {
+				mainPkg.Dependencies = append(mainPkg.Dependencies, pkg.PURL)
+			}
+		}
+	}
+	if mainPkg != nil {
+		pkgs = append(pkgs, *mainPkg)
+	}
+	return pkgs
+}
+
+// parseRecipe extracts the attributes and the literal requirements of a recipe
+func parseRecipe(content string) *recipe {
+	r := &recipe{requires: make(map[string][]string)}
+	for _, loc := range recipeAttrRe.FindAllStringSubmatchIndex(content, -1) {
+		attr := content[loc[2]:loc[3]]
+		values := quotedValues(pythonExpr(content[loc[1]:]))
+		switch attr {
+		case "name":
+			if len(values) > 0 && r.name == "" {
+				r.name = values[0]
+			}
+		case "version":
+			if len(values) > 0 && r.version == "" {
+				r.version = values[0]
+			}
+		case "license":
+			r.licenses = append(r.licenses, values...)
+		default:
+			r.requires[attr] = append(r.requires[attr], values...)
+		}
+	}
+	for _, m := range requireCallRe.FindAllStringSubmatch(content, -1) {
+		r.requires[m[1]] = append(r.requires[m[1]], m[2])
+	}
+	return r
+}
+
+// pythonExpr returns the expression at the beginning of the text, which ends at the end of the line
+// unless it is in the brackets
+func pythonExpr(text string) string {
+	depth := 0
+	for i, c := range text {
+		switch c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case '\n':
+			if depth <= 0 {
+				return text[:i]
+			}
+		}
+	}
+	return text
+}
+
+func quotedValues(expr string) []string {
+	values := make([]string, 0)
+	for _, m := range quotedRe.FindAllStringSubmatch(expr, -1) {
+		if v := strings.TrimSpace(m[1]); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -76,8 +76,89 @@ var conanlockTestdata = []testitem{
 		}
 		`,
 		expected: []model.Package{
-			{Name: "zlib", Version: "1.2.11", Type: model.PkgTypeConan},
-			{Name: "base64", Version: "0.4.0", Type: model.PkgTypeConan},
+			{Name: "zlib", Version: "1.2.11", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "base64", Version: "0.4.0", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+		},
+	},
+	{
+		title: "BuildRequires",
+		content: `
+		{
+			"version": "0.5",
+			"requires": [
+				"openssl/3.1.2@mycompany/stable#3e0c6bfd1d2bd2ad1d0d4ae1a7e2bd65%1694508392.46"
+			],
+			"build_requires": [
+				"cmake/3.27.4#0f1f38ed5b1d5ae4fca08bb6ad7eb0b2%1693828287.98"
+			],
+			"python_requires": []
+		}
+		`,
+		expected: []model.Package{
+			{Name: "openssl", Version: "3.1.2", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "cmake", Version: "3.27.4", Type: model.PkgTypeConan, Scope: model.ScopeDev},
+		},
+	},
+	{
+		title: "Conan1",
+		content: `
+		{
+			"graph_lock": {
+				"nodes": {
+					"0": {"options": "", "requires": ["1"], "build_requires": ["2"], "path": "conanfile.txt", "context": "host"},
+					"1": {"ref": "zlib/1.2.11#dc0e384f0551386cd76dc29cc964c95e", "options": "shared=False", "context": "host"},
+					"2": {"ref": "cmake/3.19.8#7ea2feb0bbeee20f9c0e8a7a4d3d2cf5", "options": "", "context": "build"}
+				},
+				"revisions_enabled": true
+			},
+			"version": "0.4"
+		}
+		`,
+		expected: []model.Package{
+			{Name: "zlib", Version: "1.2.11", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "cmake", Version: "3.19.8", Type: model.PkgTypeConan, Scope: model.ScopeDev},
+		},
+	},
+}
+
+var conanpyTestdata = []testitem{
+	{
+		title: "Normal",
+		content: `
+from conan import ConanFile
+
+
+class AppConan(ConanFile):
+    name = "app"
+    version = "1.0.0"
+    license = ("MIT", "Apache-2.0")
+    requires = ("fmt/10.2.1",
+                "spdlog/1.12.0@user/stable")
+    test_requires = "gtest/1.14.0"
+
+    def requirements(self):
+        self.requires("zlib/[>=1.2.11 <2]")
+        self.requires(f"boost/{self.version}")
+        if self.options.with_ssl:
+            self.requires("openssl/3.1.2", transitive_headers=True)
+
+    def build_requirements(self):
+        self.tool_requires("cmake/3.27.4")
+`,
+		expected: []model.Package{
+			{Name: "fmt", Version: "10.2.1", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "spdlog", Version: "1.12.0", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "zlib", Version: "1.2.11", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "openssl", Version: "3.1.2", Type: model.PkgTypeConan, Scope: model.ScopeRuntime},
+			{Name: "cmake", Version: "3.27.4", Type: model.PkgTypeConan, Scope: model.ScopeDev},
+			{Name: "gtest", Version: "1.14.0", Type: model.PkgTypeConan, Scope: model.ScopeTest},
+			{Name: "app", Version: "1.0.0", Type: model.PkgTypeConan, LicenseDeclared: []string{"MIT", "Apache-2.0"},
+				Dependencies: []string{
+					"pkg:conan/fmt@10.2.1",
+					"pkg:conan/spdlog@1.12.0",
+					"pkg:conan/zlib@1.2.11",
+					"pkg:conan/openssl@3.1.2",
+				}},
 		},
 	},
 }
@@ -352,7 +433,7 @@ func TestParseConanlock(t *testing.T) {
 		}
 
 		if !slices.EqualFunc(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
@@ -396,3 +477,44 @@ func BenchmarkCppConanLockParser_Parse(b *testing.B) {
 		})
 	}
 }
+
+func TestParseConanPy(t *testing.T) {
+	for _, item := range conanpyTestdata {
+		pkgs := parseConanPy(item.content, "", map[string]string{})
+		if !slices.EqualFunc(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope &&
+				slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared)
+		}) {
+			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
+		}
+	}
+}
+
+func TestConanDataParser_Parse(t *testing.T) {
+	pkgs, err := NewConanDataParser().Parse("test_material/recipes/fmt/all/conandata.yml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// the recipe covers two versions of the sources but pins none, one package is taken without version
+	expected := []model.Package{
+		{Name: "fmt", Version: "", LicenseDeclared: []string{"MIT"}},
+	}
+	if !slices.EqualFunc(pkgs, expected, func(p1 model.Package, p2 model.Package) bool {
+		return model.PackageEqual(&p1, &p2) && slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared)
+	}) {
+		t.Errorf("Parse() expected = %v got %v", expected, pkgs)
+	}
+
+	pkgs, err = NewConanDataParser().Parse("test_material/recipes/zlib/conandata.yml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	expected = []model.Package{
+		{Name: "zlib", Version: "1.3.1", LicenseDeclared: []string{"Zlib"}},
+	}
+	if !slices.EqualFunc(pkgs, expected, func(p1 model.Package, p2 model.Package) bool {
+		return model.PackageEqual(&p1, &p2) && slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared)
+	}) {
+		t.Errorf("Parse() expected = %v got %v", expected, pkgs)
+	}
+}
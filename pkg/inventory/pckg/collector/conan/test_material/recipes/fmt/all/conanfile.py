@@ -0,0 +1,15 @@
+from conan import ConanFile
+from conan.tools.files import get
+
+
+class FmtConan(ConanFile):
+    name = "fmt"
+    license = "MIT"
+    homepage = "https://github.com/fmtlib/fmt"
+    settings = "os", "arch", "compiler", "build_type"
+
+    def build_requirements(self):
+        self.tool_requires("cmake/[>=3.16 <4]")
+
+    def source(self):
+        get(self, **self.conan_data["sources"][self.version], strip_root=True)
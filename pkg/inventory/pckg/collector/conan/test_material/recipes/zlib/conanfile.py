@@ -0,0 +1,13 @@
+from conan import ConanFile
+from conan.tools.files import get
+
+
+class ZlibConan(ConanFile):
+    name = "zlib"
+    version = "1.3.1"
+    license = "Zlib"
+    homepage = "https://zlib.net"
+    settings = "os", "arch", "compiler", "build_type"
+
+    def source(self):
+        get(self, **self.conan_data["sources"][self.version], strip_root=True)
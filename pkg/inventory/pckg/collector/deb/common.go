@@ -50,6 +50,24 @@ func packageURL(name, version string) string {
 	return pURL.ToString()
 }
 
+// newInstalledPackage returns a package installed into a root filesystem, the version is kept as is
+// and the package url is qualified by the arch and the distro
+func newInstalledPackage(name, version, arch, path string, release *collector.OSRelease) model.Package {
+	return model.Package{
+		Name:    name,
+		Version: version,
+		Type:    PkgType(),
+		PURL: packageurl.NewPackageURL(
+			PkgType(),
+			release.Namespace("debian"),
+			name,
+			version,
+			release.Qualifiers(arch, nil),
+			"").ToString(),
+		SourceLocation: path,
+	}
+}
+
 func debDependPkgParser(depTree *collector.DependencyTree, parentPkg model.Package, binaryDepRel []dependency.Relation, path string) {
 	for _, relation := range binaryDepRel {
 		for _, possibility := range relation.Possibilities {
@@ -58,7 +58,7 @@ func (p *DEBArchiveParser) Parse(path string) ([]model.Package, error) {
 	}
 	pkgLicenses := parseMainLicense(debfileInfo)
 	pkg := newPackage(pkgname, pkgversion, path)
-	pkg.LicenseDeclared = pkgLicenses
+	pkg.LicenseDeclared = license.NormalizeExpressions(pkgLicenses)
 	pkg.Supplier = debfileInfo.Control.Maintainer
 	depTree.AddPackage(&pkg)
 
@@ -0,0 +1,240 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package deb
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"pault.ag/go/debian/control"
+	"pault.ag/go/debian/dependency"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+const (
+	dpkgStatusPath  = "var/lib/dpkg/status"
+	dpkgStatusDPath = "var/lib/dpkg/status.d"
+	dpkgInfoPath    = "var/lib/dpkg/info"
+	md5sumsExt      = ".md5sums"
+)
+
+// dpkgEntry is a package in the dpkg database
+type dpkgEntry struct {
+	paragraph control.Paragraph
+}
+
+func (e dpkgEntry) field(key string) string {
+	return strings.TrimSpace(e.paragraph.Values[key])
+}
+
+// installed tells whether the package is installed, the distroless images have no Status field
+func (e dpkgEntry) installed() bool {
+	status := strings.Fields(e.field("Status"))
+	return len(status) == 0 || status[len(status)-1] == "installed"
+}
+
+// DpkgStatusParser is a parser for the dpkg database of a root filesystem, var/lib/dpkg/status
+// or the var/lib/dpkg/status.d dir of the distroless images, a package owns the files listed
+// in var/lib/dpkg/info/<name>.list or status.d/<name>.md5sums
+// see: https://man7.org/linux/man-pages/man1/dpkg.1.html
+type DpkgStatusParser struct{}
+
+func NewDpkgStatusParser() *DpkgStatusParser {
+	return &DpkgStatusParser{}
+}
+
+func (p *DpkgStatusParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/var/lib/dpkg/status$`),
+		regexp.MustCompile(`/var/lib/dpkg/status\.d/[^/]+$`),
+	}}
+}
+
+func (p *DpkgStatusParser) ProvidesGraph() bool {
+	return true
+}
+
+func (p *DpkgStatusParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("DpkgStatusParser path: %s", path)
+	if strings.HasSuffix(path, md5sumsExt) {
+		return nil, nil
+	}
+	statusD := filepath.Base(filepath.Dir(path)) == filepath.Base(dpkgStatusDPath)
+	var root string
+	var entries, index []dpkgEntry
+	var err error
+	if statusD {
+		root = collector.RootOf(filepath.Dir(path), dpkgStatusDPath)
+		if entries, err = readDpkgEntries(path); err != nil {
+			return nil, err
+		}
+		files, _ := os.ReadDir(filepath.Dir(path))
+		for _, f := range files {
+			if f.IsDir() || strings.HasSuffix(f.Name(), md5sumsExt) {
+				continue
+			}
+			es, _ := readDpkgEntries(filepath.Join(filepath.Dir(path), f.Name()))
+			index = append(index, es...)
+		}
+	} else {
+		root = collector.RootOf(path, dpkgStatusPath)
+		if entries, err = readDpkgEntries(path); err != nil {
+			return nil, err
+		}
+		index = entries
+	}
+	release := collector.ReadOSRelease(root)
+
+	// the installed packages and the virtual packages provided by them
+	installed := make(map[string]string)
+	for _, e := range index {
+		purl := newInstalledPackage(e.field("Package"), e.field("Version"), e.field("Architecture"), path, release).PURL
+		installed[e.field("Package")] = purl
+		if provides, err := dependency.Parse(e.field("Provides")); err == nil {
+			for _, relation := range provides.Relations {
+				for _, possibility := range relation.Possibilities {
+					if _, ok := installed[possibility.Name]; !ok {
+						installed[possibility.Name] = purl
+					}
+				}
+			}
+		}
+	}
+
+	pkgs := make([]model.Package, 0, len(entries))
+	for _, e := range entries {
+		name := e.field("Package")
+		pkg := newInstalledPackage(name, e.field("Version"), e.field("Architecture"), path, release)
+		pkg.Supplier = e.field("Maintainer")
+		for _, key := range []string{"Pre-Depends", "Depends"} {
+			pkg.Dependencies = append(pkg.Dependencies, resolveInstalled(e.field(key), installed)...)
+		}
+		pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+		pkg.LicenseDeclared = copyrightLicenses(root, name)
+		if statusD {
+			pkg.Files = md5sumsFiles(root, filepath.Join(root, dpkgStatusDPath, name+md5sumsExt))
+		} else {
+			pkg.Files = listFiles(root, name, e.field("Architecture"))
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func readDpkgEntries(path string) ([]dpkgEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader, err := control.NewParagraphReader(bufio.NewReader(f), nil)
+	if err != nil {
+		return nil, err
+	}
+	paragraphs, err := reader.All()
+	if err != nil {
+		log.Warnf("read dpkg database %s error: %s", path, err.Error())
+	}
+	entries := make([]dpkgEntry, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		e := dpkgEntry{paragraph: paragraph}
+		if e.field("Package") != "" && e.installed() {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// resolveInstalled returns the installed packages satisfying the relations,
+// the first installed one of the alternatives is chosen
+func resolveInstalled(relations string, installed map[string]string) []string {
+	deps := make([]string, 0)
+	if relations == "" {
+		return deps
+	}
+	parsed, err := dependency.Parse(relations)
+	if err != nil {
+		log.Warnf("parse dependency %s error: %s", relations, err.Error())
+		return deps
+	}
+	for _, relation := range parsed.Relations {
+		for _, possibility := range relation.Possibilities {
+			if purl, ok := installed[possibility.Name]; ok {
+				deps = append(deps, purl)
+				break
+			}
+		}
+	}
+	return deps
+}
+
+// listFiles returns the files listed in var/lib/dpkg/info/<name>.list, the dirs are skipped
+func listFiles(root, name, arch string) []string {
+	data, err := os.ReadFile(filepath.Join(root, dpkgInfoPath, name+":"+arch+".list"))
+	if err != nil {
+		if data, err = os.ReadFile(filepath.Join(root, dpkgInfoPath, name+".list")); err != nil {
+			return nil
+		}
+	}
+	files := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "/." {
+			continue
+		}
+		file := filepath.Join(root, filepath.FromSlash(line))
+		if info, err := os.Lstat(file); err == nil && info.IsDir() {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// md5sumsFiles returns the files listed in the md5sums file, the lines are like "<md5>  usr/bin/foo"
+func md5sumsFiles(root, path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	files := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		file := strings.TrimSpace(line[strings.Index(line, fields[1]):])
+		files = append(files, filepath.Join(root, filepath.FromSlash(file)))
+	}
+	return files
+}
+
+// copyrightLicenses returns the licenses in usr/share/doc/<name>/copyright
+func copyrightLicenses(root, name string) []string {
+	f, err := os.Open(filepath.Join(root, "usr/share/doc", name, license.CopyrightFileName))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	licenses := license.GetLicensesFromCopyright(f)
+	if len(licenses) == 0 {
+		return nil
+	}
+	return license.NormalizeExpressions(licenses)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package deb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestDpkgStatusParser_Parse(t *testing.T) {
+	tests := []struct {
+		root string
+		path string
+		want []model.Package
+	}{
+		{
+			root: "test_material/rootfs",
+			path: "var/lib/dpkg/status",
+			want: []model.Package{
+				{Name: "libc6", Version: "2.36-9+deb12u4", PURL: "pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12",
+					LicenseDeclared: []string{"GPL-2.0-or-later", "LGPL-2.1-or-later"},
+					Dependencies:    []string{"pkg:deb/debian/libgcc-s1@12.2.0-14?arch=amd64&distro=debian-12"},
+					Files:           []string{"usr/lib/x86_64-linux-gnu/libc.so.6", "usr/share/doc/libc6/copyright"}},
+				{Name: "libgcc-s1", Version: "12.2.0-14", PURL: "pkg:deb/debian/libgcc-s1@12.2.0-14?arch=amd64&distro=debian-12",
+					Dependencies: []string{"pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12"},
+					Files:        []string{"usr/lib/x86_64-linux-gnu/libgcc_s.so.1"}},
+				{Name: "mawk", Version: "1.3.4.20200120-3.1", PURL: "pkg:deb/debian/mawk@1.3.4.20200120-3.1?arch=amd64&distro=debian-12",
+					Dependencies: []string{"pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12"},
+					Files:        []string{"usr/bin/mawk"}},
+				{Name: "curl", Version: "7.88.1-10+deb12u5", PURL: "pkg:deb/debian/curl@7.88.1-10+deb12u5?arch=amd64&distro=debian-12",
+					Dependencies: []string{
+						"pkg:deb/debian/mawk@1.3.4.20200120-3.1?arch=amd64&distro=debian-12",
+						"pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12",
+					},
+					Files: []string{"usr/bin/curl"}},
+			},
+		},
+		{
+			root: "test_material/distroless",
+			path: "var/lib/dpkg/status.d/netbase",
+			want: []model.Package{
+				{Name: "netbase", Version: "6.4", PURL: "pkg:deb/debian/netbase@6.4?arch=all&distro=debian-12",
+					Dependencies: []string{"pkg:deb/debian/base-files@12.4+deb12u5?arch=amd64&distro=debian-12"},
+					Files:        []string{"etc/protocols"}},
+			},
+		},
+		{
+			root: "test_material/distroless",
+			path: "var/lib/dpkg/status.d/base-files.md5sums",
+		},
+	}
+	parser := NewDpkgStatusParser()
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parser.Parse(filepath.Join(tt.root, tt.path))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for i := range got {
+				for j, file := range got[i].Files {
+					rel, _ := filepath.Rel(tt.root, file)
+					got[i].Files[j] = filepath.ToSlash(rel)
+				}
+			}
+			if !slices.EqualFunc(got, tt.want, func(p1, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2) && p1.PURL == p2.PURL &&
+					slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
+					slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.Files, p2.Files)
+			}) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
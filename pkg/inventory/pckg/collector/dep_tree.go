@@ -116,3 +116,31 @@ func (tree *DependencyTree) ToList() []model.Package {
 	}
 	return OrganizePackage(pkgs)
 }
+
+// PropagateScope passes the scope of packages down to their dependencies, a dependency takes the widest scope among the packages depending on it,
+// e.g. the dependencies of a test package are test packages unless a runtime package depends on them too.
+// It should be used when the scopes of all direct dependencies are known
+func PropagateScope(pkgs []model.Package) {
+	index := make(map[string]int, len(pkgs))
+	queue := make([]int, 0, len(pkgs))
+	for i := range pkgs {
+		index[pkgs[i].PURL] = i
+		if pkgs[i].Scope != "" {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, dep := range pkgs[i].Dependencies {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			if scope := model.MergeScope(pkgs[j].Scope, pkgs[i].Scope); scope != pkgs[j].Scope {
+				pkgs[j].Scope = scope
+				queue = append(queue, j)
+			}
+		}
+	}
+}
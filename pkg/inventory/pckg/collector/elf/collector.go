@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package elf
+
+import (
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+type Collector struct {
+	collector.BaseCollector
+	parser *ELFParser
+}
+
+func NewCollector() *Collector {
+	c := Collector{parser: NewELFParser()}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = []collector.FileParser{c.parser}
+	return &c
+}
+
+// Collect parses the accepted binaries, and links a binary to the libraries of its DT_NEEDED entries
+// found in the same tree, the libraries not found are left out of the dependencies
+func (c *Collector) Collect() ([]model.Package, error) {
+	binaries := make([]*binary, 0, len(c.Requests))
+	sonames := make(map[string]string)
+	for _, request := range c.Requests {
+		bin, err := c.parser.parseBinary(request.File.FullName())
+		if err != nil {
+			log.Warnf("parse elf file error: %s", err.Error())
+			continue
+		}
+		binaries = append(binaries, bin)
+		if bin.pkg != nil && bin.soname != "" {
+			sonames[bin.soname] = bin.pkg.PURL
+		}
+	}
+	pkgs := make([]model.Package, 0)
+	for _, bin := range binaries {
+		if bin.pkg != nil {
+			for _, needed := range bin.needed {
+				if purl, ok := sonames[needed]; ok && purl != bin.pkg.PURL {
+					bin.pkg.Dependencies = append(bin.pkg.Dependencies, purl)
+				}
+			}
+			pkgs = append(pkgs, *bin.pkg)
+		}
+		pkgs = append(pkgs, bin.embedded...)
+	}
+	return collector.OrganizePackage(pkgs), nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package elf
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestELFCollector_Collect(t *testing.T) {
+	root := "test_material/dist"
+	c := NewCollector()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			c.TryToAccept(collector.NewFileMeta(path))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the script and the symlink are not accepted
+	if len(c.Requests) != 3 {
+		t.Fatalf("TryToAccept() accepted %d files, want 3", len(c.Requests))
+	}
+	got, err := c.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	slices.SortFunc(got, func(p1, p2 model.Package) bool {
+		return p1.Name < p2.Name
+	})
+
+	app := filepath.Join(root, "bin/app")
+	libz := filepath.Join(root, "lib/libz.so.1.2.13")
+	want := []model.Package{
+		{Name: "curl", Version: "8.1.2", Type: model.PkgTypeGeneric, PURL: "pkg:generic/curl@8.1.2", SourceLocation: app},
+		{Name: "demo", Version: "1.2.3-1.fc38", Type: model.PkgTypeRPM,
+			PURL:           "pkg:rpm/fedora/demo@1.2.3-1.fc38?arch=x86_64&distro=fedora-38",
+			SourceLocation: filepath.Join(root, "lib/libdemo.so.1.2.3"), Dependencies: []string{"pkg:generic/libz@1.2.13"}},
+		{Name: "libz", Version: "1.2.13", Type: model.PkgTypeGeneric, PURL: "pkg:generic/libz@1.2.13",
+			SourceLocation: libz, Dependencies: []string{"pkg:generic/zlib@1.2.13"}},
+		{Name: "openssl", Version: "3.0.8", Type: model.PkgTypeGeneric, PURL: "pkg:generic/openssl@3.0.8", SourceLocation: app},
+		{Name: "zlib", Version: "1.2.13", Type: model.PkgTypeGeneric, PURL: "pkg:generic/zlib@1.2.13", SourceLocation: libz},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Collect() got %d packages %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !model.PackageEqual(&got[i], &want[i]) || got[i].Type != want[i].Type || got[i].PURL != want[i].PURL ||
+			got[i].SourceLocation != want[i].SourceLocation {
+			t.Errorf("Collect() got %+v, want %+v", got[i], want[i])
+		}
+		if !slices.Equal(got[i].Dependencies, want[i].Dependencies) {
+			t.Errorf("Collect() got dependencies %v of %s, want %v", got[i].Dependencies, got[i].Name, want[i].Dependencies)
+		}
+	}
+}
+
+func TestLibraryNameVersion(t *testing.T) {
+	tests := []struct {
+		soname      string
+		fileName    string
+		wantName    string
+		wantVersion string
+	}{
+		{soname: "libz.so.1", fileName: "libz.so.1.2.13", wantName: "libz", wantVersion: "1.2.13"},
+		{soname: "libssl.so.3", fileName: "libssl.so.3", wantName: "libssl", wantVersion: "3"},
+		{soname: "libfoo.so.2", fileName: "libfoo.so", wantName: "libfoo", wantVersion: "2"},
+		{soname: "libbar-1.4.so", fileName: "libbar-1.4.so", wantName: "libbar-1.4"},
+		{fileName: "libplugin.so.0.9", wantName: "libplugin", wantVersion: "0.9"},
+		{soname: "libc.so.6", fileName: "libc-2.31.so", wantName: "libc", wantVersion: "6"},
+		{fileName: "app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			name, version := libraryNameVersion(tt.soname, tt.fileName)
+			if name != tt.wantName || version != tt.wantVersion {
+				t.Errorf("libraryNameVersion() got %s %s, want %s %s", name, version, tt.wantName, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestCompilers(t *testing.T) {
+	compilers := Compilers("test_material/dist/bin/app")
+	if len(compilers) == 0 || !slices.ContainsFunc(compilers, func(c string) bool { return strings.HasPrefix(c, "GCC:") }) {
+		t.Errorf("Compilers() got %v, want a GCC compiler", compilers)
+	}
+	if compilers := Compilers("test_material/dist/bin/run.sh"); len(compilers) != 0 {
+		t.Errorf("Compilers() got %v of a script", compilers)
+	}
+}
+
+func TestMatchSignatures(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]string
+	}{
+		{name: "openssl", data: "\x00OpenSSL 1.1.1w  11 Sep 2023\x00", want: map[string]string{"openssl": "1.1.1w"}},
+		{name: "sqlite", data: "SQLite format 3\x00\x003.42.0\x00", want: map[string]string{"sqlite": "3.42.0"}},
+		{name: "sqlite without marker", data: "\x003.42.0\x00", want: map[string]string{}},
+		{name: "bzip2", data: "bzip2/libbzip2: internal error\x001.0.8, 13-Jul-2019\x00", want: map[string]string{"bzip2": "1.0.8"}},
+		{name: "libpng and expat", data: "libpng version 1.6.39\x00expat_2.5.0\x00",
+			want: map[string]string{"libpng": "1.6.39", "expat": "2.5.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchSignatures([]byte(tt.data)); !maps.Equal(got, tt.want) {
+				t.Errorf("matchSignatures() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package elf
+
+import (
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// notePurlTypes maps the package types of the package metadata note to the purl types
+var notePurlTypes = map[string]model.PkgType{
+	"rpm":  model.PkgTypeRPM,
+	"deb":  model.PkgTypeDEB,
+	"apk":  model.PkgTypeAPK,
+	"alpm": "alpm",
+}
+
+// packageNote is the package metadata note(.note.package) of a binary,
+// ref https://systemd.io/ELF_PACKAGE_METADATA/
+type packageNote struct {
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	OSVersion    string `json:"osVersion"`
+}
+
+func newPackage(name, version string, path string) *model.Package {
+	return &model.Package{
+		Name:           name,
+		Version:        version,
+		Type:           PkgType(),
+		PURL:           packageURL(name, version),
+		SourceLocation: path,
+	}
+}
+
+func packageURL(name, version string) string {
+	return packageurl.NewPackageURL(
+		PkgType(),
+		"",
+		name,
+		version,
+		nil,
+		"",
+	).ToString()
+}
+
+// newNotePackage returns the package described by the package metadata note, qualified by the arch and the distro
+func newNotePackage(note *packageNote, path string) *model.Package {
+	pkgType, ok := notePurlTypes[note.Type]
+	if !ok {
+		pkgType = PkgType()
+	}
+	release := &collector.OSRelease{ID: note.OS, VersionID: note.OSVersion}
+	return &model.Package{
+		Name:    note.Name,
+		Version: note.Version,
+		Type:    pkgType,
+		PURL: packageurl.NewPackageURL(
+			pkgType,
+			release.Namespace(""),
+			note.Name,
+			note.Version,
+			release.Qualifiers(note.Architecture, nil),
+			"",
+		).ToString(),
+		SourceLocation: path,
+	}
+}
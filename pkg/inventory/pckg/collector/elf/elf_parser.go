@@ -0,0 +1,262 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package elf
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+const (
+	// packageNoteOwner and packageNoteType identify the package metadata note
+	packageNoteOwner = "FDO"
+	packageNoteType  = 0xcafe1a7e
+	// minELFSize is the size of the smallest ELF header
+	minELFSize = 52
+)
+
+var (
+	elfMagic       = []byte("\x7fELF")
+	soFileRe       = regexp.MustCompile(`\.so(\.\d+)*$`)
+	soVersionRe    = regexp.MustCompile(`^\d+(\.\d+)*$`)
+	commentSection = ".comment"
+	noteSection    = ".note.package"
+	// dataSections are the sections holding the constant strings of a binary
+	dataSections = []string{".rodata", ".data.rel.ro", ".data"}
+)
+
+// binary is what is found in an ELF file
+type binary struct {
+	// pkg is the package of the binary itself, nil if the binary is not identified
+	pkg *model.Package
+	// soname is the DT_SONAME of a shared object
+	soname string
+	// needed are the DT_NEEDED sonames
+	needed []string
+	// embedded are the libraries found in the binary by the signatures
+	embedded []model.Package
+}
+
+// ELFParser is a parser for ELF shared objects and executables
+type ELFParser struct{}
+
+// NewELFParser returns a new ELFParser
+func NewELFParser() *ELFParser {
+	return &ELFParser{}
+}
+
+func (p *ELFParser) Matcher() collector.FileMatcher {
+	return &elfFileMatcher{}
+}
+
+func (p *ELFParser) Parse(path string) ([]model.Package, error) {
+	bin, err := p.parseBinary(path)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]model.Package, 0, len(bin.embedded)+1)
+	if bin.pkg != nil {
+		pkgs = append(pkgs, *bin.pkg)
+	}
+	return append(pkgs, bin.embedded...), nil
+}
+
+// parseBinary identifies an ELF file by its package metadata note or its soname,
+// and the libraries embedded in it by the signatures
+func (p *ELFParser) parseBinary(path string) (*binary, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(f *elf.File) {
+		_ = f.Close()
+	}(f)
+
+	bin := &binary{}
+	// a static binary has no dynamic section
+	if sonames, _ := f.DynString(elf.DT_SONAME); len(sonames) > 0 {
+		bin.soname = sonames[0]
+	}
+	bin.needed, _ = f.DynString(elf.DT_NEEDED)
+	if note := readPackageNote(f); note != nil && note.Name != "" {
+		bin.pkg = newNotePackage(note, path)
+	} else if name, version := libraryNameVersion(bin.soname, filepath.Base(path)); name != "" {
+		bin.pkg = newPackage(name, version, path)
+	}
+
+	found := make(map[string]string)
+	for _, name := range dataSections {
+		section := f.Section(name)
+		if section == nil || section.Type == elf.SHT_NOBITS {
+			continue
+		}
+		data, err := section.Data()
+		if err != nil {
+			continue
+		}
+		for lib, version := range matchSignatures(data) {
+			if _, ok := found[lib]; !ok {
+				found[lib] = version
+			}
+		}
+	}
+	libs := make([]string, 0, len(found))
+	for lib := range found {
+		libs = append(libs, lib)
+	}
+	sort.Strings(libs)
+	for _, lib := range libs {
+		embedded := newPackage(lib, found[lib], path)
+		bin.embedded = append(bin.embedded, *embedded)
+		if bin.pkg != nil {
+			bin.pkg.Dependencies = append(bin.pkg.Dependencies, embedded.PURL)
+		}
+	}
+	return bin, nil
+}
+
+// libraryNameVersion returns the name and the version of a shared object,
+// e.g. libz 1.2.13 for the soname libz.so.1 and the file libz.so.1.2.13
+func libraryNameVersion(soname, fileName string) (string, string) {
+	base := soname
+	if base == "" {
+		if !soFileRe.MatchString(fileName) {
+			return "", ""
+		}
+		base = fileName
+	}
+	i := strings.Index(base, ".so")
+	if i <= 0 {
+		return "", ""
+	}
+	name := base[:i]
+	version := strings.TrimPrefix(base[i+len(".so"):], ".")
+	// the file name is usually more specific than the soname
+	if v := strings.TrimPrefix(fileName, name+".so."); v != fileName && soVersionRe.MatchString(v) &&
+		(version == "" || strings.HasPrefix(v, version+".")) {
+		version = v
+	}
+	if !soVersionRe.MatchString(version) {
+		version = ""
+	}
+	return name, version
+}
+
+// readPackageNote reads the package metadata note, returns nil if the binary has none
+func readPackageNote(f *elf.File) *packageNote {
+	section := f.Section(noteSection)
+	if section == nil {
+		return nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil
+	}
+	// each note is a header of namesz, descsz and type followed by the name and the desc, both aligned to 4 bytes
+	for len(data) >= 12 {
+		nameSize := uint64(f.ByteOrder.Uint32(data[0:4]))
+		descSize := uint64(f.ByteOrder.Uint32(data[4:8]))
+		noteType := f.ByteOrder.Uint32(data[8:12])
+		nameEnd := 12 + align4(nameSize)
+		descEnd := nameEnd + align4(descSize)
+		if descEnd > uint64(len(data)) {
+			return nil
+		}
+		name := strings.TrimRight(string(data[12:12+nameSize]), "\x00")
+		if name == packageNoteOwner && noteType == packageNoteType {
+			note := &packageNote{}
+			if json.Unmarshal(bytes.TrimRight(data[nameEnd:nameEnd+descSize], "\x00"), note) != nil {
+				return nil
+			}
+			return note
+		}
+		data = data[descEnd:]
+	}
+	return nil
+}
+
+func align4(n uint64) uint64 {
+	return (n + 3) &^ 3
+}
+
+// IsELF returns true if the file starts with the ELF magic
+func IsELF(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	magic := make([]byte, len(elfMagic))
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, elfMagic)
+}
+
+// Compilers returns the compiler strings recorded in the .comment section of an ELF file,
+// e.g. GCC: (GNU) 12.2.0
+func Compilers(path string) []string {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func(f *elf.File) {
+		_ = f.Close()
+	}(f)
+	section := f.Section(commentSection)
+	if section == nil {
+		return nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil
+	}
+	compilers := make([]string, 0)
+	for _, item := range bytes.Split(data, []byte{0}) {
+		compiler := strings.TrimSpace(string(item))
+		if compiler == "" || util.SliceContains(compilers, compiler) {
+			continue
+		}
+		compilers = append(compilers, compiler)
+	}
+	return compilers
+}
+
+// elfFileMatcher matches the shared objects and the executables by the ELF magic, symlinks are skipped
+type elfFileMatcher struct{}
+
+func (m *elfFileMatcher) Match(file collector.File) bool {
+	stat, err := os.Lstat(file.FullName())
+	if err != nil || !stat.Mode().IsRegular() || stat.Size() < minELFSize {
+		return false
+	}
+	if !soFileRe.MatchString(file.FileName()) && stat.Mode().Perm()&0111 == 0 {
+		return false
+	}
+	return IsELF(file.FullName())
+}
+
+func (m *elfFileMatcher) Description() string {
+	return "elf: *.so, *.so.*, executables"
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package elf
+
+import (
+	"regexp"
+)
+
+// signature finds a well-known library statically linked or bundled into a binary by its embedded version string
+type signature struct {
+	name string
+	// marker must be present in the binary if it is not nil, for the version strings too generic to match alone
+	marker *regexp.Regexp
+	// version matches the version string, the first group is the version
+	version *regexp.Regexp
+}
+
+// signatures are matched against the read-only data of the binaries
+var signatures = []signature{
+	{
+		name:    "openssl",
+		version: regexp.MustCompile(`OpenSSL (\d+\.\d+\.\d+[a-z]{0,2})(?:-[\w.]+)? +\d{1,2} [A-Z][a-z]{2} \d{4}`),
+	},
+	{
+		name:    "zlib",
+		version: regexp.MustCompile(`(?:deflate|inflate) (\d+\.\d+\.\d+(?:\.\d+)?) Copyright \d{4}-\d{4}`),
+	},
+	{
+		name:    "curl",
+		version: regexp.MustCompile(`libcurl/(\d+\.\d+\.\d+)`),
+	},
+	{
+		name:    "sqlite",
+		marker:  regexp.MustCompile(`SQLite format 3\x00`),
+		version: regexp.MustCompile(`\x00(3\.\d{1,2}\.\d{1,2})\x00`),
+	},
+	{
+		name:    "libpng",
+		version: regexp.MustCompile(`libpng version (\d+\.\d+\.\d+)`),
+	},
+	{
+		name:    "expat",
+		version: regexp.MustCompile(`expat_(\d+\.\d+\.\d+)`),
+	},
+	{
+		name:    "bzip2",
+		marker:  regexp.MustCompile(`bzip2/libbzip2`),
+		version: regexp.MustCompile(`(\d\.\d\.\d{1,2}), \d{1,2}-[A-Z][a-z]{2}-\d{4}`),
+	},
+}
+
+// matchSignatures returns the versions of the libraries found in the data by name
+func matchSignatures(data []byte) map[string]string {
+	found := make(map[string]string)
+	for _, s := range signatures {
+		if s.marker != nil && !s.marker.Match(data) {
+			continue
+		}
+		if m := s.version.FindSubmatch(data); m != nil {
+			found[s.name] = string(m[1])
+		}
+	}
+	return found
+}
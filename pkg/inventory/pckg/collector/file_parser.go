@@ -27,6 +27,18 @@ type MainPkgParser interface {
 	ParseMain(path string) (pkg *model.Package, err error)
 }
 
+// GraphParser is implemented by the file parsers discovering the dependencies between packages,
+// they are marked with [graph] in the info command
+type GraphParser interface {
+	ProvidesGraph() bool
+}
+
+// ProvidesGraph returns true if the parser discovers the dependencies between packages
+func ProvidesGraph(parser FileParser) bool {
+	gp, ok := parser.(GraphParser)
+	return ok && gp.ProvidesGraph()
+}
+
 // Request represents a file and parser pair, and the parser can recognize and parse the file
 type Request struct {
 	File   File
@@ -20,10 +20,15 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the gems installed into a gem home, e.g. vendor/bundle
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewGemSpecParser())
 	parsers = append(parsers, NewGemfileParser())
 	parsers = append(parsers, NewGemFileLockParser())
+
+	installedParsers = append(installedParsers, NewInstalledSpecParser())
 }
 
 func NewCollector() *Collector {
@@ -33,3 +38,12 @@ func NewCollector() *Collector {
 	c.Parsers = parsers
 	return &c
 }
+
+// NewInstalledCollector returns the collector of the gems installed into a gem home
+func NewInstalledCollector() *Collector {
+	c := Collector{}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = installedParsers
+	return &c
+}
@@ -14,6 +14,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/exp/slices"
@@ -37,6 +38,10 @@ func (p *GemFileLockParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"Gemfile.lock"}}
 }
 
+func (p *GemFileLockParser) ProvidesGraph() bool {
+	return true
+}
+
 func (p *GemFileLockParser) Parse(path string) ([]model.Package, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -47,7 +52,7 @@ func (p *GemFileLockParser) Parse(path string) ([]model.Package, error) {
 	}()
 	scanner := bufio.NewScanner(file)
 
-	lines := parseLines(scanner)
+	lines, directDeps := parseLines(scanner)
 
 	depTree := collector.NewDependencyTree()
 	depMap := make(map[string][]string)
@@ -95,13 +100,28 @@ func (p *GemFileLockParser) Parse(path string) ([]model.Package, error) {
 	}
 	pkgs := depTree.ToList()
 
+	// scopes of the direct dependencies come from the groups of Gemfile
+	if scopes, err := parseGemfileScopes(filepath.Join(filepath.Dir(path), "Gemfile")); err == nil {
+		for i := range pkgs {
+			if slices.Contains(directDeps, pkgs[i].Name) {
+				pkgs[i].Scope = model.ScopeRuntime
+				if scope, ok := scopes[pkgs[i].Name]; ok {
+					pkgs[i].Scope = scope
+				}
+			}
+		}
+		collector.PropagateScope(pkgs)
+	}
+
 	return pkgs, nil
 }
 
-func parseLines(scanner *bufio.Scanner) []string {
+// parseLines returns the lines of specs and the names of direct dependencies
+func parseLines(scanner *bufio.Scanner) ([]string, []string) {
 	lines := make([]string, 0)
+	directDeps := make([]string, 0)
 	var header string
-	var specs bool
+	var specs, dependencies bool
 	for scanner.Scan() {
 		line := scanner.Text()
 		trimLine := strings.TrimSpace(line)
@@ -111,11 +131,17 @@ func parseLines(scanner *bufio.Scanner) []string {
 		if line[0] != ' ' {
 			header = ""
 			specs = false
+			dependencies = trimLine == "DEPENDENCIES"
 			if slices.Contains(headers, trimLine) {
 				header = trimLine
 			}
 			continue
 		}
+		if dependencies {
+			name := strings.SplitN(trimLine, " ", 2)[0]
+			directDeps = append(directDeps, strings.TrimSuffix(name, "!"))
+			continue
+		}
 
 		if header != "" && trimLine == "specs:" {
 			specs = true
@@ -127,7 +153,7 @@ func parseLines(scanner *bufio.Scanner) []string {
 			lines = append(lines, line)
 		}
 	}
-	return lines
+	return lines, directDeps
 }
 
 func isPkg(line string) bool {
@@ -55,7 +55,7 @@ func TestGemfileLockParser_Parse(t *testing.T) {
 			assert.NoError(t, err)
 
 			if !util.SliceEqual(got, expectPkgs, func(p1 model.Package, p2 model.Package) bool {
-				return model.PackageEqual(&p1, &p2)
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 			}) {
 				t.Errorf("Parse() got = %v, \nwant %v", got, tt.want)
 			}
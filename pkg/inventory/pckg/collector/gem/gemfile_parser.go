@@ -22,6 +22,10 @@ import (
 )
 
 var nameVerReg = regexp.MustCompile(`\s*(".*"\s*,\s*".*")\s*`)
+var gemNameReg = regexp.MustCompile(`^\s*['"]([^'"]+)['"]`)
+var groupBlockReg = regexp.MustCompile(`^group\s+(.+?)\s+do\b`)
+var groupOptionReg = regexp.MustCompile(`\bgroups?\s*:\s*(\[[^\]]*]|:\w+|['"][^'"]*['"])`)
+var groupNameReg = regexp.MustCompile(`\w+`)
 
 // GemfileParser is a parser for Gemfile file.
 // see: https://bundler.io/v2.4/man/gemfile.5.html
@@ -44,19 +48,86 @@ func (p *GemfileParser) Parse(path string) ([]model.Package, error) {
 	defer func() {
 		_ = file.Close()
 	}()
-	scanner := bufio.NewScanner(file)
 	pkgs := make([]model.Package, 0)
+	for _, line := range parseGemfileLines(bufio.NewScanner(file)) {
+		pkg := parseGemfileDep(line.text, path)
+		if pkg != nil {
+			pkg.Scope = line.scope
+			pkgs = append(pkgs, *pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+// gemfileLine is a gem declaration of Gemfile
+type gemfileLine struct {
+	text  string // the declaration without the leading "gem "
+	scope model.Scope
+}
 
+// parseGemfileLines returns the gem declarations of Gemfile with the scopes of their groups
+func parseGemfileLines(scanner *bufio.Scanner) []gemfileLine {
+	lines := make([]gemfileLine, 0)
+	// scopes of the enclosing do...end blocks, blocks other than group inherit the outer scope
+	blocks := make([]model.Scope, 0)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, "gem ") {
-			pkg := parseGemfileDep(line[4:], path)
-			if pkg != nil {
-				pkgs = append(pkgs, *pkg)
+		scope := model.ScopeRuntime
+		if len(blocks) > 0 {
+			scope = blocks[len(blocks)-1]
+		}
+		switch {
+		case strings.HasPrefix(line, "gem "):
+			if groups := findSub(groupOptionReg, line); groups != "" {
+				scope = gemGroupScope(groups)
 			}
+			lines = append(lines, gemfileLine{text: line[4:], scope: scope})
+		case groupBlockReg.MatchString(line):
+			blocks = append(blocks, gemGroupScope(findSub(groupBlockReg, line)))
+		case strings.HasSuffix(line, " do") || strings.Contains(line, " do |"):
+			blocks = append(blocks, scope)
+		case line == "end" && len(blocks) > 0:
+			blocks = blocks[:len(blocks)-1]
 		}
 	}
-	return pkgs, nil
+	return lines
+}
+
+// parseGemfileScopes returns the scopes of the gems declared in Gemfile by name
+func parseGemfileScopes(path string) (map[string]model.Scope, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	scopes := make(map[string]model.Scope)
+	for _, line := range parseGemfileLines(bufio.NewScanner(file)) {
+		if name := findSub(gemNameReg, line.text); name != "" {
+			scopes[name] = model.MergeScope(scopes[name], line.scope)
+		}
+	}
+	return scopes, nil
+}
+
+// gemGroupScope returns the scope of gem groups, e.g. `:test` or `[:development, :test]`
+func gemGroupScope(groups string) model.Scope {
+	var scope model.Scope
+	for _, group := range groupNameReg.FindAllString(groups, -1) {
+		switch {
+		case strings.Contains(group, "test"):
+			scope = model.MergeScope(scope, model.ScopeTest)
+		case group == "development" || group == "dev":
+			scope = model.MergeScope(scope, model.ScopeDev)
+		default:
+			scope = model.MergeScope(scope, model.ScopeRuntime)
+		}
+	}
+	if scope == "" {
+		return model.ScopeRuntime
+	}
+	return scope
 }
 
 func parseGemfileDep(line string, sourcePath string) *model.Package {
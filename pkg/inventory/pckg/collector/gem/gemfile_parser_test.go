@@ -31,10 +31,25 @@ func TestGemfileParser_Parse(t *testing.T) {
 			"case-1",
 			args{path: "test_material/Gemfile"},
 			[]model.Package{
-				*newPackage("rake", "13.0", ""),
-				*newPackage("nokogiri", "1.4.2", ""),
-				*newPackage("byebug", "1.0", ""),
-				*newPackage("rails", "1.4.2", ""),
+				scopedPackage("rake", "13.0", model.ScopeRuntime),
+				scopedPackage("nokogiri", "1.4.2", model.ScopeRuntime),
+				scopedPackage("byebug", "1.0", model.ScopeRuntime),
+				scopedPackage("rails", "1.4.2", model.ScopeRuntime),
+			},
+			false,
+		},
+		{
+			"groups",
+			args{path: "test_material/groups/Gemfile"},
+			[]model.Package{
+				scopedPackage("rails", "7.0.4", model.ScopeRuntime),
+				scopedPackage("pg", "1.4.5", model.ScopeRuntime),
+				scopedPackage("jruby-openssl", "0.14.0", model.ScopeRuntime),
+				scopedPackage("listen", "3.8.0", model.ScopeDev),
+				scopedPackage("debug", "1.7.1", model.ScopeTest),
+				scopedPackage("byebug", "11.1.3", model.ScopeTest),
+				scopedPackage("rspec", "3.12.0", model.ScopeTest),
+				scopedPackage("rubocop", "1.48.1", model.ScopeDev),
 			},
 			false,
 		},
@@ -48,10 +63,16 @@ func TestGemfileParser_Parse(t *testing.T) {
 				return
 			}
 			if !util.SliceEqual(got, tt.want, func(p1 model.Package, p2 model.Package) bool {
-				return model.PackageEqual(&p1, &p2)
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 			}) {
 				t.Errorf("Parse() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func scopedPackage(name, version string, scope model.Scope) model.Package {
+	pkg := newPackage(name, version, "")
+	pkg.Scope = scope
+	return *pkg
+}
@@ -19,6 +19,7 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 const _GemSpecNewStr = "Gem::Specification.new"
@@ -101,7 +102,7 @@ func (p *GemSpecParser) Parse(path string) ([]model.Package, error) {
 	if name != "" && version != "" {
 		pkg := newPackage(name, version, path)
 		if len(licenses) > 0 {
-			pkg.LicenseDeclared = licenses
+			pkg.LicenseDeclared = license.NormalizeExpressions(licenses)
 		}
 		pkgs = append(pkgs, *pkg)
 	}
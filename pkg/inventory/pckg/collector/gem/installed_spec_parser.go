@@ -0,0 +1,171 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package gem
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var (
+	// specStrReg matches a string of the generated gemspec, e.g. "rack".freeze, %q<rack> or %q{rack}
+	specStrReg        = regexp.MustCompile(`"([^"]*)"|%q[<{(\[]([^>})\]]*)[>})\]]`)
+	specNameReg       = regexp.MustCompile(`^\w+\.name\s*=\s*(.*)`)
+	specVersionReg    = regexp.MustCompile(`^\w+\.version\s*=\s*(.*)`)
+	specLicensesReg   = regexp.MustCompile(`^\w+\.licenses?\s*=\s*(.*)`)
+	specRuntimeDepReg = regexp.MustCompile(`^\w+\.add_(?:runtime_)?dependency[\s(]+(.*)`)
+)
+
+// installedSpec is the gemspec generated by rubygems for an installed gem
+type installedSpec struct {
+	name         string
+	version      string
+	licenses     []string
+	dependencies []string
+}
+
+// InstalledSpecParser is a parser for the gemspec of the installed gems in specifications dir,
+// the gem owns the files in gems/<name>-<version> of the same gem home
+// see: https://guides.rubygems.org/rubygems-basics/#where-are-my-gems
+type InstalledSpecParser struct {
+	mu sync.Mutex
+	// versions are the installed versions of each specifications dir, read once for all the gems of the dir
+	versions map[string]map[string]string
+}
+
+// NewInstalledSpecParser returns a new InstalledSpecParser
+func NewInstalledSpecParser() *InstalledSpecParser {
+	return &InstalledSpecParser{versions: make(map[string]map[string]string)}
+}
+
+func (p *InstalledSpecParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/specifications/[^/]+\.gemspec$`),
+	}}
+}
+
+func (p *InstalledSpecParser) ProvidesGraph() bool {
+	return true
+}
+
+func (p *InstalledSpecParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("parse path %s", path)
+	spec, err := readInstalledSpec(path)
+	if err != nil || spec.name == "" {
+		return nil, err
+	}
+	pkg := newPackage(spec.name, spec.version, path)
+	if len(spec.licenses) > 0 {
+		pkg.LicenseDeclared = license.NormalizeExpressions(spec.licenses)
+	}
+
+	specDir := filepath.Dir(path)
+	versions := p.installedVersions(specDir)
+	for _, dep := range spec.dependencies {
+		if version, ok := versions[dep]; ok {
+			pkg.Dependencies = append(pkg.Dependencies, packageURL(dep, version))
+		}
+	}
+	pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+
+	gemHome := filepath.Dir(specDir)
+	gemDir := filepath.Join(gemHome, "gems", strings.TrimSuffix(filepath.Base(path), ".gemspec"))
+	pkg.Files = append([]string{path}, collector.ListFiles(gemDir, nil)...)
+	return []model.Package{*pkg}, nil
+}
+
+// installedVersions returns the installed versions of the specifications dir, the dir is read at the first call
+func (p *InstalledSpecParser) installedVersions(specDir string) map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	versions, ok := p.versions[specDir]
+	if !ok {
+		versions = installedVersions(specDir)
+		p.versions[specDir] = versions
+	}
+	return versions
+}
+
+// installedVersions returns the versions of the installed gems by name, the latest one is used
+// like rubygems does when several versions of a gem are installed
+func installedVersions(specDir string) map[string]string {
+	versions := make(map[string]string)
+	entries, err := os.ReadDir(specDir)
+	if err != nil {
+		return versions
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".gemspec") {
+			continue
+		}
+		spec, err := readInstalledSpec(filepath.Join(specDir, entry.Name()))
+		if err != nil || spec.name == "" {
+			continue
+		}
+		if v, ok := versions[spec.name]; !ok || util.CompareVersion(spec.version, v) > 0 {
+			versions[spec.name] = spec.version
+		}
+	}
+	return versions
+}
+
+func readInstalledSpec(path string) (*installedSpec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	spec := &installedSpec{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case specNameReg.MatchString(line):
+			spec.name = firstSpecStr(specNameReg.FindStringSubmatch(line)[1])
+		case specVersionReg.MatchString(line):
+			spec.version = firstSpecStr(specVersionReg.FindStringSubmatch(line)[1])
+		case specLicensesReg.MatchString(line):
+			spec.licenses = append(spec.licenses, specStrs(specLicensesReg.FindStringSubmatch(line)[1])...)
+		case specRuntimeDepReg.MatchString(line):
+			if name := firstSpecStr(specRuntimeDepReg.FindStringSubmatch(line)[1]); name != "" {
+				spec.dependencies = append(spec.dependencies, name)
+			}
+		}
+	}
+	return spec, scanner.Err()
+}
+
+func specStrs(value string) []string {
+	strs := make([]string, 0)
+	for _, m := range specStrReg.FindAllStringSubmatch(value, -1) {
+		strs = append(strs, m[1]+m[2])
+	}
+	return strs
+}
+
+func firstSpecStr(value string) string {
+	if strs := specStrs(value); len(strs) > 0 {
+		return strs[0]
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package gem
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestInstalledSpecParser_Parse(t *testing.T) {
+	gemHome := "test_material/bundle/ruby/3.2.0"
+	tests := []struct {
+		path string
+		want model.Package
+	}{
+		{
+			path: "specifications/rack-3.0.8.gemspec",
+			want: model.Package{Name: "rack", Version: "3.0.8", LicenseDeclared: []string{"MIT"},
+				Files: []string{"specifications/rack-3.0.8.gemspec", "gems/rack-3.0.8/lib/rack.rb"}},
+		},
+		{
+			path: "specifications/rack-test-2.1.0.gemspec",
+			want: model.Package{Name: "rack-test", Version: "2.1.0", LicenseDeclared: []string{"MIT"},
+				Dependencies: []string{"pkg:gem/rack@3.0.8"},
+				Files:        []string{"specifications/rack-test-2.1.0.gemspec", "gems/rack-test-2.1.0/lib/rack-test.rb"}},
+		},
+		{
+			path: "specifications/nokogiri-1.15.4-x86_64-linux.gemspec",
+			want: model.Package{Name: "nokogiri", Version: "1.15.4", LicenseDeclared: []string{"MIT"},
+				Dependencies: []string{"pkg:gem/racc@1.7.1"},
+				Files: []string{
+					"specifications/nokogiri-1.15.4-x86_64-linux.gemspec",
+					"gems/nokogiri-1.15.4-x86_64-linux/lib/nokogiri.rb",
+				}},
+		},
+		{
+			path: "specifications/racc-1.7.1.gemspec",
+			want: model.Package{Name: "racc", Version: "1.7.1", LicenseDeclared: []string{"Ruby"},
+				Files: []string{"specifications/racc-1.7.1.gemspec", "gems/racc-1.7.1/lib/racc.rb"}},
+		},
+	}
+	parser := NewInstalledSpecParser()
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parser.Parse(filepath.Join(gemHome, tt.path))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("Parse() got %d packages, want 1", len(got))
+			}
+			pkg := got[0]
+			for i, file := range pkg.Files {
+				rel, _ := filepath.Rel(gemHome, file)
+				pkg.Files[i] = filepath.ToSlash(rel)
+			}
+			if !model.PackageEqual(&pkg, &tt.want) || !slices.Equal(pkg.LicenseDeclared, tt.want.LicenseDeclared) ||
+				!slices.Equal(pkg.Dependencies, tt.want.Dependencies) || !slices.Equal(pkg.Files, tt.want.Files) {
+				t.Errorf("Parse() got = %v, want %v", pkg, tt.want)
+			}
+		})
+	}
+	// the versions of the specifications dir are read once for all the gems
+	if len(parser.versions) != 1 {
+		t.Errorf("versions of %d dirs, want 1", len(parser.versions))
+	}
+}
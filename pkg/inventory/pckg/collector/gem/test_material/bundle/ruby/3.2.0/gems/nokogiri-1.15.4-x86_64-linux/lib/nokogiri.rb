@@ -0,0 +1 @@
+module Nokogiri; end
@@ -0,0 +1 @@
+module Racc; end
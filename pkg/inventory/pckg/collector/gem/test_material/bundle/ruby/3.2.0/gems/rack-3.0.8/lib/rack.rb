@@ -0,0 +1 @@
+module Rack; end
@@ -0,0 +1 @@
+module Rack::Test; end
@@ -20,6 +20,9 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the modules vendored by go mod vendor
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewGoModFileParser())
 	parsers = append(parsers, NewGoBinaryParser())
@@ -28,6 +31,8 @@ func init() {
 	parsers = append(parsers, NewGodepsJSONParser())
 	parsers = append(parsers, NewGopkgTOMLParser())
 	parsers = append(parsers, NewGvtManifestParser())
+
+	installedParsers = append(installedParsers, NewVendorModulesParser())
 }
 
 func NewCollector() *Collector {
@@ -37,3 +42,12 @@ func NewCollector() *Collector {
 	c.Parsers = parsers
 	return &c
 }
+
+// NewInstalledCollector returns the collector of the modules vendored by go mod vendor
+func NewInstalledCollector() *Collector {
+	c := Collector{}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = installedParsers
+	return &c
+}
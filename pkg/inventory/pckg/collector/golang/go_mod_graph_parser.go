@@ -37,6 +37,10 @@ func (p *GoModGraphParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"go-mod-graph", "go-mod-graph.txt"}}
 }
 
+func (p *GoModGraphParser) ProvidesGraph() bool {
+	return true
+}
+
 func (p *GoModGraphParser) Parse(path string) ([]model.Package, error) {
 	log.Infof("golang GoModGraphParser file path: %s", path)
 	file, err := os.Open(path)
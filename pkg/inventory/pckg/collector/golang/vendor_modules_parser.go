@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package golang
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/module"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// vendorModule is a module in vendor/modules.txt, the dir is the vendor dir of the module path
+type vendorModule struct {
+	pkg *model.Package
+	dir string
+}
+
+// VendorModulesParser is a parser for vendor/modules.txt written by go mod vendor,
+// the module owns the files vendored in vendor/<module path> except the nested modules
+// see: https://go.dev/ref/mod#vendoring
+type VendorModulesParser struct{}
+
+func NewVendorModulesParser() *VendorModulesParser {
+	return &VendorModulesParser{}
+}
+
+func (g VendorModulesParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/vendor/modules\.txt$`),
+	}}
+}
+
+func (g VendorModulesParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("golang VendorModulesParser file path: %s", path)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vendorDir := filepath.Dir(path)
+	modules := make([]vendorModule, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "# ") {
+			// "## explicit" annotations and the vendored packages
+			continue
+		}
+		// # path version [=> new path [new version]]
+		old, replacement, _ := strings.Cut(strings.TrimPrefix(line, "# "), "=>")
+		fields := strings.Fields(old)
+		if len(fields) != 2 {
+			// the replacement for all versions has no packages vendored
+			continue
+		}
+		importPath, version := fields[0], fields[1]
+		if fields := strings.Fields(replacement); len(fields) == 2 && module.Check(fields[0], fields[1]) == nil {
+			importPath, version = fields[0], fields[1]
+		}
+		modules = append(modules, vendorModule{
+			pkg: newPackage(importPath, version, path),
+			dir: filepath.Join(vendorDir, filepath.FromSlash(fields[0])),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	moduleDirs := make(map[string]bool)
+	for _, m := range modules {
+		moduleDirs[m.dir] = true
+	}
+	pkgs := make([]model.Package, 0, len(modules))
+	for _, m := range modules {
+		m.pkg.Files = collector.ListFiles(m.dir, func(dir string) bool {
+			return moduleDirs[dir]
+		})
+		pkgs = append(pkgs, *m.pkg)
+	}
+	return pkgs, nil
+}
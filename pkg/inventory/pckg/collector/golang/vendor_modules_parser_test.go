@@ -0,0 +1,48 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package golang
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestVendorModulesParser_Parse(t *testing.T) {
+	path := "test_material/vendor-modules/vendor/modules.txt"
+	withFiles := func(pkg *model.Package, files ...string) model.Package {
+		for _, file := range files {
+			pkg.Files = append(pkg.Files, filepath.Join("test_material/vendor-modules/vendor", file))
+		}
+		return *pkg
+	}
+	want := []model.Package{
+		withFiles(newPackage("cloud.google.com/go", "v0.110.0", path),
+			"cloud.google.com/go/LICENSE", "cloud.google.com/go/internal/annotate.go"),
+		withFiles(newPackage("cloud.google.com/go/storage", "v1.30.1", path),
+			"cloud.google.com/go/storage/storage.go"),
+		withFiles(newPackage("github.com/pkg/errors", "v0.9.1", path),
+			"github.com/pkg/errors/errors.go"),
+		withFiles(newPackage("golang.org/x/sys", "v0.16.0", path),
+			"golang.org/x/sys/unix/syscall.go"),
+		withFiles(newPackage("example.com/local", "v1.0.0", path),
+			"example.com/local/local.go"),
+	}
+	got, err := VendorModulesParser{}.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() got = %v, \nwant %v", got, want)
+	}
+}
@@ -81,8 +81,7 @@ func (p *RockSpecParser) Parse(path string) ([]model.Package, error) {
 	if name != "" && version != "" {
 		mainPkg := newPackage(name, version, path)
 		if licenseName != "" {
-			value, _, _ := license.ParseLicenseName(licenseName)
-			mainPkg.LicenseDeclared = []string{value}
+			mainPkg.LicenseDeclared = license.NormalizeExpressions([]string{licenseName})
 		}
 
 		for i := 0; i < len(pkgs); i++ {
@@ -48,7 +48,38 @@ type JarManifest struct {
 
 type Manifest map[string]string
 
-func DiscoverMainPackage(path string) (*model.Package, error) {
+// MainPackage is the package of an archive identified by its file name, manifest and module descriptor
+type MainPackage struct {
+	model.Package
+	// the identity declared by the manifest and the module descriptor, see Unidentified
+	groupID       string
+	symbolicName  string
+	moduleName    string
+	fileVersioned bool
+}
+
+// Unidentified applies the identity declared by the archive when no pom identifies the package,
+// the OSGi symbolic name or the module name replaces a file name without version, e.g. bundle.jar,
+// and the Implementation-Vendor-Id is the group of the package otherwise
+func (p *MainPackage) Unidentified() {
+	name := ""
+	if !p.fileVersioned {
+		name = p.symbolicName
+		if name == "" {
+			name = p.moduleName
+		}
+	}
+	if name != "" {
+		p.Name = name
+	} else if p.groupID != "" && p.Name != "" {
+		p.Name = p.groupID + "/" + p.Name
+	}
+}
+
+// moduleInfoFiles are the module descriptors of plain and multi-release jars
+var moduleInfoFiles = []string{"/module-info.class", "/META-INF/versions/*/module-info.class"}
+
+func DiscoverMainPackage(path string) (*MainPackage, error) {
 	text, err := ziputil.GetTextFromZip(path, manifestFile)
 	if err != nil {
 		log.Errorf(err.Error())
@@ -76,19 +107,58 @@ func DiscoverMainPackage(path string) (*model.Package, error) {
 	if version == "" {
 		version = resolveVersion(manifest.MainSection)
 	}
+	module := discoverModuleInfo(path)
+	if module != nil {
+		if name == "" {
+			name = module.Name
+		}
+		if version == "" {
+			version = module.Version
+		}
+	}
 	// TODO call newPackage
-	pkg := &model.Package{
-		Name:             name,
-		Version:          version,
-		Type:             model.PkgTypeMaven,
-		PURL:             packageurl.NewPackageURL(model.PkgTypeMaven, "", name, version, nil, "").String(),
-		LicenseDeclared:  licenseDeclaredList,
-		LicenseConcluded: licenseConcludedList,
-		SourceLocation:   path,
+	pkg := &MainPackage{
+		Package: model.Package{
+			Name:             name,
+			Version:          version,
+			Type:             model.PkgTypeMaven,
+			PURL:             packageurl.NewPackageURL(model.PkgTypeMaven, "", name, version, nil, "").String(),
+			LicenseDeclared:  licenseDeclaredList,
+			LicenseConcluded: licenseConcludedList,
+			SourceLocation:   path,
+		},
+		groupID:       strings.TrimSpace(manifest.MainSection["Implementation-Vendor-Id"]),
+		symbolicName:  resolveSymbolicName(manifest.MainSection),
+		fileVersioned: filenameObj.version != "",
+	}
+	if module != nil {
+		pkg.moduleName = module.Name
 	}
 	return pkg, nil
 }
 
+// discoverModuleInfo returns the module declared in the archive, nil if the archive is not a named module
+func discoverModuleInfo(path string) *ModuleInfo {
+	zipManifest, err := ziputil.ResolveFileManifest(path)
+	if err != nil {
+		return nil
+	}
+	paths := zipManifest.GlobMatch(moduleInfoFiles...)
+	if len(paths) == 0 {
+		return nil
+	}
+	contents, err := ziputil.GetLinesFromZip(path, paths[0])
+	if err != nil {
+		return nil
+	}
+	module, err := parseModuleInfo([]byte(contents[paths[0]]))
+	if err != nil {
+		log.Warnf("parse module-info error: %s %s", path, err.Error())
+		return nil
+	}
+	return module
+}
+
 func parseJarManifest(content string) (*JarManifest, error) {
 	var manifest JarManifest
 	var sections []map[string]string
@@ -181,6 +251,9 @@ func parseJarManifest(content string) (*JarManifest, error) {
 }
 
 func resolveName(manifest Manifest) string {
+	if v := resolveSymbolicName(manifest); v != "" {
+		return v
+	}
 	fields := []string{"Name", "Bundle-Name", "Short-Name", "Extension-Name", "Implementation-Title"}
 	for _, key := range fields {
 		if v := manifest[key]; v != "" {
@@ -190,8 +263,15 @@ func resolveName(manifest Manifest) string {
 	return ""
 }
 
+// resolveSymbolicName returns the symbolic name of an OSGi bundle without directives, e.g. org.example.bundle;singleton:=true
+func resolveSymbolicName(manifest Manifest) string {
+	name, _, _ := strings.Cut(manifest["Bundle-SymbolicName"], ";")
+	return strings.TrimSpace(name)
+}
+
 func resolveVersion(manifest Manifest) string {
-	fields := []string{"Implementation-Version", "Specification-Version", "Plugin-Version", "Bundle-Version"}
+	// the specification version is usually less precise than the bundle version
+	fields := []string{"Implementation-Version", "Bundle-Version", "Specification-Version", "Plugin-Version"}
 	for _, key := range fields {
 		if v := manifest[key]; v != "" {
 			return v
@@ -208,10 +288,10 @@ func resolveLicense(manifest Manifest) []string {
 		for _, l := range arr {
 			result, _, _ := license.ParseLicenseURL(l)
 			if result != "" {
-				licenses = license.SplitLicense(result, licenses)
+				licenses = license.SplitLicense(license.NormalizeExpression(result), licenses)
 			}
 		}
 	}
 
-	return license.UniqueStrings(licenses)
+	return license.NormalizeExpressions(licenses)
 }
@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package archive
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ModuleInfo is the module declared by module-info.class
+// see: https://docs.oracle.com/javase/specs/jvms/se17/html/jvms-4.html#jvms-4.7.25
+type ModuleInfo struct {
+	Name    string
+	Version string
+}
+
+const (
+	classMagic     = 0xCAFEBABE
+	constantUtf8   = 1
+	constantLong   = 5
+	constantDouble = 6
+	constantModule = 19
+)
+
+// constantSizes are the sizes of the constant pool entries after the tag, utf8 entries are sized by their length
+var constantSizes = map[byte]int{
+	3: 4, 4: 4, 5: 8, 6: 8, 7: 2, 8: 2, 9: 4, 10: 4, 11: 4, 12: 4, 15: 3, 16: 2, 17: 4, 18: 4, 19: 2, 20: 2,
+}
+
+var errClassFormat = errors.New("invalid class file")
+
+type classReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *classReader) read(n int) []byte {
+	if r.err != nil || r.pos+n > len(r.data) {
+		r.err = errClassFormat
+		return make([]byte, n)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *classReader) u2() int {
+	return int(binary.BigEndian.Uint16(r.read(2)))
+}
+
+func (r *classReader) u4() uint32 {
+	return binary.BigEndian.Uint32(r.read(4))
+}
+
+// skipMembers skips the fields or the methods with their attributes
+func (r *classReader) skipMembers() {
+	for i, count := 0, r.u2(); i < count && r.err == nil; i++ {
+		r.read(6)
+		r.skipAttributes(r.u2())
+	}
+}
+
+func (r *classReader) skipAttributes(count int) {
+	for i := 0; i < count && r.err == nil; i++ {
+		r.read(2)
+		r.read(int(r.u4()))
+	}
+}
+
+// parseModuleInfo reads the name and the version of the Module attribute of a module-info.class
+func parseModuleInfo(data []byte) (*ModuleInfo, error) {
+	r := &classReader{data: data}
+	if r.u4() != classMagic {
+		return nil, errClassFormat
+	}
+	r.read(4)
+
+	// the constant pool is indexed from 1, long and double entries take two slots
+	count := r.u2()
+	utf8s := make(map[int]string)
+	modules := make(map[int]int)
+	for i := 1; i < count && r.err == nil; i++ {
+		tag := r.read(1)[0]
+		switch tag {
+		case constantUtf8:
+			utf8s[i] = string(r.read(r.u2()))
+		case constantModule:
+			modules[i] = r.u2()
+		default:
+			size, ok := constantSizes[tag]
+			if !ok {
+				return nil, fmt.Errorf("%w: constant tag %d", errClassFormat, tag)
+			}
+			r.read(size)
+			if tag == constantLong || tag == constantDouble {
+				i++
+			}
+		}
+	}
+
+	// access flags, this class and super class
+	r.read(6)
+	r.read(2 * r.u2())
+	r.skipMembers()
+	r.skipMembers()
+	for i, attrs := 0, r.u2(); i < attrs && r.err == nil; i++ {
+		name := utf8s[r.u2()]
+		length := int(r.u4())
+		if name != "Module" {
+			r.read(length)
+			continue
+		}
+		attr := &classReader{data: r.read(length)}
+		module := &ModuleInfo{Name: utf8s[modules[attr.u2()]]}
+		attr.read(2)
+		module.Version = utf8s[attr.u2()]
+		if attr.err != nil || module.Name == "" {
+			return nil, errClassFormat
+		}
+		return module, nil
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return nil, fmt.Errorf("%w: no module attribute", errClassFormat)
+}
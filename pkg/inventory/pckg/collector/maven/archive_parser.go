@@ -14,25 +14,31 @@ import (
 	"archive/zip"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"golang.org/x/exp/maps"
+
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/maven/archive"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/maven/mvn"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/ziputil"
 )
 
-var archiveFormats = []string{"**/*.jar", "**/*.war"}
+var archiveFormats = []string{"**/*.jar", "**/*.war", "**/*.ear"}
 var sbomArchiveTempFirstDirPrefixName = "sbom-archive-"
 var sbomArchiveTempSecondDirName = "items"
 
 // ArchiveParser is a parser for maven archive files
 type ArchiveParser struct {
 	Embedded bool
+	index    *mavenIndex
 }
 
 // NewArchiveParser returns a new ArchiveParser
@@ -41,10 +47,25 @@ func NewArchiveParser() *ArchiveParser {
 }
 
 func (m *ArchiveParser) Matcher() collector.FileMatcher {
-	return &collector.FilePatternMatcher{Patterns: []string{"*.jar"}}
+	return &collector.FilePatternMatcher{Patterns: []string{"*.jar", "*.war", "*.ear"}}
+}
+
+// mavenIndex returns the index of the parser, the default index if it is not given
+func (m *ArchiveParser) mavenIndex() *mavenIndex {
+	if m.index != nil {
+		return m.index
+	}
+	return defaultMavenIndex()
 }
 
 func (m *ArchiveParser) Parse(path string) ([]model.Package, error) {
+	pkgs, _, err := m.parse(path)
+	return pkgs, err
+}
+
+// parse returns the packages found in the archive and the package of the archive itself,
+// which contains the embedded artifacts, the shaded classes and the nested archives
+func (m *ArchiveParser) parse(path string) ([]model.Package, *model.Package, error) {
 	var pkgs []model.Package
 
 	log.Infof("parse path: " + path)
@@ -54,16 +75,17 @@ func (m *ArchiveParser) Parse(path string) ([]model.Package, error) {
 
 	mainPkg, err := archive.DiscoverMainPackage(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 解析pom.properties和pom.xml
 	log.Debugf("parse pom.properties and pom.xml")
-	pomPackages, err := discoverPackagesFromPomFiles(path, m.Embedded)
+	pomPackages, declaredPackages, err := discoverPackagesFromPomFiles(path, m.Embedded)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	identified := false
 	for _, pomPackage := range pomPackages {
 		artifactId := pomPackage.Name
 		segs := strings.Split(artifactId, "/")
@@ -84,62 +106,164 @@ func (m *ArchiveParser) Parse(path string) ([]model.Package, error) {
 			if len(pomPackage.LicenseDeclared) > 0 {
 				mainPkg.LicenseDeclared = pomPackage.LicenseDeclared
 			}
+			identified = true
 			continue
 		} else {
+			// the pom of another artifact is embedded by shading
 			pkgs = append(pkgs, pomPackage)
+			mainPkg.Contains = append(mainPkg.Contains, pomPackage.PURL)
 		}
 	}
-
-	if mainPkg.PURL == "" {
-		mainPkg.PURL = packageURL(mainPkg.Name, mainPkg.Version, "")
+	pkgs = append(pkgs, declaredPackages...)
+
+	// the pom metadata of shaded and repackaged archives is often stripped
+	if !identified {
+		if coords, ok := m.mavenIndex().lookup(path); ok {
+			mainPkg.Name = coords.groupID + "/" + coords.artifactID
+			mainPkg.Version = coords.version
+			mainPkg.PURL = packageURL(coords.artifactID, coords.version, coords.groupID)
+		} else {
+			mainPkg.Unidentified()
+			group, name, _ := strings.Cut(mainPkg.Name, "/")
+			if name == "" {
+				group, name = "", group
+			}
+			mainPkg.PURL = packageURL(name, mainPkg.Version, group)
+		}
 	}
 
-	// mainPkg放入Pkg列表
-	pkgs = append(pkgs, *mainPkg)
+	// 解析重定位或未重定位的依赖类
+	log.Debugf("parse shaded classes")
+	ownPackage := ""
+	if identified {
+		group, _, _ := strings.Cut(mainPkg.Name, "/")
+		ownPackage = strings.ReplaceAll(group, ".", "/") + "/"
+	}
+	shadedPkgs, shadedPURLs := m.discoverShadedPackages(path, &mainPkg.Package, pkgs, ownPackage)
+	mainPkg.Contains = append(mainPkg.Contains, shadedPURLs...)
+	pkgs = append(pkgs, shadedPkgs...)
 
 	// 解析内嵌的归档文件
 	log.Debugf("parse nested archive files")
-	nestedPkgs, _ := discoverPackagesFromArchiveFiles(path)
-	if len(nestedPkgs) > 0 {
-		nestedPkgs = modifyNestedPkgSourcePath(mainPkg, nestedPkgs)
-		pkgs = append(pkgs, nestedPkgs...)
+	nestedPkgs, nestedMainPkgs := m.discoverPackagesFromArchiveFiles(path)
+	for _, nestedMainPkg := range nestedMainPkgs {
+		mainPkg.Contains = append(mainPkg.Contains, nestedMainPkg.PURL)
 	}
+	pkgs = append(pkgs, nestedPkgs...)
+
+	// mainPkg放入Pkg列表
+	mainPkg.Contains = util.SliceUnique(mainPkg.Contains)
+	pkgs = append(pkgs, mainPkg.Package)
 	pkgs = collector.SortPackage(pkgs)
-	return pkgs, nil
+	return pkgs, &mainPkg.Package, nil
 }
 
-func modifyNestedPkgSourcePath(mainPkg *model.Package, nestedPkgs []model.Package) []model.Package {
-	for i, pkg := range nestedPkgs {
-		if strings.Contains(pkg.SourceLocation, sbomArchiveTempFirstDirPrefixName) && strings.Contains(pkg.SourceLocation, sbomArchiveTempSecondDirName) {
-			location := strings.Split(pkg.SourceLocation, "/"+sbomArchiveTempSecondDirName)[1]
-			nestedPkgs[i].SourceLocation = mainPkg.SourceLocation + "!" + pkgNameRemoveEndTimestamp(location)
+// discoverShadedPackages finds the artifacts of the classes copied into the archive by their package prefixes,
+// it returns the packages of the artifacts not found yet and the PURLs of all the artifacts found.
+// If the archive has the pom metadata of its own, given by the package of its group, the classes not relocated
+// under the namespace of the group are not taken, since an artifact may split a package with another of the
+// same vendor, e.g. httpclient and httpcore
+func (m *ArchiveParser) discoverShadedPackages(archivePath string, mainPkg *model.Package, found []model.Package, ownPackage string) ([]model.Package, []string) {
+	zipManifest, err := ziputil.ResolveFileManifest(archivePath)
+	if err != nil {
+		return nil, nil
+	}
+	artifactId := func(pkg *model.Package) string {
+		segs := strings.Split(pkg.Name, "/")
+		return segs[len(segs)-1]
+	}
+	// the classes of the archive itself are not shaded
+	purls := map[string]string{artifactId(mainPkg): ""}
+	for i := range found {
+		if _, ok := purls[artifactId(&found[i])]; !ok {
+			purls[artifactId(&found[i])] = found[i].PURL
 		}
 	}
-	return nestedPkgs
-}
+	dirs := make([]string, 0)
+	for name := range zipManifest {
+		if strings.HasSuffix(name, ".class") && !strings.HasPrefix(name, "META-INF/") {
+			dirs = append(dirs, path.Dir(name)+"/")
+		}
+	}
+	sort.Strings(dirs)
 
-func discoverPackagesFromArchiveFiles(archivePath string) ([]model.Package, error) {
 	var pkgs []model.Package
+	var contained []string
+	for _, dir := range util.SliceUnique(dirs) {
+		coords, location, relocation, ok := m.mavenIndex().classArtifact(dir)
+		if !ok || ownPackage != "" && (relocation == "" && vendor(location) == vendor(ownPackage) || relocation == ownPackage) {
+			continue
+		}
+		if purl, ok := purls[coords.artifactID]; ok {
+			// e.g. a declared dependency shaded without its pom
+			if purl != "" {
+				contained = append(contained, purl)
+			}
+			continue
+		}
+		p := newPackage(coords.groupID, coords.artifactID, coords.version, archivePath+"!"+location)
+		if p == nil {
+			continue
+		}
+		purls[coords.artifactID] = p.PURL
+		pkgs = append(pkgs, *p)
+		contained = append(contained, p.PURL)
+	}
+	return pkgs, contained
+}
+
+// vendor returns the first two segments of a package dir, e.g. org/apache/ of org/apache/http/
+func vendor(dir string) string {
+	segs := strings.SplitN(dir, "/", 3)
+	if len(segs) < 3 {
+		return dir
+	}
+	return segs[0] + "/" + segs[1] + "/"
+}
+
+// discoverPackagesFromArchiveFiles returns the packages of the nested archives, e.g. BOOT-INF/lib/*.jar and WEB-INF/lib/*.jar,
+// and the packages of the nested archives themselves
+func (m *ArchiveParser) discoverPackagesFromArchiveFiles(archivePath string) ([]model.Package, []model.Package) {
+	var pkgs, mainPkgs []model.Package
 	tempDir, err := os.MkdirTemp("", sbomArchiveTempFirstDirPrefixName)
 	if err != nil {
-		return pkgs, nil
+		return nil, nil
 	}
 	itemsDir := filepath.Join(tempDir, sbomArchiveTempSecondDirName)
 	_ = os.Mkdir(itemsDir, 0o755)
 	defer func(path string) {
 		_ = os.RemoveAll(path)
-	}(itemsDir)
+	}(tempDir)
 
 	items, err := PickArchiveFilesToUniqueTempFile(archivePath, itemsDir)
-	if err == nil && len(items) > 0 {
-		for _, item := range items {
-			ap := NewArchiveParser()
-			ap.Embedded = true
-			subPkgs, _ := ap.Parse(item)
-			pkgs = append(pkgs, subPkgs...)
+	if err != nil || len(items) == 0 {
+		return nil, nil
+	}
+	names := maps.Keys(items)
+	sort.Strings(names)
+	for _, name := range names {
+		ap := &ArchiveParser{Embedded: true, index: m.mavenIndex()}
+		subPkgs, subMainPkg, err := ap.parse(items[name])
+		if err != nil {
+			continue
+		}
+		// the locations in the temp file are relative to the outer archive
+		for i := range subPkgs {
+			subPkgs[i].SourceLocation = nestedLocation(subPkgs[i].SourceLocation, items[name], archivePath+"!"+name)
 		}
+		subMainPkg.SourceLocation = nestedLocation(subMainPkg.SourceLocation, items[name], archivePath+"!"+name)
+		pkgs = append(pkgs, subPkgs...)
+		mainPkgs = append(mainPkgs, *subMainPkg)
+	}
+	return pkgs, mainPkgs
+}
+
+// nestedLocation replaces the temp file of a nested archive in the location with the path in the outer archive
+func nestedLocation(location, tempFile, nestedPath string) string {
+	if location == tempFile || strings.HasPrefix(location, tempFile+"!") {
+		return nestedPath + strings.TrimPrefix(location, tempFile)
 	}
-	return pkgs, nil
+	return location
 }
 
 func PickArchiveFilesToUniqueTempFile(archivePath, dir string) (map[string]string, error) {
@@ -192,18 +316,20 @@ func PickArchiveFilesToUniqueTempFile(archivePath, dir string) (map[string]strin
 	return pickedFiles, ziputil.TraverseFilesInZip(archivePath, visitor, paths...)
 }
 
-func discoverPackagesFromPomFiles(archivePath string, embedded bool) ([]model.Package, error) {
-	var pkgs []model.Package
+// discoverPackagesFromPomFiles returns the artifacts whose pom files are embedded in the archive,
+// and the dependencies declared by the poms unless the archive is nested
+func discoverPackagesFromPomFiles(archivePath string, embedded bool) ([]model.Package, []model.Package, error) {
+	var pkgs, declared []model.Package
 	// pom.properties
 	properties, err := mvn.PomPropertiesByParentPath(archivePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// pom.xml
 	projects, err := mvn.PomProjectByParentPath(archivePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	hasProps := map[string]struct{}{}
 	for parentPath, propertiesObj := range properties {
@@ -218,12 +344,7 @@ func discoverPackagesFromPomFiles(archivePath string, embedded bool) ([]model.Pa
 			pkgs = append(pkgs, *pkgFromPom)
 		}
 		if !embedded && pomProject != nil {
-			for _, dep := range pomProject.Dependencies {
-				p := newPackage(dep.GroupID, dep.ArtifactID, dep.Version, archivePath)
-				if p != nil {
-					pkgs = append(pkgs, *p)
-				}
-			}
+			declared = append(declared, declaredDependencies(pomProject, archivePath)...)
 		}
 	}
 	for parentPath, projectObj := range projects {
@@ -235,25 +356,29 @@ func discoverPackagesFromPomFiles(archivePath string, embedded bool) ([]model.Pa
 			pkgs = append(pkgs, *pkg)
 		}
 		if !embedded {
-			for _, dep := range projectObj.Dependencies {
-				p := newPackage(dep.GroupID, dep.ArtifactID, dep.Version, archivePath)
-				if p != nil {
-					pkgs = append(pkgs, *p)
-				}
-			}
+			declared = append(declared, declaredDependencies(&projectObj, archivePath)...)
 		}
 	}
-	return pkgs, nil
+	return pkgs, declared, nil
+}
+
+func declaredDependencies(project *mvn.PomProject, archivePath string) []model.Package {
+	var pkgs []model.Package
+	for _, dep := range project.Dependencies {
+		p := newPackage(dep.GroupID, dep.ArtifactID, dep.Version, archivePath)
+		if p != nil {
+			p.Scope = mavenScope(dep.Scope, dep.Optional)
+			pkgs = append(pkgs, *p)
+		}
+	}
+	return pkgs
 }
 
 func newPackageFromMavenData(pomProperties mvn.PomProperties, pomProject *mvn.PomProject, archivePath string) *model.Package {
 	licenses := make([]string, 0)
 	if pomProject != nil && len(pomProject.Licenses) > 0 {
 		for _, lic := range pomProject.Licenses {
-			value, _, _ := license.ParseLicenseName(lic)
-			if value != "" {
-				licenses = license.SplitLicense(value, licenses)
-			}
+			licenses = license.SplitLicense(license.NormalizeExpression(lic), licenses)
 		}
 	}
 	groupId := trim(pomProperties.GroupID)
@@ -262,7 +387,7 @@ func newPackageFromMavenData(pomProperties mvn.PomProperties, pomProject *mvn.Po
 
 	p := newPackage(groupId, artifactId, version, archivePath)
 	if p != nil {
-		p.LicenseDeclared = license.UniqueStrings(licenses)
+		p.LicenseDeclared = license.NormalizeExpressions(licenses)
 	}
 	return p
 }
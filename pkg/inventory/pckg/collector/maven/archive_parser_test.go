@@ -81,6 +81,7 @@ func TestParseJarEmbeddedJarFile(t *testing.T) {
 				newPackageWithLicense("com.google.code.gson", "gson", "2.10.1", []string{"Apache-2.0"}, ""),
 				newPackageWithLicense("com.google.code.gson", "gson", "2.10.1", []string{"Apache-2.0"}, ""),
 				newPackageWithLicense("org.sbom", "example-java-jar-embedded-jar-test", "0.1.0", []string{"Apache-2.0"}, ""),
+				*newPackage("org.springframework.boot", "spring-boot-loader", "", ""),
 				newPackageWithLicense("", "spring-boot-jarmode-layertools", "2.7.1", []string{"Apache-2.0"}, ""),
 			},
 		},
@@ -100,6 +101,141 @@ func TestParseJarEmbeddedJarFile(t *testing.T) {
 	}
 }
 
+func TestParseShadedArchive(t *testing.T) {
+	index, err := loadMavenIndex("test_material/jar/shaded/maven-index.txt")
+	if err != nil {
+		t.Fatalf("load index error: %v", err)
+	}
+	type want struct {
+		pkgs     []model.Package
+		contains []string
+	}
+	tests := []struct {
+		title    string
+		filePath string
+		want     want
+	}{
+		{
+			title:    "relocated",
+			filePath: "test_material/jar/shaded/shaded-app-1.0.jar",
+			want: want{
+				pkgs: []model.Package{
+					*newPackage("com.example", "legacy", "", ""),
+					*newPackage("com.example", "shaded-app", "1.0", ""),
+					*newPackage("com.google.guava", "guava", "", ""),
+					*newPackage("com.squareup.okhttp3", "okhttp", "", ""),
+				},
+				contains: []string{"pkg:maven/com.example/legacy", "pkg:maven/com.google.guava/guava", "pkg:maven/com.squareup.okhttp3/okhttp"},
+			},
+		},
+		{
+			title:    "osgi",
+			filePath: "test_material/jar/shaded/bundle.jar",
+			want: want{
+				pkgs: []model.Package{*newPackage("", "org.example.bundle", "2.3.4", "")},
+			},
+		},
+		{
+			title:    "module",
+			filePath: "test_material/jar/shaded/module.jar",
+			want: want{
+				pkgs: []model.Package{*newPackage("", "org.example.module", "1.2.0", "")},
+			},
+		},
+		{
+			title:    "war",
+			filePath: "test_material/jar/shaded/web-app-1.0.war",
+			want: want{
+				pkgs: []model.Package{
+					*newPackage("com.example", "lib-a", "0.1.0", ""),
+					*newPackage("com.example", "web-app", "1.0", ""),
+					*newPackage("", "org.example.bundle", "2.3.4", ""),
+				},
+				contains: []string{"pkg:maven/com.example/lib-a@0.1.0", "pkg:maven/org.example.bundle@2.3.4"},
+			},
+		},
+		{
+			title:    "split package netty",
+			filePath: "test_material/jar/shaded/netty-codec-http-4.1.100.Final.jar",
+			want: want{
+				pkgs: []model.Package{*newPackage("io.netty", "netty-codec-http", "4.1.100.Final", "")},
+			},
+		},
+		{
+			title:    "split package httpcomponents",
+			filePath: "test_material/jar/shaded/httpclient-4.5.14.jar",
+			want: want{
+				pkgs: []model.Package{*newPackage("org.apache.httpcomponents", "httpclient", "4.5.14", "")},
+			},
+		},
+		{
+			title:    "kotlin module",
+			filePath: "test_material/jar/shaded/jackson-module-kotlin-2.15.3.jar",
+			want: want{
+				pkgs: []model.Package{*newPackage("com.fasterxml.jackson.module", "jackson-module-kotlin", "2.15.3", "")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			parser := &ArchiveParser{index: index}
+			pkgs, mainPkg, err := parser.parse(tt.filePath)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if !slices.EqualFunc(pkgs, tt.want.pkgs, func(p1 model.Package, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2)
+			}) {
+				t.Errorf("parse() got = %v, want %v", pkgs, tt.want.pkgs)
+			}
+			contains := slices.Clone(mainPkg.Contains)
+			slices.Sort(contains)
+			if !slices.Equal(contains, tt.want.contains) {
+				t.Errorf("parse() contains = %v, want %v", contains, tt.want.contains)
+			}
+		})
+	}
+}
+
+func TestNestedLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{location: "/tmp/items/lib-a.jar-1", want: "app.war!WEB-INF/lib/lib-a.jar"},
+		{location: "/tmp/items/lib-a.jar-1!BOOT-INF/lib/b.jar", want: "app.war!WEB-INF/lib/lib-a.jar!BOOT-INF/lib/b.jar"},
+		{location: "/tmp/items/lib-a.jar-10", want: "/tmp/items/lib-a.jar-10"},
+	}
+	for _, tt := range tests {
+		if got := nestedLocation(tt.location, "/tmp/items/lib-a.jar-1", "app.war!WEB-INF/lib/lib-a.jar"); got != tt.want {
+			t.Errorf("nestedLocation(%s) = %s, want %s", tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestMavenIndex_ClassArtifact(t *testing.T) {
+	index := newMavenIndex()
+	tests := []struct {
+		dir      string
+		want     string
+		location string
+	}{
+		{dir: "com/google/common/base/", want: "guava", location: "com/google/common/"},
+		{dir: "org/example/shaded/com/google/common/base/", want: "guava", location: "org/example/shaded/com/google/common/"},
+		{dir: "org/apache/http/client/methods/", want: "httpclient", location: "org/apache/http/client/"},
+		{dir: "BOOT-INF/classes/okhttp3/", want: "okhttp", location: "okhttp3/"},
+		{dir: "org/example/notokhttp3/", want: ""},
+		{dir: "com/fasterxml/jackson/module/kotlin/", want: ""},
+		{dir: "org/example/shaded/okio/", want: ""},
+	}
+	for _, tt := range tests {
+		coords, location, _, ok := index.classArtifact(tt.dir)
+		if ok != (tt.want != "") || coords.artifactID != tt.want || location != tt.location {
+			t.Errorf("classArtifact(%s) = %v %s, want %s %s", tt.dir, coords, location, tt.want, tt.location)
+		}
+	}
+}
+
 func BenchmarkArchiveParser(b *testing.B) {
 	var jarTestdata = []testMavenitem{
 		{
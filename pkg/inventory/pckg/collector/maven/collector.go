@@ -56,8 +56,20 @@ func (c *Collector) Collect() (pkgs []model.Package, err error) {
 			}
 		}
 	} else {
+		// the poms share a resolver, so that the modules of a reactor know each other
+		poms := newPomResolver(localRepository())
 		for _, request := range c.Requests {
-			items, _ := request.Parser.Parse(request.File.FullName())
+			if _, ok := request.Parser.(*POMXMLParser); ok {
+				poms.index(request.File.FullName())
+			}
+		}
+		for _, request := range c.Requests {
+			var items []model.Package
+			if _, ok := request.Parser.(*POMXMLParser); ok {
+				items, _ = poms.packages(request.File.FullName())
+			} else {
+				items, _ = request.Parser.Parse(request.File.FullName())
+			}
 			pkgs = append(pkgs, items...)
 		}
 	}
@@ -33,11 +33,21 @@ func TestMavenCollector_Collect(t *testing.T) {
 				collector.NewFileMeta("test_material/jar/example-java-jar-embedded-jar-test-0.1.0.jar"),
 			},
 			wantResult: []model.Package{
-				newPackageWithLicense("com.google.code.gson", "gson", "2.10.1", []string{"Apache-2.0"}, ""),
-				newPackageWithLicense("junit", "junit", "", nil, ""),
+				func() model.Package {
+					p := newPackageWithLicense("com.google.code.gson", "gson", "2.10.1", []string{"Apache-2.0"}, "")
+					p.Scope = model.ScopeRuntime
+					return p
+				}(),
+				func() model.Package {
+					p := newPackageWithLicense("junit", "junit", "", nil, "")
+					p.Scope = model.ScopeTest
+					return p
+				}(),
 				newPackageWithLicense("org.sbom", "example-java-jar-embedded-jar-test", "0.1.0", []string{"Apache-2.0"}, ""),
 				newPackageWithLicense("org.sbom", "example-java-jar-embedded-pom-test", "0.1.0", []string{"Apache-2.0"}, ""),
 				newPackageWithLicense("org.sbom", "example-java-jar-nodep-test", "0.1.0", []string{"Apache-2.0"}, ""),
+				// the launcher classes of the spring boot fat jar
+				*newPackage("org.springframework.boot", "spring-boot-loader", "", ""),
 				func() model.Package {
 					p := newPackageWithLicense("", "spring-boot-jarmode-layertools", "2.7.1", []string{"Apache-2.0"}, "")
 					p.LicenseConcluded = p.LicenseDeclared
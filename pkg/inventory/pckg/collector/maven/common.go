@@ -17,6 +17,7 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 func newPackage(groupId, artifactId, version string, path string) *model.Package {
@@ -39,7 +40,7 @@ func newPackage(groupId, artifactId, version string, path string) *model.Package
 
 func newPackageWithLicense(groupId, artifactId, version string, licenses []string, sourcePath string) model.Package {
 	pkg := newPackage(groupId, artifactId, version, sourcePath)
-	pkg.LicenseDeclared = licenses
+	pkg.LicenseDeclared = license.NormalizeExpressions(licenses)
 	return *pkg
 }
 
@@ -81,11 +82,43 @@ func trim(val string) string {
 	return strings.TrimSpace(val)
 }
 
-func pkgNameRemoveEndTimestamp(val string) string {
-	lastIndex := strings.LastIndex(val, "-")
-	if lastIndex > 0 {
-		return val[:lastIndex]
+// mavenScope maps a maven dependency scope to the package scope, the default scope is compile
+// see: https://maven.apache.org/guides/introduction/introduction-to-dependency-mechanism.html#dependency-scope
+func mavenScope(scope string, optional bool) model.Scope {
+	switch strings.ToLower(trim(scope)) {
+	case "test":
+		return model.ScopeTest
+	case "provided", "system":
+		return model.ScopeProvided
+	case "import":
+		return ""
 	}
+	if optional {
+		return model.ScopeOptional
+	}
+	return model.ScopeRuntime
+}
 
-	return val
+// gradleScope maps a gradle configuration to the package scope
+// see: https://docs.gradle.org/current/userguide/java_library_plugin.html#sec:java_library_configurations_graph
+func gradleScope(configuration string) model.Scope {
+	configuration = strings.ToLower(trim(configuration))
+	switch {
+	case configuration == "":
+		return ""
+	case strings.Contains(configuration, "test"):
+		// test, androidTest and testFixtures configurations
+		return model.ScopeTest
+	case strings.HasPrefix(configuration, "developmentonly"):
+		return model.ScopeDev
+	case strings.HasSuffix(configuration, "compileonly"), strings.HasSuffix(configuration, "compileonlyapi"),
+		strings.HasSuffix(configuration, "annotationprocessor"), strings.HasPrefix(configuration, "kapt"),
+		configuration == "compileclasspath":
+		return model.ScopeProvided
+	case strings.HasSuffix(configuration, "implementation"), strings.HasSuffix(configuration, "api"),
+		strings.HasSuffix(configuration, "runtimeonly"), strings.HasSuffix(configuration, "runtimeclasspath"),
+		configuration == "compile", configuration == "runtime":
+		return model.ScopeRuntime
+	}
+	return ""
 }
@@ -47,6 +47,10 @@ func (m *DependencyTreeParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"maven-dependency-tree.txt"}}
 }
 
+func (m *DependencyTreeParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *DependencyTreeParser) Parse(filePath string) ([]model.Package, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -130,14 +134,20 @@ func generatePackage(line string, lastBlankIdx int, sourcePath string) *model.Pa
 	groupId := strings.TrimSpace(tags[0])
 	artifactId := strings.TrimSpace(tags[1])
 	version := ""
+	scope := ""
 
 	isMavenScope := IsMavenScope(tags[len(tags)-1])
 	if !isMavenScope {
 		version = strings.TrimSpace(tags[len(tags)-1])
 	} else {
 		version = strings.TrimSpace(tags[len(tags)-2])
+		scope = mavenScope(tags[len(tags)-1], false)
+	}
+	pkg := newPackage(groupId, artifactId, version, sourcePath)
+	if pkg != nil {
+		pkg.Scope = scope
 	}
-	return newPackage(groupId, artifactId, version, sourcePath)
+	return pkg
 }
 
 // IsMavenScope 判断字符串是否为Maven依赖坐标中scope的有效取值
@@ -181,3 +181,18 @@ func TestParseMavenDependencyTreeFile(t *testing.T) {
 	}
 
 }
+
+func TestParseMavenDependencyTreeScopes(t *testing.T) {
+	pkgs, err := NewDependencyTreeParser().Parse("test_material/dependencyTree/tree.txt")
+	assert.NoError(t, err)
+
+	scopes := make(map[string]model.Scope)
+	for _, pkg := range pkgs {
+		scopes[pkg.Name] = pkg.Scope
+	}
+	assert.Equal(t, "", scopes["org.example/test-web"])
+	assert.Equal(t, model.ScopeRuntime, scopes["org.example/test-service"])
+	assert.Equal(t, model.ScopeRuntime, scopes["org.example/test-dao"])
+	assert.Equal(t, model.ScopeTest, scopes["org.springframework.boot/spring-boot-starter-test"])
+	assert.Equal(t, model.ScopeTest, scopes["jakarta.activation/jakarta.activation-api"])
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package maven
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vifraa/gopom"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// maxInterpolationDepth bounds the nesting of the properties referring to other properties
+const maxInterpolationDepth = 10
+
+var propertyRe = regexp.MustCompile(`[$][{][^}]+[}]`)
+
+// effectivePom is a pom.xml merged with its parents, the declarations are kept raw and
+// interpolated with the properties of the pom inheriting them, as maven does
+// see: https://maven.apache.org/guides/introduction/introduction-to-the-pom.html#project-inheritance
+type effectivePom struct {
+	path    string
+	project *gopom.Project
+	parent  *effectivePom
+	// inTree is set if the pom is read from the scanned tree rather than the local repository
+	inTree     bool
+	groupID    string
+	artifactID string
+	version    string
+	properties map[string]string
+	managed    []gopom.Dependency
+	declared   []gopom.Dependency
+	// managedDeps caches the interpolated dependencyManagement with the imported boms, by groupId:artifactId
+	managedDeps map[string]gopom.Dependency
+}
+
+// pomResolver resolves the effective poms of a project tree, the parents are looked up through their
+// relativePath and then in the local repository, the boms in the tree and then in the local repository
+type pomResolver struct {
+	repo    string
+	byPath  map[string]*effectivePom
+	byCoord map[string]*effectivePom
+	modules map[string]bool
+	loading map[string]bool
+}
+
+func newPomResolver(repo string) *pomResolver {
+	return &pomResolver{
+		repo:    repo,
+		byPath:  make(map[string]*effectivePom),
+		byCoord: make(map[string]*effectivePom),
+		modules: make(map[string]bool),
+		loading: make(map[string]bool),
+	}
+}
+
+// localRepository returns the local maven repository at $SBOM_MAVEN_REPO or ~/.m2/repository,
+// it is used as an offline lookup of the parents and boms not in the tree
+func localRepository() string {
+	if repo := os.Getenv("SBOM_MAVEN_REPO"); repo != "" {
+		return repo
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	repo := filepath.Join(home, ".m2", "repository")
+	if info, err := os.Stat(repo); err != nil || !info.IsDir() {
+		return ""
+	}
+	return repo
+}
+
+// index loads a pom of the tree ahead, so that its coordinates and modules are known to the other poms
+func (r *pomResolver) index(path string) {
+	_, _ = r.load(path, true)
+}
+
+// packages returns the dependencies of the effective pom, the module itself is added with the edges
+// to its dependencies when the pom belongs to a multi-module reactor
+func (r *pomResolver) packages(path string) ([]model.Package, error) {
+	pom, err := r.load(path, true)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]model.Package, 0)
+	for _, dep := range r.dependencies(pom) {
+		pkg := newPackage(dep.GroupID, dep.ArtifactID, dep.Version, path)
+		if pkg != nil {
+			pkg.Scope = mavenScope(dep.Scope, trim(dep.Optional) == "true")
+			pkgs = append(pkgs, *pkg)
+		}
+	}
+	if !r.inReactor(pom) {
+		return pkgs, nil
+	}
+	module := newPackage(pom.groupID, pom.artifactID, pom.version, path)
+	if module == nil {
+		return pkgs, nil
+	}
+	for i := range pkgs {
+		module.Dependencies = append(module.Dependencies, pkgs[i].PURL)
+	}
+	return append([]model.Package{*module}, pkgs...), nil
+}
+
+// inReactor reports whether the pom is an aggregator, a listed module or the child of a pom in the tree
+func (r *pomResolver) inReactor(pom *effectivePom) bool {
+	if !pom.inTree {
+		return false
+	}
+	return len(pom.project.Modules) > 0 || r.modules[pom.path] || (pom.parent != nil && pom.parent.inTree)
+}
+
+func (r *pomResolver) load(path string, inTree bool) (*effectivePom, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if pom, ok := r.byPath[path]; ok {
+		return pom, nil
+	}
+	if r.loading[path] {
+		return nil, fmt.Errorf("cyclic parent of pom: %s", path)
+	}
+	r.loading[path] = true
+	defer delete(r.loading, path)
+
+	project, err := gopom.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	pom := &effectivePom{
+		path:       path,
+		project:    project,
+		inTree:     inTree,
+		properties: make(map[string]string),
+	}
+	parentTag := project.Parent
+	if trim(parentTag.ArtifactID) != "" {
+		pom.parent = r.resolveParent(path, project, inTree)
+	}
+	groupID, version := project.GroupID, project.Version
+	if pom.parent != nil {
+		for k, v := range pom.parent.properties {
+			pom.properties[k] = v
+		}
+		pom.managed = append(pom.managed, pom.parent.managed...)
+		pom.declared = append(pom.declared, pom.parent.declared...)
+	}
+	if trim(groupID) == "" {
+		groupID = parentTag.GroupID
+	}
+	if trim(version) == "" {
+		version = parentTag.Version
+	}
+	for k, v := range project.Properties.Entries {
+		pom.properties[k] = v
+	}
+	packaging := trim(project.Packaging)
+	if packaging == "" {
+		packaging = "jar"
+	}
+	builtins := map[string]string{
+		"groupId":             groupID,
+		"artifactId":          project.ArtifactID,
+		"version":             version,
+		"packaging":           packaging,
+		"basedir":             filepath.Dir(path),
+		"parent.groupId":      parentTag.GroupID,
+		"parent.artifactId":   parentTag.ArtifactID,
+		"parent.version":      parentTag.Version,
+		"parent.relativePath": parentTag.RelativePath,
+	}
+	for k, v := range builtins {
+		pom.properties["project."+k] = v
+		pom.properties["pom."+k] = v
+	}
+	pom.managed = append(pom.managed, project.DependencyManagement.Dependencies...)
+	pom.declared = append(pom.declared, project.Dependencies...)
+	pom.groupID = pom.interpolate(groupID)
+	pom.artifactID = pom.interpolate(project.ArtifactID)
+	pom.version = pom.interpolate(version)
+
+	r.byPath[path] = pom
+	if inTree {
+		r.byCoord[pom.coordinate()] = pom
+		for _, module := range project.Modules {
+			r.modules[pomFile(filepath.Join(filepath.Dir(path), trim(module)))] = true
+		}
+	}
+	return pom, nil
+}
+
+// resolveParent looks up the parent through its relativePath, ../pom.xml by default, and then in the local repository,
+// the pom found at the relativePath is taken only if it matches the coordinates of the parent
+func (r *pomResolver) resolveParent(path string, project *gopom.Project, inTree bool) *effectivePom {
+	// the parent coordinates may only refer to the properties of the pom itself, e.g. ${revision}
+	own := &effectivePom{properties: project.Properties.Entries}
+	groupID := own.interpolate(project.Parent.GroupID)
+	artifactID := own.interpolate(project.Parent.ArtifactID)
+	version := own.interpolate(project.Parent.Version)
+	if inTree {
+		relativePath := trim(project.Parent.RelativePath)
+		if relativePath == "" {
+			relativePath = "../pom.xml"
+		}
+		candidate := pomFile(filepath.Join(filepath.Dir(path), relativePath))
+		if _, err := os.Stat(candidate); err == nil {
+			parent, err := r.load(candidate, true)
+			if err == nil && parent.artifactID == artifactID && (groupID == "" || parent.groupID == groupID) &&
+				(version == "" || strings.Contains(version, "${") || parent.version == version) {
+				return parent
+			}
+		}
+	}
+	return r.lookup(groupID, artifactID, version)
+}
+
+// lookup finds a pom by its coordinates in the tree and then in the local repository
+func (r *pomResolver) lookup(groupID, artifactID, version string) *effectivePom {
+	if pom, ok := r.byCoord[groupID+":"+artifactID+":"+version]; ok {
+		return pom
+	}
+	if r.repo == "" || groupID == "" || artifactID == "" || version == "" || hasBadStrs(version) {
+		return nil
+	}
+	path := filepath.Join(r.repo, filepath.FromSlash(strings.ReplaceAll(groupID, ".", "/")),
+		artifactID, version, artifactID+"-"+version+".pom")
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	pom, err := r.load(path, false)
+	if err != nil {
+		return nil
+	}
+	return pom
+}
+
+// managedDependencies returns the dependencyManagement of the pom, the declared entries win over the imported boms
+// and the first imported bom wins over the later ones
+func (r *pomResolver) managedDependencies(pom *effectivePom) map[string]gopom.Dependency {
+	if pom.managedDeps != nil {
+		return pom.managedDeps
+	}
+	managed := make(map[string]gopom.Dependency)
+	// guards against the boms importing each other
+	pom.managedDeps = managed
+	imports := make([]gopom.Dependency, 0)
+	for _, dep := range pom.managed {
+		dep = pom.interpolateDependency(dep)
+		if strings.EqualFold(dep.Scope, "import") {
+			imports = append(imports, dep)
+			continue
+		}
+		managed[dep.GroupID+":"+dep.ArtifactID] = dep
+	}
+	for _, dep := range imports {
+		bom := r.lookup(dep.GroupID, dep.ArtifactID, dep.Version)
+		if bom == nil {
+			continue
+		}
+		for key, imported := range r.managedDependencies(bom) {
+			if _, ok := managed[key]; !ok {
+				managed[key] = imported
+			}
+		}
+	}
+	return managed
+}
+
+// dependencies returns the declared dependencies of the pom and its parents, completed with the managed versions and scopes
+func (r *pomResolver) dependencies(pom *effectivePom) []gopom.Dependency {
+	managed := r.managedDependencies(pom)
+	deps := make([]gopom.Dependency, 0, len(pom.declared))
+	seen := make(map[string]bool)
+	// the dependencies of the pom come after the inherited ones but override them
+	for i := len(pom.declared) - 1; i >= 0; i-- {
+		dep := pom.interpolateDependency(pom.declared[i])
+		key := dep.GroupID + ":" + dep.ArtifactID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if m, ok := managed[key]; ok {
+			if dep.Version == "" {
+				dep.Version = m.Version
+			}
+			if dep.Scope == "" {
+				dep.Scope = m.Scope
+			}
+			if dep.Optional == "" {
+				dep.Optional = m.Optional
+			}
+		}
+		deps = append(deps, dep)
+	}
+	for i, j := 0, len(deps)-1; i < j; i, j = i+1, j-1 {
+		deps[i], deps[j] = deps[j], deps[i]
+	}
+	return deps
+}
+
+func (p *effectivePom) coordinate() string {
+	return p.groupID + ":" + p.artifactID + ":" + p.version
+}
+
+func (p *effectivePom) interpolateDependency(dep gopom.Dependency) gopom.Dependency {
+	dep.GroupID = p.interpolate(dep.GroupID)
+	dep.ArtifactID = p.interpolate(dep.ArtifactID)
+	dep.Version = p.interpolate(dep.Version)
+	dep.Scope = p.interpolate(dep.Scope)
+	dep.Type = p.interpolate(dep.Type)
+	dep.Optional = p.interpolate(dep.Optional)
+	return dep
+}
+
+// interpolate replaces the ${...} expressions with the properties, the unknown ones are left as is
+func (p *effectivePom) interpolate(value string) string {
+	for i := 0; i < maxInterpolationDepth && strings.Contains(value, "${"); i++ {
+		next := propertyRe.ReplaceAllStringFunc(value, func(match string) string {
+			if v, ok := p.properties[strings.TrimSpace(match[2:len(match)-1])]; ok {
+				return v
+			}
+			return match
+		})
+		if next == value {
+			break
+		}
+		value = next
+	}
+	return trim(value)
+}
+
+// pomFile returns the pom.xml of a module dir, or the path itself if it is a file
+func pomFile(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "pom.xml")
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package maven
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// versionCatalog is a gradle version catalog, gradle/libs.versions.toml by default,
+// the aliases are kept in the form of their accessors, e.g. androidx-core-ktx as androidx.core.ktx
+// see: https://docs.gradle.org/current/userguide/platforms.html#sub:conventional-dependencies-toml
+type versionCatalog struct {
+	versions  map[string]string
+	libraries map[string]coordinates
+	bundles   map[string][]string
+	plugins   map[string]Plugin
+}
+
+// loadVersionCatalog loads a version catalog in the toml format
+func loadVersionCatalog(path string) (*versionCatalog, error) {
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load version catalog: %w", err)
+	}
+	values := tree.ToMap()
+	catalog := &versionCatalog{
+		versions:  make(map[string]string),
+		libraries: make(map[string]coordinates),
+		bundles:   make(map[string][]string),
+		plugins:   make(map[string]Plugin),
+	}
+	for alias, value := range tomlTable(values["versions"]) {
+		catalog.versions[catalogAlias(alias)] = catalog.version(value)
+	}
+	for alias, value := range tomlTable(values["libraries"]) {
+		var coords coordinates
+		switch v := value.(type) {
+		case string:
+			deps := coordinateDependency(v)
+			if len(deps) == 0 {
+				continue
+			}
+			coords = coordinates{groupID: deps[0].groupID, artifactID: deps[0].artifactID, version: deps[0].version}
+		case map[string]interface{}:
+			if module, ok := v["module"].(string); ok {
+				coords.groupID, coords.artifactID, _ = strings.Cut(module, ":")
+			} else {
+				coords.groupID, _ = v["group"].(string)
+				coords.artifactID, _ = v["name"].(string)
+			}
+			coords.version = catalog.version(v["version"])
+		}
+		if coords.artifactID != "" {
+			catalog.libraries[catalogAlias(alias)] = coords
+		}
+	}
+	for alias, value := range tomlTable(values["bundles"]) {
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				if s, ok := item.(string); ok {
+					catalog.bundles[catalogAlias(alias)] = append(catalog.bundles[catalogAlias(alias)], catalogAlias(s))
+				}
+			}
+		}
+	}
+	for alias, value := range tomlTable(values["plugins"]) {
+		var plugin Plugin
+		switch v := value.(type) {
+		case string:
+			plugin.GroupID, plugin.Version, _ = strings.Cut(v, ":")
+		case map[string]interface{}:
+			plugin.GroupID, _ = v["id"].(string)
+			plugin.Version = catalog.version(v["version"])
+		}
+		if plugin.GroupID != "" {
+			catalog.plugins[catalogAlias(alias)] = plugin
+		}
+	}
+	return catalog, nil
+}
+
+// version resolves a version, either a string, a reference to [versions] or a rich version
+func (c *versionCatalog) version(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return gradleVersion(v)
+	case map[string]interface{}:
+		if ref, ok := v["ref"].(string); ok {
+			return c.versions[catalogAlias(ref)]
+		}
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if s, ok := v[key].(string); ok {
+				return gradleVersion(s)
+			}
+		}
+	}
+	return ""
+}
+
+// dependencies resolves the accessor of a library or a bundle, e.g. androidx.core.ktx or bundles.network
+func (c *versionCatalog) dependencies(accessor string) []gradleDependency {
+	accessor = catalogAlias(accessor)
+	aliases := []string{accessor}
+	if strings.HasPrefix(accessor, "bundles.") {
+		aliases = c.bundles[strings.TrimPrefix(accessor, "bundles.")]
+	}
+	var deps []gradleDependency
+	for _, alias := range aliases {
+		if coords, ok := c.libraries[alias]; ok {
+			deps = append(deps, gradleDependency{groupID: coords.groupID, artifactID: coords.artifactID, version: coords.version})
+		}
+	}
+	return deps
+}
+
+// plugin resolves the accessor of a plugin, e.g. plugins.kotlin.jvm
+func (c *versionCatalog) plugin(accessor string) (Plugin, bool) {
+	plugin, ok := c.plugins[strings.TrimPrefix(catalogAlias(accessor), "plugins.")]
+	return plugin, ok
+}
+
+// catalogAlias maps an alias to its accessor, the dashes and underscores separate the accessor segments
+func catalogAlias(alias string) string {
+	return strings.ToLower(strings.NewReplacer("-", ".", "_", ".").Replace(strings.Trim(alias, `"' `)))
+}
+
+func tomlTable(value interface{}) map[string]interface{} {
+	if table, ok := value.(map[string]interface{}); ok {
+		return table
+	}
+	return nil
+}
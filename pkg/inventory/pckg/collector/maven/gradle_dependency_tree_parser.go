@@ -29,6 +29,9 @@ type segment struct {
 	lines    []string
 }
 
+// gradleClasspaths are the resolved configurations to collect, a package in several classpaths takes the widest scope
+var gradleClasspaths = []string{"runtimeClasspath", "compileClasspath", "testRuntimeClasspath"}
+
 // GradleDependencyTreeParser is a parser for output of executing 'gradlew dependencies' command.
 // see: https://docs.gradle.org/current/userguide/command_line_interface.html#listing_project_dependencies
 type GradleDependencyTreeParser struct{}
@@ -46,6 +49,10 @@ func (m *GradleDependencyTreeParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"gradle-dependency-tree.txt"}}
 }
 
+func (m *GradleDependencyTreeParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *GradleDependencyTreeParser) Parse(filePath string) ([]model.Package, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -69,7 +76,9 @@ func parseGradleDependencyTreeFile(lines []string, sourcePath string) ([]model.P
 	pkgMap := make(map[string]*model.Package)
 
 	itemLines, mainPackage := getItemLinesAndMainPkg(pkgMap, lines, sourcePath)
-	parseDependenciesLines(mainPackage, pkgMap, itemLines, 0, 0, sourcePath)
+	for _, classpath := range gradleClasspaths {
+		parseDependenciesLines(mainPackage, pkgMap, itemLines[classpath], 0, 0, gradleScope(classpath), sourcePath)
+	}
 
 	if mainPackage != nil {
 		for purl, pkgs := range pkgMap {
@@ -87,7 +96,7 @@ func parseGradleDependencyTreeFile(lines []string, sourcePath string) ([]model.P
 	return collector.SortPackage(pkgs), nil
 }
 
-func parseDependenciesLines(parentPkg *model.Package, pkgMap map[string]*model.Package, pkgLines []string, startLine int, parentLevel int, sourcePath string) int {
+func parseDependenciesLines(parentPkg *model.Package, pkgMap map[string]*model.Package, pkgLines []string, startLine int, parentLevel int, scope model.Scope, sourcePath string) int {
 	scanLines := 0
 	for i := startLine; i < len(pkgLines); i++ {
 		line := pkgLines[i]
@@ -96,14 +105,17 @@ func parseDependenciesLines(parentPkg *model.Package, pkgMap map[string]*model.P
 		if level == parentLevel+1 {
 			pkg := parsePackageFromLine(line, sourcePath)
 			if pkg != nil {
-				if _, found := pkgMap[pkg.PURL]; !found {
+				if found, ok := pkgMap[pkg.PURL]; !ok {
+					pkg.Scope = scope
 					pkgMap[pkg.PURL] = pkg
+				} else {
+					found.Scope = model.MergeScope(found.Scope, scope)
 				}
 				if parentPkg != nil && !slices.Contains(parentPkg.Dependencies, pkg.PURL) && !strings.Contains(pkg.Name, "preparatory_dependency") {
 					parentPkg.Dependencies = append(parentPkg.Dependencies, pkg.PURL)
 				}
 			}
-			scan := parseDependenciesLines(pkg, pkgMap, pkgLines, i+1, level, sourcePath)
+			scan := parseDependenciesLines(pkg, pkgMap, pkgLines, i+1, level, scope, sourcePath)
 			scanLines = scanLines + scan + 1
 			if line[lastBlankIdx-4:lastBlankIdx] == `\---` {
 				return scanLines
@@ -169,8 +181,8 @@ func findDashIndex(input string) int {
 	return -1
 }
 
-func getItemLinesAndMainPkg(pkgMap map[string]*model.Package, lines []string, sourcePath string) ([]string, *model.Package) {
-	itemLines := []string{}
+func getItemLinesAndMainPkg(pkgMap map[string]*model.Package, lines []string, sourcePath string) (map[string][]string, *model.Package) {
+	itemLines := make(map[string][]string)
 	taskPattern := regexp.MustCompile(`^\w+$|^\w+\s-`)
 	projectPattern := regexp.MustCompile("(?:Root project|[Pp]roject) ([':A-Za-z0-9._-]+)")
 
@@ -219,8 +231,8 @@ func getItemLinesAndMainPkg(pkgMap map[string]*model.Package, lines []string, so
 	}
 
 	for _, segment := range segments {
-		if segment.headline == "runtimeClasspath" {
-			itemLines = segment.lines
+		if slices.Contains(gradleClasspaths, segment.headline) {
+			itemLines[segment.headline] = segment.lines
 		}
 	}
 
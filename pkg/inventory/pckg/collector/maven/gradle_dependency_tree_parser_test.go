@@ -199,3 +199,20 @@ func TestParseGradleDependencyTreeFile(t *testing.T) {
 		t.Errorf("Parse() got = %v, \nwant %v", pkgs, expectedPkgs)
 	}
 }
+
+func TestParseGradleDependencyTreeScopes(t *testing.T) {
+	pkgs, err := NewGradleDependencyTreeParser().Parse("test_material/gradle/gradle-dependency-tree-scopes.txt")
+	assert.NoError(t, err)
+
+	scopes := make(map[string]model.Scope)
+	for _, pkg := range pkgs {
+		scopes[pkg.Name] = pkg.Scope
+	}
+	assert.Equal(t, map[string]model.Scope{
+		"demo":                                "",
+		"com.google.code.gson/gson":           model.ScopeRuntime,
+		"org.projectlombok/lombok":            model.ScopeProvided,
+		"org.junit.jupiter/junit-jupiter":     model.ScopeTest,
+		"org.junit.jupiter/junit-jupiter-api": model.ScopeTest,
+	}, scopes)
+}
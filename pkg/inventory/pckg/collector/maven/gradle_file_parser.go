@@ -11,24 +11,22 @@
 package maven
 
 import (
-	"bufio"
 	"io"
 	"os"
-	"strings"
+	"path/filepath"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 )
 
-var trimStringArr = []string{"\"", "'", "(", ")"}
-
 type Plugin struct {
 	GroupID string
 	Version string
 }
 
-// GradleFileParser is a parser for build.gradle
-// see: https://docs.gradle.org/current/userguide/working_with_files.html
+// GradleFileParser is a parser for build.gradle and build.gradle.kts, the version catalogs, settings.gradle(.kts)
+// and gradle.properties of the build are read as well
+// see: https://docs.gradle.org/current/userguide/declaring_dependencies.html
 type GradleFileParser struct{}
 
 // NewJavaGradleFileParser returns a new GradleFileParser
@@ -55,121 +53,16 @@ func (m *GradleFileParser) Parse(filePath string) ([]model.Package, error) {
 	return parseGradleFile(f, filePath)
 }
 
-// parseGradleFile parses build.gradle
+// parseGradleFile parses build.gradle within its build
 func parseGradleFile(reader io.Reader, sourcePath string) ([]model.Package, error) {
-	var pkgs []model.Package
-	var plugins []Plugin
-	dependencyScanner := bufio.NewScanner(reader)
-	dependenciesSign := false
-	dependencyEndFlagNum := 0
-	pluginsSign := false
-
-	for dependencyScanner.Scan() {
-		line := dependencyScanner.Text()
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		if strings.HasPrefix(line, "exclude") {
-			continue
-		}
-
-		if strings.HasPrefix(line, "dependencies {") {
-			dependenciesSign = true
-
-			continue
-		}
-
-		if strings.HasPrefix(line, "plugins {") {
-			pluginsSign = true
-
-			continue
-		}
-
-		if line == "}" {
-			if dependencyEndFlagNum > 0 {
-				dependencyEndFlagNum = dependencyEndFlagNum - 1
-				continue
-			}
-			if dependencyEndFlagNum == 0 {
-				dependenciesSign = false
-			}
-
-			pluginsSign = false
-			continue
-		}
-
-		if pluginsSign {
-			fields := strings.Fields(line)
-			if len(fields) >= 3 && strings.Contains(line, "version") {
-				start := strings.Index(fields[0], "(") + 1
-				end := strings.Index(fields[0], ")")
-				if start < 1 || end < 0 || start >= end {
-					continue
-				}
-				groupName := trimCustomString(fields[0][start:end], trimStringArr)
-				version := trimCustomString(fields[2], trimStringArr)
-				plugin := Plugin{GroupID: groupName, Version: version}
-				plugins = append(plugins, plugin)
-			}
-		}
-
-		if dependenciesSign {
-			if strings.Contains(line, "{") {
-				dependencyEndFlagNum = dependencyEndFlagNum + 1
-			}
-
-			if strings.Contains(line, "classpath") || strings.Contains(line, "project(") || strings.Contains(line, "fileTree(") {
-				continue
-			}
-
-			start := strings.IndexFunc(line, func(r rune) bool {
-				return r == '(' || r == ' '
-			}) + 1
-
-			end := len(line) - 1
-			dependencyStr := trimCustomString(line[start:end], trimStringArr)
-			dependencyParts := strings.Split(dependencyStr, ":")
-
-			if len(dependencyParts) == 2 {
-				artifactId := dependencyParts[1]
-				version, groupId := getVersionAndGroupIDFromPlugins(dependencyParts[0], plugins)
-				pkg := newPackage(groupId, artifactId, version, sourcePath)
-				if pkg != nil {
-					pkgs = append(pkgs, *pkg)
-				}
-			}
-			if len(dependencyParts) == 3 || len(dependencyParts) == 4 {
-				artifactId := dependencyParts[1]
-				groupId := dependencyParts[0]
-				version := dependencyParts[2]
-				if strings.Contains(version, "$") || strings.Contains(version, "+") {
-					version = ""
-				}
-				pkg := newPackage(groupId, artifactId, version, sourcePath)
-				if pkg != nil {
-					pkgs = append(pkgs, *pkg)
-				}
-			}
-		}
-	}
-
-	return pkgs, nil
-}
-
-func getVersionAndGroupIDFromPlugins(group string, plugins []Plugin) (string, string) {
-	for _, v := range plugins {
-		if v.GroupID == group {
-			return v.Version, v.GroupID
-		}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
 	}
-	return "", ""
-}
-
-func trimCustomString(line string, stringArr []string) string {
-	for _, str := range stringArr {
-		line = strings.ReplaceAll(line, str, "")
+	buildFile := sourcePath
+	if abs, err := filepath.Abs(sourcePath); err == nil {
+		buildFile = abs
 	}
-	return strings.Trim(line, " ")
+	build := newGradleBuild(buildFile)
+	return build.packages(sourcePath, build.script(buildFile, string(content))), nil
 }
@@ -68,10 +68,13 @@ func parseGradleLock(reader io.Reader, sourcePath string) ([]model.Package, []mo
 		if len(dependencyParts) == depParts {
 			groupId := dependencyParts[0]
 			artifactId := dependencyParts[1]
-			version := strings.Split(dependencyParts[2], "=")[0]
+			version, configurations, _ := strings.Cut(dependencyParts[2], "=")
 
 			pkg := newPackage(groupId, artifactId, version, sourcePath)
 			if pkg != nil {
+				for _, configuration := range strings.Split(configurations, ",") {
+					pkg.Scope = model.MergeScope(pkg.Scope, gradleScope(configuration))
+				}
 				pkgs = append(pkgs, *pkg)
 			}
 		}
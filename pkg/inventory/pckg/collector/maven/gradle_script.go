@@ -0,0 +1,530 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package maven
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+var (
+	gradleInterpolationRe = regexp.MustCompile(`\$\{([^}]+)\}|\$([A-Za-z_][\w.]*)`)
+	gradleKeyRe           = regexp.MustCompile(`^[\w.]*(?:\[|\()\s*["']([^"']+)["']\s*(?:\]|\))$`)
+	gradleVarRe           = regexp.MustCompile(`^(?:(?:val|var|def|final|String)\s+)+([A-Za-z_]\w*)(?:\s*:\s*\w+)?\s*=\s*(.+)$`)
+	gradleExtVarRe        = regexp.MustCompile(`^(?:(?:rootProject|project)\.)?(?:ext|extra)\.([A-Za-z_]\w*)\s*=\s*(.+)$`)
+	gradleExtraVarRe      = regexp.MustCompile(`^(?:(?:rootProject|project)\.)?extra\[\s*"([^"]+)"\s*\]\s*=\s*(.+)$`)
+	gradleByExtraRe       = regexp.MustCompile(`^val\s+([A-Za-z_]\w*)(?:\s*:\s*\w+)?\s+by\s+extra\((.+)\)$`)
+	gradleSetVarRe        = regexp.MustCompile(`^set\(\s*["']([^"']+)["']\s*,\s*(.+)\)$`)
+	gradleAssignRe        = regexp.MustCompile(`^([A-Za-z_]\w*)\s*=\s*(.+)$`)
+	gradleProjectAttrRe   = regexp.MustCompile(`^(?:project\.)?(group|version)\s*=\s*(.+)$`)
+	gradleConfigRe        = regexp.MustCompile(`^["']?([A-Za-z_]\w*)["']?\s*(.*)$`)
+	gradleNamedArgRe      = regexp.MustCompile(`^(group|name|version|classifier|ext|path)\s*[:=]\s*(.+)$`)
+	gradlePluginRe        = regexp.MustCompile(`^(id|kotlin)\s*\(?\s*["']([^"']+)["']\s*\)?(?:\s+version\s+(.+?))?(?:\s+apply\s+\w+)?$`)
+	gradleAliasRe         = regexp.MustCompile(`^alias\s*\(\s*([\w.]+)\s*\)`)
+)
+
+// gradleStatement is a statement of a gradle script with the headers of the blocks enclosing it
+type gradleStatement struct {
+	blocks []string
+	text   string
+}
+
+// gradleDependency is a dependency declared in a build script, either a module or another project of the build
+type gradleDependency struct {
+	configuration string
+	groupID       string
+	artifactID    string
+	version       string
+	platform      bool
+	project       string
+}
+
+// gradleScript holds what a groovy or kotlin build script declares
+type gradleScript struct {
+	vars     map[string]string
+	catalogs map[string]*versionCatalog
+	plugins  []Plugin
+	group    string
+	version  string
+	// projectsGroup and projectsVersion are set in the allprojects and subprojects blocks
+	projectsGroup   string
+	projectsVersion string
+	deps            []gradleDependency
+	// constraints are the versions declared in dependencies.constraints, by groupId:artifactId
+	constraints map[string]string
+}
+
+// parseGradleScript parses a build script, the variables inherited from gradle.properties and the root script
+// are overridden by the ones of the script itself
+func parseGradleScript(content string, vars map[string]string, catalogs map[string]*versionCatalog) *gradleScript {
+	s := &gradleScript{
+		vars:        make(map[string]string),
+		catalogs:    catalogs,
+		constraints: make(map[string]string),
+	}
+	for k, v := range vars {
+		s.vars[k] = v
+	}
+	stmts := splitGradleScript(content)
+	// variables first, a groovy script may refer to them before the ext block declaring them
+	for _, stmt := range stmts {
+		s.parseVariable(stmt)
+	}
+	for _, stmt := range stmts {
+		inner := innerBlock(stmt.blocks, 0)
+		switch {
+		case inner == "plugins":
+			s.parsePlugin(stmt.text)
+		case inner == "dependencies" && !slices.ContainsFunc(stmt.blocks, func(b string) bool {
+			return blockName(b) == "buildscript"
+		}):
+			s.deps = append(s.deps, s.parseDependency(stmt.text)...)
+		case inner == "constraints" && innerBlock(stmt.blocks, 1) == "dependencies":
+			for _, dep := range s.parseDependency(stmt.text) {
+				if dep.version != "" {
+					s.constraints[dep.groupID+":"+dep.artifactID] = dep.version
+				}
+			}
+		default:
+			s.parseProjectAttribute(stmt)
+		}
+	}
+	return s
+}
+
+func (s *gradleScript) parseVariable(stmt gradleStatement) {
+	inExt := innerBlock(stmt.blocks, 0) == "ext"
+	for _, re := range []*regexp.Regexp{gradleVarRe, gradleExtVarRe, gradleExtraVarRe, gradleByExtraRe, gradleSetVarRe, gradleAssignRe} {
+		if re == gradleAssignRe && !inExt {
+			continue
+		}
+		if match := re.FindStringSubmatch(stmt.text); match != nil {
+			if value, ok := s.literal(match[2]); ok {
+				s.vars[match[1]] = value
+			}
+			return
+		}
+	}
+}
+
+func (s *gradleScript) parseProjectAttribute(stmt gradleStatement) {
+	match := gradleProjectAttrRe.FindStringSubmatch(stmt.text)
+	if match == nil {
+		return
+	}
+	value, ok := s.literal(match[2])
+	if !ok {
+		return
+	}
+	switch {
+	case len(stmt.blocks) == 0 && match[1] == "group":
+		s.group = value
+	case len(stmt.blocks) == 0:
+		s.version = value
+	case len(stmt.blocks) == 1 && slices.Contains([]string{"allprojects", "subprojects"}, blockName(stmt.blocks[0])):
+		if match[1] == "group" {
+			s.projectsGroup = value
+		} else {
+			s.projectsVersion = value
+		}
+	}
+}
+
+// parsePlugin parses id("x") version "v", kotlin("jvm") version "v" and alias(libs.plugins.x)
+func (s *gradleScript) parsePlugin(text string) {
+	if match := gradlePluginRe.FindStringSubmatch(text); match != nil {
+		id := match[2]
+		if match[1] == "kotlin" {
+			id = "org.jetbrains.kotlin." + id
+		}
+		version, _ := s.literal(match[3])
+		s.plugins = append(s.plugins, Plugin{GroupID: id, Version: version})
+		return
+	}
+	if match := gradleAliasRe.FindStringSubmatch(text); match != nil {
+		name, accessor, _ := strings.Cut(match[1], ".")
+		if catalog, ok := s.catalogs[name]; ok {
+			if plugin, ok := catalog.plugin(accessor); ok {
+				s.plugins = append(s.plugins, plugin)
+			}
+		}
+	}
+}
+
+// parseDependency parses a dependency declaration, e.g.
+//
+//	implementation 'g:a:v', 'g:b:v'
+//	implementation group: 'g', name: 'a', version: 'v'
+//	implementation(platform(libs.spring.boot.bom))
+//	testImplementation(kotlin("test"))
+//	add("implementation", project(":core"))
+func (s *gradleScript) parseDependency(text string) []gradleDependency {
+	match := gradleConfigRe.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+	configuration, args := match[1], strings.TrimSpace(match[2])
+	if strings.HasPrefix(args, "(") {
+		args = args[1:closingParen(args)]
+	}
+	items := splitArguments(args)
+	if configuration == "add" && len(items) > 1 {
+		configuration, _ = s.literal(items[0])
+		items = items[1:]
+	}
+	if configuration == "" || configuration == "classpath" || len(items) == 0 {
+		return nil
+	}
+	var deps []gradleDependency
+	if gradleNamedArgRe.MatchString(items[0]) {
+		named := make(map[string]string)
+		for _, item := range items {
+			if m := gradleNamedArgRe.FindStringSubmatch(item); m != nil {
+				named[m[1]], _ = s.literal(m[2])
+			}
+		}
+		if named["path"] != "" {
+			deps = append(deps, gradleDependency{project: named["path"]})
+		} else {
+			deps = append(deps, gradleDependency{groupID: named["group"], artifactID: named["name"], version: gradleVersion(named["version"])})
+		}
+	} else {
+		for _, item := range items {
+			deps = append(deps, s.notation(item)...)
+		}
+	}
+	for i := range deps {
+		deps[i].configuration = configuration
+	}
+	return deps
+}
+
+// notation resolves a dependency notation to the modules or the project it refers to
+func (s *gradleScript) notation(expr string) []gradleDependency {
+	expr = strings.TrimSpace(expr)
+	if value, ok := unquote(expr); ok {
+		if expr[0] == '"' {
+			value = s.interpolate(value)
+		}
+		return coordinateDependency(value)
+	}
+	if inner, ok := call(expr, "platform", "enforcedPlatform"); ok {
+		deps := s.notation(inner)
+		for i := range deps {
+			deps[i].platform = true
+		}
+		return deps
+	}
+	if inner, ok := call(expr, "project"); ok {
+		args := splitArguments(inner)
+		if len(args) == 0 {
+			return nil
+		}
+		if m := gradleNamedArgRe.FindStringSubmatch(args[0]); m != nil {
+			args[0] = m[2]
+		}
+		if path, ok := s.literal(args[0]); ok {
+			return []gradleDependency{{project: path}}
+		}
+		return nil
+	}
+	if strings.HasPrefix(expr, "projects.") {
+		return []gradleDependency{{project: expr}}
+	}
+	if inner, ok := call(expr, "kotlin"); ok {
+		args := splitArguments(inner)
+		if len(args) == 0 {
+			return nil
+		}
+		module, _ := s.literal(args[0])
+		version := ""
+		if len(args) > 1 {
+			version, _ = s.literal(args[1])
+		} else {
+			version = s.pluginVersion("org.jetbrains.kotlin.")
+		}
+		return []gradleDependency{{groupID: "org.jetbrains.kotlin", artifactID: "kotlin-" + module, version: gradleVersion(version)}}
+	}
+	expr = strings.TrimSuffix(expr, ".get()")
+	if name, accessor, ok := strings.Cut(expr, "."); ok {
+		if catalog, ok := s.catalogs[name]; ok {
+			return catalog.dependencies(accessor)
+		}
+	}
+	if value, ok := s.vars[expr]; ok {
+		return coordinateDependency(value)
+	}
+	return nil
+}
+
+// pluginVersion returns the version of the plugin, an id ending with a dot matches the plugins under it
+func (s *gradleScript) pluginVersion(id string) string {
+	for _, plugin := range s.plugins {
+		if plugin.Version == "" {
+			continue
+		}
+		if plugin.GroupID == id || strings.HasSuffix(id, ".") && strings.HasPrefix(plugin.GroupID, id) {
+			return plugin.Version
+		}
+	}
+	return ""
+}
+
+// literal evaluates a string literal, a number or a variable
+func (s *gradleScript) literal(expr string) (string, bool) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", false
+	}
+	if value, ok := unquote(expr); ok {
+		// single quoted groovy strings are not interpolated
+		if expr[0] == '"' {
+			value = s.interpolate(value)
+		}
+		return value, true
+	}
+	if strings.Trim(expr, "0123456789.") == "" {
+		return expr, true
+	}
+	return s.lookup(expr)
+}
+
+// interpolate replaces the $name and ${expr} templates, the unknown ones are left as is
+func (s *gradleScript) interpolate(value string) string {
+	return gradleInterpolationRe.ReplaceAllStringFunc(value, func(match string) string {
+		sub := gradleInterpolationRe.FindStringSubmatch(match)
+		expr := sub[1]
+		if expr == "" {
+			expr = strings.TrimSuffix(sub[2], ".")
+		}
+		if v, ok := s.lookup(expr); ok {
+			return v
+		}
+		return match
+	})
+}
+
+// lookup resolves the variable expressions, e.g. kotlinVersion, rootProject.ext.kotlinVersion,
+// extra["kotlinVersion"], property("kotlinVersion") and libs.versions.kotlin.get()
+func (s *gradleScript) lookup(expr string) (string, bool) {
+	expr = strings.TrimSuffix(strings.TrimSpace(expr), ".get()")
+	if m := gradleKeyRe.FindStringSubmatch(expr); m != nil {
+		expr = m[1]
+	}
+	if name, accessor, ok := strings.Cut(expr, ".versions."); ok {
+		if catalog, ok := s.catalogs[name]; ok {
+			v, ok := catalog.versions[catalogAlias(accessor)]
+			return v, ok
+		}
+	}
+	for _, prefix := range []string{"rootProject.", "project.", "ext.", "extra."} {
+		expr = strings.TrimPrefix(expr, prefix)
+	}
+	v, ok := s.vars[expr]
+	return v, ok
+}
+
+// coordinateDependency parses group:artifact[:version[:classifier]][@type]
+func coordinateDependency(value string) []gradleDependency {
+	if i := strings.LastIndex(value, "@"); i > 0 {
+		value = value[:i]
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil
+	}
+	dep := gradleDependency{groupID: parts[0], artifactID: parts[1]}
+	if len(parts) > 2 {
+		dep.version = gradleVersion(parts[2])
+	}
+	return []gradleDependency{dep}
+}
+
+// gradleVersion drops the dynamic and the uninterpolated versions, the strict marker is removed
+func gradleVersion(version string) string {
+	version = strings.TrimSuffix(trim(version), "!!")
+	if strings.Contains(version, "$") || strings.Contains(version, "+") {
+		return ""
+	}
+	return version
+}
+
+// splitGradleScript splits a groovy or kotlin script into statements, the comments are dropped, and the
+// statements spanning several lines within parentheses or after a trailing comma are joined
+func splitGradleScript(content string) []gradleStatement {
+	var stmts []gradleStatement
+	var blocks []string
+	var buf strings.Builder
+	depth := 0
+	flush := func() {
+		text := strings.TrimSpace(buf.String())
+		buf.Reset()
+		if text != "" {
+			stmts = append(stmts, gradleStatement{blocks: slices.Clone(blocks), text: text})
+		}
+	}
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case strings.HasPrefix(content[i:], "//"):
+			for i+1 < len(content) && content[i+1] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				i = len(content)
+			} else {
+				i += end + 3
+			}
+		case c == '"' || c == '\'':
+			end := closingQuote(content, i)
+			buf.WriteString(content[i : end+1])
+			i = end
+		case c == '(' || c == '[':
+			depth++
+			buf.WriteByte(c)
+		case c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+			buf.WriteByte(c)
+		case c == '{' && depth == 0:
+			header := strings.TrimSpace(buf.String())
+			flush()
+			blocks = append(blocks, header)
+		case c == '}' && depth == 0:
+			flush()
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+		case (c == '\n' || c == ';') && depth == 0:
+			if c == '\n' && strings.HasSuffix(strings.TrimSpace(buf.String()), ",") {
+				buf.WriteByte(' ')
+				continue
+			}
+			flush()
+		case c == '\n' || c == '\r' || c == '\t':
+			buf.WriteByte(' ')
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
+
+// closingQuote returns the index of the quote closing the string starting at i, or the last index
+func closingQuote(content string, i int) int {
+	quote := content[i : i+1]
+	if strings.HasPrefix(content[i:], strings.Repeat(quote, 3)) {
+		if end := strings.Index(content[i+3:], strings.Repeat(quote, 3)); end >= 0 {
+			return i + 3 + end + 2
+		}
+		return len(content) - 1
+	}
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			j++
+		case '\n':
+			return j - 1
+		case quote[0]:
+			return j
+		}
+	}
+	return len(content) - 1
+}
+
+// closingParen returns the index of the parenthesis closing the one at the start of text
+func closingParen(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			i = closingQuote(text, i)
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
+
+// splitArguments splits the arguments of a call by the top level commas
+func splitArguments(args string) []string {
+	var items []string
+	depth, start := 0, 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case '"', '\'':
+			i = closingQuote(args, i)
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, strings.TrimSpace(args[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(args[start:]); last != "" {
+		items = append(items, last)
+	}
+	return items
+}
+
+// call returns the arguments of expr if it calls one of the functions
+func call(expr string, names ...string) (string, bool) {
+	for _, name := range names {
+		if strings.HasPrefix(expr, name+"(") && closingParen(expr[len(name):]) == len(expr)-len(name)-1 {
+			return expr[len(name)+1 : len(expr)-1], true
+		}
+	}
+	return "", false
+}
+
+// unquote returns the content of a string literal
+func unquote(expr string) (string, bool) {
+	for _, quote := range []string{`"""`, `'''`, `"`, `'`} {
+		if len(expr) >= 2*len(quote) && strings.HasPrefix(expr, quote) && strings.HasSuffix(expr, quote) {
+			return expr[len(quote) : len(expr)-len(quote)], true
+		}
+	}
+	return "", false
+}
+
+// blockName returns the name of a block from its header, e.g. dependencies for "dependencies" and create for `create("libs")`
+func blockName(header string) string {
+	end := strings.IndexFunc(header, func(r rune) bool {
+		return !(r == '_' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end < 0 {
+		return header
+	}
+	return header[:end]
+}
+
+// innerBlock returns the name of the n-th innermost block
+func innerBlock(blocks []string, n int) string {
+	if len(blocks) <= n {
+		return ""
+	}
+	return blockName(blocks[len(blocks)-1-n])
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package maven
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var (
+	gradleSettingsFiles = []string{"settings.gradle", "settings.gradle.kts"}
+	gradleBuildFiles    = []string{"build.gradle", "build.gradle.kts"}
+
+	gradleRootNameRe   = regexp.MustCompile(`^rootProject\.name\s*=\s*(.+)$`)
+	gradleIncludeRe    = regexp.MustCompile(`^include\s*(.+)$`)
+	gradleProjectDirRe = regexp.MustCompile(`^project\(\s*["']([^"']+)["']\s*\)\.projectDir\s*=\s*(.+)$`)
+	gradleFromFilesRe  = regexp.MustCompile(`^from\s*\(?\s*files\(\s*["']([^"']+)["']\s*\)`)
+	gradleLastStringRe = regexp.MustCompile(`["']([^"']+)["'][^"']*$`)
+)
+
+// gradleBuild is the build a build script belongs to, a multi-project build is described by settings.gradle(.kts)
+// see: https://docs.gradle.org/current/userguide/multi_project_builds.html
+type gradleBuild struct {
+	root     string
+	rootName string
+	// multiProject is set if the build script is one of the projects of a settings script
+	multiProject bool
+	// projects are the dirs of the projects by their paths, e.g. :core or :libs:util
+	projects   map[string]string
+	catalogs   map[string]*versionCatalog
+	properties map[string]string
+	rootScript *gradleScript
+}
+
+// newGradleBuild discovers the build of a build script through the nearest settings script above it,
+// the version catalogs, gradle.properties and the root build script of the build
+func newGradleBuild(buildFile string) *gradleBuild {
+	if abs, err := filepath.Abs(buildFile); err == nil {
+		buildFile = abs
+	}
+	dir := filepath.Dir(buildFile)
+	b := &gradleBuild{
+		root:     dir,
+		rootName: filepath.Base(dir),
+		projects: map[string]string{":": dir},
+		catalogs: make(map[string]*versionCatalog),
+	}
+	if settings := findGradleSettings(dir); settings != "" {
+		candidate := &gradleBuild{
+			root:     filepath.Dir(settings),
+			rootName: filepath.Base(filepath.Dir(settings)),
+			projects: map[string]string{":": filepath.Dir(settings)},
+			catalogs: make(map[string]*versionCatalog),
+		}
+		candidate.properties = parseGradleProperties(filepath.Join(candidate.root, "gradle.properties"))
+		candidate.parseSettings(settings)
+		if _, ok := candidate.projectOf(dir); ok {
+			b = candidate
+			b.multiProject = true
+		}
+	}
+	if b.properties == nil {
+		b.properties = parseGradleProperties(filepath.Join(b.root, "gradle.properties"))
+	}
+	if _, ok := b.catalogs["libs"]; !ok {
+		b.loadCatalog("libs", filepath.Join(b.root, "gradle", "libs.versions.toml"))
+	}
+	if b.multiProject {
+		if path := gradleBuildFile(b.root); path != "" {
+			if content, err := os.ReadFile(path); err == nil {
+				b.rootScript = parseGradleScript(string(content), b.properties, b.catalogs)
+			}
+		}
+	}
+	return b
+}
+
+// findGradleSettings returns the nearest settings script in the dir or above it
+func findGradleSettings(dir string) string {
+	for {
+		for _, name := range gradleSettingsFiles {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// gradleBuildFile returns the build script of a project dir
+func gradleBuildFile(dir string) string {
+	for _, name := range gradleBuildFiles {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// parseSettings reads the root project name, the included projects, their dirs and the version catalogs
+func (b *gradleBuild) parseSettings(path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	s := &gradleScript{vars: b.properties}
+	for _, stmt := range splitGradleScript(string(content)) {
+		if match := gradleRootNameRe.FindStringSubmatch(stmt.text); match != nil {
+			if name, ok := s.literal(match[1]); ok {
+				b.rootName = name
+			}
+		} else if match := gradleIncludeRe.FindStringSubmatch(stmt.text); match != nil {
+			args := match[1]
+			if strings.HasPrefix(args, "(") {
+				args = args[1:closingParen(args)]
+			}
+			for _, arg := range splitArguments(args) {
+				if projectPath, ok := s.literal(arg); ok {
+					b.include(projectPath)
+				}
+			}
+		} else if match := gradleProjectDirRe.FindStringSubmatch(stmt.text); match != nil {
+			if dir := gradleLastStringRe.FindStringSubmatch(match[2]); dir != nil {
+				b.projects[gradleProjectPath(match[1])] = filepath.Join(b.root, filepath.FromSlash(dir[1]))
+			}
+		} else if match := gradleFromFilesRe.FindStringSubmatch(stmt.text); match != nil && innerBlock(stmt.blocks, 1) == "versionCatalogs" {
+			header := stmt.blocks[len(stmt.blocks)-1]
+			name := blockName(header)
+			if inner, ok := call(header, "create"); ok {
+				name, _ = s.literal(inner)
+			}
+			b.loadCatalog(name, filepath.Join(b.root, filepath.FromSlash(match[1])))
+		}
+	}
+}
+
+// include adds a project and its parents, :libs:util is in the dir libs/util by default
+func (b *gradleBuild) include(projectPath string) {
+	projectPath = gradleProjectPath(projectPath)
+	segments := strings.Split(strings.TrimPrefix(projectPath, ":"), ":")
+	for i := range segments {
+		path := ":" + strings.Join(segments[:i+1], ":")
+		if _, ok := b.projects[path]; !ok {
+			b.projects[path] = filepath.Join(append([]string{b.root}, segments[:i+1]...)...)
+		}
+	}
+}
+
+func (b *gradleBuild) loadCatalog(name, path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	catalog, err := loadVersionCatalog(path)
+	if err != nil {
+		log.Warnf("load version catalog error: %s %s", path, err.Error())
+		return
+	}
+	b.catalogs[name] = catalog
+}
+
+// projectOf returns the path of the project in the dir
+func (b *gradleBuild) projectOf(dir string) (string, bool) {
+	for path, projectDir := range b.projects {
+		if projectDir == dir {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// script parses a build script of the build, the subprojects inherit the variables of the root script
+func (b *gradleBuild) script(buildFile string, content string) *gradleScript {
+	vars := b.properties
+	if b.rootScript != nil && filepath.Dir(buildFile) != b.root {
+		vars = b.rootScript.vars
+	}
+	return parseGradleScript(content, vars, b.catalogs)
+}
+
+// packages returns the dependencies of a build script, the project itself is added with the edges to its
+// dependencies and to the other projects it depends on when the script belongs to a multi-project build
+func (b *gradleBuild) packages(sourcePath string, script *gradleScript) []model.Package {
+	managed := b.managedVersions(script)
+	pkgs := make([]model.Package, 0)
+	edges := make([]string, 0)
+	for _, dep := range script.deps {
+		if dep.project != "" {
+			if project := b.projectPackage(dep.project); project != nil && !slices.Contains(edges, project.PURL) {
+				edges = append(edges, project.PURL)
+			}
+			continue
+		}
+		version := dep.version
+		if version == "" {
+			version = script.pluginVersion(dep.groupID)
+		}
+		if version == "" {
+			version = managed[dep.groupID+":"+dep.artifactID]
+		}
+		pkg := newPackage(dep.groupID, dep.artifactID, version, sourcePath)
+		if pkg == nil {
+			continue
+		}
+		pkg.Scope = gradleScope(dep.configuration)
+		pkgs = append(pkgs, *pkg)
+		if !slices.Contains(edges, pkg.PURL) {
+			edges = append(edges, pkg.PURL)
+		}
+	}
+	if !b.multiProject {
+		return pkgs
+	}
+	dir := filepath.Dir(sourcePath)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	path, _ := b.projectOf(dir)
+	project := b.newProjectPackage(path, script, sourcePath)
+	if project == nil {
+		return pkgs
+	}
+	project.Dependencies = edges
+	return append([]model.Package{*project}, pkgs...)
+}
+
+// managedVersions returns the versions of the dependency constraints and the platforms, the platforms
+// are either other projects of the build or boms looked up in the local maven repository
+func (b *gradleBuild) managedVersions(script *gradleScript) map[string]string {
+	managed := make(map[string]string)
+	for key, version := range script.constraints {
+		managed[key] = version
+	}
+	var poms *pomResolver
+	for _, dep := range script.deps {
+		if !dep.platform {
+			continue
+		}
+		if dep.project != "" {
+			if _, platform := b.projectScript(dep.project); platform != nil {
+				for key, version := range platform.constraints {
+					if _, ok := managed[key]; !ok {
+						managed[key] = version
+					}
+				}
+			}
+			continue
+		}
+		if poms == nil {
+			poms = newPomResolver(localRepository())
+		}
+		bom := poms.lookup(dep.groupID, dep.artifactID, dep.version)
+		if bom == nil {
+			continue
+		}
+		for key, m := range poms.managedDependencies(bom) {
+			if _, ok := managed[key]; !ok && m.Version != "" {
+				managed[key] = m.Version
+			}
+		}
+	}
+	return managed
+}
+
+// projectScript resolves a project dependency, either a path or a type-safe accessor, and parses its build script
+func (b *gradleBuild) projectScript(ref string) (string, *gradleScript) {
+	path := ""
+	if strings.HasPrefix(ref, "projects.") {
+		accessor := strings.ToLower(strings.TrimPrefix(ref, "projects."))
+		for projectPath := range b.projects {
+			if projectAccessor(projectPath) == accessor {
+				path = projectPath
+				break
+			}
+		}
+	} else {
+		path = gradleProjectPath(ref)
+	}
+	dir, ok := b.projects[path]
+	if !ok {
+		return "", nil
+	}
+	script := &gradleScript{}
+	if buildFile := gradleBuildFile(dir); buildFile != "" {
+		if content, err := os.ReadFile(buildFile); err == nil {
+			script = b.script(buildFile, string(content))
+		}
+	}
+	return path, script
+}
+
+func (b *gradleBuild) projectPackage(ref string) *model.Package {
+	path, script := b.projectScript(ref)
+	if script == nil {
+		return nil
+	}
+	return b.newProjectPackage(path, script, "")
+}
+
+// newProjectPackage returns the package of a project, the group and the version not set by the script are taken
+// from the allprojects and subprojects blocks of the root script, then from gradle.properties
+func (b *gradleBuild) newProjectPackage(path string, script *gradleScript, sourcePath string) *model.Package {
+	name := b.rootName
+	if path != ":" {
+		name = path[strings.LastIndex(path, ":")+1:]
+	}
+	group, version := script.group, script.version
+	if b.rootScript != nil {
+		if group == "" {
+			group = b.rootScript.projectsGroup
+		}
+		if version == "" {
+			version = b.rootScript.projectsVersion
+		}
+	}
+	if group == "" {
+		group = b.properties["group"]
+	}
+	if version == "" {
+		version = b.properties["version"]
+	}
+	return newPackage(group, name, gradleVersion(version), sourcePath)
+}
+
+// gradleProjectPath makes a project path absolute, e.g. core to :core
+func gradleProjectPath(path string) string {
+	path = trim(path)
+	if !strings.HasPrefix(path, ":") {
+		path = ":" + path
+	}
+	return path
+}
+
+// projectAccessor returns the type-safe accessor of a project path in lower case, e.g. :libs:my-util to libs.myutil
+func projectAccessor(path string) string {
+	return strings.ToLower(strings.NewReplacer(":", ".", "-", "", "_", "").Replace(strings.TrimPrefix(path, ":")))
+}
+
+// parseGradleProperties reads the key=value and key:value pairs of gradle.properties
+func parseGradleProperties(path string) map[string]string {
+	properties := make(map[string]string)
+	f, err := os.Open(path)
+	if err != nil {
+		return properties
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if i := strings.IndexAny(line, "=:"); i > 0 {
+			properties[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	return properties
+}
@@ -28,9 +28,9 @@ var gradleFileTestdata = []testGradleitem{
 		title:    "Normal",
 		filePath: "test_material/gradle/build.gradle",
 		expected: []model.Package{
-			{Name: "com.android.support.test/runner", Version: "1.0.1", Type: model.PkgTypeMaven},
-			{Name: "com.android.support.constraint/constraint-layout", Version: "", Type: model.PkgTypeMaven},
-			{Name: "joda-time/joda-time", Version: "2.2", Type: model.PkgTypeMaven},
+			{Name: "com.android.support.test/runner", Version: "1.0.1", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+			{Name: "com.android.support.constraint/constraint-layout", Version: "", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "joda-time/joda-time", Version: "2.2", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
 		},
 	},
 }
@@ -44,7 +44,105 @@ func TestParseGradleFile(t *testing.T) {
 		}
 
 		if !util.SliceEqual(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
+		}) {
+			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
+		}
+	}
+}
+
+var gradleBuildTestdata = []testGradleitem{
+	{
+		title:    "RootProject",
+		filePath: "test_material/gradle/multi/build.gradle.kts",
+		expected: []model.Package{
+			{Name: "com.example.shop/shop", Version: "2.1.0", Type: model.PkgTypeMaven},
+		},
+	},
+	{
+		title:    "VersionCatalog",
+		filePath: "test_material/gradle/multi/core/build.gradle.kts",
+		expected: []model.Package{
+			{Name: "com.example.shop/core", Version: "2.1.0", Type: model.PkgTypeMaven, Dependencies: []string{
+				"pkg:maven/org.springframework.boot/spring-boot-dependencies@3.1.5",
+				"pkg:maven/com.squareup.okhttp3/okhttp@4.11.0",
+				"pkg:maven/com.squareup.okhttp3/logging-interceptor@4.11.0",
+				"pkg:maven/com.squareup.retrofit2/retrofit@2.9.0",
+				"pkg:maven/com.google.guava/guava@32.1.3-jre",
+				"pkg:maven/org.jetbrains.kotlin/kotlin-stdlib@1.9.20",
+				"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.3",
+				"pkg:maven/junit/junit@4.13.2",
+			}},
+			{Name: "org.springframework.boot/spring-boot-dependencies", Version: "3.1.5", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.squareup.okhttp3/okhttp", Version: "4.11.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.squareup.okhttp3/logging-interceptor", Version: "4.11.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.squareup.retrofit2/retrofit", Version: "2.9.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.google.guava/guava", Version: "32.1.3-jre", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.jetbrains.kotlin/kotlin-stdlib", Version: "1.9.20", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.fasterxml.jackson.core/jackson-databind", Version: "2.15.3", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "junit/junit", Version: "4.13.2", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+		},
+	},
+	{
+		title:    "ProjectDependencies",
+		filePath: "test_material/gradle/multi/app/build.gradle",
+		expected: []model.Package{
+			{Name: "com.example.shop/app", Version: "2.1.0", Type: model.PkgTypeMaven, Dependencies: []string{
+				"pkg:maven/com.example.shop/core@2.1.0",
+				"pkg:maven/com.example.shop/network-util@0.3.0",
+				"pkg:maven/androidx.lifecycle/lifecycle-runtime@2.6.2",
+				"pkg:maven/androidx.core/core-ktx@1.12.0",
+				"pkg:maven/com.squareup.retrofit2/converter-gson@2.9.0",
+				"pkg:maven/org.slf4j/slf4j-api@2.0.9",
+				"pkg:maven/com.google.dagger/dagger-compiler@2.48",
+				"pkg:maven/com.google.dagger/hilt-compiler@2.48",
+				"pkg:maven/junit/junit@4.13.2",
+			}},
+			{Name: "androidx.lifecycle/lifecycle-runtime", Version: "2.6.2", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "androidx.core/core-ktx", Version: "1.12.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.squareup.retrofit2/converter-gson", Version: "2.9.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.slf4j/slf4j-api", Version: "2.0.9", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.google.dagger/dagger-compiler", Version: "2.48", Type: model.PkgTypeMaven, Scope: model.ScopeProvided},
+			{Name: "com.google.dagger/hilt-compiler", Version: "2.48", Type: model.PkgTypeMaven, Scope: model.ScopeProvided},
+			{Name: "junit/junit", Version: "4.13.2", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+		},
+	},
+	{
+		title:    "ProjectPlatform",
+		filePath: "test_material/gradle/multi/libs/network-util/build.gradle.kts",
+		expected: []model.Package{
+			{Name: "com.example.shop/network-util", Version: "0.3.0", Type: model.PkgTypeMaven, Dependencies: []string{
+				"pkg:maven/com.example.shop/platform@2.1.0",
+				"pkg:maven/com.squareup.okio/okio@3.6.0",
+			}},
+			{Name: "com.squareup.okio/okio", Version: "3.6.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+		},
+	},
+	{
+		title:    "BOMPlatform",
+		filePath: "test_material/gradle/platform/build.gradle.kts",
+		expected: []model.Package{
+			{Name: "com.example.platform/platform-bom", Version: "2.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "com.fasterxml.jackson.core/jackson-databind", Version: "2.15.2", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.junit.jupiter/junit-jupiter", Version: "5.10.0", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+		},
+	},
+}
+
+func TestParseGradleBuild(t *testing.T) {
+	// the boms of the platforms are looked up in the local repository
+	t.Setenv("SBOM_MAVEN_REPO", "test_material/pom/reactor/repository")
+
+	for _, item := range gradleBuildTestdata {
+		pkgs, err := NewJavaGradleFileParser().Parse(item.filePath)
+		if err != nil {
+			t.Errorf("test error[%v]: %e", item.title, err)
+		}
+
+		if !util.SliceEqual(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope && util.SliceEqual(p1.Dependencies, p2.Dependencies, func(d1, d2 string) bool {
+				return d1 == d2
+			})
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
@@ -56,7 +154,7 @@ var gradleLockTestdata = []testGradleitem{
 		title:    "Normal",
 		filePath: "test_material/gradle/gradle.lockfile",
 		expected: []model.Package{
-			{Name: "ch.qos.logback/logback-classic", Version: "1.4.5", Type: model.PkgTypeMaven},
+			{Name: "ch.qos.logback/logback-classic", Version: "1.4.5", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
 			{Name: "ch.epfl.scala/scalafix-interfaces", Version: "0.10.4", Type: model.PkgTypeMaven},
 			{Name: "com.adtran/scala-multiversion-plugin", Version: "2.0.4", Type: model.PkgTypeMaven},
 			{Name: "com.fasterxml.jackson/jackson-bom", Version: "2.11.0", Type: model.PkgTypeMaven},
@@ -74,7 +172,7 @@ func TestParseGradleLock(t *testing.T) {
 		}
 
 		if !util.SliceEqual(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
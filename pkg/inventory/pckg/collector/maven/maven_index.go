@@ -0,0 +1,214 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package maven
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// coordinates identify a maven artifact, the version of a class prefix is unknown
+type coordinates struct {
+	groupID    string
+	artifactID string
+	version    string
+}
+
+// mavenIndex identifies the artifacts of archives by their SHA-1 and the artifacts of classes by their package prefix
+type mavenIndex struct {
+	checksums map[string]coordinates
+	prefixes  map[string]coordinates
+}
+
+// knownPrefixes are the packages of widely shaded artifacts, the longest prefix of a class wins
+var knownPrefixes = map[string]coordinates{
+	"com/google/common/":                {groupID: "com.google.guava", artifactID: "guava"},
+	"com/google/gson/":                  {groupID: "com.google.code.gson", artifactID: "gson"},
+	"com/google/protobuf/":              {groupID: "com.google.protobuf", artifactID: "protobuf-java"},
+	"com/fasterxml/jackson/core/":       {groupID: "com.fasterxml.jackson.core", artifactID: "jackson-core"},
+	"com/fasterxml/jackson/databind/":   {groupID: "com.fasterxml.jackson.core", artifactID: "jackson-databind"},
+	"com/fasterxml/jackson/annotation/": {groupID: "com.fasterxml.jackson.core", artifactID: "jackson-annotations"},
+	"io/netty/buffer/":                  {groupID: "io.netty", artifactID: "netty-buffer"},
+	"io/netty/channel/":                 {groupID: "io.netty", artifactID: "netty-transport"},
+	"io/netty/handler/":                 {groupID: "io.netty", artifactID: "netty-handler"},
+	"io/netty/util/":                    {groupID: "io.netty", artifactID: "netty-common"},
+	"org/apache/commons/lang3/":         {groupID: "org.apache.commons", artifactID: "commons-lang3"},
+	"org/apache/commons/collections4/":  {groupID: "org.apache.commons", artifactID: "commons-collections4"},
+	"org/apache/commons/io/":            {groupID: "commons-io", artifactID: "commons-io"},
+	"org/apache/commons/codec/":         {groupID: "commons-codec", artifactID: "commons-codec"},
+	"org/apache/http/":                  {groupID: "org.apache.httpcomponents", artifactID: "httpcore"},
+	"org/apache/http/client/":           {groupID: "org.apache.httpcomponents", artifactID: "httpclient"},
+	"org/apache/logging/log4j/":         {groupID: "org.apache.logging.log4j", artifactID: "log4j-api"},
+	"org/apache/logging/log4j/core/":    {groupID: "org.apache.logging.log4j", artifactID: "log4j-core"},
+	"org/slf4j/":                        {groupID: "org.slf4j", artifactID: "slf4j-api"},
+	"org/yaml/snakeyaml/":               {groupID: "org.yaml", artifactID: "snakeyaml"},
+	"org/objectweb/asm/":                {groupID: "org.ow2.asm", artifactID: "asm"},
+	"net/bytebuddy/":                    {groupID: "net.bytebuddy", artifactID: "byte-buddy"},
+	"okhttp3/":                          {groupID: "com.squareup.okhttp3", artifactID: "okhttp"},
+	"okio/":                             {groupID: "com.squareup.okio", artifactID: "okio"},
+	"kotlin/":                           {groupID: "org.jetbrains.kotlin", artifactID: "kotlin-stdlib"},
+	"org/springframework/boot/loader/":  {groupID: "org.springframework.boot", artifactID: "spring-boot-loader"},
+}
+
+// classRoots are the dirs of an archive holding the classes of the application
+var classRoots = []string{"BOOT-INF/classes/", "WEB-INF/classes/"}
+
+var sha1Re = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+var (
+	defaultIndex     *mavenIndex
+	defaultIndexOnce sync.Once
+)
+
+// defaultMavenIndex returns the known prefixes and the local index at $SBOM_MAVEN_INDEX or ~/sbom-tool/maven-index.txt,
+// the local index is optional
+func defaultMavenIndex() *mavenIndex {
+	defaultIndexOnce.Do(func() {
+		path := os.Getenv("SBOM_MAVEN_INDEX")
+		if path == "" {
+			path = filepath.Join(config.UserAppHome(), "maven-index.txt")
+			if _, err := os.Stat(path); err != nil {
+				defaultIndex = newMavenIndex()
+				return
+			}
+		}
+		index, err := loadMavenIndex(path)
+		if err != nil {
+			log.Warnf("load maven index error: %s %s", path, err.Error())
+			index = newMavenIndex()
+		}
+		defaultIndex = index
+	})
+	return defaultIndex
+}
+
+func newMavenIndex() *mavenIndex {
+	index := &mavenIndex{checksums: make(map[string]coordinates), prefixes: make(map[string]coordinates)}
+	for prefix, coords := range knownPrefixes {
+		index.prefixes[prefix] = coords
+	}
+	return index
+}
+
+// loadMavenIndex loads a local index on top of the known prefixes, each line is a SHA-1 or a package prefix
+// followed by the coordinates, split by spaces or a comma:
+//
+//	b3add478d4382b78ea20b1671390a858002feb6c com.google.code.gson:gson:2.10.1
+//	com/example/util/ com.example:util
+func loadMavenIndex(path string) (*mavenIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	index := newMavenIndex()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(fields) < 2 {
+			continue
+		}
+		coords, ok := parseCoordinates(fields[1])
+		if !ok {
+			continue
+		}
+		if sha1Re.MatchString(fields[0]) {
+			index.checksums[strings.ToLower(fields[0])] = coords
+		} else {
+			prefix := strings.Trim(strings.ReplaceAll(fields[0], ".", "/"), "/") + "/"
+			coords.version = ""
+			index.prefixes[prefix] = coords
+		}
+	}
+	return index, scanner.Err()
+}
+
+// parseCoordinates parses groupId:artifactId[:packaging[:classifier]]:version, the version is optional
+func parseCoordinates(val string) (coordinates, bool) {
+	parts := strings.Split(val, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return coordinates{}, false
+	}
+	coords := coordinates{groupID: parts[0], artifactID: parts[1]}
+	if len(parts) > 2 {
+		coords.version = parts[len(parts)-1]
+	}
+	return coords, true
+}
+
+// lookup returns the artifact of the archive by its SHA-1
+func (idx *mavenIndex) lookup(path string) (coordinates, bool) {
+	if len(idx.checksums) == 0 {
+		return coordinates{}, false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return coordinates{}, false
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	h := sha1.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return coordinates{}, false
+	}
+	coords, ok := idx.checksums[hex.EncodeToString(h.Sum(nil))]
+	return coords, ok
+}
+
+// classArtifact returns the artifact of the package dir of a class, the dir the artifact is found at and the
+// relocation prefixing that dir if the classes are relocated by shading, the single-segment prefixes like kotlin/
+// are only matched at the start of the dir since they are common package names
+func (idx *mavenIndex) classArtifact(dir string) (coordinates, string, string, bool) {
+	for _, root := range classRoots {
+		dir = strings.TrimPrefix(dir, root)
+	}
+	var found coordinates
+	location, foundAt := "", -1
+	for prefix, coords := range idx.prefixes {
+		at := -1
+		if strings.HasPrefix(dir, prefix) {
+			at = 0
+		} else if i := strings.Index(dir, "/"+prefix); i > -1 && strings.Count(prefix, "/") > 1 {
+			at = i + 1
+		}
+		if at < 0 {
+			continue
+		}
+		// the longest prefix wins, the outermost one if they are as long
+		if foundAt > -1 && (len(prefix) < len(location)-foundAt || len(prefix) == len(location)-foundAt && at > foundAt) {
+			continue
+		}
+		found, location, foundAt = coords, dir[:at+len(prefix)], at
+	}
+	if foundAt < 0 {
+		return coordinates{}, "", "", false
+	}
+	return found, location, dir[:foundAt], true
+}
@@ -11,8 +11,6 @@
 package maven
 
 import (
-	"github.com/vifraa/gopom"
-
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 )
@@ -36,23 +34,7 @@ func (m *POMXMLParser) Parse(pomPath string) ([]model.Package, error) {
 	return parsePomFile(pomPath)
 }
 
-// parsePomFile parses pom.xml
+// parsePomFile parses pom.xml with its effective pom, resolved within the project tree and the local repository
 func parsePomFile(path string) ([]model.Package, error) {
-	pkgs := make([]model.Package, 0)
-	pomInfo, err := gopom.Parse(path)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, dependencyPkg := range pomInfo.Dependencies {
-		groupId := trim(dependencyPkg.GroupID)
-		artifactId := trim(dependencyPkg.ArtifactID)
-		version := trim(dependencyPkg.Version)
-		pkg := newPackage(groupId, artifactId, version, path)
-		if pkg != nil {
-			pkgs = append(pkgs, *pkg)
-		}
-	}
-
-	return pkgs, nil
+	return newPomResolver(localRepository()).packages(path)
 }
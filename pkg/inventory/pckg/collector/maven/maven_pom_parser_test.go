@@ -13,6 +13,7 @@ package maven
 import (
 	"testing"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
@@ -29,16 +30,29 @@ var pomTestdata = []testMavenitem{
 		title:    "Normal",
 		filePath: "test_material/pom/pom.xml",
 		expected: []model.Package{
-			{Name: "org.antlr/antlr-runtime", Version: "3.5.2", Type: model.PkgTypeMaven},
-			{Name: "org.tmatesoft.sqljet/sqljet", Version: "1.1.1", Type: model.PkgTypeMaven},
-			{Name: "org.springframework.boot/spring-boot-starter-web", Version: "", Type: model.PkgTypeMaven},
-			{Name: "group-1/artifact-1", Version: "", Type: model.PkgTypeMaven},
-			{Name: "group-2/artifact-2", Version: "", Type: model.PkgTypeMaven},
+			{Name: "org.antlr/antlr-runtime", Version: "3.5.2", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.tmatesoft.sqljet/sqljet", Version: "1.1.1", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.springframework.boot/spring-boot-starter-web", Version: "", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "group-1/artifact-1", Version: "", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "group-2/artifact-2", Version: "", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+		},
+	},
+	{
+		title:    "Scopes",
+		filePath: "test_material/pom/scopes/pom.xml",
+		expected: []model.Package{
+			{Name: "com.google.code.gson/gson", Version: "2.10.1", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "org.postgresql/postgresql", Version: "42.6.0", Type: model.PkgTypeMaven, Scope: model.ScopeRuntime},
+			{Name: "javax.servlet/javax.servlet-api", Version: "4.0.1", Type: model.PkgTypeMaven, Scope: model.ScopeProvided},
+			{Name: "org.junit.jupiter/junit-jupiter", Version: "5.10.0", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+			{Name: "com.github.luben/zstd-jni", Version: "1.5.5-5", Type: model.PkgTypeMaven, Scope: model.ScopeOptional},
 		},
 	},
 }
 
 func TestParsePomfile(t *testing.T) {
+	// keeps the parents out of the local repository of the host
+	t.Setenv("SBOM_MAVEN_REPO", "test_material/pom/reactor/repository")
 
 	for _, item := range pomTestdata {
 		pkgs, err := parsePomFile(item.filePath)
@@ -47,13 +61,103 @@ func TestParsePomfile(t *testing.T) {
 		}
 
 		if !slices.EqualFunc(pkgs, item.expected, func(p1 model.Package, p2 model.Package) bool {
-			return model.PackageEqual(&p1, &p2)
+			return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 		}) {
 			t.Errorf("test failed[%v]: expected = %v got %v", item.title, item.expected, pkgs)
 		}
 	}
 }
 
+func TestParsePomfile_Reactor(t *testing.T) {
+	runtime := func(name, version string, deps ...string) model.Package {
+		return model.Package{Name: name, Version: version, Type: model.PkgTypeMaven, Scope: model.ScopeRuntime, Dependencies: deps}
+	}
+	module := func(name, version string, deps ...string) model.Package {
+		return model.Package{Name: name, Version: version, Type: model.PkgTypeMaven, Dependencies: deps}
+	}
+	tests := []struct {
+		name     string
+		path     string
+		expected []model.Package
+	}{
+		{
+			name: "Aggregator",
+			path: "test_material/pom/reactor/pom.xml",
+			expected: []model.Package{
+				module("com.example/reactor", "1.2.0", "pkg:maven/org.slf4j/slf4j-api@2.0.9"),
+				runtime("org.slf4j/slf4j-api", "2.0.9"),
+			},
+		},
+		{
+			name: "PropertyOverride",
+			path: "test_material/pom/reactor/core/pom.xml",
+			expected: []model.Package{
+				module("com.example/core", "1.2.0",
+					"pkg:maven/org.slf4j/slf4j-api@2.0.9",
+					"pkg:maven/com.google.guava/guava@31.1-jre",
+					"pkg:maven/org.apache.commons/commons-lang3@3.13.0",
+					"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.2"),
+				runtime("org.slf4j/slf4j-api", "2.0.9"),
+				runtime("com.google.guava/guava", "31.1-jre"),
+				runtime("org.apache.commons/commons-lang3", "3.13.0"),
+				runtime("com.fasterxml.jackson.core/jackson-databind", "2.15.2"),
+			},
+		},
+		{
+			name: "InterModule",
+			path: "test_material/pom/reactor/app/pom.xml",
+			expected: []model.Package{
+				module("com.example/app", "1.2.0",
+					"pkg:maven/org.slf4j/slf4j-api@2.0.9",
+					"pkg:maven/com.example/core@1.2.0",
+					"pkg:maven/org.junit.jupiter/junit-jupiter@5.10.0"),
+				runtime("org.slf4j/slf4j-api", "2.0.9"),
+				runtime("com.example/core", "1.2.0"),
+				{Name: "org.junit.jupiter/junit-jupiter", Version: "5.10.0", Type: model.PkgTypeMaven, Scope: model.ScopeTest},
+			},
+		},
+	}
+	poms := newPomResolver("test_material/pom/reactor/repository")
+	for _, tt := range tests {
+		poms.index(tt.path)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs, err := poms.packages(tt.path)
+			if err != nil {
+				t.Fatalf("packages() error = %v", err)
+			}
+			if !slices.EqualFunc(pkgs, tt.expected, func(p1 model.Package, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope && slices.Equal(p1.Dependencies, p2.Dependencies)
+			}) {
+				t.Errorf("packages() expected = %v got %v", tt.expected, pkgs)
+			}
+		})
+	}
+}
+
+func TestParsePomfile_LocalRepository(t *testing.T) {
+	// without the local repository the versions of the corporate parent and the bom are unknown
+	pkgs, err := newPomResolver("").packages("test_material/pom/reactor/core/pom.xml")
+	if err != nil {
+		t.Fatalf("packages() error = %v", err)
+	}
+	versions := make(map[string]string)
+	for _, pkg := range pkgs {
+		versions[pkg.Name] = pkg.Version
+	}
+	expected := map[string]string{
+		"com.example/core":                            "1.2.0",
+		"org.slf4j/slf4j-api":                         "2.0.9",
+		"com.google.guava/guava":                      "31.1-jre",
+		"org.apache.commons/commons-lang3":            "",
+		"com.fasterxml.jackson.core/jackson-databind": "",
+	}
+	if !maps.Equal(versions, expected) {
+		t.Errorf("packages() expected = %v got %v", expected, versions)
+	}
+}
+
 func BenchmarkPomfileParser(b *testing.B) {
 	parser := POMXMLParser{}
 	for _, item := range pomTestdata {
@@ -45,4 +45,6 @@ type PomDependency struct {
 	GroupID    string
 	ArtifactID string
 	Version    string
+	Scope      string
+	Optional   bool
 }
@@ -67,6 +67,8 @@ func newPomProject(path string, p gopom.Project) *PomProject {
 				GroupID:    dep.GroupID,
 				ArtifactID: dep.ArtifactID,
 				Version:    dep.Version,
+				Scope:      dep.Scope,
+				Optional:   strings.TrimSpace(dep.Optional) == "true",
 			}
 		}),
 	}
@@ -0,0 +1,9 @@
+plugins {
+    `java-platform`
+}
+
+dependencies {
+    constraints {
+        api("com.squareup.okio:okio:3.6.0")
+    }
+}
@@ -0,0 +1,7 @@
+plugins {
+    alias(libs.plugins.kotlin.jvm) apply false
+}
+
+allprojects {
+    version = "2.1.0"
+}
@@ -0,0 +1,21 @@
+plugins {
+    alias(libs.plugins.kotlin.jvm)
+    `java-library`
+}
+
+val jacksonVersion = "2.15.3"
+
+dependencies {
+    api(platform(libs.spring.boot.bom))
+    implementation(libs.bundles.network)
+    implementation("com.google.guava:guava:${property("guavaVersion")}")
+    implementation(kotlin("stdlib"))
+    implementation(libs.jackson.databind)
+    constraints {
+        implementation("com.fasterxml.jackson.core:jackson-databind:$jacksonVersion")
+    }
+    testImplementation(testLibs.junit)
+    /*
+    implementation("com.example:removed:1.0")
+    */
+}
@@ -0,0 +1,6 @@
+version = "0.3.0"
+
+dependencies {
+    api(platform(project(":platform")))
+    api("com.squareup.okio:okio")
+}
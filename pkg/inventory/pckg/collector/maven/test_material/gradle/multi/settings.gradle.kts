@@ -0,0 +1,20 @@
+pluginManagement {
+    repositories {
+        gradlePluginPortal()
+    }
+}
+
+dependencyResolutionManagement {
+    versionCatalogs {
+        create("testLibs") {
+            from(files("gradle/test-libs.versions.toml"))
+        }
+    }
+}
+
+rootProject.name = "shop"
+
+include(":app", ":core")
+include(":libs:network-util")
+include(":platform")
+project(":platform").projectDir = file("bom")
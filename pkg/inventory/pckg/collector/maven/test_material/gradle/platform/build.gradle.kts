@@ -0,0 +1,5 @@
+dependencies {
+    implementation(enforcedPlatform("com.example.platform:platform-bom:2.0"))
+    implementation("com.fasterxml.jackson.core:jackson-databind")
+    testImplementation("org.junit.jupiter:junit-jupiter")
+}
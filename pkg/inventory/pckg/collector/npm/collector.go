@@ -24,11 +24,16 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the packages installed into node_modules
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewPackageJSONParser())
 	parsers = append(parsers, NewPackageLockJSONParser())
 	parsers = append(parsers, NewYarnLockParser())
 	parsers = append(parsers, NewPnpmLockParser())
+
+	installedParsers = append(installedParsers, NewNodeModulesParser())
 }
 
 func NewCollector() *Collector {
@@ -39,6 +44,20 @@ func NewCollector() *Collector {
 	return &c
 }
 
+// InstalledCollector collects the packages installed into node_modules, every package.json is a package,
+// so the grouping by the main package of Collector is not needed
+type InstalledCollector struct {
+	collector.BaseCollector
+}
+
+func NewInstalledCollector() *InstalledCollector {
+	c := InstalledCollector{}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = installedParsers
+	return &c
+}
+
 func (c *Collector) Collect() ([]model.Package, error) {
 	// 根据目录分组
 	dirRequests := make(map[string][]collector.Request)
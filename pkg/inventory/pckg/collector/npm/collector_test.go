@@ -32,8 +32,20 @@ func TestNPMCollector_Collect(t *testing.T) {
 				collector.NewFileMeta("test_material/normal/package-lock.json"),
 			},
 			wantResult: []model.Package{
-				*newPackage("tslib", "2.3.0", ""),
-				*newPackage("wj-demo2", "1.1.3", ""),
+				{
+					Name:    "tslib",
+					Version: "2.3.0",
+					Type:    model.PkgTypeNPM,
+					PURL:    "pkg:npm/tslib@2.3.0",
+					Scope:   model.ScopeRuntime,
+				},
+				{
+					Name:    "wj-demo2",
+					Version: "1.1.3",
+					Type:    model.PkgTypeNPM,
+					PURL:    "pkg:npm/wj-demo2@1.1.3",
+					Scope:   model.ScopeRuntime,
+				},
 				{
 					Name:            "wj-demo3",
 					Version:         "8.8.2",
@@ -46,7 +58,13 @@ func TestNPMCollector_Collect(t *testing.T) {
 						"pkg:npm/zrender@5.4.3",
 					},
 				},
-				*newPackage("zrender", "5.4.3", ""),
+				{
+					Name:    "zrender",
+					Version: "5.4.3",
+					Type:    model.PkgTypeNPM,
+					PURL:    "pkg:npm/zrender@5.4.3",
+					Scope:   model.ScopeRuntime,
+				},
 			},
 			wantErr: false,
 		},
@@ -60,6 +78,7 @@ func TestNPMCollector_Collect(t *testing.T) {
 					Type:            model.PkgTypeNPM,
 					LicenseDeclared: nil,
 					PURL:            "pkg:npm/tslib@2.3.0",
+					Scope:           model.ScopeRuntime,
 				},
 				{
 					Name:            "wj-demo2",
@@ -67,6 +86,7 @@ func TestNPMCollector_Collect(t *testing.T) {
 					Type:            model.PkgTypeNPM,
 					LicenseDeclared: nil,
 					PURL:            "pkg:npm/wj-demo2@1.1.3",
+					Scope:           model.ScopeRuntime,
 				},
 				{
 					Name:            "wj-demo3",
@@ -81,6 +101,7 @@ func TestNPMCollector_Collect(t *testing.T) {
 					Type:            model.PkgTypeNPM,
 					LicenseDeclared: nil,
 					PURL:            "pkg:npm/zrender@5.4.3",
+					Scope:           model.ScopeRuntime,
 				},
 			},
 			wantErr: false,
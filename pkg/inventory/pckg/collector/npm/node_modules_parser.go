@@ -0,0 +1,95 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// NodeModulesParser is a parser for the packages installed into node_modules, the dependencies are
+// resolved like node does, from the nearest node_modules up to the top one, the same package installed
+// at several places is merged into one with all the files
+// see: https://nodejs.org/api/modules.html#loading-from-node_modules-folders
+type NodeModulesParser struct{}
+
+func NewNodeModulesParser() *NodeModulesParser {
+	return &NodeModulesParser{}
+}
+
+func (NodeModulesParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/node_modules/(@[^/]+/)?[^/.@][^/]*/package\.json$`),
+	}}
+}
+
+func (NodeModulesParser) ProvidesGraph() bool {
+	return true
+}
+
+func (NodeModulesParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("parse path %s", path)
+	content, err := getPackageJSONContent(path)
+	if err != nil || content == nil {
+		return nil, err
+	}
+	pkg := newPackage(content.Name, content.Version, path)
+	if pkg == nil {
+		return nil, nil
+	}
+	pkg.LicenseDeclared, _ = extractLicenses(content)
+
+	pkgDir := filepath.Dir(path)
+	for _, deps := range []map[string]string{content.Dependencies, content.OptionalDependencies} {
+		for name := range deps {
+			if dep := resolveInstalled(pkgDir, name); dep != nil {
+				pkg.Dependencies = append(pkg.Dependencies, dep.PURL)
+			}
+		}
+	}
+	pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+	pkg.Files = collector.ListFiles(pkgDir, func(dir string) bool {
+		return filepath.Base(dir) == folderNameNodeModules
+	})
+	return []model.Package{*pkg}, nil
+}
+
+// resolveInstalled returns the installed package required by the package in the dir,
+// looked up in the node_modules of the dir and of its ancestors
+func resolveInstalled(dir, name string) *model.Package {
+	for {
+		if filepath.Base(dir) != folderNameNodeModules {
+			path := filepath.Join(dir, folderNameNodeModules, filepath.FromSlash(name), "package.json")
+			if _, err := os.Stat(path); err == nil {
+				content, err := getPackageJSONContent(path)
+				if err != nil || content == nil {
+					return nil
+				}
+				return newPackage(content.Name, content.Version, path)
+			}
+		}
+		if !hasSubFolder(dir, folderNameNodeModules) {
+			// the project dir containing the top node_modules
+			return nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package npm
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestInstalledCollector_Collect(t *testing.T) {
+	root := "test_material/installed"
+	c := NewInstalledCollector()
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			c.TryToAccept(collector.NewFileMeta(path))
+		}
+		return nil
+	})
+	if len(c.GetRequests()) != 7 {
+		t.Fatalf("requests = %d, want 7", len(c.GetRequests()))
+	}
+	got, err := c.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	want := []model.Package{
+		{Name: "@scope/d", Version: "3.0.0", PURL: "pkg:npm/%40scope/d@3.0.0", LicenseDeclared: []string{"ISC"},
+			Files: []string{"node_modules/@scope/d/lib/package.json", "node_modules/@scope/d/package.json"}},
+		{Name: "a", Version: "1.0.0", PURL: "pkg:npm/a@1.0.0", LicenseDeclared: []string{"MIT"},
+			Dependencies: []string{"pkg:npm/c@1.0.0"},
+			Files:        []string{"node_modules/a/index.js", "node_modules/a/package.json"}},
+		{Name: "b", Version: "2.0.0", PURL: "pkg:npm/b@2.0.0",
+			Dependencies: []string{"pkg:npm/%40scope/d@3.0.0", "pkg:npm/c@2.0.0"},
+			Files:        []string{"node_modules/b/package.json"}},
+		{Name: "c", Version: "1.0.0", PURL: "pkg:npm/c@1.0.0",
+			Files: []string{
+				"node_modules/a/node_modules/c/index.js",
+				"node_modules/a/node_modules/c/package.json",
+				"node_modules/e/node_modules/c/index.js",
+				"node_modules/e/node_modules/c/package.json",
+			}},
+		{Name: "c", Version: "2.0.0", PURL: "pkg:npm/c@2.0.0",
+			Files: []string{"node_modules/c/package.json"}},
+		{Name: "e", Version: "1.0.0", PURL: "pkg:npm/e@1.0.0",
+			Dependencies: []string{"pkg:npm/c@1.0.0"},
+			Files:        []string{"node_modules/e/package.json"}},
+	}
+	for i := range got {
+		for j, file := range got[i].Files {
+			rel, _ := filepath.Rel(root, file)
+			got[i].Files[j] = filepath.ToSlash(rel)
+		}
+	}
+	if !slices.EqualFunc(got, want, func(p1, p2 model.Package) bool {
+		return p1.PURL == p2.PURL && slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
+			slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.Files, p2.Files)
+	}) {
+		t.Errorf("Collect() got = %v, want %v", got, want)
+	}
+}
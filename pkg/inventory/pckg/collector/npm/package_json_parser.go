@@ -25,11 +25,13 @@ import (
 
 // content of package.json
 type packageJSONContent struct {
-	Name         string            `json:"name"`
-	Version      string            `json:"version"`
-	License      json.RawMessage   `json:"license"`
-	Licenses     json.RawMessage   `json:"licenses"`
-	Dependencies map[string]string `json:"dependencies"`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	License              json.RawMessage   `json:"license"`
+	Licenses             json.RawMessage   `json:"licenses"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
 }
 
 type licenseField struct {
@@ -84,11 +86,17 @@ func (PackageJSONParser) Parse(path string) ([]model.Package, error) {
 
 	if !hasSubFolder(path, folderNameNodeModules) {
 		// not in node_modules
-		for name, version := range content.Dependencies {
-			ver := strings.Trim(version, "^><=@~")
-			pkg := newPackage(name, ver, path)
-			pkgs = append(pkgs, *pkg)
-			mainPkg.Dependencies = append(mainPkg.Dependencies, pkg.PURL)
+		for scope, deps := range content.scopedDependencies() {
+			for name, version := range deps {
+				ver := strings.Trim(version, "^><=@~")
+				pkg := newPackage(name, ver, path)
+				if pkg == nil {
+					continue
+				}
+				pkg.Scope = scope
+				pkgs = append(pkgs, *pkg)
+				mainPkg.Dependencies = append(mainPkg.Dependencies, pkg.PURL)
+			}
 		}
 	}
 
@@ -97,6 +105,15 @@ func (PackageJSONParser) Parse(path string) ([]model.Package, error) {
 	return pkgs, nil
 }
 
+// scopedDependencies returns the direct dependencies grouped by scope
+func (content *packageJSONContent) scopedDependencies() map[model.Scope]map[string]string {
+	return map[model.Scope]map[string]string{
+		model.ScopeRuntime:  content.Dependencies,
+		model.ScopeDev:      content.DevDependencies,
+		model.ScopeOptional: content.OptionalDependencies,
+	}
+}
+
 func getPackageJSONContent(path string) (*packageJSONContent, error) {
 	reader, _ := os.Open(path)
 	defer func(reader *os.File) {
@@ -115,7 +132,6 @@ func getPackageJSONContent(path string) (*packageJSONContent, error) {
 }
 
 func extractLicenses(content *packageJSONContent) ([]string, error) {
-	licensesResult := make([]string, 0)
 	if content == nil {
 		return nil, nil
 	}
@@ -128,14 +144,7 @@ func extractLicenses(content *packageJSONContent) ([]string, error) {
 		return nil, nil
 	}
 
-	for _, name := range licenses {
-		value, _, _ := license.ParseLicenseName(name)
-		if len(strings.TrimSpace(value)) != 0 {
-			licensesResult = append(licensesResult, value)
-		}
-	}
-
-	return licensesResult, err
+	return license.NormalizeExpressions(licenses), err
 }
 
 // for details, ref https://docs.npmjs.com/cli/v9/configuring-npm/package-json#license
@@ -154,10 +163,9 @@ func getFromLicenseField(b []byte) ([]string, error) {
 	return nil, err
 }
 
+// getFromLicenseString returns the license expression, licenses joined by OR are a single choice of licenses
 func getFromLicenseString(str string) []string {
-	str = strings.TrimPrefix(str, "(")
-	str = strings.TrimSuffix(str, ")")
-	return strings.Split(str, " OR ")
+	return license.NormalizeExpressions([]string{str})
 }
 
 func getFromLicensesField(b []byte) ([]string, error) {
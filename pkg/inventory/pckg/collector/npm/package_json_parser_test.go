@@ -36,7 +36,7 @@ func TestGetFromLicenseField(t *testing.T) {
 		{
 			name: "case-string-multiple",
 			args: args{[]byte(`"MIT OR Apache-2.0"`)},
-			want: []string{"MIT", "Apache-2.0"},
+			want: []string{"MIT OR Apache-2.0"},
 		},
 	}
 	for _, tt := range tests {
@@ -65,14 +65,14 @@ var packageJSONTests = []struct {
 		name: "case-package-license-string",
 		args: args{path: "test_material/package/package-license-string.json"},
 		want: []model.Package{
-			*newPackage("wj-demo2", "1.1.3", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo4", "1.1.3", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo5", "", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo6", "", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo7", "", "test_material/package/package-license-string.json"),
-			*newPackage("ant-design-vue", "3.0.0-alpha.9", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo9", "", "test_material/package/package-license-string.json"),
-			*newPackage("wj-demo10", "", "test_material/package/package-license-string.json"),
+			scopedPackage("wj-demo2", "1.1.3", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo4", "1.1.3", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo5", "", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo6", "", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo7", "", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("ant-design-vue", "3.0.0-alpha.9", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo9", "", "test_material/package/package-license-string.json", model.ScopeRuntime),
+			scopedPackage("wj-demo10", "", "test_material/package/package-license-string.json", model.ScopeRuntime),
 			func() model.Package {
 				p := newPackage("wj-demo3", "8.8.2", "test_material/package/package-license-string.json")
 				p.Dependencies = []string{"pkg:npm/wj-demo2@1.1.3",
@@ -94,7 +94,7 @@ var packageJSONTests = []struct {
 		name: "case-package-license-object",
 		args: args{path: "test_material/package/package-license-object.json"},
 		want: []model.Package{
-			*newPackage("wj-demo2", "1.1.3", "test_material/package/package-license-object.json"),
+			scopedPackage("wj-demo2", "1.1.3", "test_material/package/package-license-object.json", model.ScopeRuntime),
 			func() model.Package {
 				p := newPackage("wj-demo3", "8.8.2", "test_material/package/package-license-object.json")
 				p.Dependencies = []string{"pkg:npm/wj-demo2@1.1.3"}
@@ -108,7 +108,7 @@ var packageJSONTests = []struct {
 		name: "case-package-license-array",
 		args: args{path: "test_material/package/package-licenses-array.json"},
 		want: []model.Package{
-			*newPackage("wj-demo2", "1.1.3", "test_material/package/package-licenses-array.json"),
+			scopedPackage("wj-demo2", "1.1.3", "test_material/package/package-licenses-array.json", model.ScopeRuntime),
 			func() model.Package {
 				p := newPackage("wj-demo3", "8.8.2", "test_material/package/package-licenses-array.json")
 				p.Dependencies = []string{"pkg:npm/wj-demo2@1.1.3"}
@@ -118,9 +118,31 @@ var packageJSONTests = []struct {
 		},
 		want1:   nil,
 		wantErr: false,
+	}, {
+		name: "case-package-scopes",
+		args: args{path: "test_material/package/package-scopes.json"},
+		want: []model.Package{
+			scopedPackage("wj-demo2", "1.1.3", "test_material/package/package-scopes.json", model.ScopeRuntime),
+			scopedPackage("jest", "29.7.0", "test_material/package/package-scopes.json", model.ScopeDev),
+			scopedPackage("fsevents", "2.3.3", "test_material/package/package-scopes.json", model.ScopeOptional),
+			func() model.Package {
+				p := newPackage("wj-demo3", "8.8.2", "test_material/package/package-scopes.json")
+				p.Dependencies = []string{"pkg:npm/fsevents@2.3.3", "pkg:npm/jest@29.7.0", "pkg:npm/wj-demo2@1.1.3"}
+				p.LicenseDeclared = []string{"MIT"}
+				return *p
+			}(),
+		},
+		want1:   nil,
+		wantErr: false,
 	},
 }
 
+func scopedPackage(name, version, path string, scope model.Scope) model.Package {
+	pkg := newPackage(name, version, path)
+	pkg.Scope = scope
+	return *pkg
+}
+
 func TestPackageJsonParserParse(t *testing.T) {
 	for _, tt := range packageJSONTests {
 		t.Run(tt.name, func(t *testing.T) {
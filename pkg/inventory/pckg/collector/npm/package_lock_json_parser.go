@@ -44,18 +44,21 @@ type packageLockJSONContent struct {
 }
 
 type lockPackageItem struct {
-	Name      string `json:"name"`
-	Version   string `json:"version"`
-	Resolved  string `json:"resolved"`
-	Integrity string `json:"integrity"`
-	License   string `json:"license"`
-	Dev       bool   `json:"dev"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Resolved    string `json:"resolved"`
+	Integrity   string `json:"integrity"`
+	License     string `json:"license"`
+	Dev         bool   `json:"dev"`
+	Optional    bool   `json:"optional"`
+	DevOptional bool   `json:"devOptional"`
 }
 type lockDependencyItem struct {
 	Version   string `json:"version"`
 	Resolved  string `json:"resolved"`
 	Integrity string `json:"integrity"`
 	Dev       bool   `json:"dev"`
+	Optional  bool   `json:"optional"`
 }
 
 func NewPackageLockJSONParser() *PackageLockJSONParser {
@@ -94,10 +97,8 @@ func (PackageLockJSONParser) Parse(path string) ([]model.Package, error) {
 	// the lockfileVersion differs depending on the npm version. for details ref https://docs.npmjs.com/cli/v9/configuring-npm/package-lock-json#lockfileversion
 	if content.LockfileVersion == 1 {
 		for name, dep := range content.Dependencies {
-			if dep.Dev {
-				continue
-			}
 			pkg := newPackage(name, dep.Version, path)
+			pkg.Scope = lockScope(dep.Dev, dep.Optional)
 			if nodeModulesExist {
 				subPkgPath := filepath.Join(dir, folderNameNodeModules, name, "package.json")
 				licenses, err := selectLicenses(subPkgPath)
@@ -113,9 +114,6 @@ func (PackageLockJSONParser) Parse(path string) ([]model.Package, error) {
 		}
 	} else if content.LockfileVersion == 2 || content.LockfileVersion == 3 {
 		for name, item := range content.Packages {
-			if item.Dev {
-				continue
-			}
 			if name == "" && item.Name == "" {
 				continue
 			}
@@ -130,6 +128,10 @@ func (PackageLockJSONParser) Parse(path string) ([]model.Package, error) {
 			}
 
 			pkg := newPackage(depName, item.Version, path)
+			if name != "" {
+				// the entry with empty key is the project itself
+				pkg.Scope = lockScope(item.Dev || item.DevOptional, item.Optional)
+			}
 			var licenses []string
 			if item.License != "" {
 				licenses = getFromLicenseString(item.License)
@@ -154,6 +156,18 @@ func (PackageLockJSONParser) Parse(path string) ([]model.Package, error) {
 	return pkgs, nil
 }
 
+// lockScope returns the scope of a package by the flags in package-lock.json
+func lockScope(dev, optional bool) model.Scope {
+	switch {
+	case dev:
+		return model.ScopeDev
+	case optional:
+		return model.ScopeOptional
+	default:
+		return model.ScopeRuntime
+	}
+}
+
 func selectLicenses(packageJSONPath string) ([]string, error) {
 	stat, err := os.Stat(packageJSONPath)
 	if err != nil {
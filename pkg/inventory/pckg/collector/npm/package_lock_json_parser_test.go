@@ -35,6 +35,7 @@ var packageLockTests = []struct {
 				PURL:            packageURL("tslib", "2.3.0"),
 				LicenseDeclared: nil,
 				SourceLocation:  "test_material/packageLock/packageLock.json",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "wj-demo2",
@@ -43,6 +44,7 @@ var packageLockTests = []struct {
 				PURL:            packageURL("wj-demo2", "1.1.3"),
 				LicenseDeclared: nil,
 				SourceLocation:  "test_material/packageLock/packageLock.json",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "wj-demo3",
@@ -59,6 +61,7 @@ var packageLockTests = []struct {
 				PURL:            packageURL("zrender", "5.4.3"),
 				LicenseDeclared: nil,
 				SourceLocation:  "test_material/packageLock/packageLock.json",
+				Scope:           model.ScopeRuntime,
 			},
 		},
 		want1:   nil,
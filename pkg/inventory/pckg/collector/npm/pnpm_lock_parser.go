@@ -24,17 +24,19 @@ import (
 )
 
 type LockFile struct {
-	LockfileVersion string                 `yaml:"lockfileVersion"`
-	Dependencies    map[string]any         `yaml:"dependencies,omitempty"`
-	DevDependencies map[string]any         `yaml:"devDependencies,omitempty"`
-	Packages        map[string]PackageInfo `yaml:"packages,omitempty"`
+	LockfileVersion      string                 `yaml:"lockfileVersion"`
+	Dependencies         map[string]any         `yaml:"dependencies,omitempty"`
+	DevDependencies      map[string]any         `yaml:"devDependencies,omitempty"`
+	OptionalDependencies map[string]any         `yaml:"optionalDependencies,omitempty"`
+	Packages             map[string]PackageInfo `yaml:"packages,omitempty"`
 }
 
 type PackageInfo struct {
 	Resolution      PackageResolution `yaml:"resolution"`
 	Dependencies    map[string]string `yaml:"dependencies,omitempty"`
 	DevDependencies map[string]string `yaml:"devDependencies,omitempty"`
-	IsDev           bool              `yaml:"dev,omitempty"`
+	IsDev           *bool             `yaml:"dev,omitempty"`
+	IsOptional      bool              `yaml:"optional,omitempty"`
 	Name            string            `yaml:"name,omitempty"`
 	Version         string            `yaml:"version,omitempty"`
 }
@@ -54,6 +56,10 @@ func (PnpmLockParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"pnpm.lock", "pnpm-lock.yaml"}}
 }
 
+func (PnpmLockParser) ProvidesGraph() bool {
+	return true
+}
+
 func (PnpmLockParser) Parse(path string) ([]model.Package, error) {
 	log.Infof("parse path %s", path)
 	if hasSubFolder(path, folderNameNodeModules) {
@@ -88,9 +94,6 @@ func (PnpmLockParser) Parse(path string) ([]model.Package, error) {
 
 	// for pnpm.lock spec, ref https://github.com/pnpm/spec/blob/ad27a225f81d9215becadfa540ef05fa4ad6dd60/lockfile/5.md
 	for key, value := range lockFile.Packages {
-		if value.IsDev {
-			continue
-		}
 		name := value.Name
 		version := value.Version
 
@@ -99,16 +102,46 @@ func (PnpmLockParser) Parse(path string) ([]model.Package, error) {
 		}
 
 		pkg := newPackage(name, version, path)
+		pkg.Scope = value.scope()
 		depTree.AddPackage(pkg)
 		for depName, depVer := range value.Dependencies {
 			depTree.AddDependency(pkg.PURL, newPackage(depName, depVer, path).PURL)
 		}
 	}
+	// packages without the dev flag take the scope of the direct dependencies depending on them
+	for scope, deps := range map[model.Scope]map[string]any{
+		model.ScopeRuntime:  lockFile.Dependencies,
+		model.ScopeDev:      lockFile.DevDependencies,
+		model.ScopeOptional: lockFile.OptionalDependencies,
+	} {
+		for name := range deps {
+			for _, p := range depTree.GetPackagesByName(name) {
+				if pkg := depTree.GetPackage(p.PURL); pkg.Scope == "" {
+					pkg.Scope = scope
+				}
+			}
+		}
+	}
 	pkgs := depTree.ToList()
+	collector.PropagateScope(pkgs)
 	log.Infof("pnpmLockParser %d packages found", len(pkgs))
 	return pkgs, nil
 }
 
+// scope returns the scope of the package, the dev flag is omitted by pnpm when the package is used by both dev and prod dependencies
+func (info PackageInfo) scope() model.Scope {
+	switch {
+	case info.IsDev == nil:
+		return ""
+	case *info.IsDev:
+		return model.ScopeDev
+	case info.IsOptional:
+		return model.ScopeOptional
+	default:
+		return model.ScopeRuntime
+	}
+}
+
 func getVersionSeparator(lockFileVersion float64) string {
 	sep := "@"
 	if lockFileVersion < 6 {
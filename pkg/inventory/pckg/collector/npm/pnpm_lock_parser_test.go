@@ -35,6 +35,7 @@ var pnpmLockTests = []struct {
 				PURL:            packageURL("tslib", "2.3.0"),
 				LicenseDeclared: nil,
 				SourceLocation:  "test_material/pnpm/pnpm-v5.1.lock",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "wj-demo2",
@@ -44,6 +45,7 @@ var pnpmLockTests = []struct {
 				LicenseDeclared: nil,
 				Dependencies:    []string{"pkg:npm/zrender@5.4.4"},
 				SourceLocation:  "test_material/pnpm/pnpm-v5.1.lock",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "zrender",
@@ -53,6 +55,7 @@ var pnpmLockTests = []struct {
 				LicenseDeclared: nil,
 				Dependencies:    []string{"pkg:npm/tslib@2.3.0"},
 				SourceLocation:  "test_material/pnpm/pnpm-v5.1.lock",
+				Scope:           model.ScopeRuntime,
 			},
 		},
 		want1:   nil,
@@ -69,6 +72,7 @@ var pnpmLockTests = []struct {
 				PURL:            packageURL("tslib", "2.3.0"),
 				LicenseDeclared: nil,
 				SourceLocation:  "test_material/pnpm/pnpm.lock",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "wj-demo2",
@@ -78,6 +82,7 @@ var pnpmLockTests = []struct {
 				LicenseDeclared: nil,
 				Dependencies:    []string{"pkg:npm/zrender@5.4.4"},
 				SourceLocation:  "test_material/pnpm/pnpm.lock",
+				Scope:           model.ScopeRuntime,
 			},
 			{
 				Name:            "zrender",
@@ -87,6 +92,41 @@ var pnpmLockTests = []struct {
 				LicenseDeclared: nil,
 				Dependencies:    []string{"pkg:npm/tslib@2.3.0"},
 				SourceLocation:  "test_material/pnpm/pnpm.lock",
+				Scope:           model.ScopeRuntime,
+			},
+		},
+		want1:   nil,
+		wantErr: false,
+	},
+	{
+		name: "case-pnpm-dev",
+		args: args{path: "test_material/pnpm/pnpm-dev.lock"},
+		want: []model.Package{
+			{
+				Name:           "tslib",
+				Version:        "2.3.0",
+				Type:           PkgType(),
+				PURL:           packageURL("tslib", "2.3.0"),
+				SourceLocation: "test_material/pnpm/pnpm-dev.lock",
+				Scope:          model.ScopeRuntime,
+			},
+			{
+				Name:           "typescript",
+				Version:        "5.2.2",
+				Type:           PkgType(),
+				PURL:           packageURL("typescript", "5.2.2"),
+				Dependencies:   []string{"pkg:npm/tslib@2.3.0"},
+				SourceLocation: "test_material/pnpm/pnpm-dev.lock",
+				Scope:          model.ScopeDev,
+			},
+			{
+				Name:           "wj-demo2",
+				Version:        "1.1.3",
+				Type:           PkgType(),
+				PURL:           packageURL("wj-demo2", "1.1.3"),
+				Dependencies:   []string{"pkg:npm/tslib@2.3.0"},
+				SourceLocation: "test_material/pnpm/pnpm-dev.lock",
+				Scope:          model.ScopeRuntime,
 			},
 		},
 		want1:   nil,
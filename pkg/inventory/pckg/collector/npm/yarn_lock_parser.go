@@ -14,6 +14,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -57,6 +58,10 @@ func (YarnLockParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"yarn.lock"}}
 }
 
+func (YarnLockParser) ProvidesGraph() bool {
+	return true
+}
+
 func (YarnLockParser) Parse(path string) ([]model.Package, error) {
 	log.Infof("parse path %s", path)
 	if hasSubFolder(path, folderNameNodeModules) {
@@ -130,6 +135,17 @@ func (YarnLockParser) Parse(path string) ([]model.Package, error) {
 		}
 	}
 
+	// the scopes of direct dependencies are declared in the package.json beside yarn.lock
+	if content, _ := getPackageJSONContent(filepath.Join(filepath.Dir(path), "package.json")); content != nil {
+		for scope, deps := range content.scopedDependencies() {
+			for name, version := range deps {
+				if pkg := pkgMap[name+"@"+version]; pkg != nil {
+					pkg.Scope = model.MergeScope(pkg.Scope, scope)
+				}
+			}
+		}
+	}
+
 	depTree := collector.NewDependencyTree()
 	for _, content := range pkgContents {
 		depTree.AddPackage(content.pkg)
@@ -142,6 +158,7 @@ func (YarnLockParser) Parse(path string) ([]model.Package, error) {
 	}
 
 	pkgs = depTree.ToList()
+	collector.PropagateScope(pkgs)
 	log.Infof("yarnLockParser %d packages found", len(pkgs))
 	return pkgs, nil
 }
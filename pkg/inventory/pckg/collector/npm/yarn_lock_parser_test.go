@@ -57,6 +57,39 @@ var yarnLockTests = []struct {
 		want1:   nil,
 		wantErr: false,
 	},
+	{
+		name: "case-yarnLock-scopes",
+		args: args{path: "test_material/yarn-scopes/yarn.lock"},
+		want: []model.Package{
+			{
+				Name:           "tslib",
+				Version:        "2.3.0",
+				Type:           PkgType(),
+				PURL:           packageURL("tslib", "2.3.0"),
+				SourceLocation: "test_material/yarn-scopes/yarn.lock",
+				Scope:          model.ScopeRuntime,
+			},
+			{
+				Name:           "yarn",
+				Version:        "1.22.19",
+				Type:           PkgType(),
+				PURL:           packageURL("yarn", "1.22.19"),
+				SourceLocation: "test_material/yarn-scopes/yarn.lock",
+				Scope:          model.ScopeDev,
+			},
+			{
+				Name:           "zrender",
+				Version:        "5.4.4",
+				Type:           PkgType(),
+				PURL:           packageURL("zrender", "5.4.4"),
+				Dependencies:   []string{"pkg:npm/tslib@2.3.0"},
+				SourceLocation: "test_material/yarn-scopes/yarn.lock",
+				Scope:          model.ScopeRuntime,
+			},
+		},
+		want1:   nil,
+		wantErr: false,
+	},
 }
 
 func TestYarnLockParser_Parse(t *testing.T) {
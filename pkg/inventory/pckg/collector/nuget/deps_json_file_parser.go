@@ -34,6 +34,10 @@ func (g DepsJsonFileParser) Matcher() collector.FileMatcher {
 	return &collector.FilePatternMatcher{Patterns: []string{"*.deps.json"}}
 }
 
+func (g DepsJsonFileParser) ProvidesGraph() bool {
+	return true
+}
+
 type dotnetDeps struct {
 	Targets map[string]map[string]struct {
 		Type         string            `json:"type"`
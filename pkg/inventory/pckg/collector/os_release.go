@@ -0,0 +1,100 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package collector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+)
+
+// OSRelease is the identification of the operating system of a root filesystem
+// see: https://www.freedesktop.org/software/systemd/man/os-release.html
+type OSRelease struct {
+	ID        string
+	VersionID string
+}
+
+// ReadOSRelease reads etc/os-release or usr/lib/os-release under the root dir, nil if none is found
+func ReadOSRelease(root string) *OSRelease {
+	for _, path := range []string{"etc/os-release", "usr/lib/os-release"} {
+		f, err := os.Open(filepath.Join(root, path))
+		if err != nil {
+			continue
+		}
+		release := &OSRelease{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(value, `"'`)
+			switch key {
+			case "ID":
+				release.ID = value
+			case "VERSION_ID":
+				release.VersionID = value
+			}
+		}
+		_ = f.Close()
+		if release.ID != "" {
+			return release
+		}
+	}
+	return nil
+}
+
+// Namespace returns the namespace of the package urls of the distro, or the default one if the distro is unknown
+func (r *OSRelease) Namespace(defaultNamespace string) string {
+	if r == nil || r.ID == "" {
+		return defaultNamespace
+	}
+	return r.ID
+}
+
+// Qualifiers returns the qualifiers of the package urls with the arch and the distro, e.g. distro=debian-12,
+// the empty values are omitted
+func (r *OSRelease) Qualifiers(arch string, extra map[string]string) packageurl.Qualifiers {
+	qualifiers := make(map[string]string)
+	for key, value := range extra {
+		if value != "" {
+			qualifiers[key] = value
+		}
+	}
+	if arch != "" {
+		qualifiers["arch"] = arch
+	}
+	if r != nil && r.ID != "" {
+		qualifiers["distro"] = r.ID
+		if r.VersionID != "" {
+			qualifiers["distro"] = r.ID + "-" + r.VersionID
+		}
+	}
+	return packageurl.QualifiersFromMap(qualifiers)
+}
+
+// RootOf returns the root filesystem of a file at the given path inside it,
+// e.g. /rootfs of /rootfs/var/lib/dpkg/status and var/lib/dpkg/status
+func RootOf(path, pathInRoot string) string {
+	path = filepath.ToSlash(path)
+	root := strings.TrimSuffix(path, "/"+strings.TrimPrefix(pathInRoot, "/"))
+	if root == path {
+		return filepath.Dir(filepath.FromSlash(path))
+	}
+	if root == "" {
+		return string(filepath.Separator)
+	}
+	return filepath.FromSlash(root)
+}
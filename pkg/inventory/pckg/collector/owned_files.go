@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package collector
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ListFiles returns the files under the dir owned by an installed package, the dirs for which skipDir
+// returns true are not walked, e.g. the nested packages
+func ListFiles(dir string, skipDir func(path string) bool) []string {
+	files := make([]string, 0)
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && skipDir != nil && skipDir(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files
+}
+
+// ResolveFiles joins the paths relative to the base dir of a file list, e.g. RECORD of python packages,
+// the paths outside the base dir are kept as long as they are relative
+func ResolveFiles(base string, paths []string) []string {
+	files := make([]string, 0, len(paths))
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(base, filepath.FromSlash(p))
+		}
+		files = append(files, filepath.Clean(p))
+	}
+	return files
+}
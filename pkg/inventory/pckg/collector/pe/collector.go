@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+type Collector struct {
+	collector.BaseCollector
+	parser *PEParser
+}
+
+func NewCollector() *Collector {
+	c := Collector{parser: NewPEParser()}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = []collector.FileParser{c.parser}
+	return &c
+}
+
+// Collect parses the accepted files, and links an assembly to the assemblies it references found in the same tree
+// by the name and the public key token, the assemblies not found, e.g. of the framework, are left out of the dependencies
+func (c *Collector) Collect() ([]model.Package, error) {
+	binaries := make([]*peFile, 0, len(c.Requests))
+	assemblies := make(map[string]string)
+	for _, request := range c.Requests {
+		bin, err := c.parser.parseBinary(request.File.FullName())
+		if err != nil {
+			log.Warnf("parse pe file error: %s %s", request.File.FullName(), err.Error())
+			continue
+		}
+		binaries = append(binaries, bin)
+		if bin.pkg != nil && bin.assembly != nil {
+			assemblies[assemblyKey(bin.assembly.name, bin.assembly.publicKeyToken)] = bin.pkg.PURL
+		}
+	}
+	pkgs := make([]model.Package, 0)
+	for _, bin := range binaries {
+		if bin.pkg == nil {
+			continue
+		}
+		if bin.assembly != nil {
+			for _, ref := range bin.assembly.references {
+				if purl, ok := assemblies[assemblyKey(ref.name, ref.publicKeyToken)]; ok && purl != bin.pkg.PURL {
+					bin.pkg.Dependencies = append(bin.pkg.Dependencies, purl)
+				}
+			}
+		}
+		pkgs = append(pkgs, *bin.pkg)
+	}
+	return collector.OrganizePackage(pkgs), nil
+}
+
+// assemblyKey identifies an assembly, the names of the assemblies are case-insensitive
+func assemblyKey(name, publicKeyToken string) string {
+	return strings.ToLower(name) + "/" + publicKeyToken
+}
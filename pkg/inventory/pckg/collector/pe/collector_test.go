@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestPECollector_Collect(t *testing.T) {
+	root := "test_material/dist"
+	c := NewCollector()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			c.TryToAccept(collector.NewFileMeta(path))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	slices.SortFunc(got, func(p1, p2 model.Package) bool {
+		return p1.Name < p2.Name
+	})
+
+	want := []model.Package{
+		// System.Runtime of the framework is not in the tree
+		{Name: "Demo.Core", Version: "2.1.3", Type: model.PkgTypeNuget, PURL: "pkg:nuget/Demo.Core@2.1.3",
+			Supplier: "Demo Inc.", Copyright: "Copyright (c) Demo Inc. 2023",
+			SourceLocation: filepath.Join(root, "Demo.Core.dll"), Dependencies: []string{"pkg:nuget/Demo.Util@1.0.0.0"}},
+		// no version resource, the assembly version is taken
+		{Name: "Demo.Util", Version: "1.0.0.0", Type: model.PkgTypeNuget, PURL: "pkg:nuget/Demo.Util@1.0.0.0",
+			SourceLocation: filepath.Join(root, "Demo.Util.dll")},
+		{Name: "zlib", Version: "1.2.13.0", Type: model.PkgTypeGeneric, PURL: "pkg:generic/zlib@1.2.13.0",
+			Copyright: "(C) 1995-2022 Jean-loup Gailly & Mark Adler", SourceLocation: filepath.Join(root, "zlib1.dll")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Collect() got %d packages %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !model.PackageEqual(&got[i], &want[i]) || got[i].Type != want[i].Type || got[i].PURL != want[i].PURL ||
+			got[i].SourceLocation != want[i].SourceLocation || got[i].Supplier != want[i].Supplier ||
+			got[i].Copyright != want[i].Copyright {
+			t.Errorf("Collect() got %+v, want %+v", got[i], want[i])
+		}
+		if !slices.Equal(got[i].Dependencies, want[i].Dependencies) {
+			t.Errorf("Collect() got dependencies %v of %s, want %v", got[i].Dependencies, got[i].Name, want[i].Dependencies)
+		}
+	}
+}
+
+func TestReadAssembly(t *testing.T) {
+	bin, err := NewPEParser().parseBinary("test_material/dist/Demo.Core.dll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	asm := bin.assembly
+	if asm == nil || asm.name != "Demo.Core" || asm.version != "2.1.0.0" || asm.publicKeyToken != "714ab3ca970461da" {
+		t.Fatalf("readAssembly() got %+v", asm)
+	}
+	want := []assemblyRef{
+		{name: "System.Runtime", version: "7.0.0.0", publicKeyToken: "b03f5f7f11d50a3a"},
+		{name: "Demo.Util", version: "1.0.0.0", publicKeyToken: "473c444ebb4661a5"},
+	}
+	if !slices.Equal(asm.references, want) {
+		t.Errorf("readAssembly() got references %+v, want %+v", asm.references, want)
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := map[string]string{
+		"1, 2, 13, 0":                         "1.2.13.0",
+		"1,2,13,0":                            "1.2.13.0",
+		"2.1.3+9f1c2ab":                       "2.1.3",
+		"v8.0.0-preview.1.2":                  "8.0.0-preview.1.2",
+		"10.0.19041.1 (WinBuild.160101.0800)": "10.0.19041.1",
+		"unknown":                             "",
+	}
+	for value, want := range tests {
+		if got := normalizeVersion(value); got != want {
+			t.Errorf("normalizeVersion(%q) got %s, want %s", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// versionRe matches the leading version of a version resource value, e.g. 1.2.13 of "1, 2, 13" or 2.1.3 of "2.1.3+9f1c2ab"
+var versionRe = regexp.MustCompile(`^v?(\d+(?:\s*[.,]\s*\d+)*(?:-[\w.]+)?)`)
+
+func newPackage(pkgType model.PkgType, name, version string, path string) *model.Package {
+	return &model.Package{
+		Name:           name,
+		Version:        version,
+		Type:           pkgType,
+		PURL:           packageURL(pkgType, name, version),
+		SourceLocation: path,
+	}
+}
+
+func packageURL(pkgType model.PkgType, name, version string) string {
+	return packageurl.NewPackageURL(
+		pkgType,
+		"",
+		name,
+		version,
+		nil,
+		"",
+	).ToString()
+}
+
+// normalizeVersion returns the version of a version resource value without the separators
+// written by the resource compilers and the build metadata
+func normalizeVersion(value string) string {
+	m := versionRe.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return ""
+	}
+	return strings.Join(strings.FieldsFunc(m[1], func(r rune) bool {
+		return r == ',' || r == '.' || r == ' '
+	}), ".")
+}
@@ -0,0 +1,407 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"debug/pe"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// the CLI metadata, ref ECMA-335 partition II
+
+const (
+	metadataSignature = 0x424A5342
+	// assemblyRefFlagPublicKey marks the full public key instead of the token in an AssemblyRef
+	assemblyRefFlagPublicKey = 0x0001
+	// heap size flags of the tables stream
+	heapStringsWide = 0x01
+	heapGUIDWide    = 0x02
+	heapBlobWide    = 0x04
+	heapExtraData   = 0x40
+)
+
+// the metadata tables
+const (
+	tModule                 = 0x00
+	tTypeRef                = 0x01
+	tTypeDef                = 0x02
+	tFieldPtr               = 0x03
+	tField                  = 0x04
+	tMethodPtr              = 0x05
+	tMethodDef              = 0x06
+	tParamPtr               = 0x07
+	tParam                  = 0x08
+	tInterfaceImpl          = 0x09
+	tMemberRef              = 0x0A
+	tConstant               = 0x0B
+	tCustomAttribute        = 0x0C
+	tFieldMarshal           = 0x0D
+	tDeclSecurity           = 0x0E
+	tClassLayout            = 0x0F
+	tFieldLayout            = 0x10
+	tStandAloneSig          = 0x11
+	tEventMap               = 0x12
+	tEventPtr               = 0x13
+	tEvent                  = 0x14
+	tPropertyMap            = 0x15
+	tPropertyPtr            = 0x16
+	tProperty               = 0x17
+	tMethodSemantics        = 0x18
+	tMethodImpl             = 0x19
+	tModuleRef              = 0x1A
+	tTypeSpec               = 0x1B
+	tImplMap                = 0x1C
+	tFieldRVA               = 0x1D
+	tEncLog                 = 0x1E
+	tEncMap                 = 0x1F
+	tAssembly               = 0x20
+	tAssemblyProcessor      = 0x21
+	tAssemblyOS             = 0x22
+	tAssemblyRef            = 0x23
+	tFile                   = 0x26
+	tExportedType           = 0x27
+	tManifestResource       = 0x28
+	tGenericParam           = 0x2A
+	tMethodSpec             = 0x2B
+	tGenericParamConstraint = 0x2C
+)
+
+const (
+	colU16 = iota
+	colU32
+	colString
+	colGUID
+	colBlob
+	colTable
+	colCoded
+)
+
+// column is a column of a metadata table, the size of an index column depends on the heap sizes and the row counts
+type column struct {
+	kind    int
+	tables  []int
+	tagBits uint
+}
+
+var (
+	u16  = column{kind: colU16}
+	u32  = column{kind: colU32}
+	str  = column{kind: colString}
+	guid = column{kind: colGUID}
+	blob = column{kind: colBlob}
+
+	typeDefOrRef       = coded(2, tTypeDef, tTypeRef, tTypeSpec)
+	hasConstant        = coded(2, tField, tParam, tProperty)
+	hasCustomAttribute = coded(5, tMethodDef, tField, tTypeRef, tTypeDef, tParam, tInterfaceImpl, tMemberRef, tModule,
+		tDeclSecurity, tProperty, tEvent, tStandAloneSig, tModuleRef, tTypeSpec, tAssembly, tAssemblyRef, tFile,
+		tExportedType, tManifestResource, tGenericParam, tGenericParamConstraint, tMethodSpec)
+	hasFieldMarshal     = coded(1, tField, tParam)
+	hasDeclSecurity     = coded(2, tTypeDef, tMethodDef, tAssembly)
+	memberRefParent     = coded(3, tTypeDef, tTypeRef, tModuleRef, tMethodDef, tTypeSpec)
+	hasSemantics        = coded(1, tEvent, tProperty)
+	methodDefOrRef      = coded(1, tMethodDef, tMemberRef)
+	memberForwarded     = coded(1, tField, tMethodDef)
+	implementation      = coded(2, tFile, tAssemblyRef, tExportedType)
+	customAttributeType = coded(3, tMethodDef, tMemberRef)
+	resolutionScope     = coded(2, tModule, tModuleRef, tAssemblyRef, tTypeRef)
+	typeOrMethodDef     = coded(1, tTypeDef, tMethodDef)
+
+	// tableSchemas are the columns of the tables up to AssemblyRef, the tables after it are not read
+	tableSchemas = [][]column{
+		tModule:            {u16, str, guid, guid, guid},
+		tTypeRef:           {resolutionScope, str, str},
+		tTypeDef:           {u32, str, str, typeDefOrRef, index(tField), index(tMethodDef)},
+		tFieldPtr:          {index(tField)},
+		tField:             {u16, str, blob},
+		tMethodPtr:         {index(tMethodDef)},
+		tMethodDef:         {u32, u16, u16, str, blob, index(tParam)},
+		tParamPtr:          {index(tParam)},
+		tParam:             {u16, u16, str},
+		tInterfaceImpl:     {index(tTypeDef), typeDefOrRef},
+		tMemberRef:         {memberRefParent, str, blob},
+		tConstant:          {u16, hasConstant, blob},
+		tCustomAttribute:   {hasCustomAttribute, customAttributeType, blob},
+		tFieldMarshal:      {hasFieldMarshal, blob},
+		tDeclSecurity:      {u16, hasDeclSecurity, blob},
+		tClassLayout:       {u16, u32, index(tTypeDef)},
+		tFieldLayout:       {u32, index(tField)},
+		tStandAloneSig:     {blob},
+		tEventMap:          {index(tTypeDef), index(tEvent)},
+		tEventPtr:          {index(tEvent)},
+		tEvent:             {u16, str, typeDefOrRef},
+		tPropertyMap:       {index(tTypeDef), index(tProperty)},
+		tPropertyPtr:       {index(tProperty)},
+		tProperty:          {u16, str, blob},
+		tMethodSemantics:   {u16, index(tMethodDef), hasSemantics},
+		tMethodImpl:        {index(tTypeDef), methodDefOrRef, methodDefOrRef},
+		tModuleRef:         {str},
+		tTypeSpec:          {blob},
+		tImplMap:           {u16, memberForwarded, str, index(tModuleRef)},
+		tFieldRVA:          {u32, index(tField)},
+		tEncLog:            {u32, u32},
+		tEncMap:            {u32},
+		tAssembly:          {u32, u16, u16, u16, u16, u32, blob, str, str},
+		tAssemblyProcessor: {u32},
+		tAssemblyOS:        {u32, u32, u32},
+		tAssemblyRef:       {u16, u16, u16, u16, u32, blob, str, str, blob},
+	}
+
+	errNoMetadata = errors.New("invalid cli metadata")
+)
+
+func coded(tagBits uint, tables ...int) column {
+	return column{kind: colCoded, tables: tables, tagBits: tagBits}
+}
+
+func index(table int) column {
+	return column{kind: colTable, tables: []int{table}}
+}
+
+// assembly is the identity of a managed assembly and the assemblies it references
+type assembly struct {
+	name           string
+	version        string
+	publicKeyToken string
+	references     []assemblyRef
+}
+
+type assemblyRef struct {
+	name           string
+	version        string
+	publicKeyToken string
+}
+
+// metadata is the tables stream and the heaps of the CLI metadata
+type metadata struct {
+	heapSizes byte
+	rows      [64]uint32
+	// offsets are the offsets of the tables in the tables stream
+	offsets [tAssemblyRef + 1]int
+	tables  []byte
+	strings []byte
+	blobs   []byte
+}
+
+// readAssembly reads the assembly of the CLI metadata, returns nil if the file is not a managed assembly
+func readAssembly(f *pe.File) (*assembly, error) {
+	dir := dataDirectory(f, pe.IMAGE_DIRECTORY_ENTRY_COM_DESCRIPTOR)
+	if dir.VirtualAddress == 0 || dir.Size == 0 {
+		return nil, nil
+	}
+	// the CLI header locates the metadata at offset 8
+	cli := readRVA(f, dir.VirtualAddress, 16)
+	if cli == nil {
+		return nil, errNoMetadata
+	}
+	m, err := parseMetadata(readRVA(f, binary.LittleEndian.Uint32(cli[8:]), binary.LittleEndian.Uint32(cli[12:])))
+	if err != nil {
+		return nil, err
+	}
+	if m.rows[tAssembly] == 0 {
+		// a module of a multi-module assembly
+		return nil, nil
+	}
+	row := m.row(tAssembly, 0)
+	asm := &assembly{
+		name:           m.string(row[7]),
+		version:        fmt.Sprintf("%d.%d.%d.%d", row[1], row[2], row[3], row[4]),
+		publicKeyToken: publicKeyToken(m.blob(row[6])),
+	}
+	for i := uint32(0); i < m.rows[tAssemblyRef]; i++ {
+		row := m.row(tAssemblyRef, i)
+		token := m.blob(row[5])
+		if row[4]&assemblyRefFlagPublicKey != 0 {
+			token = publicKeyTokenBytes(token)
+		}
+		asm.references = append(asm.references, assemblyRef{
+			name:           m.string(row[6]),
+			version:        fmt.Sprintf("%d.%d.%d.%d", row[0], row[1], row[2], row[3]),
+			publicKeyToken: hex.EncodeToString(token),
+		})
+	}
+	return asm, nil
+}
+
+// parseMetadata parses the metadata root, the stream headers and the header of the tables stream
+func parseMetadata(data []byte) (*metadata, error) {
+	if len(data) < 16 || binary.LittleEndian.Uint32(data) != metadataSignature {
+		return nil, errNoMetadata
+	}
+	offset := 16 + int(binary.LittleEndian.Uint32(data[12:]))
+	if offset+4 > len(data) {
+		return nil, errNoMetadata
+	}
+	count := int(binary.LittleEndian.Uint16(data[offset+2:]))
+	offset += 4
+	m := &metadata{}
+	for i := 0; i < count; i++ {
+		if offset+8 > len(data) {
+			return nil, errNoMetadata
+		}
+		start := int(binary.LittleEndian.Uint32(data[offset:]))
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		end := bytes.IndexByte(data[offset+8:], 0)
+		if end < 0 || start < 0 || size < 0 || start+size > len(data) {
+			return nil, errNoMetadata
+		}
+		name := string(data[offset+8 : offset+8+end])
+		offset = offset + 8 + align4(end+1)
+		switch name {
+		case "#~", "#-":
+			m.tables = data[start : start+size]
+		case "#Strings":
+			m.strings = data[start : start+size]
+		case "#Blob":
+			m.blobs = data[start : start+size]
+		}
+	}
+	if len(m.tables) < 24 {
+		return nil, errNoMetadata
+	}
+	m.heapSizes = m.tables[6]
+	valid := binary.LittleEndian.Uint64(m.tables[8:])
+	offset = 24
+	for i := 0; i < 64; i++ {
+		if valid&(1<<uint(i)) == 0 {
+			continue
+		}
+		if offset+4 > len(m.tables) {
+			return nil, errNoMetadata
+		}
+		m.rows[i] = binary.LittleEndian.Uint32(m.tables[offset:])
+		offset += 4
+	}
+	if m.heapSizes&heapExtraData != 0 {
+		offset += 4
+	}
+	for i := range tableSchemas {
+		m.offsets[i] = offset
+		offset += int(m.rows[i]) * m.rowSize(i)
+	}
+	if offset > len(m.tables) {
+		return nil, errNoMetadata
+	}
+	return m, nil
+}
+
+func (m *metadata) columnSize(c column) int {
+	switch c.kind {
+	case colU16:
+		return 2
+	case colU32:
+		return 4
+	case colString:
+		return m.heapIndexSize(heapStringsWide)
+	case colGUID:
+		return m.heapIndexSize(heapGUIDWide)
+	case colBlob:
+		return m.heapIndexSize(heapBlobWide)
+	}
+	// an index is wide if the rows do not fit in the bits left by the tag
+	var maxRows uint32
+	for _, table := range c.tables {
+		if m.rows[table] > maxRows {
+			maxRows = m.rows[table]
+		}
+	}
+	if maxRows < 1<<(16-c.tagBits) {
+		return 2
+	}
+	return 4
+}
+
+func (m *metadata) heapIndexSize(flag byte) int {
+	if m.heapSizes&flag != 0 {
+		return 4
+	}
+	return 2
+}
+
+func (m *metadata) rowSize(table int) int {
+	size := 0
+	for _, c := range tableSchemas[table] {
+		size += m.columnSize(c)
+	}
+	return size
+}
+
+// row reads the columns of the i-th row of the table, the caller checks the row exists
+func (m *metadata) row(table int, i uint32) []uint32 {
+	offset := m.offsets[table] + int(i)*m.rowSize(table)
+	values := make([]uint32, 0, len(tableSchemas[table]))
+	for _, c := range tableSchemas[table] {
+		if m.columnSize(c) == 2 {
+			values = append(values, uint32(binary.LittleEndian.Uint16(m.tables[offset:])))
+			offset += 2
+		} else {
+			values = append(values, binary.LittleEndian.Uint32(m.tables[offset:]))
+			offset += 4
+		}
+	}
+	return values
+}
+
+func (m *metadata) string(index uint32) string {
+	if int(index) >= len(m.strings) {
+		return ""
+	}
+	data := m.strings[index:]
+	if end := bytes.IndexByte(data, 0); end >= 0 {
+		data = data[:end]
+	}
+	return string(data)
+}
+
+// blob reads the blob at the index, which is prefixed by a compressed length
+func (m *metadata) blob(index uint32) []byte {
+	if int(index) >= len(m.blobs) {
+		return nil
+	}
+	data := m.blobs[index:]
+	var length, n int
+	switch {
+	case data[0]&0x80 == 0:
+		length, n = int(data[0]), 1
+	case data[0]&0xC0 == 0x80 && len(data) >= 2:
+		length, n = int(data[0]&0x3F)<<8|int(data[1]), 2
+	case data[0]&0xE0 == 0xC0 && len(data) >= 4:
+		length, n = int(data[0]&0x1F)<<24|int(data[1])<<16|int(data[2])<<8|int(data[3]), 4
+	default:
+		return nil
+	}
+	if n+length > len(data) {
+		return nil
+	}
+	return data[n : n+length]
+}
+
+// publicKeyToken returns the token of a public key in hex, empty for an assembly not strong named
+func publicKeyToken(key []byte) string {
+	if len(key) == 0 {
+		return ""
+	}
+	return hex.EncodeToString(publicKeyTokenBytes(key))
+}
+
+// publicKeyTokenBytes returns the last 8 bytes of the SHA1 hash of the key in reverse order
+func publicKeyTokenBytes(key []byte) []byte {
+	sum := sha1.Sum(key)
+	token := make([]byte, 8)
+	for i := range token {
+		token[i] = sum[len(sum)-1-i]
+	}
+	return token
+}
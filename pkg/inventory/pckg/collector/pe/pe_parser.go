@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"debug/pe"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// peFile is what is found in a PE file
+type peFile struct {
+	// pkg is the package of the file, nil if the file has neither a version resource nor an assembly
+	pkg *model.Package
+	// assembly is the CLI metadata of a managed assembly
+	assembly *assembly
+}
+
+// PEParser is a parser for the dll and exe files, a managed assembly is a nuget package
+// and a native file with a version resource is a generic package
+type PEParser struct{}
+
+// NewPEParser returns a new PEParser
+func NewPEParser() *PEParser {
+	return &PEParser{}
+}
+
+func (p *PEParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{regexp.MustCompile(`(?i)\.(dll|exe)$`)}}
+}
+
+func (p *PEParser) Parse(path string) ([]model.Package, error) {
+	bin, err := p.parseBinary(path)
+	if err != nil || bin.pkg == nil {
+		return nil, err
+	}
+	return []model.Package{*bin.pkg}, nil
+}
+
+func (p *PEParser) parseBinary(path string) (*peFile, error) {
+	f, err := pe.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(f *pe.File) {
+		_ = f.Close()
+	}(f)
+
+	info := readVersionInfo(f)
+	asm, err := readAssembly(f)
+	if err != nil {
+		// the version resource is still taken
+		log.Warnf("read cli metadata error: %s %s", path, err.Error())
+	}
+	bin := &peFile{assembly: asm}
+	if asm != nil && asm.name != "" {
+		// the product version is the informational version of the assembly, which is close to the nuget version
+		version := normalizeVersion(info["ProductVersion"])
+		if version == "" {
+			version = asm.version
+		}
+		bin.pkg = newPackage(model.PkgTypeNuget, asm.name, version, path)
+	} else if info != nil {
+		name := info["ProductName"]
+		if name == "" {
+			name = strings.TrimSuffix(info["OriginalFilename"], filepath.Ext(info["OriginalFilename"]))
+		}
+		version := normalizeVersion(info["ProductVersion"])
+		if version == "" {
+			version = normalizeVersion(info["FileVersion"])
+		}
+		if name != "" {
+			bin.pkg = newPackage(PkgType(), name, version, path)
+		}
+	}
+	if bin.pkg != nil && info != nil {
+		bin.pkg.Supplier = info["CompanyName"]
+		bin.pkg.Copyright = info["LegalCopyright"]
+	}
+	return bin, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pe
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// rtVersion is the resource type of the version resource
+	rtVersion = 16
+	// fixedFileInfoSignature is the signature of VS_FIXEDFILEINFO
+	fixedFileInfoSignature = 0xFEEF04BD
+	// versionInfoID is the resource name of VS_VERSION_INFO
+	versionInfoID = 1
+	// langEnglishUS is the language preferred among the version resources of several languages
+	langEnglishUS = 0x409
+)
+
+// versionBlock is a node of the VS_VERSIONINFO tree, e.g. StringFileInfo, a string table or a string
+type versionBlock struct {
+	key      string
+	text     bool
+	value    []byte
+	children []versionBlock
+}
+
+// readVersionInfo reads the strings of the version resource, e.g. ProductName, FileVersion and CompanyName,
+// the file version of VS_FIXEDFILEINFO is taken when the strings have none, returns nil if the file has no version resource
+func readVersionInfo(f *pe.File) map[string]string {
+	dir := dataDirectory(f, pe.IMAGE_DIRECTORY_ENTRY_RESOURCE)
+	if dir.VirtualAddress == 0 || dir.Size == 0 {
+		return nil
+	}
+	rsrc := readRVA(f, dir.VirtualAddress, dir.Size)
+	if rsrc == nil {
+		return nil
+	}
+	offset, ok := uint32(0), false
+	// the resource tree is type, name and language, the first name and language are taken if none matches
+	for depth, want := range []uint32{rtVersion, versionInfoID, langEnglishUS} {
+		offset, ok = resourceEntry(rsrc, offset, want, depth > 0)
+		if !ok {
+			return nil
+		}
+	}
+	// the leaf is IMAGE_RESOURCE_DATA_ENTRY, its data is located by an rva
+	if int(offset)+8 > len(rsrc) {
+		return nil
+	}
+	data := readRVA(f, binary.LittleEndian.Uint32(rsrc[offset:]), binary.LittleEndian.Uint32(rsrc[offset+4:]))
+	root, _, ok := parseVersionBlock(data)
+	if !ok || root.key != "VS_VERSION_INFO" {
+		return nil
+	}
+
+	info := make(map[string]string)
+	for _, child := range root.children {
+		if child.key != "StringFileInfo" {
+			continue
+		}
+		for _, table := range child.children {
+			for _, s := range table.children {
+				if _, ok := info[s.key]; !ok && s.text {
+					info[s.key] = decodeUTF16(s.value)
+				}
+			}
+		}
+	}
+	if info["FileVersion"] == "" && len(root.value) >= 16 &&
+		binary.LittleEndian.Uint32(root.value) == fixedFileInfoSignature {
+		ms, ls := binary.LittleEndian.Uint32(root.value[8:]), binary.LittleEndian.Uint32(root.value[12:])
+		info["FileVersion"] = fmt.Sprintf("%d.%d.%d.%d", ms>>16, ms&0xffff, ls>>16, ls&0xffff)
+	}
+	return info
+}
+
+// resourceEntry returns the offset of the entry with the id in the resource directory at the offset,
+// the first entry is taken if no entry matches and any entry is allowed
+func resourceEntry(rsrc []byte, offset uint32, id uint32, any bool) (uint32, bool) {
+	if int(offset)+16 > len(rsrc) {
+		return 0, false
+	}
+	count := int(binary.LittleEndian.Uint16(rsrc[offset+12:])) + int(binary.LittleEndian.Uint16(rsrc[offset+14:]))
+	found, ok := uint32(0), false
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 16 + i*8
+		if entry+8 > len(rsrc) {
+			break
+		}
+		name := binary.LittleEndian.Uint32(rsrc[entry:])
+		// the high bit of the offset marks a subdirectory
+		target := binary.LittleEndian.Uint32(rsrc[entry+4:]) &^ 0x80000000
+		if name == id {
+			return target, true
+		}
+		if any && !ok {
+			found, ok = target, true
+		}
+	}
+	return found, ok
+}
+
+// parseVersionBlock parses a block of the version resource,
+// which is wLength, wValueLength, wType, the key, the value and the children, aligned to 4 bytes
+func parseVersionBlock(data []byte) (versionBlock, int, bool) {
+	block := versionBlock{}
+	if len(data) < 6 {
+		return block, 0, false
+	}
+	length := int(binary.LittleEndian.Uint16(data))
+	valueLength := int(binary.LittleEndian.Uint16(data[2:]))
+	block.text = binary.LittleEndian.Uint16(data[4:]) == 1
+	if length < 6 || length > len(data) {
+		return block, 0, false
+	}
+	data = data[:length]
+	offset := 6
+	for offset+2 <= length && (data[offset] != 0 || data[offset+1] != 0) {
+		offset += 2
+	}
+	block.key = decodeUTF16(data[6:offset])
+	offset = align4(offset + 2)
+	// the length of a text value is in words
+	if block.text {
+		valueLength *= 2
+	}
+	if offset+valueLength > length {
+		valueLength = length - offset
+	}
+	if valueLength > 0 {
+		block.value = data[offset : offset+valueLength]
+		offset = align4(offset + valueLength)
+	}
+	for offset+6 <= length {
+		child, n, ok := parseVersionBlock(data[offset:])
+		if !ok {
+			break
+		}
+		block.children = append(block.children, child)
+		offset = align4(offset + n)
+	}
+	return block, length, true
+}
+
+func decodeUTF16(data []byte) string {
+	words := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		words = append(words, binary.LittleEndian.Uint16(data[i:]))
+	}
+	return strings.TrimSpace(strings.TrimRight(string(utf16.Decode(words)), "\x00"))
+}
+
+func align4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// dataDirectory returns the data directory entry of the optional header
+func dataDirectory(f *pe.File, index int) pe.DataDirectory {
+	switch header := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if uint32(index) < header.NumberOfRvaAndSizes {
+			return header.DataDirectory[index]
+		}
+	case *pe.OptionalHeader64:
+		if uint32(index) < header.NumberOfRvaAndSizes {
+			return header.DataDirectory[index]
+		}
+	}
+	return pe.DataDirectory{}
+}
+
+// readRVA reads the data at the relative virtual address from the section containing it
+func readRVA(f *pe.File, rva, size uint32) []byte {
+	for _, section := range f.Sections {
+		if rva < section.VirtualAddress || rva-section.VirtualAddress >= section.Size {
+			continue
+		}
+		data, err := section.Data()
+		if err != nil {
+			return nil
+		}
+		start := uint64(rva - section.VirtualAddress)
+		end := start + uint64(size)
+		if end > uint64(len(data)) {
+			return nil
+		}
+		return data[start:end]
+	}
+	return nil
+}
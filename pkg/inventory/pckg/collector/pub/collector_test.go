@@ -36,8 +36,10 @@ func TestPubCollector_Collect(t *testing.T) {
 				newPackage("cupertino_icons", "0.1.2", ""),
 				newPackage("cupertino_icons", "0.1.3", ""),
 				newPackage("flutter", "0.0.0", ""),
+				newPackage("flutter_lints", "2.1.0", ""),
 				newPackage("flutter_markdown", "0.6.1", ""),
 				newPackage("flutter_syntax_view", "3.2.2", ""),
+				newPackage("flutter_test", "", ""),
 				newPackage("flutter_web_plugins", "0.0.0", ""),
 				newPackage("kittens", "", ""),
 				newPackage("scoped_model", "1.1.0", ""),
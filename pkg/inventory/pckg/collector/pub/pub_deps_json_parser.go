@@ -32,6 +32,10 @@ func (p *PubDepsJSONParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"pub-deps.json"}}
 }
 
+func (p *PubDepsJSONParser) ProvidesGraph() bool {
+	return true
+}
+
 type pubDepsJson struct {
 	Root     string
 	Packages []pubDepsPkg
@@ -62,19 +66,18 @@ func (p *PubDepsJSONParser) Parse(path string) ([]model.Package, error) {
 	}
 	purlMap := make(map[string]string)
 	for i := 0; i < len(jsonFile.Packages); i++ {
-		if jsonFile.Packages[i].Kind != "dev" {
-			name := jsonFile.Packages[i].Name
-			version := jsonFile.Packages[i].Version
-			pkg := newPackage(name, version, path)
-			jsonFile.Packages[i].pkgModel = &pkg
-			purlMap[name] = pkg.PURL
-		}
+		name := jsonFile.Packages[i].Name
+		version := jsonFile.Packages[i].Version
+		pkg := newPackage(name, version, path)
+		pkg.Scope = pubDepsScope(jsonFile.Packages[i].Kind)
+		jsonFile.Packages[i].pkgModel = &pkg
+		purlMap[name] = pkg.PURL
 	}
 	pkgs := make([]model.Package, 0)
 	// assembly package's dependencies
 	for i := 0; i < len(jsonFile.Packages); i++ {
 		pkg := jsonFile.Packages[i].pkgModel
-		if jsonFile.Packages[i].Kind != "dev" && pkg != nil {
+		if pkg != nil {
 			deps := make([]string, 0)
 			for _, depName := range jsonFile.Packages[i].Dependencies {
 				if purl, found := purlMap[depName]; found && purl != "" {
@@ -86,6 +89,20 @@ func (p *PubDepsJSONParser) Parse(path string) ([]model.Package, error) {
 			pkgs = append(pkgs, *pkg)
 		}
 	}
+	// transitive packages take the scope of the direct dependencies requiring them
+	collector.PropagateScope(pkgs)
 	pkgs = collector.SortPackage(pkgs)
 	return pkgs, nil
 }
+
+// pubDepsScope returns the scope of a package by its kind: root direct dev transitive
+func pubDepsScope(kind string) model.Scope {
+	switch kind {
+	case "direct":
+		return model.ScopeRuntime
+	case "dev":
+		return model.ScopeDev
+	default:
+		return ""
+	}
+}
@@ -59,9 +59,23 @@ func (p *PubSpecLockParser) Parse(path string) ([]model.Package, error) {
 	pkgs := make([]model.Package, 0)
 
 	for name, pkg := range lockFile.Packages {
-		pkg := newPackage(name, getVersion(pkg.Version), path)
-		pkgs = append(pkgs, pkg)
+		p := newPackage(name, getVersion(pkg.Version), path)
+		p.Scope = pubLockScope(pkg.Dependency)
+		pkgs = append(pkgs, p)
 	}
 	pkgs = collector.SortPackage(pkgs)
 	return pkgs, nil
 }
+
+// pubLockScope returns the scope of a package by its dependency type,
+// the scope of transitive packages is unknown as pubspec.lock does not record the dependency relationship
+func pubLockScope(dependency string) model.Scope {
+	switch dependency {
+	case "direct main", "direct overridden":
+		return model.ScopeRuntime
+	case "direct dev":
+		return model.ScopeDev
+	default:
+		return ""
+	}
+}
@@ -31,19 +31,19 @@ func TestPubSpecLockParser_Parse(t *testing.T) {
 			"normal",
 			args{path: "test_material/pubspec.lock"},
 			[]model.Package{
-				newPackage("collection", "1.15.0", ""),
-				newPackage("cupertino_icons", "0.1.3", ""),
-				newPackage("flutter", "0.0.0", ""),
-				newPackage("flutter_markdown", "0.6.1", ""),
-				newPackage("flutter_syntax_view", "3.2.2", ""),
-				newPackage("flutter_web_plugins", "0.0.0", ""),
-				newPackage("scoped_model", "1.1.0", ""),
-				newPackage("shared_preferences", "2.0.5", ""),
-				newPackage("shared_preferences_linux", "2.0.0", ""),
-				newPackage("shared_preferences_macos", "2.0.0", ""),
-				newPackage("shared_preferences_platform_interface", "2.0.0", ""),
-				newPackage("shared_preferences_web", "2.0.0", ""),
-				newPackage("shared_preferences_windows", "2.0.0", ""),
+				scopedPackage("collection", "1.15.0", ""),
+				scopedPackage("cupertino_icons", "0.1.3", model.ScopeRuntime),
+				scopedPackage("flutter", "0.0.0", model.ScopeRuntime),
+				scopedPackage("flutter_markdown", "0.6.1", model.ScopeRuntime),
+				scopedPackage("flutter_syntax_view", "3.2.2", model.ScopeRuntime),
+				scopedPackage("flutter_web_plugins", "0.0.0", ""),
+				scopedPackage("scoped_model", "1.1.0", model.ScopeRuntime),
+				scopedPackage("shared_preferences", "2.0.5", model.ScopeRuntime),
+				scopedPackage("shared_preferences_linux", "2.0.0", ""),
+				scopedPackage("shared_preferences_macos", "2.0.0", ""),
+				scopedPackage("shared_preferences_platform_interface", "2.0.0", ""),
+				scopedPackage("shared_preferences_web", "2.0.0", ""),
+				scopedPackage("shared_preferences_windows", "2.0.0", ""),
 			},
 			false,
 		},
@@ -57,7 +57,7 @@ func TestPubSpecLockParser_Parse(t *testing.T) {
 				return
 			}
 			if !util.SliceEqual(got, tt.want, func(p1 model.Package, p2 model.Package) bool {
-				return model.PackageEqual(&p1, &p2)
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 			}) {
 				t.Errorf("Parse() got = %v, want %v", got, tt.want)
 			}
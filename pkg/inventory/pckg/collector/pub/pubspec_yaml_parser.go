@@ -33,9 +33,10 @@ func (p *PubSpecYAMLParser) Matcher() collector.FileMatcher {
 }
 
 type pubSpecYaml struct {
-	Name         string
-	Version      string
-	Dependencies map[string]interface{}
+	Name            string
+	Version         string
+	Dependencies    map[string]interface{}
+	DevDependencies map[string]interface{} `yaml:"dev_dependencies"`
 }
 
 func (p *PubSpecYAMLParser) Parse(path string) ([]model.Package, error) {
@@ -53,9 +54,16 @@ func (p *PubSpecYAMLParser) Parse(path string) ([]model.Package, error) {
 	if err != nil {
 		return nil, err
 	}
-	pkgs := make([]model.Package, 0)
+	pkgs := parsePubDependencies(yamlFile.Dependencies, model.ScopeRuntime, path)
+	pkgs = append(pkgs, parsePubDependencies(yamlFile.DevDependencies, model.ScopeDev, path)...)
+	pkgs = collector.SortPackage(pkgs)
+	return pkgs, nil
+}
 
-	for name, obj := range yamlFile.Dependencies {
+// parsePubDependencies parses the dependencies map of pubspec.yaml
+func parsePubDependencies(deps map[string]interface{}, scope model.Scope, path string) []model.Package {
+	pkgs := make([]model.Package, 0, len(deps))
+	for name, obj := range deps {
 		var version string
 		switch dep := obj.(type) {
 		case string:
@@ -68,8 +76,8 @@ func (p *PubSpecYAMLParser) Parse(path string) ([]model.Package, error) {
 			}
 		}
 		pkg := newPackage(name, getVersion(version), path)
+		pkg.Scope = scope
 		pkgs = append(pkgs, pkg)
 	}
-	pkgs = collector.SortPackage(pkgs)
-	return pkgs, nil
+	return pkgs
 }
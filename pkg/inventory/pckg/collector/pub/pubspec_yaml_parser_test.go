@@ -31,14 +31,16 @@ func TestPubSpecYAMLParser_Parse(t *testing.T) {
 			"normal",
 			args{path: "test_material/pubspec.yaml"},
 			[]model.Package{
-				newPackage("cupertino_icons", "0.1.2", ""),
-				newPackage("flutter", "", ""),
-				newPackage("kittens", "", ""),
-				newPackage("scoped_model", "1.2.3", ""),
-				newPackage("shared_preferences", "2.0.5", ""),
-				newPackage("testlib", "", ""),
-				newPackage("transmogrify", "1.4.0", ""),
-				newPackage("url_launcher", "6.0.3", ""),
+				scopedPackage("cupertino_icons", "0.1.2", model.ScopeRuntime),
+				scopedPackage("flutter", "", model.ScopeRuntime),
+				scopedPackage("flutter_lints", "2.1.0", model.ScopeDev),
+				scopedPackage("flutter_test", "", model.ScopeDev),
+				scopedPackage("kittens", "", model.ScopeRuntime),
+				scopedPackage("scoped_model", "1.2.3", model.ScopeRuntime),
+				scopedPackage("shared_preferences", "2.0.5", model.ScopeRuntime),
+				scopedPackage("testlib", "", model.ScopeRuntime),
+				scopedPackage("transmogrify", "1.4.0", model.ScopeRuntime),
+				scopedPackage("url_launcher", "6.0.3", model.ScopeRuntime),
 			},
 			false,
 		},
@@ -52,7 +54,7 @@ func TestPubSpecYAMLParser_Parse(t *testing.T) {
 				return
 			}
 			if !util.SliceEqual(got, tt.want, func(p1 model.Package, p2 model.Package) bool {
-				return model.PackageEqual(&p1, &p2)
+				return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope
 			}) {
 				t.Errorf("Parse() got = %v, want %v", got, tt.want)
 			}
@@ -66,3 +68,9 @@ func BenchmarkPubSpecYAMLParser(b *testing.B) {
 		_, _ = g.Parse("test_material/pubspec.yaml")
 	}
 }
+
+func scopedPackage(name, version string, scope model.Scope) model.Package {
+	pkg := newPackage(name, version, "")
+	pkg.Scope = scope
+	return pkg
+}
@@ -22,6 +22,9 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the packages installed into site-packages
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewRequirementsParser())
 	parsers = append(parsers, NewPipLockParser())
@@ -29,7 +32,13 @@ func init() {
 	parsers = append(parsers, NewSetUpParser())
 	parsers = append(parsers, NewPkgMetadataParser())
 	parsers = append(parsers, NewPipenvGraphParser())
+	parsers = append(parsers, NewPyProjectParser())
+	parsers = append(parsers, NewSetupCfgParser())
+	parsers = append(parsers, NewPipfileParser())
+	parsers = append(parsers, NewUvLockParser())
+	parsers = append(parsers, NewPdmLockParser())
 
+	installedParsers = append(installedParsers, NewSitePackagesParser())
 }
 
 func NewCollector() *Collector {
@@ -39,3 +48,12 @@ func NewCollector() *Collector {
 	c.Parsers = parsers
 	return &c
 }
+
+// NewInstalledCollector returns the collector of the packages installed into site-packages
+func NewInstalledCollector() *Collector {
+	c := Collector{}
+	c.Name = "pypi"
+	c.PurlType = packageurl.TypePyPi
+	c.Parsers = installedParsers
+	return &c
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// lockGraph is the dependency graph of the packages of a lockfile, the scopes of the packages
+// are propagated from the direct dependencies of the project to the transitive dependencies
+type lockGraph struct {
+	// nodes are the packages in order
+	nodes []*lockNode
+	// versions are the nodes by the normalized name, a lockfile may resolve several versions of a package
+	versions map[string][]*lockNode
+	// roots are the direct dependencies of the project with the scopes
+	roots map[*lockNode]model.Scope
+}
+
+type lockNode struct {
+	name    string
+	version string
+	deps    []*lockNode
+	scope   model.Scope
+	// explicitScope is the scope given by the lockfile instead of resolved from the graph
+	explicitScope model.Scope
+}
+
+func newLockGraph() *lockGraph {
+	return &lockGraph{versions: make(map[string][]*lockNode), roots: make(map[*lockNode]model.Scope)}
+}
+
+func (g *lockGraph) addPackage(name, version string) *lockNode {
+	node := &lockNode{name: name, version: version}
+	g.nodes = append(g.nodes, node)
+	key := normalizeName(name)
+	g.versions[key] = append(g.versions[key], node)
+	return node
+}
+
+// find returns the node of the package, the version is needed only if several versions are resolved
+func (g *lockGraph) find(name, version string) *lockNode {
+	nodes := g.versions[normalizeName(name)]
+	if len(nodes) == 1 || version == "" && len(nodes) > 0 {
+		return nodes[0]
+	}
+	for _, node := range nodes {
+		if node.version == version {
+			return node
+		}
+	}
+	return nil
+}
+
+func (g *lockGraph) addEdge(name, version, depName, depVersion string) {
+	from, to := g.find(name, version), g.find(depName, depVersion)
+	if from == nil || to == nil || from == to {
+		return
+	}
+	for _, dep := range from.deps {
+		if dep == to {
+			return
+		}
+	}
+	from.deps = append(from.deps, to)
+}
+
+func (g *lockGraph) addRoot(name, version string, scope model.Scope) {
+	if node := g.find(name, version); node != nil {
+		g.roots[node] = model.MergeScope(g.roots[node], scope)
+	}
+}
+
+// resolveScopes propagates the scopes of the roots to the reachable packages
+func (g *lockGraph) resolveScopes() {
+	var visit func(node *lockNode, scope model.Scope)
+	visit = func(node *lockNode, scope model.Scope) {
+		if scope == "" {
+			return
+		}
+		if merged := model.MergeScope(node.scope, scope); merged != node.scope {
+			node.scope = merged
+			for _, dep := range node.deps {
+				visit(dep, merged)
+			}
+		}
+	}
+	for _, node := range g.nodes {
+		if scope, ok := g.roots[node]; ok {
+			visit(node, scope)
+		}
+	}
+}
+
+// packages returns the packages of the graph
+func (g *lockGraph) packages(sourcePath string) []model.Package {
+	g.resolveScopes()
+	pkgs := make([]model.Package, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		if node.name == "" {
+			continue
+		}
+		pkg := newPackage(node.name, node.version, sourcePath)
+		pkg.Scope = node.scope
+		if node.explicitScope != "" {
+			pkg.Scope = node.explicitScope
+		}
+		for _, dep := range node.deps {
+			pkg.Dependencies = append(pkg.Dependencies, packageURL(dep.name, dep.version))
+		}
+		pkgs = append(pkgs, *pkg)
+	}
+	return collector.SortPackage(pkgs)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// content of pdm.lock
+type pdmLock struct {
+	Packages []struct {
+		Name         string   `toml:"name"`
+		Version      string   `toml:"version"`
+		Groups       []string `toml:"groups"`
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"package"`
+}
+
+// PdmLockParser is a parser for pdm.lock file, the dependency graph is taken
+// and the scopes are taken from the groups of the packages
+// see: https://pdm-project.org/latest/usage/lockfile/
+type PdmLockParser struct{}
+
+func NewPdmLockParser() *PdmLockParser {
+	return &PdmLockParser{}
+}
+
+func (m *PdmLockParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"pdm.lock"}}
+}
+
+func (m *PdmLockParser) ProvidesGraph() bool {
+	return true
+}
+
+func (m *PdmLockParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("python PdmLockParser file path: %s", filePath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var lock pdmLock
+	if err = toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse pdm.lock file: %w", err)
+	}
+	return parsePdmLock(&lock, filePath), nil
+}
+
+func parsePdmLock(lock *pdmLock, sourcePath string) []model.Package {
+	graph := newLockGraph()
+	for _, p := range lock.Packages {
+		node := graph.find(p.Name, p.Version)
+		if node == nil || node.version != p.Version {
+			node = graph.addPackage(p.Name, p.Version)
+		}
+		// a package with extras is locked again, the groups of both are merged
+		for _, group := range p.Groups {
+			scope := model.ScopeRuntime
+			if group != "default" {
+				scope = groupScope(group, model.ScopeDev)
+			}
+			node.explicitScope = model.MergeScope(node.explicitScope, scope)
+		}
+	}
+	for _, p := range lock.Packages {
+		for _, requirement := range p.Dependencies {
+			name, version := parseRequirement(requirement)
+			graph.addEdge(p.Name, p.Version, name, version)
+		}
+	}
+	return graph.packages(sourcePath)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"testing"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestPdmLockParser_Parse(t *testing.T) {
+	got, err := NewPdmLockParser().Parse("test_material/pdmlock/pdm.lock")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	assertPackages(t, got, []model.Package{
+		{Name: "certifi", Version: "2024.2.2", Scope: model.ScopeRuntime},
+		{Name: "iniconfig", Version: "2.0.0", Scope: model.ScopeTest},
+		{Name: "pytest", Version: "8.1.1", Scope: model.ScopeTest, Dependencies: []string{"pkg:pypi/iniconfig@2.0.0"}},
+		{Name: "requests", Version: "2.31.0", Scope: model.ScopeRuntime, Dependencies: []string{
+			"pkg:pypi/certifi@2024.2.2",
+			"pkg:pypi/urllib3@2.2.1",
+		}},
+		{Name: "urllib3", Version: "2.2.1", Scope: model.ScopeRuntime},
+	})
+}
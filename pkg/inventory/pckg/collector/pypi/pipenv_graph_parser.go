@@ -38,6 +38,10 @@ func (m *PipenvGraphParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"pipenv-graph", "pipenv-graph.txt"}}
 }
 
+func (m *PipenvGraphParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *PipenvGraphParser) Parse(filePath string) ([]model.Package, error) {
 	log.Infof("python PipenvGraphParser file path: %s", filePath)
 	f, err := os.Open(filePath)
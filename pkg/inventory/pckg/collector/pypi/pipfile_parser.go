@@ -0,0 +1,73 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// content of Pipfile
+type pipfile struct {
+	Packages    map[string]interface{} `toml:"packages"`
+	DevPackages map[string]interface{} `toml:"dev-packages"`
+}
+
+// PipfileParser is a parser for Pipfile file, the packages are the runtime dependencies
+// and the dev-packages are the development dependencies
+// see: https://pipenv.pypa.io/en/latest/pipfile.html
+type PipfileParser struct{}
+
+func NewPipfileParser() *PipfileParser {
+	return &PipfileParser{}
+}
+
+func (m *PipfileParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"Pipfile"}}
+}
+
+func (m *PipfileParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("python PipfileParser file path: %s", filePath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var pf pipfile
+	if err = toml.Unmarshal(content, &pf); err != nil {
+		return nil, fmt.Errorf("failed to parse Pipfile file: %w", err)
+	}
+	deps := newDeclaredDependencies(filePath)
+	addPipfilePackages(deps, pf.Packages, model.ScopeRuntime)
+	addPipfilePackages(deps, pf.DevPackages, model.ScopeDev)
+	return deps.packages("", "", nil), nil
+}
+
+// addPipfilePackages adds the packages of Pipfile, a package is a version specifier or a table
+func addPipfilePackages(deps *declaredDependencies, packages map[string]interface{}, scope model.Scope) {
+	for _, name := range sortedKeys(packages) {
+		specifier := ""
+		switch v := packages[name].(type) {
+		case string:
+			specifier = v
+		case map[string]interface{}:
+			specifier, _ = v["version"].(string)
+		case *toml.Tree:
+			specifier, _ = v.Get("version").(string)
+		}
+		deps.add(name, specifierVersion(specifier), scope)
+	}
+}
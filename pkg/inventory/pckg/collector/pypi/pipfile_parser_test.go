@@ -0,0 +1,30 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"testing"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestPipfileParser_Parse(t *testing.T) {
+	got, err := NewPipfileParser().Parse("test_material/pipfile/Pipfile")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	assertPackages(t, got, []model.Package{
+		{Name: "black", Version: "24.3.0", Scope: model.ScopeDev},
+		{Name: "flask", Version: "2.3", Scope: model.ScopeRuntime},
+		{Name: "gunicorn", Scope: model.ScopeRuntime},
+		{Name: "requests", Version: "2.31.0", Scope: model.ScopeRuntime},
+	})
+}
@@ -28,6 +28,9 @@ type PoetryLockData struct {
 	PoetryPackages []struct {
 		Name         string                 `toml:"name"`
 		Version      string                 `toml:"version"`
+		Category     string                 `toml:"category"`
+		Groups       []string               `toml:"groups"`
+		Optional     bool                   `toml:"optional"`
 		Dependencies map[string]interface{} `toml:"dependencies"`
 	} `toml:"package"`
 }
@@ -44,6 +47,10 @@ func (m *PoetryLockParser) Matcher() collector.FileMatcher {
 	return &collector.FileNameMatcher{Names: []string{"poetry.lock"}}
 }
 
+func (m *PoetryLockParser) ProvidesGraph() bool {
+	return true
+}
+
 func (m *PoetryLockParser) Parse(filePath string) ([]model.Package, error) {
 	log.Infof("python PoetryLockParser file path: %s", filePath)
 	f, err := os.Open(filePath)
@@ -81,6 +88,10 @@ func parsePoetryLockFile(reader io.Reader, sourcePath string) ([]model.Package,
 			continue
 		}
 		pkg := newPackage(packageName, packageVersion, sourcePath)
+		// poetry 1.x writes category, poetry 2.x writes groups
+		for _, group := range append(poetryPackage.Groups, poetryPackage.Category) {
+			pkg.Scope = model.MergeScope(pkg.Scope, poetryGroupScope(group, poetryPackage.Optional))
+		}
 
 		if poetryPackage.Dependencies != nil {
 			pkg.Dependencies = parseDependenciesList(poetryPackage.Dependencies)
@@ -93,6 +104,24 @@ func parsePoetryLockFile(reader io.Reader, sourcePath string) ([]model.Package,
 	return pkgs, nil
 }
 
+// poetryGroupScope maps a poetry dependency group to the package scope, groups other than main are development groups
+// see: https://python-poetry.org/docs/managing-dependencies/#dependency-groups
+func poetryGroupScope(group string, optional bool) model.Scope {
+	group = strings.ToLower(strings.TrimSpace(group))
+	switch {
+	case group == "":
+		return ""
+	case group == "main" && optional:
+		return model.ScopeOptional
+	case group == "main":
+		return model.ScopeRuntime
+	case strings.Contains(group, "test"):
+		return model.ScopeTest
+	default:
+		return model.ScopeDev
+	}
+}
+
 func parseDependenciesList(dependenciesList map[string]interface{}) []string {
 	depList := make([]string, 0)
 	for name := range dependenciesList {
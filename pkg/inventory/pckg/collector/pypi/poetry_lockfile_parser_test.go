@@ -391,3 +391,37 @@ func BenchmarkPoetrylockFileParser(b *testing.B) {
 		_, _ = parse.Parse("test_material/poetrylock/poetry.lock")
 	}
 }
+
+func TestParsePoetryLockFileScopes(t *testing.T) {
+	tests := []struct {
+		path string
+		want map[string]model.Scope
+	}{
+		{
+			path: "test_material/poetrylock/poetry.lock",
+			want: map[string]model.Scope{"atomicwrites": model.ScopeDev, "certifi": model.ScopeRuntime},
+		},
+		{
+			path: "test_material/poetrylock/groups/poetry.lock",
+			want: map[string]model.Scope{
+				"certifi": model.ScopeRuntime,
+				"pytest":  model.ScopeTest,
+				"ruff":    model.ScopeDev,
+				"ujson":   model.ScopeOptional,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			pkgs, err := NewPoetryLockParser().Parse(tt.path)
+			assert.NoError(t, err)
+			got := make(map[string]model.Scope)
+			for _, pkg := range pkgs {
+				if _, ok := tt.want[pkg.Name]; ok {
+					got[pkg.Name] = pkg.Scope
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
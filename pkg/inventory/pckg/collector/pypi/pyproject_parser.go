@@ -0,0 +1,266 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// content of pyproject.toml
+type pyProject struct {
+	Project struct {
+		Name                 string              `toml:"name"`
+		Version              string              `toml:"version"`
+		License              interface{}         `toml:"license"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// DependencyGroups are the PEP 735 dependency groups, an item is a requirement or an include-group table
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Name            string                 `toml:"name"`
+			Version         string                 `toml:"version"`
+			License         string                 `toml:"license"`
+			Dependencies    map[string]interface{} `toml:"dependencies"`
+			DevDependencies map[string]interface{} `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+		Pdm struct {
+			DevDependencies map[string][]string `toml:"dev-dependencies"`
+		} `toml:"pdm"`
+		Uv struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+	} `toml:"tool"`
+}
+
+// PyProjectParser is a parser for pyproject.toml file, the PEP 621 project table, the PEP 735 dependency groups
+// and the dependencies of poetry, pdm and uv are taken
+// see: https://packaging.python.org/en/latest/specifications/pyproject-toml/
+type PyProjectParser struct{}
+
+func NewPyProjectParser() *PyProjectParser {
+	return &PyProjectParser{}
+}
+
+func (m *PyProjectParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"pyproject.toml"}}
+}
+
+func (m *PyProjectParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("python PyProjectParser file path: %s", filePath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var project pyProject
+	if err = toml.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml file: %w", err)
+	}
+	return parsePyProject(&project, filePath), nil
+}
+
+// parsePyProject returns the declared dependencies and the project itself
+func parsePyProject(project *pyProject, sourcePath string) []model.Package {
+	deps := newDeclaredDependencies(sourcePath)
+	deps.addRequirements(project.Project.Dependencies, model.ScopeRuntime)
+	for _, group := range sortedKeys(project.Project.OptionalDependencies) {
+		deps.addRequirements(project.Project.OptionalDependencies[group], groupScope(group, model.ScopeOptional))
+	}
+	for _, group := range sortedKeys(project.DependencyGroups) {
+		deps.addRequirements(dependencyGroupRequirements(project.DependencyGroups, group, 0), groupScope(group, model.ScopeDev))
+	}
+	for _, group := range sortedKeys(project.Tool.Pdm.DevDependencies) {
+		deps.addRequirements(project.Tool.Pdm.DevDependencies[group], groupScope(group, model.ScopeDev))
+	}
+	deps.addRequirements(project.Tool.Uv.DevDependencies, model.ScopeDev)
+
+	poetry := project.Tool.Poetry
+	deps.addPoetryDependencies(poetry.Dependencies, model.ScopeRuntime)
+	deps.addPoetryDependencies(poetry.DevDependencies, model.ScopeDev)
+	groups := make([]string, 0, len(poetry.Group))
+	for group := range poetry.Group {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		deps.addPoetryDependencies(poetry.Group[group].Dependencies, poetryGroupScope(group, false))
+	}
+
+	name, version := project.Project.Name, project.Project.Version
+	licenses := projectLicenses(project.Project.License)
+	if name == "" {
+		name, version = poetry.Name, poetry.Version
+		if poetry.License != "" {
+			licenses = []string{poetry.License}
+		}
+	}
+	return deps.packages(name, version, licenses)
+}
+
+// dependencyGroupRequirements returns the requirements of a PEP 735 dependency group, the included groups are expanded
+func dependencyGroupRequirements(groups map[string][]interface{}, group string, depth int) []string {
+	requirements := make([]string, 0)
+	if depth > len(groups) {
+		// cyclic includes
+		return requirements
+	}
+	for _, item := range groups[group] {
+		switch v := item.(type) {
+		case string:
+			requirements = append(requirements, v)
+		case map[string]interface{}:
+			if include, ok := v["include-group"].(string); ok {
+				requirements = append(requirements, dependencyGroupRequirements(groups, include, depth+1)...)
+			}
+		case *toml.Tree:
+			if include, ok := v.Get("include-group").(string); ok {
+				requirements = append(requirements, dependencyGroupRequirements(groups, include, depth+1)...)
+			}
+		}
+	}
+	return requirements
+}
+
+// projectLicenses returns the license of the project table, either a SPDX expression or a table with the text
+func projectLicenses(value interface{}) []string {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case map[string]interface{}:
+		text, _ = v["text"].(string)
+	case *toml.Tree:
+		text, _ = v.Get("text").(string)
+	}
+	if text = strings.TrimSpace(text); text == "" || strings.Contains(text, "\n") {
+		return nil
+	}
+	return license.NormalizeExpressions([]string{text})
+}
+
+// declaredDependencies collects the direct dependencies of a manifest, a dependency declared by several
+// groups takes the widest scope
+type declaredDependencies struct {
+	sourcePath string
+	pkgs       []model.Package
+	index      map[string]int
+}
+
+func newDeclaredDependencies(sourcePath string) *declaredDependencies {
+	return &declaredDependencies{sourcePath: sourcePath, index: make(map[string]int)}
+}
+
+func (d *declaredDependencies) add(name, version string, scope model.Scope) {
+	if name == "" || normalizeName(name) == "python" {
+		return
+	}
+	key := normalizeName(name)
+	if i, ok := d.index[key]; ok {
+		d.pkgs[i].Scope = model.MergeScope(d.pkgs[i].Scope, scope)
+		if d.pkgs[i].Version == "" && version != "" {
+			d.pkgs[i] = *newPackage(name, version, d.sourcePath)
+			d.pkgs[i].Scope = model.MergeScope(d.pkgs[i].Scope, scope)
+		}
+		return
+	}
+	pkg := newPackage(name, version, d.sourcePath)
+	pkg.Scope = scope
+	d.index[key] = len(d.pkgs)
+	d.pkgs = append(d.pkgs, *pkg)
+}
+
+func (d *declaredDependencies) addRequirements(requirements []string, scope model.Scope) {
+	for _, requirement := range requirements {
+		name, version := parseRequirement(requirement)
+		d.add(name, version, scope)
+	}
+}
+
+// addPoetryDependencies adds the poetry dependencies, a dependency is a version constraint or a table
+// see: https://python-poetry.org/docs/dependency-specification/
+func (d *declaredDependencies) addPoetryDependencies(deps map[string]interface{}, scope model.Scope) {
+	for _, name := range sortedKeys(deps) {
+		constraint := ""
+		optional := false
+		switch v := deps[name].(type) {
+		case string:
+			constraint = v
+		case map[string]interface{}:
+			constraint, _ = v["version"].(string)
+			optional, _ = v["optional"].(bool)
+		case *toml.Tree:
+			constraint, _ = v.Get("version").(string)
+			optional, _ = v.Get("optional").(bool)
+		}
+		depScope := scope
+		if optional && scope == model.ScopeRuntime {
+			depScope = model.ScopeOptional
+		}
+		d.add(name, poetryConstraintVersion(constraint), depScope)
+	}
+}
+
+// packages returns the dependencies and the main package depending on the runtime dependencies
+func (d *declaredDependencies) packages(name, version string, licenses []string) []model.Package {
+	pkgs := d.pkgs
+	if name != "" {
+		mainPkg := newPackage(name, version, d.sourcePath)
+		mainPkg.LicenseDeclared = licenses
+		for _, pkg := range d.pkgs {
+			if pkg.Scope == model.ScopeRuntime {
+				mainPkg.Dependencies = append(mainPkg.Dependencies, pkg.PURL)
+			}
+		}
+		pkgs = append(pkgs, *mainPkg)
+	}
+	if pkgs == nil {
+		pkgs = make([]model.Package, 0)
+	}
+	return collector.SortPackage(pkgs)
+}
+
+// poetryConstraintVersion returns the version of a poetry constraint, e.g. ^2.28 or ~1.2 or >=1.0,<2.0
+func poetryConstraintVersion(constraint string) string {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "*" {
+		return ""
+	}
+	if strings.HasPrefix(constraint, "^") || strings.HasPrefix(constraint, "~") && !strings.HasPrefix(constraint, "~=") {
+		return strings.TrimSpace(strings.TrimLeft(constraint, "^~"))
+	}
+	if version := specifierVersion(constraint); version != "" || strings.ContainsAny(constraint, "<>=!") {
+		return version
+	}
+	return strings.TrimSuffix(constraint, ".*")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// assertPackages checks the name, version, scope, licenses and dependencies of the packages
+func assertPackages(t *testing.T, got, want []model.Package) {
+	t.Helper()
+	if !slices.EqualFunc(got, want, func(p1, p2 model.Package) bool {
+		return model.PackageEqual(&p1, &p2) && p1.Scope == p2.Scope && slices.Equal(p1.Dependencies, p2.Dependencies) &&
+			slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared)
+	}) {
+		t.Errorf("got = %v, want %v", got, want)
+	}
+}
+
+func TestPyProjectParser_Parse(t *testing.T) {
+	tests := []struct {
+		path string
+		want []model.Package
+	}{
+		{
+			path: "test_material/pyproject/pyproject.toml",
+			want: []model.Package{
+				{Name: "billing-service", Version: "2.4.0", LicenseDeclared: []string{"Apache-2.0"}, Dependencies: []string{
+					"pkg:pypi/fastapi@0.110.0",
+					"pkg:pypi/httpx@0.27",
+					"pkg:pypi/pydantic@2.6.4",
+					"pkg:pypi/tomli",
+				}},
+				{Name: "fastapi", Version: "0.110.0", Scope: model.ScopeRuntime},
+				{Name: "httpx", Version: "0.27", Scope: model.ScopeRuntime},
+				{Name: "mypy", Version: "1.9", Scope: model.ScopeDev},
+				{Name: "psycopg", Version: "3.1", Scope: model.ScopeOptional},
+				{Name: "pydantic", Version: "2.6.4", Scope: model.ScopeRuntime},
+				{Name: "pytest-cov", Scope: model.ScopeTest},
+				{Name: "pytest", Version: "8.0", Scope: model.ScopeTest},
+				{Name: "ruff", Version: "0.3.4", Scope: model.ScopeDev},
+				{Name: "tomli", Scope: model.ScopeRuntime},
+			},
+		},
+		{
+			path: "test_material/poetry-pyproject/pyproject.toml",
+			want: []model.Package{
+				{Name: "boto3", Version: "1.34", Scope: model.ScopeRuntime},
+				{Name: "celery", Version: "5.3.6", Scope: model.ScopeRuntime},
+				{Name: "pytest", Scope: model.ScopeTest},
+				{Name: "redis", Version: "5.0", Scope: model.ScopeOptional},
+				{Name: "report-worker", Version: "0.3.1", LicenseDeclared: []string{"MIT"}, Dependencies: []string{
+					"pkg:pypi/boto3@1.34",
+					"pkg:pypi/celery@5.3.6",
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := NewPyProjectParser().Parse(tt.path)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			assertPackages(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		requirement string
+		name        string
+		version     string
+	}{
+		{"requests", "requests", ""},
+		{"requests[socks] == 2.31.0", "requests", "2.31.0"},
+		{"Django>=4.2,<5.0", "Django", "4.2"},
+		{"numpy==1.26.*", "numpy", "1.26"},
+		{"tomli; python_version < '3.11'", "tomli", ""},
+		{"mylib @ git+https://example.com/mylib.git@v1.0", "mylib", ""},
+		{"zope.interface (>=6.0)", "zope.interface", "6.0"},
+	}
+	for _, tt := range tests {
+		name, version := parseRequirement(tt.requirement)
+		if name != tt.name || version != tt.version {
+			t.Errorf("parseRequirement(%q) = %s %s, want %s %s", tt.requirement, name, version, tt.name, tt.version)
+		}
+	}
+}
@@ -84,8 +84,6 @@ func ParseMetadataContent(reader io.Reader, sourcePath string) ([]model.Package,
 	if err != nil {
 		return pkgs, fmt.Errorf("failed to parse parseMetadataContent mapstructure.Decode : %w", err)
 	}
-	licenseValue, _, _ := license.ParseLicenseName(pythonArtifactInfo.License)
-
 	if pythonArtifactInfo.Name == "" {
 		return pkgs, fmt.Errorf("parseMetadataContent pythonArtifactInfo.Name is null !")
 	}
@@ -94,7 +92,7 @@ func ParseMetadataContent(reader io.Reader, sourcePath string) ([]model.Package,
 	pkgVersion := pythonArtifactInfo.Version
 
 	pkg := newPackage(pkgName, pkgVersion, sourcePath)
-	pkg.LicenseConcluded = []string{licenseValue}
+	pkg.LicenseConcluded = license.NormalizeExpressions([]string{pythonArtifactInfo.License})
 	pkgs = append(pkgs, *pkg)
 
 	return pkgs, nil
@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+var (
+	// requirementRe matches a PEP 508 requirement, e.g. requests[socks]>=2.8.1,<3 ; python_version < "3.8"
+	requirementRe = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+	nameSepRe     = regexp.MustCompile(`[-_.]+`)
+)
+
+// parseRequirement parses a PEP 508 requirement, the version is the pinned version,
+// or the lower bound of the version specifiers like the other manifests
+// see: https://peps.python.org/pep-0508/
+func parseRequirement(requirement string) (name, version string) {
+	requirement = strings.Split(requirement, ";")[0]
+	m := requirementRe.FindStringSubmatch(requirement)
+	if m == nil {
+		return "", ""
+	}
+	return m[1], specifierVersion(m[2])
+}
+
+// specifierVersion returns the version of the version specifiers, e.g. ==1.2.* or >=1.2,<2
+func specifierVersion(specifiers string) string {
+	specifiers = strings.Trim(strings.TrimSpace(specifiers), "()")
+	if strings.HasPrefix(specifiers, "@") {
+		// direct reference
+		return ""
+	}
+	lower := ""
+	for _, spec := range strings.Split(specifiers, ",") {
+		spec = strings.TrimSpace(spec)
+		switch {
+		case strings.HasPrefix(spec, "==="):
+			return strings.TrimSpace(spec[3:])
+		case strings.HasPrefix(spec, "=="):
+			return strings.TrimSuffix(strings.TrimSpace(spec[2:]), ".*")
+		case strings.HasPrefix(spec, ">=") || strings.HasPrefix(spec, "~="):
+			lower = strings.TrimSpace(spec[2:])
+		}
+	}
+	return lower
+}
+
+// normalizeName normalizes a package name for comparison
+// see: https://packaging.python.org/en/latest/specifications/name-normalization/
+func normalizeName(name string) string {
+	return nameSepRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// groupScope maps a group of dependencies to the scope, the test and development groups are told by the name
+func groupScope(group string, defaultScope model.Scope) model.Scope {
+	group = strings.ToLower(group)
+	switch {
+	case strings.Contains(group, "test"):
+		return model.ScopeTest
+	case group == "dev" || strings.HasPrefix(group, "dev-") || strings.HasPrefix(group, "dev_") ||
+		strings.Contains(group, "lint") || strings.HasPrefix(group, "doc") || group == "typing":
+		return model.ScopeDev
+	default:
+		return defaultScope
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// SetupCfgParser is a parser for setup.cfg file of setuptools
+// see: https://setuptools.pypa.io/en/latest/userguide/declarative_config.html
+type SetupCfgParser struct{}
+
+func NewSetupCfgParser() *SetupCfgParser {
+	return &SetupCfgParser{}
+}
+
+func (m *SetupCfgParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"setup.cfg"}}
+}
+
+func (m *SetupCfgParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("python SetupCfgParser file path: %s", filePath)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+	return parseSetupCfg(f, filePath)
+}
+
+func parseSetupCfg(reader io.Reader, sourcePath string) ([]model.Package, error) {
+	sections, err := parseIni(reader)
+	if err != nil {
+		return nil, err
+	}
+	metadata, options := sections["metadata"], sections["options"]
+
+	deps := newDeclaredDependencies(sourcePath)
+	deps.addRequirements(iniList(options["install_requires"]), model.ScopeRuntime)
+	deps.addRequirements(iniList(options["tests_require"]), model.ScopeTest)
+	deps.addRequirements(iniList(options["setup_requires"]), model.ScopeDev)
+	extras := sections["options.extras_require"]
+	for _, extra := range sortedKeys(extras) {
+		deps.addRequirements(iniList(extras[extra]), groupScope(extra, model.ScopeOptional))
+	}
+
+	version := metadata["version"]
+	if strings.HasPrefix(version, "attr:") || strings.HasPrefix(version, "file:") {
+		version = ""
+	}
+	var licenses []string
+	if l := metadata["license"]; l != "" && !strings.Contains(l, "\n") {
+		licenses = license.NormalizeExpressions([]string{l})
+	}
+	return deps.packages(metadata["name"], version, licenses), nil
+}
+
+// parseIni parses the sections of an ini file, the indented lines continue the value of the previous key
+func parseIni(reader io.Reader) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	section, key := "", ""
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			key = ""
+			if sections[section] == nil {
+				sections[section] = make(map[string]string)
+			}
+		case (line[0] == ' ' || line[0] == '\t') && key != "":
+			sections[section][key] = strings.TrimSpace(sections[section][key] + "\n" + trimmed)
+		default:
+			i := strings.IndexAny(line, "=:")
+			if i < 0 || sections[section] == nil {
+				continue
+			}
+			key = strings.TrimSpace(line[:i])
+			sections[section][key] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	return sections, scanner.Err()
+}
+
+// iniList splits a list value of setup.cfg, the items are separated by lines or semicolons
+func iniList(value string) []string {
+	items := make([]string, 0)
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(strings.Split(line, " #")[0])
+		if line == "" {
+			continue
+		}
+		if strings.Contains(line, ";") && !strings.Contains(line, "\"") && !strings.Contains(line, "'") {
+			// a dangling list in a single line
+			items = append(items, strings.Split(line, ";")...)
+			continue
+		}
+		items = append(items, line)
+	}
+	return items
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"testing"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestSetupCfgParser_Parse(t *testing.T) {
+	got, err := NewSetupCfgParser().Parse("test_material/setupcfg/setup.cfg")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	assertPackages(t, got, []model.Package{
+		{Name: "PyYAML", Version: "6.0.1", Scope: model.ScopeRuntime},
+		{Name: "boto3", Version: "1.26", Scope: model.ScopeOptional},
+		{Name: "click", Version: "8.0", Scope: model.ScopeRuntime},
+		{Name: "importlib-metadata", Scope: model.ScopeRuntime},
+		{Name: "legacy-tool", Version: "1.8.2", LicenseDeclared: []string{"BSD-3-Clause"}, Dependencies: []string{
+			"pkg:pypi/PyYAML@6.0.1",
+			"pkg:pypi/click@8.0",
+			"pkg:pypi/importlib-metadata",
+		}},
+		{Name: "pytest", Scope: model.ScopeTest},
+		{Name: "setuptools_scm", Version: "6.2", Scope: model.ScopeDev},
+		{Name: "sphinx", Version: "7.0", Scope: model.ScopeDev},
+	})
+}
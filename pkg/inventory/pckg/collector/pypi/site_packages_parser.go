@@ -0,0 +1,191 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// extraMarkerRe matches the environment marker of an optional feature, e.g. extra == "socks"
+var extraMarkerRe = regexp.MustCompile(`\bextra\s*==`)
+
+// SitePackagesParser is a parser for the packages installed into site-packages,
+// the package owns the files listed in RECORD of *.dist-info or installed-files.txt of *.egg-info
+// see: https://packaging.python.org/en/latest/specifications/recording-installed-packages/
+type SitePackagesParser struct {
+	mu sync.Mutex
+	// dists are the installed dists of each site-packages dir, read once for all the packages of the dir
+	dists map[string]map[string][2]string
+}
+
+func NewSitePackagesParser() *SitePackagesParser {
+	return &SitePackagesParser{dists: make(map[string]map[string][2]string)}
+}
+
+func (p *SitePackagesParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/[^/]+\.dist-info/METADATA$`),
+		regexp.MustCompile(`/[^/]+\.egg-info/PKG-INFO$`),
+	}}
+}
+
+func (p *SitePackagesParser) ProvidesGraph() bool {
+	return true
+}
+
+func (p *SitePackagesParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("parse path %s", path)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	headers := readMetadataHeaders(f)
+	name := firstHeader(headers, "Name")
+	if name == "" {
+		return nil, nil
+	}
+	metaDir := filepath.Dir(path)
+	sitePackages := filepath.Dir(metaDir)
+
+	pkg := newPackage(name, firstHeader(headers, "Version"), path)
+	licenses := headers["License-Expression"]
+	if len(licenses) == 0 {
+		licenses = headers["License"]
+	}
+	pkg.LicenseDeclared = license.NormalizeExpressions(licenses)
+
+	dists := p.installedDists(sitePackages)
+	for _, requirement := range headers["Requires-Dist"] {
+		if extraMarkerRe.MatchString(requirement) {
+			continue
+		}
+		depName, _ := parseRequirement(requirement)
+		if dist, ok := dists[normalizeName(depName)]; ok {
+			pkg.Dependencies = append(pkg.Dependencies, packageURL(dist[0], dist[1]))
+		}
+	}
+	pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+	pkg.Files = ownedFiles(metaDir, sitePackages)
+	return []model.Package{*pkg}, nil
+}
+
+// readMetadataHeaders reads the headers of the core metadata, the description body after the blank line is skipped
+func readMetadataHeaders(reader io.Reader) map[string][]string {
+	headers := make(map[string][]string)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			// continuation of a multi-line value
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		headers[key] = append(headers[key], strings.TrimSpace(value))
+	}
+	return headers
+}
+
+func firstHeader(headers map[string][]string, key string) string {
+	if values := headers[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// installedDists returns the installed dists of the site-packages dir, the dir is read at the first call
+func (p *SitePackagesParser) installedDists(sitePackages string) map[string][2]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dists, ok := p.dists[sitePackages]
+	if !ok {
+		dists = installedDists(sitePackages)
+		p.dists[sitePackages] = dists
+	}
+	return dists
+}
+
+// installedDists returns the name and version of the packages installed into site-packages by normalized name,
+// read from the headers of the metadata files
+func installedDists(sitePackages string) map[string][2]string {
+	dists := make(map[string][2]string)
+	entries, err := os.ReadDir(sitePackages)
+	if err != nil {
+		return dists
+	}
+	for _, entry := range entries {
+		var metadata string
+		switch {
+		case !entry.IsDir():
+			continue
+		case strings.HasSuffix(entry.Name(), ".dist-info"):
+			metadata = "METADATA"
+		case strings.HasSuffix(entry.Name(), ".egg-info"):
+			metadata = "PKG-INFO"
+		default:
+			continue
+		}
+		f, err := os.Open(filepath.Join(sitePackages, entry.Name(), metadata))
+		if err != nil {
+			continue
+		}
+		headers := readMetadataHeaders(f)
+		_ = f.Close()
+		if name := firstHeader(headers, "Name"); name != "" {
+			dists[normalizeName(name)] = [2]string{name, firstHeader(headers, "Version")}
+		}
+	}
+	return dists
+}
+
+// ownedFiles returns the files of an installed package, listed in RECORD relative to site-packages,
+// or in installed-files.txt relative to the metadata dir, the metadata dir itself as a fallback
+func ownedFiles(metaDir, sitePackages string) []string {
+	if f, err := os.Open(filepath.Join(metaDir, "RECORD")); err == nil {
+		defer f.Close()
+		reader := csv.NewReader(f)
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err == nil {
+			paths := make([]string, 0, len(records))
+			for _, record := range records {
+				paths = append(paths, record[0])
+			}
+			return collector.ResolveFiles(sitePackages, paths)
+		}
+		log.Warnf("read RECORD of %s error: %s", metaDir, err.Error())
+	}
+	if data, err := os.ReadFile(filepath.Join(metaDir, "installed-files.txt")); err == nil {
+		return collector.ResolveFiles(metaDir, strings.Split(string(data), "\n"))
+	}
+	return collector.ListFiles(metaDir, nil)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestSitePackagesParser_Parse(t *testing.T) {
+	sitePackages := "test_material/site-packages"
+	tests := []struct {
+		path  string
+		want  []model.Package
+		files []string
+	}{
+		{
+			path: "requests-2.31.0.dist-info/METADATA",
+			want: []model.Package{
+				{Name: "requests", Version: "2.31.0", LicenseDeclared: []string{"Apache-2.0"}, Dependencies: []string{
+					"pkg:pypi/urllib3@2.0.7",
+					"pkg:pypi/six@1.16.0",
+				}},
+			},
+			files: []string{
+				"requests-2.31.0.dist-info/METADATA",
+				"requests-2.31.0.dist-info/RECORD",
+				"requests/__init__.py",
+				"requests/api.py",
+			},
+		},
+		{
+			path: "urllib3-2.0.7.dist-info/METADATA",
+			want: []model.Package{
+				{Name: "urllib3", Version: "2.0.7", LicenseDeclared: []string{"MIT"}},
+			},
+			files: []string{
+				"urllib3-2.0.7.dist-info/METADATA",
+				"urllib3-2.0.7.dist-info/RECORD",
+				"urllib3/__init__.py",
+				"urllib3/a,b.py",
+			},
+		},
+		{
+			path: "six-1.16.0-py3.11.egg-info/PKG-INFO",
+			want: []model.Package{
+				{Name: "six", Version: "1.16.0", LicenseDeclared: []string{"MIT"}},
+			},
+			files: []string{
+				"six.py",
+				"six-1.16.0-py3.11.egg-info/PKG-INFO",
+				"six-1.16.0-py3.11.egg-info/installed-files.txt",
+			},
+		},
+	}
+	parser := NewSitePackagesParser()
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parser.Parse(filepath.Join(sitePackages, tt.path))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			assertPackages(t, got, tt.want)
+			if len(got) != 1 {
+				return
+			}
+			files := make([]string, 0, len(got[0].Files))
+			for _, file := range got[0].Files {
+				rel, _ := filepath.Rel(sitePackages, file)
+				files = append(files, filepath.ToSlash(rel))
+			}
+			if !slices.Equal(files, tt.files) {
+				t.Errorf("files = %v, want %v", files, tt.files)
+			}
+		})
+	}
+	// the dists of site-packages are read once for all the packages
+	if len(parser.dists) != 1 {
+		t.Errorf("dists of %d dirs, want 1", len(parser.dists))
+	}
+}
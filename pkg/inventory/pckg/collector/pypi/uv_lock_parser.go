@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// content of uv.lock
+type uvLock struct {
+	Packages []uvPackage `toml:"package"`
+}
+
+type uvPackage struct {
+	Name                 string                    `toml:"name"`
+	Version              string                    `toml:"version"`
+	Source               map[string]interface{}    `toml:"source"`
+	Dependencies         []uvDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+}
+
+type uvDependency struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+}
+
+// isProject returns true if the package is the project or a member of the workspace
+func (p *uvPackage) isProject() bool {
+	_, editable := p.Source["editable"]
+	_, virtual := p.Source["virtual"]
+	return editable || virtual
+}
+
+// UvLockParser is a parser for uv.lock file, the dependency graph is taken
+// and the scopes are resolved from the dependencies of the project
+// see: https://docs.astral.sh/uv/concepts/projects/layout/#the-lockfile
+type UvLockParser struct{}
+
+func NewUvLockParser() *UvLockParser {
+	return &UvLockParser{}
+}
+
+func (m *UvLockParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"uv.lock"}}
+}
+
+func (m *UvLockParser) ProvidesGraph() bool {
+	return true
+}
+
+func (m *UvLockParser) Parse(filePath string) ([]model.Package, error) {
+	log.Infof("python UvLockParser file path: %s", filePath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var lock uvLock
+	if err = toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse uv.lock file: %w", err)
+	}
+	return parseUvLock(&lock, filePath), nil
+}
+
+func parseUvLock(lock *uvLock, sourcePath string) []model.Package {
+	graph := newLockGraph()
+	for _, p := range lock.Packages {
+		graph.addPackage(p.Name, p.Version)
+	}
+	for _, p := range lock.Packages {
+		for _, dep := range p.Dependencies {
+			graph.addEdge(p.Name, p.Version, dep.Name, dep.Version)
+		}
+		if !p.isProject() {
+			// the extras of a dependency are locked only if they are required
+			for _, deps := range p.OptionalDependencies {
+				for _, dep := range deps {
+					graph.addEdge(p.Name, p.Version, dep.Name, dep.Version)
+				}
+			}
+			continue
+		}
+		for _, dep := range p.Dependencies {
+			graph.addRoot(dep.Name, dep.Version, model.ScopeRuntime)
+		}
+		for _, extra := range sortedKeys(p.OptionalDependencies) {
+			for _, dep := range p.OptionalDependencies[extra] {
+				graph.addRoot(dep.Name, dep.Version, groupScope(extra, model.ScopeOptional))
+			}
+		}
+		for _, group := range sortedKeys(p.DevDependencies) {
+			for _, dep := range p.DevDependencies[group] {
+				graph.addRoot(dep.Name, dep.Version, groupScope(group, model.ScopeDev))
+			}
+		}
+	}
+	return graph.packages(sourcePath)
+}
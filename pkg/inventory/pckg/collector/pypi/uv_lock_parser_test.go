@@ -0,0 +1,38 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pypi
+
+import (
+	"testing"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestUvLockParser_Parse(t *testing.T) {
+	got, err := NewUvLockParser().Parse("test_material/uvlock/uv.lock")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	assertPackages(t, got, []model.Package{
+		{Name: "certifi", Version: "2024.2.2", Scope: model.ScopeRuntime},
+		{Name: "charset-normalizer", Version: "3.3.2", Scope: model.ScopeRuntime},
+		{Name: "iniconfig", Version: "2.0.0", Scope: model.ScopeTest},
+		{Name: "order-api", Version: "0.1.0", Dependencies: []string{"pkg:pypi/requests@2.31.0"}},
+		{Name: "orjson", Version: "3.10.0", Scope: model.ScopeOptional},
+		{Name: "pysocks", Version: "1.7.1", Scope: model.ScopeRuntime},
+		{Name: "pytest", Version: "8.1.1", Scope: model.ScopeTest, Dependencies: []string{"pkg:pypi/iniconfig@2.0.0"}},
+		{Name: "requests", Version: "2.31.0", Scope: model.ScopeRuntime, Dependencies: []string{
+			"pkg:pypi/certifi@2024.2.2",
+			"pkg:pypi/charset-normalizer@3.3.2",
+			"pkg:pypi/pysocks@1.7.1",
+		}},
+	})
+}
@@ -20,9 +20,15 @@ type Collector struct {
 
 var parsers []collector.FileParser
 
+// installedParsers parse the packages recorded in the rpm database of a root filesystem
+var installedParsers []collector.FileParser
+
 func init() {
 	parsers = append(parsers, NewRPMSpecFileParser())
 	parsers = append(parsers, NewRPMArchiveParser())
+	parsers = append(parsers, NewRPMDBParser())
+
+	installedParsers = append(installedParsers, NewRPMDBParser())
 }
 
 func NewCollector() *Collector {
@@ -32,3 +38,12 @@ func NewCollector() *Collector {
 	c.Parsers = parsers
 	return &c
 }
+
+// NewInstalledCollector returns the collector of the packages recorded in the rpm database of a root filesystem
+func NewInstalledCollector() *Collector {
+	c := Collector{}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = installedParsers
+	return &c
+}
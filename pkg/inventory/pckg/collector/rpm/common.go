@@ -15,6 +15,7 @@ import (
 
 	"github.com/anchore/packageurl-go"
 
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 )
 
@@ -41,3 +42,15 @@ func packageURL(name, version string) string {
 		"")
 	return pURL.ToString()
 }
+
+// installedPackageURL returns the package url of a package installed into a root filesystem,
+// qualified by the arch, the epoch and the distro
+func installedPackageURL(name, version, arch, epoch string, release *collector.OSRelease) string {
+	return packageurl.NewPackageURL(
+		PkgType(),
+		release.Namespace(""),
+		name,
+		version,
+		release.Qualifiers(arch, map[string]string{"epoch": epoch}),
+		"").ToString()
+}
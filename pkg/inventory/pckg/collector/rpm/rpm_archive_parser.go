@@ -20,6 +20,7 @@ import (
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
 )
 
@@ -51,9 +52,9 @@ func (p *RPMArchiveParser) Parse(path string) ([]model.Package, error) {
 	}
 	depTree := collector.NewDependencyTree()
 	mainPkg := newPackage(rpmMeta.Name(), versionRelease(rpmMeta.Version(), rpmMeta.Release()), path)
-	license := strings.TrimSpace(rpmMeta.License())
-	if license != "" {
-		mainPkg.LicenseDeclared = []string{license}
+	licenseStr := strings.TrimSpace(rpmMeta.License())
+	if licenseStr != "" {
+		mainPkg.LicenseDeclared = license.NormalizeExpressions([]string{licenseStr})
 	}
 	depTree.AddPackage(&mainPkg)
 
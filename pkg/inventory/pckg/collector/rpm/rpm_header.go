@@ -0,0 +1,182 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// the tags of the rpm header
+// see: https://github.com/rpm-software-management/rpm/blob/master/include/rpm/rpmtag.h
+const (
+	tagName        = 1000
+	tagVersion     = 1001
+	tagRelease     = 1002
+	tagEpoch       = 1003
+	tagVendor      = 1011
+	tagLicense     = 1014
+	tagArch        = 1022
+	tagFileModes   = 1030
+	tagFileFlags   = 1037
+	tagProvideName = 1047
+	tagRequireName = 1049
+	tagDirIndexes  = 1116
+	tagBaseNames   = 1117
+	tagDirNames    = 1118
+)
+
+// the types of the rpm header entries
+const (
+	typeInt16       = 3
+	typeInt32       = 4
+	typeString      = 6
+	typeStringArray = 8
+	typeI18NString  = 9
+)
+
+const (
+	// fileFlagGhost marks the files not included in the package, e.g. the log files
+	fileFlagGhost = 1 << 6
+	fileModeType  = 0o170000
+	fileModeDir   = 0o040000
+)
+
+var errInvalidHeader = errors.New("invalid rpm header")
+
+type headerEntry struct {
+	typ    uint32
+	offset uint32
+	count  uint32
+}
+
+// rpmHeader is a header blob stored in the rpm database, the index entries and the data store
+// without the magic of the header in the package files
+type rpmHeader struct {
+	entries map[uint32]headerEntry
+	data    []byte
+}
+
+func parseHeaderBlob(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, errInvalidHeader
+	}
+	il := binary.BigEndian.Uint32(blob[0:4])
+	dl := binary.BigEndian.Uint32(blob[4:8])
+	start := 8 + uint64(il)*16
+	if start+uint64(dl) > uint64(len(blob)) {
+		return nil, errInvalidHeader
+	}
+	h := &rpmHeader{entries: make(map[uint32]headerEntry, il), data: blob[start : start+uint64(dl)]}
+	for i := uint32(0); i < il; i++ {
+		e := blob[8+i*16 : 8+i*16+16]
+		h.entries[binary.BigEndian.Uint32(e[0:4])] = headerEntry{
+			typ:    binary.BigEndian.Uint32(e[4:8]),
+			offset: binary.BigEndian.Uint32(e[8:12]),
+			count:  binary.BigEndian.Uint32(e[12:16]),
+		}
+	}
+	return h, nil
+}
+
+// strings returns the values of a string, string array or i18n string entry
+func (h *rpmHeader) strings(tag uint32) []string {
+	e, ok := h.entries[tag]
+	if !ok || (e.typ != typeString && e.typ != typeStringArray && e.typ != typeI18NString) {
+		return nil
+	}
+	offset := uint64(e.offset)
+	if offset >= uint64(len(h.data)) {
+		return nil
+	}
+	// every value takes a byte at least, the count of a corrupted header is not trusted
+	capacity := uint64(len(h.data)) - offset
+	if uint64(e.count) < capacity {
+		capacity = uint64(e.count)
+	}
+	values := make([]string, 0, capacity)
+	for i := uint32(0); i < e.count && offset < uint64(len(h.data)); i++ {
+		end := bytes.IndexByte(h.data[offset:], 0)
+		if end < 0 {
+			break
+		}
+		values = append(values, string(h.data[offset:offset+uint64(end)]))
+		offset += uint64(end) + 1
+	}
+	return values
+}
+
+// string returns the first value of a string entry
+func (h *rpmHeader) string(tag uint32) string {
+	if values := h.strings(tag); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ints returns the values of an int16 or int32 entry
+func (h *rpmHeader) ints(tag uint32) []int {
+	e, ok := h.entries[tag]
+	if !ok {
+		return nil
+	}
+	size := uint64(0)
+	switch e.typ {
+	case typeInt16:
+		size = 2
+	case typeInt32:
+		size = 4
+	default:
+		return nil
+	}
+	if uint64(e.offset)+uint64(e.count)*size > uint64(len(h.data)) {
+		return nil
+	}
+	values := make([]int, 0, e.count)
+	for i := uint64(0); i < uint64(e.count); i++ {
+		b := h.data[uint64(e.offset)+i*size:]
+		if size == 2 {
+			values = append(values, int(binary.BigEndian.Uint16(b)))
+		} else {
+			values = append(values, int(int32(binary.BigEndian.Uint32(b))))
+		}
+	}
+	return values
+}
+
+func (h *rpmHeader) has(tag uint32) bool {
+	_, ok := h.entries[tag]
+	return ok
+}
+
+// files returns the paths of the files of the package, the dirs and the ghost files are skipped
+func (h *rpmHeader) files() []string {
+	baseNames := h.strings(tagBaseNames)
+	dirNames := h.strings(tagDirNames)
+	dirIndexes := h.ints(tagDirIndexes)
+	modes := h.ints(tagFileModes)
+	flags := h.ints(tagFileFlags)
+	files := make([]string, 0, len(baseNames))
+	for i, baseName := range baseNames {
+		if i >= len(dirIndexes) || dirIndexes[i] < 0 || dirIndexes[i] >= len(dirNames) {
+			break
+		}
+		if i < len(modes) && modes[i]&fileModeType == fileModeDir {
+			continue
+		}
+		if i < len(flags) && flags[i]&fileFlagGhost != 0 {
+			continue
+		}
+		files = append(files, dirNames[dirIndexes[i]]+baseName)
+	}
+	return files
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	bdbHashMagic          = 0x061561
+	bdbPageHeaderSize     = 26
+	bdbPageHashUnsorted   = 2
+	bdbPageOverflow       = 7
+	bdbPageHash           = 13
+	bdbHashItemOffPage    = 3
+	bdbOffPageItemMinSize = 12
+)
+
+var errInvalidBDB = errors.New("invalid berkeley db hash database")
+
+// readBDBBlobs returns the header blobs in the legacy Packages, a berkeley db hash database,
+// the header blobs are too large for the hash pages and are stored in the overflow pages
+// see: https://github.com/berkeleydb/libdb/blob/master/src/dbinc/db_page.h
+func readBDBBlobs(data []byte) ([][]byte, error) {
+	if len(data) < 512 {
+		return nil, errInvalidBDB
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(data[12:16]) != bdbHashMagic {
+		order = binary.BigEndian
+		if order.Uint32(data[12:16]) != bdbHashMagic {
+			return nil, errInvalidBDB
+		}
+	}
+	pageSize := int(order.Uint32(data[20:24]))
+	lastPage := int(order.Uint32(data[32:36]))
+	if pageSize < 512 || pageSize > 65536 {
+		return nil, errInvalidBDB
+	}
+	page := func(number int) []byte {
+		start := number * pageSize
+		if number < 0 || start+pageSize > len(data) {
+			return nil
+		}
+		return data[start : start+pageSize]
+	}
+
+	blobs := make([][]byte, 0)
+	for number := 1; number <= lastPage; number++ {
+		p := page(number)
+		if p == nil {
+			break
+		}
+		if p[25] != bdbPageHash && p[25] != bdbPageHashUnsorted {
+			continue
+		}
+		entries := int(order.Uint16(p[20:22]))
+		// the entries are pairs of the key and the value
+		for i := 1; i < entries; i += 2 {
+			if bdbPageHeaderSize+i*2+2 > len(p) {
+				break
+			}
+			offset := int(order.Uint16(p[bdbPageHeaderSize+i*2:]))
+			if offset+bdbOffPageItemMinSize > len(p) || p[offset] != bdbHashItemOffPage {
+				continue
+			}
+			next := int(order.Uint32(p[offset+4 : offset+8]))
+			length := int(order.Uint32(p[offset+8 : offset+12]))
+			blob := make([]byte, 0, length)
+			visited := make(map[int]bool)
+			for next != 0 && len(blob) < length && !visited[next] {
+				visited[next] = true
+				overflow := page(next)
+				if overflow == nil || overflow[25] != bdbPageOverflow {
+					return nil, errInvalidBDB
+				}
+				// the free area offset of an overflow page is the length of the data
+				size := int(order.Uint16(overflow[22:24]))
+				if bdbPageHeaderSize+size > len(overflow) {
+					return nil, errInvalidBDB
+				}
+				blob = append(blob, overflow[bdbPageHeaderSize:bdbPageHeaderSize+size]...)
+				next = int(order.Uint32(overflow[16:20]))
+			}
+			if len(blob) < length {
+				return nil, errInvalidBDB
+			}
+			blobs = append(blobs, blob[:length])
+		}
+	}
+	return blobs, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	ndbHeaderMagic     = 'R' | 'p'<<8 | 'm'<<16 | 'P'<<24
+	ndbSlotMagic       = 'R' | 'p'<<8 | 'm'<<16 | 'S'<<24
+	ndbBlobMagic       = 'R' | 'p'<<8 | 'm'<<16 | 'B'<<24
+	ndbVersion         = 0
+	ndbSlotSize        = 16
+	ndbSlotsPerPage    = 4096 / ndbSlotSize
+	ndbBlockSize       = 16
+	ndbBlobHeaderSize  = 16
+	ndbHeaderSlotCount = 2
+)
+
+var errInvalidNDB = errors.New("invalid ndb database")
+
+// readNDBBlobs returns the header blobs in Packages.db of the ndb backend of rpm, the header is followed
+// by the slots locating the blobs by the offset in blocks
+// see: https://github.com/rpm-software-management/rpm/blob/master/lib/backend/ndb/rpmpkg.c
+func readNDBBlobs(data []byte) ([][]byte, error) {
+	order := binary.LittleEndian
+	if len(data) < ndbHeaderSlotCount*ndbSlotSize || order.Uint32(data[0:4]) != ndbHeaderMagic ||
+		order.Uint32(data[4:8]) != ndbVersion {
+		return nil, errInvalidNDB
+	}
+	slotPages := int(order.Uint32(data[12:16]))
+	slots := slotPages*ndbSlotsPerPage - ndbHeaderSlotCount
+	if slots < 0 || ndbHeaderSlotCount*ndbSlotSize+slots*ndbSlotSize > len(data) {
+		return nil, errInvalidNDB
+	}
+
+	blobs := make([][]byte, 0)
+	for i := 0; i < slots; i++ {
+		slot := data[(ndbHeaderSlotCount+i)*ndbSlotSize:]
+		if order.Uint32(slot[0:4]) != ndbSlotMagic {
+			return nil, errInvalidNDB
+		}
+		pkgIndex := order.Uint32(slot[4:8])
+		if pkgIndex == 0 {
+			// empty slot
+			continue
+		}
+		offset := int(order.Uint32(slot[8:12])) * ndbBlockSize
+		if offset+ndbBlobHeaderSize > len(data) {
+			return nil, errInvalidNDB
+		}
+		blob := data[offset:]
+		if order.Uint32(blob[0:4]) != ndbBlobMagic || order.Uint32(blob[4:8]) != pkgIndex {
+			return nil, errInvalidNDB
+		}
+		length := int(order.Uint32(blob[12:16]))
+		if ndbBlobHeaderSize+length > len(blob) {
+			return nil, errInvalidNDB
+		}
+		blobs = append(blobs, blob[ndbBlobHeaderSize:ndbBlobHeaderSize+length])
+	}
+	return blobs, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// rpmdbDirs are the dirs of the rpm database in a root filesystem
+var rpmdbDirs = []string{"var/lib/rpm", "usr/lib/sysimage/rpm"}
+
+// RPMDBParser is a parser for the rpm database of a root filesystem, rpmdb.sqlite of the sqlite backend,
+// Packages of the legacy berkeley db backend or Packages.db of the ndb backend,
+// a package owns the files listed in its header
+// see: https://rpm-software-management.github.io/rpm/manual/
+type RPMDBParser struct{}
+
+// NewRPMDBParser returns a new RPMDBParser
+func NewRPMDBParser() *RPMDBParser {
+	return &RPMDBParser{}
+}
+
+func (p *RPMDBParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/var/lib/rpm/(rpmdb\.sqlite|Packages|Packages\.db)$`),
+		regexp.MustCompile(`/usr/lib/sysimage/rpm/(rpmdb\.sqlite|Packages|Packages\.db)$`),
+	}}
+}
+
+func (p *RPMDBParser) ProvidesGraph() bool {
+	return true
+}
+
+func (p *RPMDBParser) Parse(path string) ([]model.Package, error) {
+	log.Infof("RPMDBParser path: %s", path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var blobs [][]byte
+	switch {
+	case bytes.HasPrefix(data, []byte(sqliteMagic)):
+		blobs, err = readSqliteBlobs(data)
+	case bytes.HasPrefix(data, []byte("RpmP")):
+		blobs, err = readNDBBlobs(data)
+	default:
+		blobs, err = readBDBBlobs(data)
+	}
+	if err != nil {
+		log.Errorf("read rpm database %s error: %s", path, err.Error())
+		return nil, err
+	}
+
+	root := filepath.Dir(path)
+	for _, dir := range rpmdbDirs {
+		if strings.HasSuffix(filepath.ToSlash(filepath.Dir(path)), "/"+dir) {
+			root = collector.RootOf(filepath.Dir(path), dir)
+		}
+	}
+	release := collector.ReadOSRelease(root)
+
+	headers := make([]*rpmHeader, 0, len(blobs))
+	for _, blob := range blobs {
+		h, err := parseHeaderBlob(blob)
+		if err != nil {
+			log.Warnf("parse rpm header in %s error: %s", path, err.Error())
+			continue
+		}
+		// the public keys imported into the database are not packages
+		if name := h.string(tagName); name != "" && name != "gpg-pubkey" {
+			headers = append(headers, h)
+		}
+	}
+
+	// the installed packages by the capabilities and the files provided by them
+	purls := make([]string, len(headers))
+	provided := make(map[string]string)
+	for i, h := range headers {
+		epoch := ""
+		if epochs := h.ints(tagEpoch); len(epochs) > 0 {
+			epoch = strconv.Itoa(epochs[0])
+		}
+		purls[i] = installedPackageURL(h.string(tagName), versionRelease(h.string(tagVersion), h.string(tagRelease)),
+			h.string(tagArch), epoch, release)
+		for _, capability := range append(h.strings(tagProvideName), h.files()...) {
+			if _, ok := provided[capability]; !ok {
+				provided[capability] = purls[i]
+			}
+		}
+	}
+
+	pkgs := make([]model.Package, 0, len(headers))
+	for i, h := range headers {
+		pkg := newPackage(h.string(tagName), versionRelease(h.string(tagVersion), h.string(tagRelease)), path)
+		pkg.PURL = purls[i]
+		pkg.Supplier = h.string(tagVendor)
+		if l := strings.TrimSpace(h.string(tagLicense)); l != "" {
+			pkg.LicenseDeclared = license.NormalizeExpressions([]string{l})
+		}
+		for _, require := range h.strings(tagRequireName) {
+			if strings.HasPrefix(require, "rpmlib(") {
+				continue
+			}
+			if purl, ok := provided[require]; ok && purl != pkg.PURL {
+				pkg.Dependencies = append(pkg.Dependencies, purl)
+			}
+		}
+		pkg.Dependencies = util.SliceUnique(pkg.Dependencies)
+		for _, file := range h.files() {
+			pkg.Files = append(pkg.Files, filepath.Join(root, filepath.FromSlash(file)))
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestRPMDBParser_Parse(t *testing.T) {
+	packages := func(namespace, distro, supplier string) []model.Package {
+		qualifiers := "?arch=x86_64&distro=" + distro
+		glibc := "pkg:rpm/" + namespace + "/glibc@2.34-60.el9" + qualifiers
+		bash := "pkg:rpm/" + namespace + "/bash@5.1.8-6.el9" + qualifiers
+		return []model.Package{
+			{Name: "glibc", Version: "2.34-60.el9", PURL: glibc, Supplier: supplier,
+				LicenseDeclared: []string{"LGPLv2+ AND LGPLv2+ WITH exceptions AND GPL-2.0-only+"},
+				Files:           []string{"usr/lib64/libc.so.6"}},
+			{Name: "bash", Version: "5.1.8-6.el9", PURL: bash, Supplier: supplier,
+				LicenseDeclared: []string{"GPLv3+"},
+				Dependencies:    []string{glibc},
+				Files:           []string{"usr/bin/bash", "usr/bin/sh"}},
+			{Name: "openssl-libs", Version: "3.0.7-24.el9", Supplier: supplier,
+				PURL:            "pkg:rpm/" + namespace + "/openssl-libs@3.0.7-24.el9" + qualifiers + "&epoch=1",
+				LicenseDeclared: []string{"ASL 2.0"},
+				Dependencies:    []string{glibc, bash},
+				Files:           []string{"usr/lib64/libcrypto.so.3"}},
+		}
+	}
+	tests := []struct {
+		root string
+		path string
+		want []model.Package
+	}{
+		{
+			root: "test_material/rhel9",
+			path: "var/lib/rpm/rpmdb.sqlite",
+			want: packages("rhel", "rhel-9.2", "Red Hat, Inc."),
+		},
+		{
+			root: "test_material/centos7",
+			path: "var/lib/rpm/Packages",
+			want: packages("centos", "centos-7", "CentOS"),
+		},
+		{
+			root: "test_material/sles15",
+			path: "usr/lib/sysimage/rpm/Packages.db",
+			want: packages("sles", "sles-15.5", "SUSE LLC <https://www.suse.com/>"),
+		},
+	}
+	parser := NewRPMDBParser()
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parser.Parse(filepath.Join(tt.root, tt.path))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for i := range got {
+				for j, file := range got[i].Files {
+					rel, _ := filepath.Rel(tt.root, file)
+					got[i].Files[j] = filepath.ToSlash(rel)
+				}
+			}
+			if !slices.EqualFunc(got, tt.want, func(p1, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2) && p1.PURL == p2.PURL && p1.Supplier == p2.Supplier &&
+					slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared) &&
+					slices.Equal(p1.Dependencies, p2.Dependencies) && slices.Equal(p1.Files, p2.Files)
+			}) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRPMHeader_CorruptedCount(t *testing.T) {
+	data := []byte("bash\x00sh\x00")
+	blob := make([]byte, 8+2*16)
+	binary.BigEndian.PutUint32(blob[0:4], 2)
+	binary.BigEndian.PutUint32(blob[4:8], uint32(len(data)))
+	// the counts of the entries exceed the data store
+	for i, entry := range [][4]uint32{{tagName, typeStringArray, 0, 0xFFFFFFFF}, {tagVersion, typeString, 0xFFFFFFF0, 0xFFFFFFFF}} {
+		for j, v := range entry {
+			binary.BigEndian.PutUint32(blob[8+i*16+j*4:], v)
+		}
+	}
+	h, err := parseHeaderBlob(append(blob, data...))
+	if err != nil {
+		t.Fatalf("parseHeaderBlob() error = %v", err)
+	}
+	if got := h.strings(tagName); !slices.Equal(got, []string{"bash", "sh"}) {
+		t.Errorf("strings() got = %v", got)
+	}
+	if got := h.strings(tagVersion); got != nil {
+		t.Errorf("strings() got = %v", got)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package rpm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	sqliteMagic        = "SQLite format 3\x00"
+	sqliteHeaderSize   = 100
+	sqliteInteriorPage = 0x05
+	sqliteLeafPage     = 0x0d
+	// the table of the header blobs, CREATE TABLE 'Packages' (hnum INTEGER PRIMARY KEY, blob BLOB NOT NULL)
+	sqlitePackagesTable = "Packages"
+)
+
+var errInvalidSqlite = errors.New("invalid sqlite database")
+
+// sqliteDB reads the tables of a sqlite database file, only the b-tree pages of the tables are read,
+// the changes not checkpointed from the -wal file are not seen
+// see: https://www.sqlite.org/fileformat.html
+type sqliteDB struct {
+	data     []byte
+	pageSize int
+	usable   int
+}
+
+// readSqliteBlobs returns the header blobs in rpmdb.sqlite
+func readSqliteBlobs(data []byte) ([][]byte, error) {
+	if len(data) < sqliteHeaderSize || !bytes.HasPrefix(data, []byte(sqliteMagic)) {
+		return nil, errInvalidSqlite
+	}
+	db := &sqliteDB{data: data, pageSize: int(binary.BigEndian.Uint16(data[16:18]))}
+	if db.pageSize == 1 {
+		db.pageSize = 65536
+	}
+	db.usable = db.pageSize - int(data[20])
+	if db.pageSize < 512 || db.usable < 480 {
+		return nil, errInvalidSqlite
+	}
+
+	// find the root page of the table in the schema table at page 1
+	rootPage := 0
+	err := db.walkTable(1, func(record []sqliteValue) {
+		if len(record) >= 4 && string(record[0].bytes) == "table" && string(record[1].bytes) == sqlitePackagesTable {
+			rootPage = int(record[3].int)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rootPage == 0 {
+		return nil, fmt.Errorf("table %s not found", sqlitePackagesTable)
+	}
+	blobs := make([][]byte, 0)
+	err = db.walkTable(rootPage, func(record []sqliteValue) {
+		if len(record) >= 2 && len(record[1].bytes) > 0 {
+			blobs = append(blobs, record[1].bytes)
+		}
+	})
+	return blobs, err
+}
+
+func (db *sqliteDB) page(number int) ([]byte, error) {
+	start := (number - 1) * db.pageSize
+	if number < 1 || start+db.pageSize > len(db.data) {
+		return nil, fmt.Errorf("sqlite page %d out of range", number)
+	}
+	return db.data[start : start+db.pageSize], nil
+}
+
+// walkTable visits the records of the table b-tree at the root page
+func (db *sqliteDB) walkTable(rootPage int, visit func(record []sqliteValue)) error {
+	visited := make(map[int]bool)
+	pages := []int{rootPage}
+	for len(pages) > 0 {
+		number := pages[len(pages)-1]
+		pages = pages[:len(pages)-1]
+		if visited[number] {
+			return errInvalidSqlite
+		}
+		visited[number] = true
+		page, err := db.page(number)
+		if err != nil {
+			return err
+		}
+		header := 0
+		if number == 1 {
+			header = sqliteHeaderSize
+		}
+		if header+12 > len(page) {
+			return errInvalidSqlite
+		}
+		cells := int(binary.BigEndian.Uint16(page[header+3 : header+5]))
+		switch page[header] {
+		case sqliteInteriorPage:
+			pointers := header + 12
+			if pointers+cells*2 > len(page) {
+				return errInvalidSqlite
+			}
+			pages = append(pages, int(binary.BigEndian.Uint32(page[header+8:header+12])))
+			for i := cells - 1; i >= 0; i-- {
+				offset := int(binary.BigEndian.Uint16(page[pointers+i*2:]))
+				if offset+4 > len(page) {
+					return errInvalidSqlite
+				}
+				pages = append(pages, int(binary.BigEndian.Uint32(page[offset:offset+4])))
+			}
+		case sqliteLeafPage:
+			pointers := header + 8
+			if pointers+cells*2 > len(page) {
+				return errInvalidSqlite
+			}
+			for i := 0; i < cells; i++ {
+				offset := int(binary.BigEndian.Uint16(page[pointers+i*2:]))
+				payload, err := db.cellPayload(page, offset)
+				if err != nil {
+					return err
+				}
+				visit(parseSqliteRecord(payload))
+			}
+		default:
+			return errInvalidSqlite
+		}
+	}
+	return nil
+}
+
+// cellPayload returns the payload of a table leaf cell, the overflow pages are followed
+func (db *sqliteDB) cellPayload(page []byte, offset int) ([]byte, error) {
+	if offset >= len(page) {
+		return nil, errInvalidSqlite
+	}
+	size, n := sqliteVarint(page[offset:])
+	offset += n
+	if offset >= len(page) {
+		return nil, errInvalidSqlite
+	}
+	_, n = sqliteVarint(page[offset:]) // rowid
+	offset += n
+
+	maxLocal := db.usable - 35
+	if size <= int64(maxLocal) {
+		if offset+int(size) > len(page) {
+			return nil, errInvalidSqlite
+		}
+		return page[offset : offset+int(size)], nil
+	}
+	minLocal := (db.usable-12)*32/255 - 23
+	local := minLocal + int((size-int64(minLocal))%int64(db.usable-4))
+	if local > maxLocal {
+		local = minLocal
+	}
+	if offset+local+4 > len(page) {
+		return nil, errInvalidSqlite
+	}
+	payload := make([]byte, 0, size)
+	payload = append(payload, page[offset:offset+local]...)
+	next := int(binary.BigEndian.Uint32(page[offset+local:]))
+	for int64(len(payload)) < size && next != 0 {
+		overflow, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		next = int(binary.BigEndian.Uint32(overflow[0:4]))
+		n := int(size) - len(payload)
+		if n > db.usable-4 {
+			n = db.usable - 4
+		}
+		payload = append(payload, overflow[4:4+n]...)
+	}
+	if int64(len(payload)) != size {
+		return nil, errInvalidSqlite
+	}
+	return payload, nil
+}
+
+// sqliteValue is a column of a record, the integers are in int and the texts and blobs are in bytes
+type sqliteValue struct {
+	int   int64
+	bytes []byte
+}
+
+func parseSqliteRecord(payload []byte) []sqliteValue {
+	headerSize, n := sqliteVarint(payload)
+	if headerSize > int64(len(payload)) {
+		return nil
+	}
+	types := make([]int64, 0)
+	for offset := n; offset < int(headerSize); {
+		typ, n := sqliteVarint(payload[offset:])
+		types = append(types, typ)
+		offset += n
+	}
+	values := make([]sqliteValue, 0, len(types))
+	offset := int(headerSize)
+	for _, typ := range types {
+		var value sqliteValue
+		size := 0
+		switch {
+		case typ >= 1 && typ <= 4:
+			size = int(typ)
+		case typ == 5:
+			size = 6
+		case typ == 6 || typ == 7:
+			size = 8
+		case typ == 9:
+			value.int = 1
+		case typ >= 12:
+			size = int(typ-12) / 2
+		}
+		if offset+size > len(payload) {
+			break
+		}
+		data := payload[offset : offset+size]
+		if typ >= 1 && typ <= 6 {
+			for i, b := range data {
+				if i == 0 {
+					value.int = int64(int8(b))
+				} else {
+					value.int = value.int<<8 | int64(b)
+				}
+			}
+		} else if typ >= 12 {
+			value.bytes = data
+		}
+		values = append(values, value)
+		offset += size
+	}
+	return values
+}
+
+// sqliteVarint reads a variable-length integer of 1 to 9 bytes
+func sqliteVarint(data []byte) (int64, int) {
+	var value int64
+	for i := 0; i < 9 && i < len(data); i++ {
+		if i == 8 {
+			return value<<8 | int64(data[i]), 9
+		}
+		value = value<<7 | int64(data[i]&0x7f)
+		if data[i]&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return value, len(data)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vcpkg
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestVcpkgCollector_Collect(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []collector.File
+		wantResult []model.Package
+	}{
+		{
+			name: "manifest",
+			files: []collector.File{
+				collector.NewFileMeta("test_material/app/vcpkg.json"),
+				collector.NewFileMeta("test_material/app/vcpkg-configuration.json"),
+			},
+			wantResult: []model.Package{
+				{Name: "app", Version: "1.2.0", PURL: "pkg:vcpkg/app@1.2.0", LicenseDeclared: []string{"Apache-2.0"},
+					Dependencies: []string{
+						"pkg:vcpkg/beicode?repository_url=https://github.com/northwindtraders/vcpkg-registry",
+						"pkg:vcpkg/fmt@10.1.1",
+						"pkg:vcpkg/zlib@1.3",
+					}},
+				{Name: "beicode", PURL: "pkg:vcpkg/beicode?repository_url=https://github.com/northwindtraders/vcpkg-registry",
+					Scope: model.ScopeRuntime},
+				{Name: "fmt", Version: "10.1.1", PURL: "pkg:vcpkg/fmt@10.1.1", Scope: model.ScopeRuntime},
+				{Name: "vcpkg-cmake", PURL: "pkg:vcpkg/vcpkg-cmake", Scope: model.ScopeDev},
+				{Name: "zlib", Version: "1.3", PURL: "pkg:vcpkg/zlib@1.3", Scope: model.ScopeRuntime},
+			},
+		},
+		{
+			name:  "installed",
+			files: []collector.File{collector.NewFileMeta("test_material/app/vcpkg_installed/x64-linux/share/zlib/vcpkg.spdx.json")},
+			wantResult: []model.Package{
+				{Name: "zlib", Version: "1.3", PURL: "pkg:vcpkg/zlib@1.3", LicenseConcluded: []string{"Zlib"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector()
+			for i := range tt.files {
+				c.TryToAccept(tt.files[i])
+			}
+			gotResult, err := c.Collect()
+			if err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+			if !slices.EqualFunc(gotResult, tt.wantResult, func(p1 model.Package, p2 model.Package) bool {
+				return model.PackageEqual(&p1, &p2) && p1.PURL == p2.PURL && p1.Scope == p2.Scope &&
+					slices.Equal(p1.Dependencies, p2.Dependencies) &&
+					slices.Equal(p1.LicenseConcluded, p2.LicenseConcluded) && slices.Equal(p1.LicenseDeclared, p2.LicenseDeclared)
+			}) {
+				t.Errorf("Collect() gotResult = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
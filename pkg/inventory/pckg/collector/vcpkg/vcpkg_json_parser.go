@@ -0,0 +1,197 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vcpkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// builtinRegistry is the repository of the builtin registry of vcpkg
+const builtinRegistry = "https://github.com/microsoft/vcpkg"
+
+// content of vcpkg.json
+type vcpkgManifest struct {
+	Name          string              `json:"name"`
+	Version       string              `json:"version"`
+	VersionSemver string              `json:"version-semver"`
+	VersionDate   string              `json:"version-date"`
+	VersionString string              `json:"version-string"`
+	License       string              `json:"license"`
+	Dependencies  []vcpkgDependency   `json:"dependencies"`
+	Overrides     []vcpkgOverride     `json:"overrides"`
+	Configuration *vcpkgConfiguration `json:"vcpkg-configuration"`
+}
+
+func (m *vcpkgManifest) version() string {
+	for _, v := range []string{m.Version, m.VersionSemver, m.VersionDate, m.VersionString} {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// vcpkgDependency is a dependency of vcpkg.json, either a port name or an object
+type vcpkgDependency struct {
+	Name       string `json:"name"`
+	MinVersion string `json:"version>="`
+	Host       bool   `json:"host"`
+}
+
+func (d *vcpkgDependency) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.Name = name
+		return nil
+	}
+	type dependency vcpkgDependency
+	return json.Unmarshal(data, (*dependency)(d))
+}
+
+type vcpkgOverride struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// content of vcpkg-configuration.json
+type vcpkgConfiguration struct {
+	DefaultRegistry *vcpkgRegistry  `json:"default-registry"`
+	Registries      []vcpkgRegistry `json:"registries"`
+}
+
+type vcpkgRegistry struct {
+	Kind       string   `json:"kind"`
+	Repository string   `json:"repository"`
+	Baseline   string   `json:"baseline"`
+	Packages   []string `json:"packages"`
+}
+
+// repository returns the repository of the registry providing the port, empty for the builtin registry
+func (c *vcpkgConfiguration) repository(port string) string {
+	if c == nil {
+		return ""
+	}
+	for _, r := range c.Registries {
+		for _, pattern := range r.Packages {
+			if pattern == port || strings.HasSuffix(pattern, "*") && strings.HasPrefix(port, strings.TrimSuffix(pattern, "*")) {
+				return r.repositoryURL()
+			}
+		}
+	}
+	if c.DefaultRegistry != nil {
+		return c.DefaultRegistry.repositoryURL()
+	}
+	return ""
+}
+
+func (r *vcpkgRegistry) repositoryURL() string {
+	repo := strings.TrimSuffix(r.Repository, ".git")
+	if r.Kind != "git" || strings.EqualFold(repo, builtinRegistry) {
+		return ""
+	}
+	return repo
+}
+
+// VcpkgJSONParser is a parser for the vcpkg.json manifest, the registries of the ports are taken
+// from the vcpkg-configuration.json beside or the vcpkg-configuration field
+// see: https://learn.microsoft.com/en-us/vcpkg/reference/vcpkg-json
+type VcpkgJSONParser struct{}
+
+// NewVcpkgJSONParser returns a new VcpkgJSONParser
+func NewVcpkgJSONParser() *VcpkgJSONParser {
+	return &VcpkgJSONParser{}
+}
+
+func (m *VcpkgJSONParser) Matcher() collector.FileMatcher {
+	return &collector.FileNameMatcher{Names: []string{"vcpkg.json"}}
+}
+
+func (m *VcpkgJSONParser) Parse(path string) ([]model.Package, error) {
+	var manifest vcpkgManifest
+	if err := readJSON(path, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode vcpkg.json file: %w", err)
+	}
+	config := manifest.Configuration
+	if config == nil {
+		configPath := filepath.Join(filepath.Dir(path), "vcpkg-configuration.json")
+		if _, err := os.Stat(configPath); err == nil {
+			config = &vcpkgConfiguration{}
+			if err = readJSON(configPath, config); err != nil {
+				return nil, fmt.Errorf("failed to decode vcpkg-configuration.json file: %w", err)
+			}
+		}
+	}
+	return parseVcpkgManifest(&manifest, config, path), nil
+}
+
+// parseVcpkgManifest returns the dependencies of the manifest, the version of a dependency is taken
+// from the overrides, or the minimum version
+func parseVcpkgManifest(manifest *vcpkgManifest, config *vcpkgConfiguration, path string) []model.Package {
+	overrides := make(map[string]string)
+	for _, o := range manifest.Overrides {
+		overrides[o.Name] = trimPortVersion(o.Version)
+	}
+
+	pkgs := make([]model.Package, 0)
+	var mainPkg *model.Package
+	if manifest.Name != "" {
+		mainPkg = newPackage(manifest.Name, manifest.version(), path, nil)
+		if manifest.License != "" {
+			mainPkg.LicenseDeclared = license.NormalizeExpressions([]string{manifest.License})
+		}
+	}
+	for _, dep := range manifest.Dependencies {
+		if dep.Name == "" {
+			continue
+		}
+		version, ok := overrides[dep.Name]
+		if !ok {
+			version = trimPortVersion(dep.MinVersion)
+		}
+		var qualifiers packageurl.Qualifiers
+		if repo := config.repository(dep.Name); repo != "" {
+			qualifiers = packageurl.QualifiersFromMap(map[string]string{"repository_url": repo})
+		}
+		pkg := newPackage(dep.Name, version, path, qualifiers)
+		pkg.Scope = model.ScopeRuntime
+		if dep.Host {
+			pkg.Scope = model.ScopeDev
+		} else if mainPkg != nil {
+			mainPkg.Dependencies = append(mainPkg.Dependencies, pkg.PURL)
+		}
+		pkgs = append(pkgs, *pkg)
+	}
+	if mainPkg != nil {
+		pkgs = append(pkgs, *mainPkg)
+	}
+	return pkgs
+}
+
+func readJSON(path string, v any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	return json.NewDecoder(file).Decode(v)
+}
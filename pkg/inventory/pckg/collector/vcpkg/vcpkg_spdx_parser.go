@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vcpkg
+
+import (
+	"fmt"
+	"regexp"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// portSPDXID is the SPDXID of the port package in a vcpkg.spdx.json
+const portSPDXID = "SPDXRef-port"
+
+// content of vcpkg.spdx.json
+type vcpkgSpdx struct {
+	Packages []struct {
+		SPDXID           string `json:"SPDXID"`
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+	} `json:"packages"`
+}
+
+// VcpkgSpdxParser is a parser for the vcpkg.spdx.json of the installed ports,
+// e.g. vcpkg_installed/x64-linux/share/zlib/vcpkg.spdx.json
+type VcpkgSpdxParser struct{}
+
+// NewVcpkgSpdxParser returns a new VcpkgSpdxParser
+func NewVcpkgSpdxParser() *VcpkgSpdxParser {
+	return &VcpkgSpdxParser{}
+}
+
+func (m *VcpkgSpdxParser) Matcher() collector.FileMatcher {
+	return &collector.FileRegexpMatcher{Regexps: []*regexp.Regexp{
+		regexp.MustCompile(`/share/[^/]+/vcpkg\.spdx\.json$`),
+	}}
+}
+
+func (m *VcpkgSpdxParser) Parse(path string) ([]model.Package, error) {
+	var doc vcpkgSpdx
+	if err := readJSON(path, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode vcpkg.spdx.json file: %w", err)
+	}
+	pkgs := make([]model.Package, 0)
+	for _, p := range doc.Packages {
+		if p.SPDXID != portSPDXID || p.Name == "" {
+			continue
+		}
+		pkg := newPackage(p.Name, trimPortVersion(p.VersionInfo), path, nil)
+		pkg.LicenseConcluded = spdxLicenses(p.LicenseConcluded)
+		pkg.LicenseDeclared = spdxLicenses(p.LicenseDeclared)
+		pkgs = append(pkgs, *pkg)
+	}
+	return pkgs, nil
+}
+
+func spdxLicenses(expr string) []string {
+	if expr == "" || expr == license.NOASSERTION_LICENSE || expr == "NONE" {
+		return nil
+	}
+	return license.NormalizeExpressions([]string{expr})
+}
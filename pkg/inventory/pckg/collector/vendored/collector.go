@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vendored
+
+import (
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// Collector collects the third-party libraries vendored in the source tree, a library is reported once
+// no matter how many of its files are accepted
+type Collector struct {
+	collector.BaseCollector
+	parser *VendoredDirParser
+}
+
+func NewCollector() *Collector {
+	c := Collector{parser: NewVendoredDirParser()}
+	c.Name = Name()
+	c.PurlType = PkgType()
+	c.Parsers = []collector.FileParser{c.parser}
+	return &c
+}
+
+// SetRoot sets the root of the scan, the vendor dirs above it are not taken
+func (c *Collector) SetRoot(root string) {
+	c.parser.root = root
+}
+
+func (c *Collector) Collect() ([]model.Package, error) {
+	pkgs := make([]model.Package, 0)
+	seen := make(map[string]struct{})
+	for _, request := range c.Requests {
+		lib := c.parser.library(request.File.FullName())
+		if lib == nil {
+			continue
+		}
+		if _, ok := seen[lib.dir]; ok {
+			continue
+		}
+		seen[lib.dir] = struct{}{}
+		if pkg := c.parser.parseLibrary(lib); pkg != nil {
+			pkgs = append(pkgs, *pkg)
+		}
+	}
+	return collector.SortPackage(pkgs), nil
+}
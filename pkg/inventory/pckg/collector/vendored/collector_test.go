@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vendored
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestVendoredCollector_Collect(t *testing.T) {
+	root := "test_material/project"
+	c := NewCollector()
+	c.SetRoot(root)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			c.TryToAccept(collector.NewFileMeta(path))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Collect()
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	slices.SortFunc(got, func(p1, p2 model.Package) bool {
+		return p1.Name < p2.Name
+	})
+
+	want := []model.Package{
+		{Name: "foo", Version: "1.4.2", Type: model.PkgTypeGeneric, PURL: "pkg:generic/foo@1.4.2",
+			SourceLocation: filepath.Join(root, "deps/foo-1.4")},
+		{Name: "github.com/pkg/errors", Type: model.PkgTypeGolang, PURL: "pkg:golang/github.com/pkg/errors",
+			SourceLocation: filepath.Join(root, "vendor/github.com/pkg/errors")},
+		{Name: "lz4", Version: "1.9.4", Type: model.PkgTypeGeneric, PURL: "pkg:generic/lz4@1.9.4",
+			SourceLocation: filepath.Join(root, "external/lz4")},
+		{Name: "mylib", Version: "2.5.0", Type: model.PkgTypeGeneric, PURL: "pkg:generic/mylib@2.5.0",
+			SourceLocation: filepath.Join(root, "third_party/mylib"), LicenseConcluded: []string{"MIT"}},
+		{Name: "zlib", Version: "1.3.1", Type: model.PkgTypeGeneric, PURL: "pkg:generic/zlib@1.3.1",
+			SourceLocation: filepath.Join(root, "third_party/zlib")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Collect() got %d packages %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !model.PackageEqual(&got[i], &want[i]) || got[i].Type != want[i].Type || got[i].PURL != want[i].PURL ||
+			got[i].SourceLocation != want[i].SourceLocation {
+			t.Errorf("Collect() got %+v, want %+v", got[i], want[i])
+		}
+		if want[i].LicenseConcluded != nil && !slices.Equal(got[i].LicenseConcluded, want[i].LicenseConcluded) {
+			t.Errorf("Collect() got licenses %v of %s, want %v", got[i].LicenseConcluded, got[i].Name, want[i].LicenseConcluded)
+		}
+	}
+}
+
+func TestVendoredDirParser_library(t *testing.T) {
+	p := &VendoredDirParser{root: "/src/project"}
+	tests := []struct {
+		path       string
+		wantDir    string
+		importPath string
+	}{
+		{path: "/src/project/third_party/zlib/zlib.h", wantDir: "/src/project/third_party/zlib"},
+		{path: "/src/project/third_party/zlib/contrib/minizip/zip.h", wantDir: "/src/project/third_party/zlib"},
+		{path: "/src/project/deps/a/third_party/b/README", wantDir: "/src/project/deps/a/third_party/b"},
+		{path: "/src/project/vendor/gopkg.in/yaml.v3/LICENSE", wantDir: "/src/project/vendor/gopkg.in/yaml.v3",
+			importPath: "gopkg.in/yaml.v3"},
+		{path: "/src/project/third_party/README"},
+		{path: "/src/project/src/main.c"},
+	}
+	// the vendor dirs above the root are not taken
+	if lib := (&VendoredDirParser{root: "/deps/x/src/project"}).library("/deps/x/src/project/lib/zlib.h"); lib != nil {
+		t.Errorf("library() got %s above the root", lib.dir)
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			lib := p.library(tt.path)
+			if tt.wantDir == "" {
+				if lib != nil {
+					t.Errorf("library() got %s, want nil", lib.dir)
+				}
+				return
+			}
+			if lib == nil || lib.dir != filepath.FromSlash(tt.wantDir) || lib.importPath != tt.importPath {
+				t.Errorf("library() got %+v, want %s %s", lib, tt.wantDir, tt.importPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+#ifndef LZ4_H_2983827168210
+#define LZ4_H_2983827168210
+
+#define LZ4_VERSION_MAJOR    1
+#define LZ4_VERSION_MINOR    9
+#define LZ4_VERSION_RELEASE  4
+
+#endif
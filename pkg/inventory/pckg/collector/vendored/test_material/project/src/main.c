@@ -0,0 +1,3 @@
+#include "zlib.h"
+
+int main(void) { return 0; }
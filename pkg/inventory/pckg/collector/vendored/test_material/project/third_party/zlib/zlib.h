@@ -0,0 +1,7 @@
+#ifndef ZLIB_H
+#define ZLIB_H
+
+#define ZLIB_VERSION "1.3.1"
+#define ZLIB_VERNUM 0x1310
+
+#endif /* ZLIB_H */
@@ -0,0 +1,168 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vendored
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// vendorDirs are the conventional dirs of vendored third-party code, each child dir of them is a library
+var vendorDirs = []string{"vendor", "vendors", "third_party", "third-party", "thirdparty", "3rdparty", "external",
+	"externals", "deps"}
+
+// managedVendorFiles are the files of the tools managing a vendor dir(go mod vendor, gvt, govendor, vndr),
+// whose packages are reported by the collectors of the tools
+var managedVendorFiles = []string{"modules.txt", "manifest", "vendor.json", "vendor.conf"}
+
+// evidenceFileRe matches the top-level files of a library which tell its name, version or license
+var evidenceFileRe = regexp.MustCompile(`(?i)^((readme|license|licence|copying|version|notice)(\.[\w.-]+)?|` +
+	`cmakelists\.txt|configure\.(ac|in)|[\w.+-]+\.(h|hpp))$`)
+
+// dirVersionRe matches a library dir named with the version, e.g. zlib-1.3.1
+var dirVersionRe = regexp.MustCompile(`^(.+?)[-_]v?(\d+(?:\.\d+)+[\w.+-]*)$`)
+
+// library is a vendored library dir
+type library struct {
+	dir string
+	// vendorDir is the vendor dir containing the library
+	vendorDir string
+	// importPath is the go import path of the library of a go vendor dir
+	importPath string
+}
+
+// VendoredDirParser detects the name, version and license of a vendored library from the files of its dir
+type VendoredDirParser struct {
+	root string
+}
+
+// NewVendoredDirParser returns a new VendoredDirParser
+func NewVendoredDirParser() *VendoredDirParser {
+	return &VendoredDirParser{}
+}
+
+func (p *VendoredDirParser) Matcher() collector.FileMatcher {
+	return &evidenceFileMatcher{parser: p}
+}
+
+// Parse returns the library containing the file
+func (p *VendoredDirParser) Parse(path string) ([]model.Package, error) {
+	lib := p.library(path)
+	if lib == nil {
+		return nil, fmt.Errorf("not a vendored file: %s", path)
+	}
+	pkgs := make([]model.Package, 0)
+	if pkg := p.parseLibrary(lib); pkg != nil {
+		pkgs = append(pkgs, *pkg)
+	}
+	return pkgs, nil
+}
+
+// libraryDir returns the dir of the library containing the file, empty if the file is not vendored
+func (p *VendoredDirParser) libraryDir(path string) string {
+	if lib := p.library(path); lib != nil {
+		return lib.dir
+	}
+	return ""
+}
+
+// library returns the innermost vendored library containing the file, nil if none
+func (p *VendoredDirParser) library(path string) *library {
+	base, rel := "", path
+	if p.root != "" {
+		if r, err := filepath.Rel(p.root, path); err == nil && !strings.HasPrefix(r, "..") {
+			base, rel = p.root, r
+		}
+	}
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(rel)), "/")
+	// a vendor dir, a library dir and a file at least
+	for i := len(parts) - 3; i >= 0; i-- {
+		if !util.SliceContains(vendorDirs, strings.ToLower(parts[i])) {
+			continue
+		}
+		n := 1
+		importPath := ""
+		if strings.ToLower(parts[i]) == "vendor" && strings.Contains(parts[i+1], ".") {
+			// go vendor dir of import paths, e.g. vendor/github.com/pkg/errors
+			n = importPathLen(parts[i+1])
+			if i+n > len(parts)-2 {
+				return nil
+			}
+			importPath = strings.Join(parts[i+1:i+1+n], "/")
+		}
+		return &library{
+			dir:        filepath.Join(base, filepath.FromSlash(strings.Join(parts[:i+1+n], "/"))),
+			vendorDir:  filepath.Join(base, filepath.FromSlash(strings.Join(parts[:i+1], "/"))),
+			importPath: importPath,
+		}
+	}
+	return nil
+}
+
+// importPathLen returns the number of the elements of the import path of a repository on the host
+func importPathLen(host string) int {
+	if host == "gopkg.in" {
+		return 2
+	}
+	return 3
+}
+
+func (p *VendoredDirParser) parseLibrary(lib *library) *model.Package {
+	for _, name := range managedVendorFiles {
+		if _, err := os.Stat(filepath.Join(lib.vendorDir, name)); err == nil {
+			return nil
+		}
+	}
+
+	var pkg *model.Package
+	if lib.importPath != "" {
+		pkg = newPackage(model.PkgTypeGolang, lib.importPath, detectVersion(lib.dir, ""), lib.dir)
+	} else {
+		name, dirVersion := filepath.Base(lib.dir), ""
+		if m := dirVersionRe.FindStringSubmatch(name); m != nil {
+			name, dirVersion = m[1], m[2]
+		}
+		version := detectVersion(lib.dir, name)
+		if version == "" {
+			version = dirVersion
+		}
+		pkg = newPackage(PkgType(), name, version, lib.dir)
+	}
+	if licenses, _, ok := license.ParseLicenseFromDir(lib.dir); ok {
+		pkg.LicenseConcluded = license.NormalizeExpressions(licenses)
+	}
+	return pkg
+}
+
+// evidenceFileMatcher matches the top-level evidence files of vendored libraries
+type evidenceFileMatcher struct {
+	parser *VendoredDirParser
+}
+
+func (m *evidenceFileMatcher) Match(file collector.File) bool {
+	if !evidenceFileRe.MatchString(file.FileName()) {
+		return false
+	}
+	dir := m.parser.libraryDir(file.FullName())
+	return dir != "" && dir == filepath.Clean(file.Dir())
+}
+
+func (m *evidenceFileMatcher) Description() string {
+	return fmt.Sprintf("vendored library files in: %s", strings.Join(vendorDirs, ","))
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vendored
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxHeaderDepth is the max depth of the dirs under a library dir to look for the version headers
+	maxHeaderDepth = 3
+	// maxHeaderFiles is the max number of the headers read for a library
+	maxHeaderFiles = 200
+	// maxReadmeLines is the number of the leading lines of a README to look for the version
+	maxReadmeLines = 30
+)
+
+var (
+	cmakeProjectRe = regexp.MustCompile(`(?is)\bproject\s*\([^)]*?\bVERSION\s+"?(\d+(?:\.\d+)*)`)
+	acInitRe       = regexp.MustCompile(`(?s)\bAC_INIT\s*\(\s*\[?[^],]*\]?\s*,\s*\[?\s*(\d[\w.+-]*)`)
+	// knownVersionMacros are the version macros of the widely vendored libraries not named after the dir
+	knownVersionMacros = []string{"ZLIB_VERSION", "LIBCURL_VERSION", "SQLITE_VERSION", "PNG_LIBPNG_VER_STRING",
+		"OPENSSL_FULL_VERSION_STR", "OPENSSL_VERSION_TEXT"}
+	stringMacroRe   = regexp.MustCompile(`(?m)^\s*#\s*define\s+(\w+)\s+"[^"\d]*(\d+(?:\.\d+)+[\w.+-]*)[^"]*"`)
+	numberMacroRe   = regexp.MustCompile(`(?m)^\s*#\s*define\s+(\w+?)_?(MAJOR|MINOR|PATCH|RELEASE|MICRO)(?:_VERSION)?\s+\(?(\d+)\)?\s*$`)
+	versionFileRe   = regexp.MustCompile(`^v?(\d+(?:\.\d+)+[\w.+-]*)$`)
+	readmeVersionRe = regexp.MustCompile(`(?i)\bversion\s*:?\s*v?(\d+(?:\.\d+)+[\w.+-]*)`)
+	nonAlnumRe      = regexp.MustCompile(`[^A-Z0-9]`)
+	// errStopWalk stops walking the headers
+	errStopWalk = errors.New("stop walk")
+)
+
+// detectVersion returns the version of the library in the dir, by the first found of
+// CMakeLists.txt project(... VERSION ...), configure.ac AC_INIT, version macros of the headers,
+// the VERSION file and the README
+func detectVersion(dir, name string) string {
+	for _, detect := range []func(string, string) string{
+		cmakeVersion, autoconfVersion, headerVersion, versionFileVersion, readmeVersion,
+	} {
+		if version := detect(dir, name); version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+func cmakeVersion(dir, _ string) string {
+	return matchFile(filepath.Join(dir, "CMakeLists.txt"), cmakeProjectRe)
+}
+
+func autoconfVersion(dir, _ string) string {
+	for _, file := range []string{"configure.ac", "configure.in"} {
+		if version := matchFile(filepath.Join(dir, file), acInitRe); version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+// headerVersion looks for the version macros in the headers of the library, which are the known ones,
+// <NAME>_VERSION(_STRING|_STR) named after the library, or a MAJOR/MINOR/PATCH triple
+func headerVersion(dir, name string) string {
+	prefix := nonAlnumRe.ReplaceAllString(strings.ToUpper(strings.TrimPrefix(name, "lib")), "")
+	count := 0
+	version := ""
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if rel, _ := filepath.Rel(dir, path); rel != "." && strings.Count(rel, string(filepath.Separator)) >= maxHeaderDepth-1 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".h" && ext != ".hpp" {
+			return nil
+		}
+		if count++; count > maxHeaderFiles {
+			return errStopWalk
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		if version = macroVersion(string(content), prefix); version != "" {
+			return errStopWalk
+		}
+		return nil
+	})
+	return version
+}
+
+func macroVersion(content, prefix string) string {
+	for _, m := range stringMacroRe.FindAllStringSubmatch(content, -1) {
+		macro := m[1]
+		for _, known := range knownVersionMacros {
+			if macro == known {
+				return m[2]
+			}
+		}
+		if prefix == "" {
+			continue
+		}
+		for _, suffix := range []string{"_VERSION", "_VERSION_STRING", "_VERSION_STR"} {
+			if strings.HasSuffix(macro, suffix) &&
+				nonAlnumRe.ReplaceAllString(strings.TrimPrefix(strings.TrimSuffix(macro, suffix), "LIB"), "") == prefix {
+				return m[2]
+			}
+		}
+	}
+
+	parts := make(map[string]map[string]string)
+	for _, m := range numberMacroRe.FindAllStringSubmatch(content, -1) {
+		if parts[m[1]] == nil {
+			parts[m[1]] = make(map[string]string)
+		}
+		parts[m[1]][m[2]] = m[3]
+	}
+	for _, p := range parts {
+		major, minor := p["MAJOR"], p["MINOR"]
+		patch := p["PATCH"]
+		if patch == "" {
+			patch = p["RELEASE"]
+		}
+		if patch == "" {
+			patch = p["MICRO"]
+		}
+		if major != "" && minor != "" && patch != "" {
+			return major + "." + minor + "." + patch
+		}
+	}
+	return ""
+}
+
+func versionFileVersion(dir, _ string) string {
+	for _, file := range []string{"VERSION", "VERSION.txt", "version.txt"} {
+		lines := readLines(filepath.Join(dir, file), 1)
+		if len(lines) > 0 {
+			if m := versionFileRe.FindStringSubmatch(strings.TrimSpace(lines[0])); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+func readmeVersion(dir, _ string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(strings.ToLower(entry.Name()), "readme") {
+			continue
+		}
+		for _, line := range readLines(filepath.Join(dir, entry.Name()), maxReadmeLines) {
+			if m := readmeVersionRe.FindStringSubmatch(line); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+func matchFile(path string, re *regexp.Regexp) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if m := re.FindSubmatch(content); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+func readLines(path string, n int) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(file)
+	for len(lines) < n && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
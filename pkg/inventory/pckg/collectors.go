@@ -16,25 +16,31 @@ import (
 	"golang.org/x/exp/slices"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/apk"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/bower"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/cargo"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/carthage"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/cmake"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/cocoapods"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/composer"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/conan"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/conda"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/deb"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/dylib"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/elf"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/gem"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/golang"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/lua"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/maven"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/npm"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/nuget"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/pe"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/pub"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/pypi"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/rpm"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/swift"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/vcpkg"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/vendored"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 )
 
@@ -59,12 +65,41 @@ func AllCollectors() []collector.Collector {
 	allCollectors = append(allCollectors, swift.NewCollector())
 	allCollectors = append(allCollectors, dylib.NewCollector())
 	allCollectors = append(allCollectors, deb.NewCollector())
+	allCollectors = append(allCollectors, vendored.NewCollector())
+	allCollectors = append(allCollectors, vcpkg.NewCollector())
+	allCollectors = append(allCollectors, cmake.NewCollector())
+	allCollectors = append(allCollectors, apk.NewCollector())
+	allCollectors = append(allCollectors, elf.NewCollector())
+	allCollectors = append(allCollectors, pe.NewCollector())
+	return allCollectors
+}
+
+// AllInstalledCollectors returns the collectors of the installed package trees,
+// e.g. site-packages, node_modules, vendor dirs and the package databases of a root filesystem
+func AllInstalledCollectors() []collector.Collector {
+	var allCollectors []collector.Collector
+	allCollectors = append(allCollectors, apk.NewInstalledCollector())
+	allCollectors = append(allCollectors, composer.NewInstalledCollector())
+	allCollectors = append(allCollectors, deb.NewInstalledCollector())
+	allCollectors = append(allCollectors, gem.NewInstalledCollector())
+	allCollectors = append(allCollectors, golang.NewInstalledCollector())
+	allCollectors = append(allCollectors, npm.NewInstalledCollector())
+	allCollectors = append(allCollectors, pypi.NewInstalledCollector())
+	allCollectors = append(allCollectors, rpm.NewInstalledCollector())
 	return allCollectors
 }
 
 // GetCollectors groups for directory
 func GetCollectors(names string) []collector.Collector {
-	allCollectors := AllCollectors()
+	return filterCollectors(AllCollectors(), names)
+}
+
+// GetInstalledCollectors returns the installed collectors by names split by comma
+func GetInstalledCollectors(names string) []collector.Collector {
+	return filterCollectors(AllInstalledCollectors(), names)
+}
+
+func filterCollectors(allCollectors []collector.Collector, names string) []collector.Collector {
 	names = strings.TrimSpace(names)
 	if names == "" || names == "*" {
 		return allCollectors
@@ -11,12 +11,14 @@
 package pckg
 
 import (
+	"fmt"
 	"io/fs"
 	"path/filepath"
 	"strings"
 	"sync"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/image"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
@@ -38,13 +40,25 @@ func NewCollectorManager(cfg *config.PackageConfig) *CollectorManager {
 
 // Collect packages using given collectors
 func (cm *CollectorManager) Collect(dirPath string) ([]model.Package, error) {
+	scopes, err := GetScopes(cm.cfg.Scopes)
+	if err != nil {
+		return nil, err
+	}
 	enabledCollectors := GetCollectors(cm.cfg.Collectors)
+	if cm.cfg.Installed {
+		enabledCollectors = GetInstalledCollectors(cm.cfg.Collectors)
+	}
 	collectorNames := util.SliceMap(enabledCollectors, func(c collector.Collector) string {
 		return c.GetName()
 	})
 	log.Infof("enabled package collectors: %s", strings.Join(collectorNames, ","))
+	for _, c := range enabledCollectors {
+		if ra, ok := c.(collector.RootAware); ok {
+			ra.SetRoot(dirPath)
+		}
+	}
 	ignoreMatcher := cm.cfg.IgnoreDirsSet()
-	err := filepath.WalkDir(dirPath, func(file string, entry fs.DirEntry, err error) error {
+	err = filepath.WalkDir(dirPath, func(file string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -98,6 +112,10 @@ func (cm *CollectorManager) Collect(dirPath string) ([]model.Package, error) {
 		pkgs = append(pkgs, r...)
 	}
 	pkgs = collector.OrganizePackage(pkgs)
+	if len(scopes) > 0 {
+		log.Infof("enabled package scopes: %s", strings.Join(scopes, ","))
+		pkgs = collector.FilterPackageByScope(pkgs, scopes)
+	}
 
 	//pkg中去掉路径前缀
 	for i := 0; i < len(pkgs); i++ {
@@ -105,10 +123,46 @@ func (cm *CollectorManager) Collect(dirPath string) ([]model.Package, error) {
 			pkgs[i].SourceLocation = strings.TrimPrefix(pkgs[i].SourceLocation, dirPath)
 			pkgs[i].SourceLocation = strings.TrimPrefix(pkgs[i].SourceLocation, "/")
 		}
+		for j, file := range pkgs[i].Files {
+			pkgs[i].Files[j] = strings.TrimPrefix(strings.TrimPrefix(file, dirPath), "/")
+		}
+	}
+	return pkgs, nil
+}
+
+// CollectImage collects packages in the squashed root filesystem of an image, and records the layers introducing them
+func (cm *CollectorManager) CollectImage(img *image.Image) ([]model.Package, error) {
+	cfg := *cm.cfg
+	cfg.Path = img.Root
+	cfg.InitIgnoreDirs()
+	pkgs, err := NewCollectorManager(&cfg).Collect(cfg.Path)
+	if err != nil {
+		return nil, err
 	}
+	img.AssignLayers(pkgs)
 	return pkgs, nil
 }
 
+// GetScopes returns the scopes by names split by comma, nil for all scopes, an unknown name is an error
+func GetScopes(names string) ([]model.Scope, error) {
+	names = strings.TrimSpace(names)
+	if names == "" || names == "*" {
+		return nil, nil
+	}
+	scopes := make([]model.Scope, 0)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !model.IsValidScope(name) {
+			return nil, fmt.Errorf("unknown package scope: %s, supported scopes: %s", name, strings.Join(model.AllScopes(), ","))
+		}
+		scopes = append(scopes, name)
+	}
+	return scopes, nil
+}
+
 func logPkgs(pkgs []model.Package) {
 	for i := 0; i < len(pkgs); i++ {
 		log.Debugf("package info { name: %s, version: %s, type: %s, dependencies: %d}",
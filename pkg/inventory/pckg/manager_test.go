@@ -0,0 +1,34 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package pckg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestGetScopes(t *testing.T) {
+	scopes, err := GetScopes("*")
+	assert.NoError(t, err)
+	assert.Nil(t, scopes)
+
+	scopes, err = GetScopes(" runtime, optional ")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Scope{model.ScopeRuntime, model.ScopeOptional}, scopes)
+
+	_, err = GetScopes("runtme")
+	assert.Error(t, err)
+	_, err = GetScopes("runtime,tset")
+	assert.Error(t, err)
+}
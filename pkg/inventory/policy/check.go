@@ -0,0 +1,245 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package policy
+
+import (
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// Result is the evaluation of a license field of a package
+type Result struct {
+	PURL    string `json:"purl"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Field   string `json:"field"`
+	License string `json:"license"`
+	Action  Action `json:"action"`
+	// Licenses are the licenses deciding the action
+	Licenses []string `json:"licenses,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+// Summary counts the packages by their most restrictive action
+type Summary struct {
+	Packages int `json:"packages"`
+	Allowed  int `json:"allowed"`
+	Review   int `json:"review"`
+	Denied   int `json:"denied"`
+}
+
+// Report is the result of checking a sbom document against a policy
+type Report struct {
+	Summary Summary  `json:"summary"`
+	Results []Result `json:"results"`
+}
+
+// Failed returns true if any package is denied, or requires review when failOnReview is true
+func (r *Report) Failed(failOnReview bool) bool {
+	return r.Summary.Denied > 0 || (failOnReview && r.Summary.Review > 0)
+}
+
+// Check evaluates the licenses of the packages against the policy
+func Check(policy *Policy, pkgs []model.Package) *Report {
+	report := &Report{Results: make([]Result, 0)}
+	for i := range pkgs {
+		results := policy.checkPackage(&pkgs[i])
+		action := ActionAllow
+		for _, r := range results {
+			if r.Action.rank() > action.rank() {
+				action = r.Action
+			}
+		}
+		report.Summary.Packages++
+		switch action {
+		case ActionAllow:
+			report.Summary.Allowed++
+		case ActionReview:
+			report.Summary.Review++
+		default:
+			report.Summary.Denied++
+		}
+		report.Results = append(report.Results, results...)
+	}
+	return report
+}
+
+func (p *Policy) checkPackage(pkg *model.Package) []Result {
+	declared := filterLicenses(pkg.LicenseDeclared)
+	concluded := filterLicenses(pkg.LicenseConcluded)
+	newResult := func(field string, licenses []string) Result {
+		return Result{PURL: pkg.PURL, Name: pkg.Name, Version: pkg.Version, Field: field, License: joinLicenses(licenses)}
+	}
+
+	exception := p.exception(pkg.PURL)
+	if exception != nil && len(exception.Licenses) == 0 {
+		field, licenses := p.Field, concluded
+		if field != FieldConcluded && (field == FieldDeclared || len(concluded) == 0) {
+			field, licenses = FieldDeclared, declared
+		}
+		r := newResult(field, licenses)
+		r.Action, r.Reason = exception.Action, "exception: "+exception.Reason
+		return []Result{r}
+	}
+
+	fields := []string{p.Field}
+	switch p.Field {
+	case FieldEffective:
+		fields = []string{FieldConcluded}
+		if len(concluded) == 0 {
+			fields = []string{FieldDeclared}
+		}
+	case FieldBoth:
+		fields = []string{FieldDeclared, FieldConcluded}
+	}
+
+	results := make([]Result, 0, len(fields)+1)
+	for _, field := range fields {
+		licenses := declared
+		if field == FieldConcluded {
+			licenses = concluded
+		}
+		r := newResult(field, licenses)
+		if len(licenses) == 0 {
+			r.License, r.Action, r.Reason = license.NOASSERTION_LICENSE, p.Missing, "no license"
+			r.Licenses = []string{license.NOASSERTION_LICENSE}
+		} else {
+			r.Action, r.Licenses = p.evaluate(r.License, exception)
+			if exception != nil && exception.covers(r.Licenses) {
+				r.Reason = "exception: " + exception.Reason
+			}
+		}
+		results = append(results, r)
+	}
+
+	if p.Mismatch != "" && len(declared) > 0 && len(concluded) > 0 {
+		decl, conc := joinLicenses(declared), joinLicenses(concluded)
+		if !sameLicense(decl, conc) {
+			r := newResult(FieldMismatch, concluded)
+			r.Action, r.Reason = p.Mismatch, "declared license "+decl+" differs from concluded license "+conc
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// evaluate returns the action of a license expression and the licenses deciding it,
+// an expression failing to parse is looked up as a single license
+func (p *Policy) evaluate(expression string, exception *Exception) (Action, []string) {
+	lookup := func(license string) Action {
+		return p.lookup(license, exception)
+	}
+	expr, err := license.ParseExpression(expression)
+	if err != nil {
+		return lookup(expression), []string{expression}
+	}
+	return evaluateExpression(expr, lookup)
+}
+
+// evaluateExpression returns the action of the expression and the licenses deciding it.
+// All licenses of an AND must be acceptable, so the most restrictive one wins;
+// any license of an OR can be chosen, so the most permissive one wins.
+func evaluateExpression(expr *license.Expression, lookup func(license string) Action) (Action, []string) {
+	if expr.Operator == "" {
+		l := expr.String()
+		return lookup(l), []string{l}
+	}
+	var result Action
+	var licenses []string
+	for i, o := range expr.Operands {
+		action, ls := evaluateExpression(o, lookup)
+		switch {
+		case i == 0:
+			result, licenses = action, ls
+		case action == result:
+			licenses = append(licenses, ls...)
+		case strings.EqualFold(expr.Operator, license.OperatorAnd) == (action.rank() > result.rank()):
+			result, licenses = action, ls
+		}
+	}
+	return result, licenses
+}
+
+// lookup returns the action of a license, "<id> WITH <exception>" falls back to the license id if not listed
+func (p *Policy) lookup(license string, exception *Exception) Action {
+	key := licenseKey(license)
+	for {
+		if exception != nil {
+			if a, ok := exception.actions[key]; ok {
+				return a
+			}
+		}
+		if a, ok := p.actions[key]; ok {
+			return a
+		}
+		i := strings.Index(key, " with ")
+		if i < 0 {
+			return p.Default
+		}
+		key = key[:i]
+	}
+}
+
+// covers returns true if any of the licenses is listed in the exception
+func (e *Exception) covers(licenses []string) bool {
+	for _, l := range licenses {
+		key := licenseKey(l)
+		for {
+			if _, ok := e.actions[key]; ok {
+				return true
+			}
+			i := strings.Index(key, " with ")
+			if i < 0 {
+				break
+			}
+			key = key[:i]
+		}
+	}
+	return false
+}
+
+// filterLicenses removes empty, NOASSERTION and NONE licenses
+func filterLicenses(licenses []string) []string {
+	result := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.EqualFold(l, license.NOASSERTION_LICENSE) || strings.EqualFold(l, license.NONE_LICENSE) {
+			continue
+		}
+		result = append(result, l)
+	}
+	return result
+}
+
+// joinLicenses combines the licenses of a package with AND as the spec writers do
+func joinLicenses(licenses []string) string {
+	expr := &license.Expression{Operator: license.OperatorAnd, Operands: make([]*license.Expression, 0, len(licenses))}
+	for _, l := range licenses {
+		operand, err := license.ParseExpression(l)
+		if err != nil {
+			return strings.Join(licenses, " "+license.OperatorAnd+" ")
+		}
+		expr.Operands = append(expr.Operands, operand)
+	}
+	return expr.Simplify().String()
+}
+
+// sameLicense returns true if the expressions are equivalent ignoring the case and the order of operands
+func sameLicense(l1, l2 string) bool {
+	e1, err1 := license.ParseExpression(l1)
+	e2, err2 := license.ParseExpression(l2)
+	if err1 != nil || err2 != nil {
+		return licenseKey(l1) == licenseKey(l2)
+	}
+	return e1.Equivalent(e2)
+}
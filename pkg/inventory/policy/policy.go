@@ -0,0 +1,204 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action is the result of evaluating a license against the policy
+type Action string
+
+const (
+	ActionAllow  Action = "allow"
+	ActionReview Action = "review"
+	ActionDeny   Action = "deny"
+)
+
+// rank orders the actions from the most permissive to the most restrictive
+func (a Action) rank() int {
+	switch a {
+	case ActionAllow:
+		return 0
+	case ActionReview:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (a Action) valid() bool {
+	return a == ActionAllow || a == ActionReview || a == ActionDeny
+}
+
+// license fields of a package to evaluate
+const (
+	FieldDeclared  = "declared"
+	FieldConcluded = "concluded"
+	// FieldEffective evaluates the concluded licenses, or the declared licenses if nothing is concluded
+	FieldEffective = "effective"
+	// FieldBoth evaluates the declared and the concluded licenses separately
+	FieldBoth = "both"
+	// FieldMismatch is the field of results for differing declared and concluded licenses
+	FieldMismatch = "mismatch"
+)
+
+// Policy is a license policy
+type Policy struct {
+	// Allow, Deny and Review are SPDX license ids, or "<id> WITH <exception>" for a license with an exception
+	Allow  []string `yaml:"allow" json:"allow"`
+	Deny   []string `yaml:"deny" json:"deny"`
+	Review []string `yaml:"review" json:"review"`
+	// Default is the action for licenses not listed, review if empty
+	Default Action `yaml:"default" json:"default"`
+	// Missing is the action for packages without license(empty, NOASSERTION or NONE), review if empty
+	Missing Action `yaml:"missing" json:"missing"`
+	// Field is the license field to evaluate, effective if empty
+	Field string `yaml:"field" json:"field"`
+	// Mismatch is the action when both licenses are present and the declared differ from the concluded, not checked if empty
+	Mismatch Action `yaml:"mismatch" json:"mismatch"`
+	// Exceptions are per-package exceptions, the first matched exception wins
+	Exceptions []Exception `yaml:"exceptions" json:"exceptions"`
+
+	actions map[string]Action
+}
+
+// Exception overrides the policy for packages matched by a PURL glob
+type Exception struct {
+	// PURL is a glob of package urls, "*" matches any characters. A pattern without version also matches all versions
+	PURL string `yaml:"purl" json:"purl"`
+	// Licenses are the licenses the exception applies to, empty for the whole package
+	Licenses []string `yaml:"licenses" json:"licenses"`
+	// Action is the action for the matched package or licenses, allow if empty
+	Action Action `yaml:"action" json:"action"`
+	Reason string `yaml:"reason" json:"reason"`
+
+	pattern *regexp.Regexp
+	actions map[string]Action
+}
+
+// LoadPolicy loads a yaml policy file
+func LoadPolicy(path string) (*Policy, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err = decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("parse policy %s error: %w", path, err)
+	}
+	if err = policy.Init(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Init validates the policy and fills the defaults, it must be called before evaluating a policy not loaded by LoadPolicy
+func (p *Policy) Init() error {
+	if p.Default == "" {
+		p.Default = ActionReview
+	}
+	if p.Missing == "" {
+		p.Missing = ActionReview
+	}
+	if p.Field == "" {
+		p.Field = FieldEffective
+	}
+	for _, a := range []Action{p.Default, p.Missing} {
+		if !a.valid() {
+			return fmt.Errorf("unknown action: %s", a)
+		}
+	}
+	if p.Mismatch != "" && !p.Mismatch.valid() {
+		return fmt.Errorf("unknown action: %s", p.Mismatch)
+	}
+	switch p.Field {
+	case FieldDeclared, FieldConcluded, FieldEffective, FieldBoth:
+	default:
+		return fmt.Errorf("unknown license field: %s", p.Field)
+	}
+
+	p.actions = make(map[string]Action)
+	for action, licenses := range map[Action][]string{ActionAllow: p.Allow, ActionReview: p.Review, ActionDeny: p.Deny} {
+		for _, l := range licenses {
+			key := licenseKey(l)
+			if a, ok := p.actions[key]; ok && a != action {
+				return fmt.Errorf("license %s is both %s and %s", l, a, action)
+			}
+			p.actions[key] = action
+		}
+	}
+	for i := range p.Exceptions {
+		e := &p.Exceptions[i]
+		if e.PURL == "" {
+			return fmt.Errorf("purl of exception %d is empty", i+1)
+		}
+		if e.Action == "" {
+			e.Action = ActionAllow
+		}
+		if !e.Action.valid() {
+			return fmt.Errorf("unknown action: %s", e.Action)
+		}
+		e.pattern = globPattern(e.PURL)
+		e.actions = make(map[string]Action)
+		for _, l := range e.Licenses {
+			e.actions[licenseKey(l)] = e.Action
+		}
+	}
+	return nil
+}
+
+// exception returns the first exception matching the purl
+func (p *Policy) exception(purl string) *Exception {
+	for i := range p.Exceptions {
+		if p.Exceptions[i].match(purl) {
+			return &p.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// match returns true if the purl, or the purl without version if the pattern has no version, matches the pattern
+func (e *Exception) match(purl string) bool {
+	if e.pattern.MatchString(purl) {
+		return true
+	}
+	if strings.Contains(e.PURL, "@") {
+		return false
+	}
+	if i := strings.IndexAny(purl, "@?#"); i >= 0 {
+		return e.pattern.MatchString(purl[:i])
+	}
+	return false
+}
+
+// globPattern converts a glob to a regexp, "*" matches any characters and "?" matches a single character
+func globPattern(glob string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(glob)
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+// licenseKey normalizes a license for lookup, SPDX license ids are case-insensitive
+func licenseKey(license string) string {
+	return strings.ToLower(strings.Join(strings.Fields(license), " "))
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	p, err := LoadPolicy("test_material/policy.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, FieldEffective, p.Field)
+	assert.Equal(t, ActionReview, p.Mismatch)
+	assert.Len(t, p.Exceptions, 2)
+	assert.Equal(t, ActionAllow, p.Exceptions[0].Action)
+
+	for _, invalid := range []*Policy{
+		{Default: "ignore"},
+		{Field: "all"},
+		{Allow: []string{"MIT"}, Deny: []string{"mit"}},
+		{Exceptions: []Exception{{Reason: "no purl"}}},
+	} {
+		assert.Error(t, invalid.Init())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p, err := LoadPolicy("test_material/policy.yaml")
+	assert.NoError(t, err)
+
+	pkgs := []model.Package{
+		{Name: "a", PURL: "pkg:npm/a@1.0.0", LicenseDeclared: []string{"MIT"}},
+		{Name: "b", PURL: "pkg:npm/b@1.0.0", LicenseDeclared: []string{"MIT OR GPL-3.0-only"}},
+		{Name: "c", PURL: "pkg:npm/c@1.0.0", LicenseDeclared: []string{"MIT", "GPL-3.0-only"}},
+		{Name: "d", PURL: "pkg:npm/d@1.0.0", LicenseDeclared: []string{"NOASSERTION"}},
+		{Name: "e", PURL: "pkg:maven/org.example/e@1.0.0", LicenseDeclared: []string{"GPL-2.0-only WITH Classpath-exception-2.0"}},
+		{Name: "f", PURL: "pkg:maven/org.example/f@1.0.0", LicenseDeclared: []string{"GPL-2.0-only WITH Autoconf-exception-2.0"}},
+		{Name: "g", PURL: "pkg:npm/g@1.0.0", LicenseDeclared: []string{"MIT"}, LicenseConcluded: []string{"GPL-3.0-only"}},
+		{Name: "h", PURL: "pkg:npm/h@1.0.0", LicenseDeclared: []string{"MIT", "Apache-2.0"}, LicenseConcluded: []string{"Apache-2.0 AND MIT"}},
+		{Name: "internal-core", PURL: "pkg:maven/com.example/internal-core@2.0", LicenseDeclared: []string{"AGPL-3.0-only"}},
+		{Name: "legacy-lib", PURL: "pkg:npm/legacy-lib@1.2.0", LicenseDeclared: []string{"GPL-3.0-only AND MIT"}},
+		{Name: "legacy-lib", PURL: "pkg:npm/legacy-lib@2.0.0", LicenseDeclared: []string{"GPL-3.0-only AND MIT"}},
+		{Name: "i", PURL: "pkg:npm/i@1.0.0", LicenseDeclared: []string{"WTFPL"}},
+	}
+	report := Check(p, pkgs)
+	assert.Equal(t, Summary{Packages: 12, Allowed: 5, Review: 3, Denied: 4}, report.Summary)
+	assert.True(t, report.Failed(false))
+
+	actions := make(map[string]Action)
+	for _, r := range report.Results {
+		actions[r.Field+" "+r.PURL] = r.Action
+	}
+	assert.Equal(t, map[string]Action{
+		"declared pkg:npm/a@1.0.0":                         ActionAllow,
+		"declared pkg:npm/b@1.0.0":                         ActionAllow,
+		"declared pkg:npm/c@1.0.0":                         ActionDeny,
+		"declared pkg:npm/d@1.0.0":                         ActionReview,
+		"declared pkg:maven/org.example/e@1.0.0":           ActionAllow,
+		"declared pkg:maven/org.example/f@1.0.0":           ActionDeny,
+		"concluded pkg:npm/g@1.0.0":                        ActionDeny,
+		"mismatch pkg:npm/g@1.0.0":                         ActionReview,
+		"concluded pkg:npm/h@1.0.0":                        ActionAllow,
+		"declared pkg:maven/com.example/internal-core@2.0": ActionAllow,
+		"declared pkg:npm/legacy-lib@1.2.0":                ActionReview,
+		"declared pkg:npm/legacy-lib@2.0.0":                ActionDeny,
+		"declared pkg:npm/i@1.0.0":                         ActionReview,
+	}, actions)
+
+	for _, r := range report.Results {
+		switch r.PURL {
+		case "pkg:npm/c@1.0.0":
+			assert.Equal(t, "MIT AND GPL-3.0-only", r.License)
+			assert.Equal(t, []string{"GPL-3.0-only"}, r.Licenses)
+		case "pkg:npm/legacy-lib@1.2.0":
+			assert.Equal(t, "exception: legacy dependency to be replaced", r.Reason)
+		}
+	}
+}
+
+func TestCheck_Field(t *testing.T) {
+	pkgs := []model.Package{
+		{Name: "a", PURL: "pkg:npm/a@1.0.0", LicenseDeclared: []string{"GPL-3.0-only"}, LicenseConcluded: []string{"MIT"}},
+	}
+	tests := []struct {
+		field string
+		want  Action
+	}{
+		{field: FieldDeclared, want: ActionDeny},
+		{field: FieldConcluded, want: ActionAllow},
+		{field: FieldEffective, want: ActionAllow},
+		{field: FieldBoth, want: ActionDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			p := &Policy{Allow: []string{"MIT"}, Deny: []string{"GPL-3.0-only"}, Field: tt.field}
+			assert.NoError(t, p.Init())
+			report := Check(p, pkgs)
+			assert.Equal(t, tt.want == ActionDeny, report.Failed(true))
+		})
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	p := &Policy{Allow: []string{"MIT"}, Deny: []string{"GPL-3.0-only"}}
+	assert.NoError(t, p.Init())
+	report := Check(p, []model.Package{
+		{Name: "a", PURL: "pkg:npm/a@1.0.0", LicenseDeclared: []string{"MIT"}},
+		{Name: "b", PURL: "pkg:npm/b@1.0.0", LicenseDeclared: []string{"GPL-3.0-only"}},
+		{Name: "c", PURL: "pkg:npm/c@1.0.0", LicenseDeclared: []string{"ISC"}},
+	})
+
+	for _, failOnReview := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, WriteJUnit(buf, report, failOnReview))
+		suites := junitTestSuites{}
+		assert.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+		assert.Equal(t, 3, suites.Tests)
+		if failOnReview {
+			assert.Equal(t, 2, suites.Failures)
+			assert.Equal(t, 0, suites.Skipped)
+		} else {
+			assert.Equal(t, 1, suites.Failures)
+			assert.Equal(t, 1, suites.Skipped)
+		}
+		assert.Equal(t, "deny", suites.Suites[0].TestCases[1].Failure.Type)
+	}
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	actions := map[string]Action{"MIT": ActionAllow, "Apache-2.0": ActionAllow, "LGPL-2.1-only": ActionReview, "GPL-3.0-only": ActionDeny}
+	lookup := func(license string) Action {
+		if a, ok := actions[license]; ok {
+			return a
+		}
+		return ActionReview
+	}
+	tests := []struct {
+		expression string
+		want       Action
+		licenses   []string
+	}{
+		{expression: "MIT", want: ActionAllow, licenses: []string{"MIT"}},
+		{expression: "MIT OR GPL-3.0-only", want: ActionAllow, licenses: []string{"MIT"}},
+		{expression: "MIT AND GPL-3.0-only", want: ActionDeny, licenses: []string{"GPL-3.0-only"}},
+		{expression: "MIT AND (LGPL-2.1-only OR GPL-3.0-only)", want: ActionReview, licenses: []string{"LGPL-2.1-only"}},
+		{expression: "GPL-3.0-only AND (MIT OR Apache-2.0)", want: ActionDeny, licenses: []string{"GPL-3.0-only"}},
+		{expression: "LGPL-2.1-only AND Unknown", want: ActionReview, licenses: []string{"LGPL-2.1-only", "Unknown"}},
+		{expression: "MIT and (Apache-2.0 and GPL-3.0-only)", want: ActionDeny, licenses: []string{"GPL-3.0-only"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := license.ParseExpression(tt.expression)
+			assert.NoError(t, err)
+			got, licenses := evaluateExpression(expr, lookup)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.licenses, licenses)
+		})
+	}
+}
+
+func TestJoinLicenses(t *testing.T) {
+	assert.Equal(t, "MIT", joinLicenses([]string{"MIT"}))
+	assert.Equal(t, "MIT AND (Apache-2.0 OR ISC) AND BSD-3-Clause", joinLicenses([]string{"MIT", "Apache-2.0 OR ISC", "BSD-3-Clause AND MIT"}))
+	assert.Equal(t, "MIT AND Apache 2.0", joinLicenses([]string{"MIT", "Apache 2.0"}))
+	assert.True(t, sameLicense("MIT AND (ISC OR Apache-2.0)", "(apache-2.0 or isc) and mit"))
+	assert.False(t, sameLicense("MIT AND Apache-2.0", "MIT OR Apache-2.0"))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package policy
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the report as indented json
+func WriteJSON(writer io.Writer, report *Report) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as a JUnit xml, a test case for each result.
+// Denied results are failures, results requiring review are failures if failOnReview is true, otherwise skipped.
+func WriteJUnit(writer io.Writer, report *Report, failOnReview bool) error {
+	suite := junitTestSuite{Name: "license-policy", TestCases: make([]junitTestCase, 0, len(report.Results))}
+	for _, r := range report.Results {
+		tc := junitTestCase{ClassName: r.PURL, Name: r.Field + " license " + r.License}
+		if r.Action != ActionAllow {
+			msg := &junitMessage{Message: resultMessage(r), Type: string(r.Action), Text: r.Reason}
+			if r.Action == ActionDeny || failOnReview {
+				tc.Failure = msg
+				suite.Failures++
+			} else {
+				tc.Skipped = msg
+				suite.Skipped++
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+	suites := junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Skipped: suite.Skipped, Suites: []junitTestSuite{suite}}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+// resultMessage describes a result which is not allowed
+func resultMessage(r Result) string {
+	if r.Field == FieldMismatch {
+		return fmt.Sprintf("%s: %s", r.Action, r.Reason)
+	}
+	return fmt.Sprintf("%s %s license of %s: %s", r.Action, r.Field, r.PURL, strings.Join(r.Licenses, ", "))
+}
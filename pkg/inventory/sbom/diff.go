@@ -0,0 +1,391 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package sbom
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// ChangeKind is the kind of a change between two SBOM documents
+type ChangeKind = string
+
+const (
+	ChangeAdded      ChangeKind = "added"
+	ChangeRemoved    ChangeKind = "removed"
+	ChangeUpgraded   ChangeKind = "upgraded"
+	ChangeDowngraded ChangeKind = "downgraded"
+	ChangeChanged    ChangeKind = "changed"
+	ChangeLicense    ChangeKind = "license"
+	ChangeSupplier   ChangeKind = "supplier"
+	ChangeDependency ChangeKind = "dependency"
+	ChangeFile       ChangeKind = "file"
+)
+
+// AllChangeKinds returns the kinds of changes which can be counted by SBOMDiff.Count
+func AllChangeKinds() []ChangeKind {
+	return []ChangeKind{ChangeAdded, ChangeRemoved, ChangeUpgraded, ChangeDowngraded,
+		ChangeLicense, ChangeSupplier, ChangeDependency, ChangeFile}
+}
+
+// PackageChange is a package added, removed, upgraded or downgraded
+type PackageChange struct {
+	Kind       ChangeKind `json:"kind"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	PURL       string     `json:"purl"` // purl in the target document, in the base document for removed packages
+	OldVersion string     `json:"oldVersion,omitempty"`
+	NewVersion string     `json:"newVersion,omitempty"`
+}
+
+// LicenseChange is a change of the declared or concluded licenses of a package
+type LicenseChange struct {
+	Name  string   `json:"name"`
+	PURL  string   `json:"purl"`
+	Field string   `json:"field"` // declared or concluded
+	Old   []string `json:"old"`
+	New   []string `json:"new"`
+}
+
+// SupplierChange is a change of the supplier of a package
+type SupplierChange struct {
+	Name string `json:"name"`
+	PURL string `json:"purl"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// DependencyChange is a dependency edge added or removed, packages are identified by purls without version
+type DependencyChange struct {
+	Kind ChangeKind `json:"kind"`
+	From string     `json:"from"`
+	To   string     `json:"to"`
+}
+
+// FileChange is an artifact file added, removed or changed
+type FileChange struct {
+	Kind        ChangeKind `json:"kind"`
+	Name        string     `json:"name"`
+	OldChecksum string     `json:"oldChecksum,omitempty"`
+	NewChecksum string     `json:"newChecksum,omitempty"`
+}
+
+// SBOMDiff is the difference between a base and a target SBOM document
+type SBOMDiff struct {
+	Packages     []PackageChange    `json:"packages"`
+	Licenses     []LicenseChange    `json:"licenses"`
+	Suppliers    []SupplierChange   `json:"suppliers"`
+	Dependencies []DependencyChange `json:"dependencies"`
+	Files        []FileChange       `json:"files"`
+}
+
+// Count returns the number of changes of the kind
+func (d *SBOMDiff) Count(kind ChangeKind) int {
+	switch kind {
+	case ChangeLicense:
+		return len(d.Licenses)
+	case ChangeSupplier:
+		return len(d.Suppliers)
+	case ChangeDependency:
+		return len(d.Dependencies)
+	case ChangeFile:
+		return len(d.Files)
+	default:
+		return util.SliceCount(d.Packages, func(c PackageChange) bool {
+			return c.Kind == kind
+		})
+	}
+}
+
+// IsEmpty returns true if there is no change
+func (d *SBOMDiff) IsEmpty() bool {
+	return len(d.Packages)+len(d.Licenses)+len(d.Suppliers)+len(d.Dependencies)+len(d.Files) == 0
+}
+
+// LoadSBOM loads a SBOM document of any supported format
+func LoadSBOM(path string) (*model.SBOM, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	sbomFormat, err := spec.DetectFormat(file)
+	if err != nil {
+		return nil, fmt.Errorf("load %s error: %w", path, err)
+	}
+	return sbomFormat.Spec().ToModel(), nil
+}
+
+// DiffSBOM compares the packages, dependencies and artifact files of two SBOM documents,
+// packages are matched by purl without version, so a version change is reported as an upgrade or a downgrade
+func DiffSBOM(base, target *model.SBOM) *SBOMDiff {
+	diff := &SBOMDiff{
+		Packages:     make([]PackageChange, 0),
+		Licenses:     make([]LicenseChange, 0),
+		Suppliers:    make([]SupplierChange, 0),
+		Dependencies: make([]DependencyChange, 0),
+		Files:        make([]FileChange, 0),
+	}
+	diffPackages(diff, base.Packages, target.Packages)
+	diffDependencies(diff, base.Packages, target.Packages)
+	diffFiles(diff, base.Artifact.Files, target.Artifact.Files)
+	return diff
+}
+
+func diffPackages(diff *SBOMDiff, basePkgs, targetPkgs []model.Package) {
+	baseGroups := util.SliceGroup(basePkgs, packageKey)
+	targetGroups := util.SliceGroup(targetPkgs, packageKey)
+	keys := make([]string, 0, len(baseGroups)+len(targetGroups))
+	for key := range baseGroups {
+		keys = append(keys, key)
+	}
+	for key := range targetGroups {
+		if _, ok := baseGroups[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		olds, news := matchPackages(baseGroups[key], targetGroups[key])
+		for i := range olds {
+			switch {
+			case olds[i] == nil:
+				diff.Packages = append(diff.Packages, packageChange(ChangeAdded, nil, news[i]))
+			case news[i] == nil:
+				diff.Packages = append(diff.Packages, packageChange(ChangeRemoved, olds[i], nil))
+			default:
+				if c := util.CompareVersion(news[i].Version, olds[i].Version); c > 0 {
+					diff.Packages = append(diff.Packages, packageChange(ChangeUpgraded, olds[i], news[i]))
+				} else if c < 0 {
+					diff.Packages = append(diff.Packages, packageChange(ChangeDowngraded, olds[i], news[i]))
+				}
+				diffPackageInfo(diff, olds[i], news[i])
+			}
+		}
+	}
+}
+
+// matchPackages pairs the versions of a package in two documents, the same versions are paired first,
+// then the rest in version order, a nil in the pairs means the package is added or removed
+func matchPackages(basePkgs, targetPkgs []model.Package) ([]*model.Package, []*model.Package) {
+	olds := make([]*model.Package, 0)
+	news := make([]*model.Package, 0)
+	matched := make([]bool, len(targetPkgs))
+	restOlds := make([]*model.Package, 0)
+	for i := range basePkgs {
+		j := -1
+		for k := range targetPkgs {
+			if !matched[k] && targetPkgs[k].Version == basePkgs[i].Version {
+				j = k
+				break
+			}
+		}
+		if j > -1 {
+			matched[j] = true
+			olds, news = append(olds, &basePkgs[i]), append(news, &targetPkgs[j])
+		} else {
+			restOlds = append(restOlds, &basePkgs[i])
+		}
+	}
+	restNews := make([]*model.Package, 0)
+	for j := range targetPkgs {
+		if !matched[j] {
+			restNews = append(restNews, &targetPkgs[j])
+		}
+	}
+	byVersion := func(p1, p2 *model.Package) bool {
+		return util.CompareVersion(p1.Version, p2.Version) < 0
+	}
+	restOlds, restNews = util.SliceSort(restOlds, byVersion), util.SliceSort(restNews, byVersion)
+	for i := 0; i < len(restOlds) || i < len(restNews); i++ {
+		var oldPkg, newPkg *model.Package
+		if i < len(restOlds) {
+			oldPkg = restOlds[i]
+		}
+		if i < len(restNews) {
+			newPkg = restNews[i]
+		}
+		olds, news = append(olds, oldPkg), append(news, newPkg)
+	}
+	return olds, news
+}
+
+func packageChange(kind ChangeKind, oldPkg, newPkg *model.Package) PackageChange {
+	change := PackageChange{Kind: kind}
+	if oldPkg != nil {
+		change.Name, change.Type, change.PURL, change.OldVersion = oldPkg.Name, oldPkg.Type, oldPkg.PURL, oldPkg.Version
+	}
+	if newPkg != nil {
+		change.Name, change.Type, change.PURL, change.NewVersion = newPkg.Name, newPkg.Type, newPkg.PURL, newPkg.Version
+	}
+	return change
+}
+
+func diffPackageInfo(diff *SBOMDiff, oldPkg, newPkg *model.Package) {
+	for _, field := range []struct {
+		name     string
+		old, new []string
+	}{
+		{"declared", oldPkg.LicenseDeclared, newPkg.LicenseDeclared},
+		{"concluded", oldPkg.LicenseConcluded, newPkg.LicenseConcluded},
+	} {
+		if !sameStrings(field.old, field.new) {
+			diff.Licenses = append(diff.Licenses, LicenseChange{
+				Name:  newPkg.Name,
+				PURL:  newPkg.PURL,
+				Field: field.name,
+				Old:   field.old,
+				New:   field.new,
+			})
+		}
+	}
+	if oldPkg.Supplier != newPkg.Supplier {
+		diff.Suppliers = append(diff.Suppliers, SupplierChange{
+			Name: newPkg.Name,
+			PURL: newPkg.PURL,
+			Old:  oldPkg.Supplier,
+			New:  newPkg.Supplier,
+		})
+	}
+}
+
+func diffDependencies(diff *SBOMDiff, basePkgs, targetPkgs []model.Package) {
+	baseEdges, targetEdges := dependencyEdges(basePkgs), dependencyEdges(targetPkgs)
+	baseSet, targetSet := edgeSet(baseEdges), edgeSet(targetEdges)
+	for _, edge := range baseEdges {
+		if _, ok := targetSet[edge]; !ok {
+			diff.Dependencies = append(diff.Dependencies, DependencyChange{Kind: ChangeRemoved, From: edge[0], To: edge[1]})
+		}
+	}
+	for _, edge := range targetEdges {
+		if _, ok := baseSet[edge]; !ok {
+			diff.Dependencies = append(diff.Dependencies, DependencyChange{Kind: ChangeAdded, From: edge[0], To: edge[1]})
+		}
+	}
+}
+
+// dependencyEdges returns the sorted dependency edges between packages identified by purls without version
+func dependencyEdges(pkgs []model.Package) [][2]string {
+	edges := make([][2]string, 0)
+	seen := make(map[[2]string]struct{})
+	for i := range pkgs {
+		from := packageKey(pkgs[i])
+		for _, dep := range pkgs[i].Dependencies {
+			edge := [2]string{from, purlKey(dep)}
+			if _, ok := seen[edge]; !ok {
+				seen[edge] = struct{}{}
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return util.SliceSort(edges, func(e1, e2 [2]string) bool {
+		return e1[0] < e2[0] || e1[0] == e2[0] && e1[1] < e2[1]
+	})
+}
+
+func edgeSet(edges [][2]string) map[[2]string]struct{} {
+	set := make(map[[2]string]struct{}, len(edges))
+	for _, edge := range edges {
+		set[edge] = struct{}{}
+	}
+	return set
+}
+
+func diffFiles(diff *SBOMDiff, baseFiles, targetFiles []model.File) {
+	baseMap := util.SliceToMap(baseFiles, func(f model.File) string { return f.Name },
+		func(f model.File) model.File { return f }, func(f1, _ model.File) model.File { return f1 })
+	targetMap := util.SliceToMap(targetFiles, func(f model.File) string { return f.Name },
+		func(f model.File) model.File { return f }, func(f1, _ model.File) model.File { return f1 })
+	for _, f := range util.SliceSort(append([]model.File{}, baseFiles...), fileNameLess) {
+		newFile, ok := targetMap[f.Name]
+		switch {
+		case !ok:
+			diff.Files = append(diff.Files, FileChange{Kind: ChangeRemoved, Name: f.Name, OldChecksum: fileChecksum(f)})
+		case checksumChanged(f.Checksums, newFile.Checksums):
+			diff.Files = append(diff.Files, FileChange{Kind: ChangeChanged, Name: f.Name,
+				OldChecksum: fileChecksum(f), NewChecksum: fileChecksum(newFile)})
+		}
+	}
+	for _, f := range util.SliceSort(append([]model.File{}, targetFiles...), fileNameLess) {
+		if _, ok := baseMap[f.Name]; !ok {
+			diff.Files = append(diff.Files, FileChange{Kind: ChangeAdded, Name: f.Name, NewChecksum: fileChecksum(f)})
+		}
+	}
+}
+
+func fileNameLess(f1, f2 model.File) bool {
+	return f1.Name < f2.Name
+}
+
+// checksumChanged returns true if any checksum of the same algorithm differs
+func checksumChanged(old, new []model.FileChecksum) bool {
+	for _, c1 := range old {
+		for _, c2 := range new {
+			if c1.Algorithm == c2.Algorithm && !strings.EqualFold(c1.Value, c2.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fileChecksum returns the strongest checksum of a file, formatted as ALGORITHM:value
+func fileChecksum(f model.File) string {
+	for _, alg := range []model.ChecksumAlgorithm{model.ChecksumSHA256, model.ChecksumSHA1, model.ChecksumMD5} {
+		for _, c := range f.Checksums {
+			if c.Algorithm == alg {
+				return string(c.Algorithm) + ":" + c.Value
+			}
+		}
+	}
+	if len(f.Checksums) > 0 {
+		return string(f.Checksums[0].Algorithm) + ":" + f.Checksums[0].Value
+	}
+	return ""
+}
+
+// packageKey identifies a package regardless of its version
+func packageKey(pkg model.Package) string {
+	if pkg.PURL != "" {
+		return purlKey(pkg.PURL)
+	}
+	return pkg.Type + "/" + pkg.Name
+}
+
+// purlKey returns the purl without version
+func purlKey(purl string) string {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return purl
+	}
+	p.Version = ""
+	return p.ToString()
+}
+
+func sameStrings(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	return util.SliceEqual(util.SliceSort(append([]string{}, s1...), func(a, b string) bool { return a < b }),
+		util.SliceSort(append([]string{}, s2...), func(a, b string) bool { return a < b }),
+		func(a, b string) bool { return a == b })
+}
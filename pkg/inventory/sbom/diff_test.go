@@ -0,0 +1,110 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestDiffSBOM(t *testing.T) {
+	base := &model.SBOM{
+		Packages: []model.Package{
+			{Name: "fastjson", Version: "1.2.78", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.78",
+				LicenseDeclared: []string{"Apache-2.0"}},
+			{Name: "logback-classic", Version: "1.2.11", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.11",
+				Supplier: "qos.ch", Dependencies: []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+			{Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+			{Name: "guava", Version: "32.0.0", Type: "maven", PURL: "pkg:maven/com.google.guava/guava@32.0.0"},
+		},
+		Artifact: model.Artifact{Files: []model.File{
+			{Name: "demo.jar", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "aaa"}}},
+			{Name: "lib/old.jar", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "bbb"}}},
+			{Name: "README", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "ccc"}}},
+		}},
+	}
+	target := &model.SBOM{
+		Packages: []model.Package{
+			{Name: "fastjson", Version: "1.2.83", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.83",
+				LicenseDeclared: []string{"Apache-2.0"}},
+			{Name: "logback-classic", Version: "1.2.9", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.9",
+				Supplier: "QOS.ch", LicenseDeclared: []string{"EPL-1.0"}},
+			{Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+			{Name: "commons-io", Version: "2.15.0", Type: "maven", PURL: "pkg:maven/commons-io/commons-io@2.15.0",
+				Dependencies: []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+		},
+		Artifact: model.Artifact{Files: []model.File{
+			{Name: "demo.jar", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "abc"}}},
+			{Name: "lib/new.jar", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "ddd"}}},
+			{Name: "README", Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "CCC"}}},
+		}},
+	}
+
+	diff := DiffSBOM(base, target)
+	assert.Equal(t, []PackageChange{
+		{Kind: ChangeDowngraded, Name: "logback-classic", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.9",
+			OldVersion: "1.2.11", NewVersion: "1.2.9"},
+		{Kind: ChangeUpgraded, Name: "fastjson", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.83",
+			OldVersion: "1.2.78", NewVersion: "1.2.83"},
+		{Kind: ChangeRemoved, Name: "guava", Type: "maven", PURL: "pkg:maven/com.google.guava/guava@32.0.0", OldVersion: "32.0.0"},
+		{Kind: ChangeAdded, Name: "commons-io", Type: "maven", PURL: "pkg:maven/commons-io/commons-io@2.15.0", NewVersion: "2.15.0"},
+	}, diff.Packages)
+	assert.Equal(t, []LicenseChange{
+		{Name: "logback-classic", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.9", Field: "declared", New: []string{"EPL-1.0"}},
+	}, diff.Licenses)
+	assert.Equal(t, []SupplierChange{
+		{Name: "logback-classic", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.9", Old: "qos.ch", New: "QOS.ch"},
+	}, diff.Suppliers)
+	assert.Equal(t, []DependencyChange{
+		{Kind: ChangeRemoved, From: "pkg:maven/ch.qos.logback/logback-classic", To: "pkg:maven/org.slf4j/slf4j-api"},
+		{Kind: ChangeAdded, From: "pkg:maven/commons-io/commons-io", To: "pkg:maven/org.slf4j/slf4j-api"},
+	}, diff.Dependencies)
+	assert.Equal(t, []FileChange{
+		{Kind: ChangeChanged, Name: "demo.jar", OldChecksum: "SHA1:aaa", NewChecksum: "SHA1:abc"},
+		{Kind: ChangeRemoved, Name: "lib/old.jar", OldChecksum: "SHA1:bbb"},
+		{Kind: ChangeAdded, Name: "lib/new.jar", NewChecksum: "SHA1:ddd"},
+	}, diff.Files)
+
+	assert.Equal(t, 1, diff.Count(ChangeAdded))
+	assert.Equal(t, 1, diff.Count(ChangeUpgraded))
+	assert.Equal(t, 3, diff.Count(ChangeFile))
+	assert.True(t, DiffSBOM(target, target).IsEmpty())
+}
+
+func TestMatchPackages(t *testing.T) {
+	pkg := func(version string) model.Package {
+		return model.Package{Name: "lodash", Version: version, PURL: "pkg:npm/lodash@" + version}
+	}
+	olds, news := matchPackages([]model.Package{pkg("4.17.21"), pkg("3.10.1"), pkg("2.4.2")},
+		[]model.Package{pkg("4.17.21"), pkg("3.10.2")})
+	versions := func(pkgs []*model.Package) []string {
+		ret := make([]string, 0, len(pkgs))
+		for _, p := range pkgs {
+			if p == nil {
+				ret = append(ret, "")
+			} else {
+				ret = append(ret, p.Version)
+			}
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"4.17.21", "2.4.2", "3.10.1"}, versions(olds))
+	assert.Equal(t, []string{"4.17.21", "3.10.2", ""}, versions(news))
+
+	// the duplicated versions are paired with the targets not matched yet
+	olds, news = matchPackages([]model.Package{pkg("4.17.21"), pkg("4.17.21")},
+		[]model.Package{pkg("4.17.21"), pkg("4.17.21"), pkg("3.10.2")})
+	assert.Equal(t, []string{"4.17.21", "4.17.21", ""}, versions(olds))
+	assert.Equal(t, []string{"4.17.21", "4.17.21", "3.10.2"}, versions(news))
+}
@@ -12,6 +12,8 @@ package sbom
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/anchore/packageurl-go"
@@ -19,10 +21,15 @@ import (
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/artifact"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/image"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/deb"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/elf"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/pe"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/rpm"
 	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/source"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/vuln"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec"
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format/xspdx"
@@ -56,23 +63,56 @@ func GenerateSBOM(cfg *config.GenerateConfig) (*model.SBOM, error) {
 		return nil, fmt.Errorf("invalid format: %s", cfg.Format)
 	}
 
+	// the packages are collected in the root filesystem of the image, which is the artifact
+	var img *image.Image
+	if len(cfg.Image) > 0 {
+		var err error
+		img, err = image.Load(cfg.Image)
+		if err != nil {
+			log.Errorf("load image error: %s", err.Error())
+			return nil, err
+		}
+		defer func() {
+			_ = img.Close()
+		}()
+	}
+
 	if slices.Contains(phases, SourcePhase) && sbomFormat.Spec().Name() == xspdx.NewSpecification().Name() {
 		sourceInfo, err := source.GetSourceInfo(&cfg.SourceConfig)
 		if err != nil {
 			log.Errorf("collect source error: %s", err.Error())
+		} else {
+			sbomDoc.Source = *sourceInfo
 		}
-		sbomDoc.Source = *sourceInfo
 	}
 	if slices.Contains(phases, PackagePhase) {
 		cm := pckg.NewCollectorManager(&cfg.PackageConfig)
-		packages, err := cm.Collect(cfg.Path)
+		var packages []model.Package
+		var err error
+		if img != nil {
+			packages, err = cm.CollectImage(img)
+		} else {
+			packages, err = cm.Collect(cfg.Path)
+		}
 		if err != nil {
 			log.Errorf("collect packages error: %s", err.Error())
 			return nil, err
 		}
+		if img == nil {
+			distPackages, err := collectDistPackages(cfg)
+			if err != nil {
+				log.Errorf("collect dist packages error: %s", err.Error())
+				return nil, err
+			}
+			if len(distPackages) > 0 {
+				packages = collector.OrganizePackage(append(packages, distPackages...))
+			}
+		}
 		sbomDoc.Packages = packages
 	}
-	if slices.Contains(phases, ArtifactPhase) {
+	if slices.Contains(phases, ArtifactPhase) && img != nil {
+		sbomDoc.Artifact = *artifact.CollectImage(&cfg.ArtifactConfig, img)
+	} else if slices.Contains(phases, ArtifactPhase) {
 		artifactInfo, err := artifact.Collect(&cfg.ArtifactConfig, cfg.Path)
 		if err != nil {
 			log.Errorf("collect artifact error: %s", err.Error())
@@ -85,6 +125,15 @@ func GenerateSBOM(cfg *config.GenerateConfig) (*model.SBOM, error) {
 		}
 	}
 
+	if cfg.VulnDB != "" {
+		db, err := vuln.LoadDB(cfg.VulnDB)
+		if err != nil {
+			log.Errorf("load vulnerability database error: %s", err.Error())
+			return nil, err
+		}
+		sbomDoc.Vulnerabilities = db.Match(sbomDoc.Packages)
+	}
+
 	//package内主包相关的license需要增加给Artifact包
 	if len(sbomDoc.Artifact.LicenseDeclared) == 0 {
 		for _, p := range sbomDoc.Packages {
@@ -114,8 +163,9 @@ func GenerateComponentSBOM(cfg *config.GenerateConfig) (*model.SBOM, error) {
 		sourceInfo, err := source.GetSourceInfo(&cfg.SourceConfig)
 		if err != nil {
 			log.Errorf("collect source error: %s", err.Error())
+		} else {
+			sbomDoc.Source = *sourceInfo
 		}
-		sbomDoc.Source = *sourceInfo
 	}
 	packageConfig := config.PackageConfig{
 		Path: cfg.DistPath,
@@ -152,6 +202,37 @@ func GenerateComponentSBOM(cfg *config.GenerateConfig) (*model.SBOM, error) {
 	return sbomDoc, nil
 }
 
+// collectDistPackages collects the components of the binaries in the dist dir, e.g. the shared objects and the dlls,
+// the dist inside the project is collected with the project
+func collectDistPackages(cfg *config.GenerateConfig) ([]model.Package, error) {
+	names := make([]string, 0)
+	for _, c := range pckg.GetCollectors(cfg.Collectors) {
+		if c.GetName() == elf.Name() || c.GetName() == pe.Name() {
+			names = append(names, c.GetName())
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if stat, err := os.Stat(cfg.DistPath); err != nil || !stat.IsDir() {
+		return nil, nil
+	}
+	projectPath, _ := filepath.Abs(cfg.Path)
+	distPath, _ := filepath.Abs(cfg.DistPath)
+	if rel, err := filepath.Rel(projectPath, distPath); err == nil && rel != ".." &&
+		!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, nil
+	}
+	packageConfig := config.PackageConfig{
+		Parallelism: cfg.PackageConfig.Parallelism,
+		Collectors:  strings.Join(names, ","),
+		Path:        cfg.DistPath,
+		IgnoreDirs:  cfg.ArtifactConfig.IgnoreDirs,
+	}
+	packageConfig.InitIgnoreDirs()
+	return pckg.NewCollectorManager(&packageConfig).Collect(cfg.DistPath)
+}
+
 func getEnabledPhases(skipPhases string) []string {
 	items := strings.Split(skipPhases, ",")
 	return util.SliceFilter(allPhases, func(s string) bool {
@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package sbom
+
+import (
+	"fmt"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/artifact"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// MergeSBOM merges the documents into one document describing a new top-level artifact.
+// The artifact of each document is kept as a package contained by the new artifact and depending on
+// the direct dependencies of the document, the packages are deduplicated and the creators are united.
+// The files of the artifacts are kept under the directory named after the artifact they belong to.
+func MergeSBOM(cfg *config.MergeConfig, docs []*model.SBOM) (*model.SBOM, *collector.MergeReport, error) {
+	if len(docs) == 0 {
+		return nil, nil, fmt.Errorf("no sbom document to merge")
+	}
+	mainPkg := artifact.ArtifactPackage(&config.ArtifactConfig{
+		PackageName:     cfg.PackageName,
+		PackageVersion:  cfg.PackageVersion,
+		PackageSupplier: cfg.PackageSupplier,
+	})
+	merged := &model.SBOM{
+		NamespaceURI: cfg.NamespaceURI,
+		Artifact:     model.Artifact{Package: *mainPkg, Files: make([]model.File, 0)},
+		CreationInfo: model.CreationInfo{
+			Creators: []model.Creator{
+				{Creator: config.AppNameVersion(), CreatorType: "Tool"},
+				{Creator: cfg.PackageSupplier, CreatorType: "Organization"},
+			},
+		},
+	}
+
+	pkgs := make([]model.Package, 0)
+	subs := make([]model.Package, 0, len(docs))
+	for _, doc := range docs {
+		sub := doc.Artifact.Package
+		if sub.PURL == "" && sub.Type == "" {
+			sub.Type = model.PkgTypeGeneric
+		}
+		sub.Dependencies = rootDependencies(doc.Packages, sub.PURL)
+		pkgs = append(pkgs, sub)
+		subs = append(subs, sub)
+		pkgs = append(pkgs, util.SliceFilter(doc.Packages, func(p model.Package) bool {
+			return p.PURL != sub.PURL
+		})...)
+
+		for _, f := range doc.Artifact.Files {
+			if sub.Name != "" {
+				f.Name = sub.Name + "/" + f.Name
+			}
+			merged.Artifact.Files = append(merged.Artifact.Files, f)
+		}
+		merged.CreationInfo.Creators = append(merged.CreationInfo.Creators, doc.CreationInfo.Creators...)
+	}
+
+	report := &collector.MergeReport{Conflicts: make([]collector.MergeConflict, 0)}
+	merged.Packages = collector.OrganizePackageWithReport(pkgs, report)
+	merged.CreationInfo.Creators = util.SliceUnique(util.SliceFilter(merged.CreationInfo.Creators, func(c model.Creator) bool {
+		return c.Creator != ""
+	}))
+	merged.Artifact.Files = util.SliceUniqueFunc(merged.Artifact.Files, func(f1, _ model.File) model.File {
+		return f1
+	}, func(f1, f2 model.File) bool {
+		return f1.Name == f2.Name
+	})
+
+	for i := range subs {
+		purl := subs[i].PURL
+		if purl == "" {
+			purl = subArtifactPURL(merged.Packages, &subs[i])
+		}
+		if purl == "" {
+			continue
+		}
+		merged.Relationships = append(merged.Relationships, model.Relationship{
+			Type:   model.Contains,
+			FromID: mainPkg.PURL,
+			ToID:   purl,
+		})
+	}
+	merged.Relationships = util.SliceUnique(merged.Relationships)
+	return merged, report, nil
+}
+
+// rootDependencies returns the PURLs of the packages which no other package depends on
+func rootDependencies(pkgs []model.Package, self string) []string {
+	deps := make(map[string]struct{})
+	for _, p := range pkgs {
+		for _, dep := range p.Dependencies {
+			deps[dep] = struct{}{}
+		}
+	}
+	roots := make([]string, 0)
+	for _, p := range pkgs {
+		if _, ok := deps[p.PURL]; !ok && p.PURL != "" && p.PURL != self {
+			roots = append(roots, p.PURL)
+		}
+	}
+	return roots
+}
+
+// subArtifactPURL finds the PURL given to the artifact of a merged document when organizing the packages
+func subArtifactPURL(pkgs []model.Package, sub *model.Package) string {
+	i := util.SliceFirst(pkgs, func(p model.Package) bool {
+		return p.Type == sub.Type && p.Name == sub.Name && p.Version == sub.Version
+	})
+	if i < 0 {
+		return ""
+	}
+	return pkgs[i].PURL
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/config"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestMergeSBOM(t *testing.T) {
+	order := &model.SBOM{
+		Artifact: model.Artifact{
+			Package: model.Package{Name: "order", Version: "1.0.0", Type: "maven", PURL: "pkg:maven/com.example/order@1.0.0"},
+			Files:   []model.File{{Name: "order.jar"}},
+		},
+		Packages: []model.Package{
+			{Name: "fastjson", Version: "1.2.83", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.83",
+				LicenseDeclared: []string{"Apache-2.0"}},
+			{Name: "logback-classic", Version: "1.2.11", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.11",
+				Dependencies: []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+			{Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+		},
+		CreationInfo: model.CreationInfo{Creators: []model.Creator{{Creator: "sbom-tool-1.0.0", CreatorType: "Tool"}}},
+	}
+	user := &model.SBOM{
+		Artifact: model.Artifact{
+			Package: model.Package{Name: "user", Version: "2.1.0"},
+			Files:   []model.File{{Name: "order.jar"}},
+		},
+		Packages: []model.Package{
+			{Name: "fastjson", Version: "1.2.83", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.83",
+				LicenseDeclared: []string{"MIT"}},
+		},
+		CreationInfo: model.CreationInfo{Creators: []model.Creator{
+			{Creator: "sbom-tool-1.0.0", CreatorType: "Tool"},
+			{Creator: "Jane Doe", CreatorType: "Person"},
+		}},
+	}
+
+	cfg := &config.MergeConfig{PackageName: "shop", PackageVersion: "3.0.0", PackageSupplier: "JD"}
+	merged, report, err := MergeSBOM(cfg, []*model.SBOM{order, user})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "pkg:generic/shop@3.0.0", merged.Artifact.PURL)
+	assert.Equal(t, "JD", merged.Artifact.Supplier)
+	assert.Equal(t, []string{"order/order.jar", "user/order.jar"},
+		[]string{merged.Artifact.Files[0].Name, merged.Artifact.Files[1].Name})
+
+	purls := make([]string, 0, len(merged.Packages))
+	for _, p := range merged.Packages {
+		purls = append(purls, p.PURL)
+	}
+	assert.Equal(t, []string{
+		"pkg:generic/user@2.1.0",
+		"pkg:maven/ch.qos.logback/logback-classic@1.2.11",
+		"pkg:maven/com.alibaba/fastjson@1.2.83",
+		"pkg:maven/com.example/order@1.0.0",
+		"pkg:maven/org.slf4j/slf4j-api@1.7.36",
+	}, purls)
+	assert.Equal(t, []string{"pkg:maven/ch.qos.logback/logback-classic@1.2.11", "pkg:maven/com.alibaba/fastjson@1.2.83"},
+		merged.Packages[3].Dependencies)
+	assert.Equal(t, []string{"pkg:maven/com.alibaba/fastjson@1.2.83"}, merged.Packages[0].Dependencies)
+
+	assert.Equal(t, []model.Relationship{
+		{Type: model.Contains, FromID: "pkg:generic/shop@3.0.0", ToID: "pkg:maven/com.example/order@1.0.0"},
+		{Type: model.Contains, FromID: "pkg:generic/shop@3.0.0", ToID: "pkg:generic/user@2.1.0"},
+	}, merged.Relationships)
+
+	assert.Len(t, merged.CreationInfo.Creators, 4)
+	assert.Contains(t, merged.CreationInfo.Creators, model.Creator{Creator: "Jane Doe", CreatorType: "Person"})
+
+	assert.Equal(t, []collector.MergeConflict{
+		{PURL: "pkg:maven/com.alibaba/fastjson@1.2.83", Field: "licenseDeclared", Values: []string{"Apache-2.0", "MIT"}},
+	}, report.Conflicts)
+
+	_, _, err = MergeSBOM(cfg, nil)
+	assert.Error(t, err)
+}
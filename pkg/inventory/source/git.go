@@ -0,0 +1,311 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+// ciBranchEnvs are the environment variables of the branch in CI, which checks out a detached HEAD
+var ciBranchEnvs = []string{
+	"GITHUB_HEAD_REF",    // GitHub Actions pull request
+	"GITHUB_REF_NAME",    // GitHub Actions
+	"CI_COMMIT_REF_NAME", // GitLab CI
+	"BRANCH_NAME",        // Jenkins multibranch pipeline
+	"BUILD_SOURCEBRANCHNAME",
+}
+
+// abbrevLength is the length of the abbreviated commit hash of a describe output
+const abbrevLength = 7
+
+// repoInfo collects the git information of the repository containing the project path
+func repoInfo(projectPath string, source *model.Source, anonymizeCommitter bool) {
+	if len(projectPath) == 0 {
+		return
+	}
+	repo, err := gogit.PlainOpenWithOptions(projectPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return
+	}
+	source.Remotes = remotes(repo)
+	for _, r := range source.Remotes {
+		if r.Name == gogit.DefaultRemoteName {
+			source.Repository = r.URL
+		}
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		log.Warnf("read git HEAD error: %s", err.Error())
+		return
+	}
+	if ref.Name() == plumbing.HEAD {
+		source.Detached = true
+		source.Branch = detachedBranch(repo, ref.Hash())
+	} else {
+		source.Branch = ref.Name().Short()
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err == nil {
+		source.Revision = commit.Hash.String()
+		source.CommitTime = commit.Committer.When.UTC().Format(time.RFC3339)
+		source.Committer = committer(commit.Committer, anonymizeCommitter)
+		source.Tag = describe(repo, commit)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return
+	}
+	source.Modified = modifiedFiles(wt)
+	source.Dirty = len(source.Modified) > 0
+	source.Submodules = submodules(wt)
+}
+
+// remotes returns the remotes of the repository sorted by name, the user info of http urls is removed
+func remotes(repo *gogit.Repository) []model.Remote {
+	conf, err := repo.Config()
+	if err != nil {
+		return nil
+	}
+	var ret []model.Remote
+	for name, remote := range conf.Remotes {
+		if len(remote.URLs) == 0 {
+			continue
+		}
+		ret = append(ret, model.Remote{Name: name, URL: normalizeRepoUrl(remote.URLs[0])})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func normalizeRepoUrl(rawUrl string) string {
+	if strings.HasPrefix(rawUrl, "http") {
+		return normalizeHttpUrl(rawUrl)
+	}
+	return rawUrl
+}
+
+// detachedBranch returns the branch of a detached HEAD, which is a local or remote branch at the commit,
+// or the branch given by the CI environment
+func detachedBranch(repo *gogit.Repository, hash plumbing.Hash) string {
+	var local, remote []string
+	refs, err := repo.References()
+	if err == nil {
+		_ = refs.ForEach(func(ref *plumbing.Reference) error {
+			if ref.Type() != plumbing.HashReference || ref.Hash() != hash {
+				return nil
+			}
+			if ref.Name().IsBranch() {
+				local = append(local, ref.Name().Short())
+			} else if ref.Name().IsRemote() && !strings.HasSuffix(ref.Name().String(), "/"+plumbing.HEAD.String()) {
+				// refs/remotes/origin/main is the branch main
+				name := strings.TrimPrefix(ref.Name().String(), "refs/remotes/")
+				if i := strings.Index(name, "/"); i >= 0 {
+					remote = append(remote, name[i+1:])
+				}
+			}
+			return nil
+		})
+	}
+	for _, branches := range [][]string{local, remote} {
+		if len(branches) > 0 {
+			sort.Strings(branches)
+			return branches[0]
+		}
+	}
+	for _, env := range ciBranchEnvs {
+		if branch := os.Getenv(env); branch != "" {
+			return branch
+		}
+	}
+	return ""
+}
+
+// committer returns the committer as `name <email>`, or a hash of the email if anonymized
+func committer(sig object.Signature, anonymize bool) string {
+	if anonymize {
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(sig.Email))))
+		return "sha256:" + hex.EncodeToString(sum[:])[:16]
+	}
+	return fmt.Sprintf("%s <%s>", sig.Name, sig.Email)
+}
+
+// describeCandidates is the number of the nearest tagged commits compared by describe, as git describe does
+const describeCandidates = 10
+
+// tagRef is a tag of a commit, the date of a lightweight tag is the committer time of the commit
+type tagRef struct {
+	name      string
+	annotated bool
+	when      time.Time
+}
+
+// describe returns the nearest tag reachable from the commit like git describe --tags,
+// `<tag>` if the commit is tagged, or `<tag>-<commits since the tag>-g<abbreviated hash>`.
+// The tagged ancestors are visited breadth first, and of the nearest candidates the one leaving the fewest commits
+// not reachable from it wins, the distance is the number of these commits
+func describe(repo *gogit.Repository, commit *object.Commit) string {
+	tags := tagsByCommit(repo)
+	if len(tags) == 0 {
+		return ""
+	}
+	if refs, ok := tags[commit.Hash]; ok {
+		return preferredTag(refs)
+	}
+
+	candidates := make([]*object.Commit, 0, describeCandidates)
+	err := object.NewCommitIterBSF(commit, nil, nil).ForEach(func(c *object.Commit) error {
+		if _, ok := tags[c.Hash]; ok {
+			candidates = append(candidates, c)
+			if len(candidates) == describeCandidates {
+				return storer.ErrStop
+			}
+		}
+		return nil
+	})
+	if (err != nil && !errors.Is(err, storer.ErrStop)) || len(candidates) == 0 {
+		return ""
+	}
+	total, err := countAncestors(commit)
+	if err != nil {
+		return ""
+	}
+	var best *object.Commit
+	distance := 0
+	for _, c := range candidates {
+		n, err := countAncestors(c)
+		if err != nil {
+			return ""
+		}
+		// the tagged commit is an ancestor, the commits not reachable from it are the rest
+		if best == nil || total-n < distance {
+			best, distance = c, total-n
+		}
+	}
+	return fmt.Sprintf("%s-%d-g%s", preferredTag(tags[best.Hash]), distance, commit.Hash.String()[:abbrevLength])
+}
+
+// countAncestors returns the number of the commits reachable from the commit, including itself
+func countAncestors(commit *object.Commit) (int, error) {
+	count := 0
+	err := object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// preferredTag returns the tag git describe takes of the tags of a commit,
+// annotated tags are preferred over lightweight ones, then the newest
+func preferredTag(refs []tagRef) string {
+	best := refs[0]
+	for _, ref := range refs[1:] {
+		switch {
+		case ref.annotated != best.annotated:
+			if ref.annotated {
+				best = ref
+			}
+		case !ref.when.Equal(best.when):
+			if ref.when.After(best.when) {
+				best = ref
+			}
+		case ref.name < best.name:
+			best = ref
+		}
+	}
+	return best.name
+}
+
+// tagsByCommit returns the lightweight and annotated tags of each commit
+func tagsByCommit(repo *gogit.Repository) map[plumbing.Hash][]tagRef {
+	tags := make(map[plumbing.Hash][]tagRef)
+	iter, err := repo.Tags()
+	if err != nil {
+		return tags
+	}
+	_ = iter.ForEach(func(ref *plumbing.Reference) error {
+		if tag, err := repo.TagObject(ref.Hash()); err == nil {
+			c, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			tags[c.Hash] = append(tags[c.Hash], tagRef{name: ref.Name().Short(), annotated: true, when: tag.Tagger.When})
+			return nil
+		}
+		c, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil
+		}
+		tags[c.Hash] = append(tags[c.Hash], tagRef{name: ref.Name().Short(), when: c.Committer.When})
+		return nil
+	})
+	return tags
+}
+
+// modifiedFiles returns the tracked files with uncommitted changes, untracked files are not counted
+// like git describe --dirty
+func modifiedFiles(wt *gogit.Worktree) []string {
+	status, err := wt.Status()
+	if err != nil {
+		log.Warnf("read git status error: %s", err.Error())
+		return nil
+	}
+	var files []string
+	for path, s := range status {
+		if s.Worktree == gogit.Untracked {
+			continue
+		}
+		if s.Worktree != gogit.Unmodified || s.Staging != gogit.Unmodified {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// submodules returns the submodules of the worktree with the urls and the pinned revisions
+func submodules(wt *gogit.Worktree) []model.Submodule {
+	subs, err := wt.Submodules()
+	if err != nil {
+		return nil
+	}
+	var ret []model.Submodule
+	for _, sub := range subs {
+		conf := sub.Config()
+		m := model.Submodule{Path: conf.Path, Repository: normalizeRepoUrl(conf.URL)}
+		if status, err := sub.Status(); err == nil && !status.Expected.IsZero() {
+			m.Revision = status.Expected.String()
+		}
+		ret = append(ret, m)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Path < ret[j].Path
+	})
+	return ret
+}
@@ -12,12 +12,8 @@ package source
 
 import (
 	"net/url"
-	"path/filepath"
-	"strings"
 	"time"
 
-	gogit "github.com/go-git/go-git/v5"
-
 	"gitee.com/JD-opensource/sbom-tool/pkg/config"
 	"gitee.com/JD-opensource/sbom-tool/pkg/fingerprint"
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
@@ -26,7 +22,10 @@ import (
 
 // GetSourceInfo returns the source information of the project
 func GetSourceInfo(cfg *config.SourceConfig) (*model.Source, error) {
-	fp, _ := fingerprint.CalcFingerprint(cfg)
+	fp, err := fingerprint.CalcFingerprint(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	source := model.Source{
 		TotalSize: fp.Metadata.TotalSize,
@@ -45,43 +44,10 @@ func GetSourceInfo(cfg *config.SourceConfig) (*model.Source, error) {
 			Files: util.SliceMap(fp.Files, toSBOMFileFingerprint),
 		},
 	}
-	repoInfo(cfg.SrcPath, &source)
+	repoInfo(cfg.SrcPath, &source, cfg.AnonymizeCommitter)
 	return &source, nil
 }
 
-func repoInfo(projectPath string, source *model.Source) {
-	if len(projectPath) == 0 {
-		return
-	}
-	repo, err := gogit.PlainOpen(projectPath)
-	if err != nil {
-		parentPath := filepath.Dir(projectPath)
-		if parentPath == "." || parentPath == "/" || projectPath == parentPath {
-			return
-		}
-		repoInfo(parentPath, source)
-	} else {
-		conf, err := repo.Config()
-		if err == nil {
-			if remote, ok := conf.Remotes["origin"]; ok {
-				source.Repository = remote.URLs[0]
-				if strings.HasPrefix(source.Repository, "http") {
-					source.Repository = normalizeHttpUrl(source.Repository)
-				}
-			}
-		}
-		ref, err := repo.Head()
-		if err == nil {
-			source.Branch = ref.Name().Short()
-		}
-
-		commit, err := repo.CommitObject(ref.Hash())
-		if err == nil {
-			source.Revision = commit.Hash.String()
-		}
-	}
-}
-
 func normalizeHttpUrl(rawUrl string) string {
 	if rawUrl == "" {
 		return ""
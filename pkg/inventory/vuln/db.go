@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/ziputil"
+)
+
+// DB is a local mirror of the OSV database, indexed by ecosystem and package name
+type DB struct {
+	index map[string][]*OSV
+	size  int
+}
+
+// NewDB creates a database of the entries
+func NewDB(entries ...*OSV) *DB {
+	db := &DB{index: make(map[string][]*OSV)}
+	for _, e := range entries {
+		db.Add(e)
+	}
+	return db
+}
+
+// Add adds an entry to the database, withdrawn entries are ignored
+func (db *DB) Add(e *OSV) {
+	if e == nil || e.ID == "" || e.Withdrawn != "" {
+		return
+	}
+	keys := make(map[string]struct{})
+	for _, a := range e.Affected {
+		keys[indexKey(baseEcosystem(a.Package.Ecosystem), a.Package.Name)] = struct{}{}
+	}
+	for key := range keys {
+		db.index[key] = append(db.index[key], e)
+	}
+	db.size++
+}
+
+// Size returns the number of entries
+func (db *DB) Size() int {
+	return db.size
+}
+
+// lookup returns the entries affecting the package of the ecosystem
+func (db *DB) lookup(ecosystem, name string) []*OSV {
+	return db.index[indexKey(ecosystem, name)]
+}
+
+func indexKey(ecosystem, name string) string {
+	return ecosystem + "|" + normalizeName(ecosystem, name)
+}
+
+// LoadDB loads the OSV database from a directory of OSV json files, or from a zip of them like the
+// all.zip dumps of osv.dev. Zips in the directory are loaded as well.
+func LoadDB(path string) (*DB, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vulnerability database: %w", err)
+	}
+	db := NewDB()
+	if stat.IsDir() {
+		err = db.loadDir(path)
+	} else {
+		err = db.loadZip(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("loaded %d vulnerabilities from %s", db.Size(), path)
+	return db, nil
+}
+
+func (db *DB) loadDir(path string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".json":
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			db.load(p, data)
+		case ".zip":
+			return db.loadZip(p)
+		}
+		return nil
+	})
+}
+
+func (db *DB) loadZip(path string) error {
+	return ziputil.TraverseFilesInZip(path, func(f *zip.File) error {
+		if f.FileInfo().IsDir() || !strings.EqualFold(filepath.Ext(f.Name), ".json") {
+			return nil
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rc.Close()
+		}()
+		buf := &bytes.Buffer{}
+		if err = ziputil.SafeCopy(buf, rc); err != nil {
+			return err
+		}
+		db.load(path+"!"+f.Name, buf.Bytes())
+		return nil
+	})
+}
+
+// load adds the entries of a json file, which holds an entry or an array of entries
+func (db *DB) load(name string, data []byte) {
+	data = bytes.TrimSpace(data)
+	var entries []*OSV
+	var err error
+	if bytes.HasPrefix(data, []byte("[")) {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		e := &OSV{}
+		err = json.Unmarshal(data, e)
+		entries = append(entries, e)
+	}
+	if err != nil {
+		log.Warnf("invalid osv file %s: %s", name, err.Error())
+		return
+	}
+	for _, e := range entries {
+		db.Add(e)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// OSV ecosystems, see https://ossf.github.io/osv-schema/#affectedpackage-field
+const (
+	EcosystemAlpine    = "Alpine"
+	EcosystemAlmaLinux = "AlmaLinux"
+	EcosystemCrates    = "crates.io"
+	EcosystemDebian    = "Debian"
+	EcosystemGo        = "Go"
+	EcosystemHex       = "Hex"
+	EcosystemMaven     = "Maven"
+	EcosystemNPM       = "npm"
+	EcosystemNuGet     = "NuGet"
+	EcosystemOpenEuler = "openEuler"
+	EcosystemOpenSUSE  = "openSUSE"
+	EcosystemPackagist = "Packagist"
+	EcosystemPub       = "Pub"
+	EcosystemPyPI      = "PyPI"
+	EcosystemRedHat    = "Red Hat"
+	EcosystemRocky     = "Rocky Linux"
+	EcosystemRubyGems  = "RubyGems"
+	EcosystemSUSE      = "SUSE"
+	EcosystemUbuntu    = "Ubuntu"
+)
+
+// ecosystems maps the purl types to the ecosystems, the types of linux distributions are mapped by namespace
+var ecosystems = map[string]string{
+	model.PkgTypeCargo:    EcosystemCrates,
+	model.PkgTypeComposer: EcosystemPackagist,
+	model.PkgTypeGem:      EcosystemRubyGems,
+	model.PkgTypeGolang:   EcosystemGo,
+	model.PkgTypeMaven:    EcosystemMaven,
+	model.PkgTypeNPM:      EcosystemNPM,
+	model.PkgTypeNuget:    EcosystemNuGet,
+	model.PkgTypePub:      EcosystemPub,
+	model.PkgTypePyPi:     EcosystemPyPI,
+	packageurl.TypeHex:    EcosystemHex,
+}
+
+// distroEcosystems maps the namespaces of deb, rpm and apk purls to the ecosystems, the collectors take the ID of
+// os-release as the namespace. The RHEL rebuilds are matched against the Red Hat advisories of the same release,
+// other distros without an ecosystem of their own (e.g. fedora, amzn) have releases unrelated to RHEL and are skipped
+var distroEcosystems = map[string]string{
+	"alpine":              EcosystemAlpine,
+	"almalinux":           EcosystemAlmaLinux,
+	"centos":              EcosystemRedHat,
+	"debian":              EcosystemDebian,
+	"ol":                  EcosystemRedHat,
+	"openeuler":           EcosystemOpenEuler,
+	"opensuse":            EcosystemOpenSUSE,
+	"opensuse-leap":       EcosystemOpenSUSE,
+	"opensuse-tumbleweed": EcosystemOpenSUSE,
+	"redhat":              EcosystemRedHat,
+	"rhel":                EcosystemRedHat,
+	"rocky":               EcosystemRocky,
+	"sles":                EcosystemSUSE,
+	"suse":                EcosystemSUSE,
+	"ubuntu":              EcosystemUbuntu,
+}
+
+// versionComparators compare the versions of the ecosystems, other ecosystems use util.CompareVersion
+var versionComparators = map[string]func(v1, v2 string) int{
+	EcosystemAlmaLinux: CompareRPMVersion,
+	EcosystemCrates:    CompareSemver,
+	EcosystemDebian:    CompareDebianVersion,
+	EcosystemGo:        CompareSemver,
+	EcosystemMaven:     CompareMavenVersion,
+	EcosystemNPM:       CompareSemver,
+	EcosystemOpenEuler: CompareRPMVersion,
+	EcosystemOpenSUSE:  CompareRPMVersion,
+	EcosystemPub:       CompareSemver,
+	EcosystemPyPI:      ComparePEP440Version,
+	EcosystemRedHat:    CompareRPMVersion,
+	EcosystemRocky:     CompareRPMVersion,
+	EcosystemRubyGems:  util.CompareVersion,
+	EcosystemSUSE:      CompareRPMVersion,
+	EcosystemUbuntu:    CompareDebianVersion,
+}
+
+// versionComparator returns the comparison of versions of the range type and ecosystem
+func versionComparator(rangeType, ecosystem string) func(v1, v2 string) int {
+	if rangeType == RangeSemver {
+		return CompareSemver
+	}
+	if cmp, ok := versionComparators[ecosystem]; ok {
+		return cmp
+	}
+	return util.CompareVersion
+}
+
+// baseEcosystem strips the release of an ecosystem, e.g. Debian:11 is Debian
+func baseEcosystem(ecosystem string) string {
+	base, _, _ := strings.Cut(ecosystem, ":")
+	return base
+}
+
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName normalizes the package name of the ecosystem which is case-insensitive
+func normalizeName(ecosystem, name string) string {
+	switch ecosystem {
+	case EcosystemPyPI:
+		return pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	case EcosystemNuGet, EcosystemPackagist:
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// packageEcosystem returns the ecosystem and the name of a package in the ecosystem
+func packageEcosystem(purl packageurl.PackageURL) (string, string) {
+	switch purl.Type {
+	case model.PkgTypeDEB, model.PkgTypeRPM, packageurl.TypeAlpine:
+		return distroEcosystems[strings.ToLower(purl.Namespace)], purl.Name
+	case model.PkgTypeMaven:
+		return EcosystemMaven, purl.Namespace + ":" + purl.Name
+	}
+	ecosystem, ok := ecosystems[purl.Type]
+	if !ok {
+		return "", ""
+	}
+	if purl.Namespace != "" {
+		return ecosystem, purl.Namespace + "/" + purl.Name
+	}
+	return ecosystem, purl.Name
+}
+
+// distroRelease returns the release of the linux distribution given by the distro qualifier in the form used by
+// the ecosystem, e.g. 11 of debian-11, v3.18 of alpine-3.18.4 and 9 of rhel-9.2. It returns an empty string if the
+// release is unknown or not comparable, which matches the entries of all releases
+func distroRelease(ecosystem string, purl packageurl.PackageURL) string {
+	distro := purl.Qualifiers.Map()["distro"]
+	i := strings.LastIndex(distro, "-")
+	if i < 0 {
+		return ""
+	}
+	id, version := distro[:i], distro[i+1:]
+	segments := strings.Split(version, ".")
+	switch ecosystem {
+	case EcosystemAlpine:
+		if len(segments) < 2 {
+			return ""
+		}
+		return "v" + segments[0] + "." + segments[1]
+	case EcosystemAlmaLinux, EcosystemDebian, EcosystemRedHat, EcosystemRocky:
+		return segments[0]
+	case EcosystemUbuntu:
+		return version
+	case EcosystemOpenSUSE:
+		if strings.HasSuffix(id, "tumbleweed") {
+			return "Tumbleweed"
+		}
+		return "Leap " + version
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// severities maps the severities given by the databases to the model
+var severities = map[string]model.Severity{
+	"low":       model.SeverityLow,
+	"moderate":  model.SeverityMedium,
+	"medium":    model.SeverityMedium,
+	"high":      model.SeverityHigh,
+	"important": model.SeverityHigh,
+	"critical":  model.SeverityCritical,
+}
+
+// Match matches the packages against the database by their PURLs and versions,
+// returns the vulnerabilities affecting them ordered by id
+func (db *DB) Match(pkgs []model.Package) []model.Vulnerability {
+	found := make(map[string]*model.Vulnerability)
+	for i := range pkgs {
+		pkg := &pkgs[i]
+		purl, err := packageurl.FromString(pkg.PURL)
+		if err != nil {
+			continue
+		}
+		ecosystem, name := packageEcosystem(purl)
+		if ecosystem == "" || pkg.Version == "" {
+			continue
+		}
+		version := pkg.Version
+		if epoch := purl.Qualifiers.Map()["epoch"]; epoch != "" && !strings.Contains(version, ":") {
+			version = epoch + ":" + version
+		}
+		for _, e := range db.lookup(ecosystem, name) {
+			fixed, ok := e.affects(ecosystem, name, distroRelease(ecosystem, purl), version)
+			if !ok {
+				continue
+			}
+			v, exist := found[e.ID]
+			if !exist {
+				v = toVulnerability(e)
+				found[e.ID] = v
+			}
+			v.Affects = util.SliceUnique(append(v.Affects, pkg.PURL))
+			v.Fixed = util.SliceUnique(append(v.Fixed, fixed...))
+		}
+	}
+	ret := make([]model.Vulnerability, 0, len(found))
+	for _, v := range found {
+		sort.Strings(v.Affects)
+		ret = append(ret, *v)
+	}
+	return util.SliceSort(ret, func(v1, v2 model.Vulnerability) bool {
+		return v1.ID < v2.ID
+	})
+}
+
+// affects checks the version of the package is affected by the entry, returns the versions fixing it.
+// The release of a linux distribution restricts the entries to the release if it is known, e.g. 11 for Debian:11
+func (e *OSV) affects(ecosystem, name, release, version string) ([]string, bool) {
+	affected := false
+	fixed := make([]string, 0)
+	for _, a := range e.Affected {
+		base, rel, _ := strings.Cut(a.Package.Ecosystem, ":")
+		if base != ecosystem || normalizeName(ecosystem, a.Package.Name) != normalizeName(ecosystem, name) {
+			continue
+		}
+		if release != "" && rel != "" && !util.SliceContains(strings.Split(rel, ":"), release) {
+			continue
+		}
+		if !a.affects(ecosystem, version) {
+			continue
+		}
+		affected = true
+		for _, r := range a.Ranges {
+			if r.Type == RangeGit {
+				continue
+			}
+			for _, ev := range r.Events {
+				if ev.Fixed != "" {
+					fixed = append(fixed, ev.Fixed)
+				}
+			}
+		}
+	}
+	return fixed, affected
+}
+
+// affects checks the version is one of the affected versions or in one of the affected ranges
+func (a *OSVAffected) affects(ecosystem, version string) bool {
+	if util.SliceContains(a.Versions, version) {
+		return true
+	}
+	if ecosystem == EcosystemGo && util.SliceContains(a.Versions, strings.TrimPrefix(version, "v")) {
+		return true
+	}
+	for _, r := range a.Ranges {
+		if r.Type == RangeGit {
+			continue
+		}
+		if inRange(r.Events, version, versionComparator(r.Type, ecosystem)) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange evaluates the events of a range in version order, see https://ossf.github.io/osv-schema/#evaluation
+func inRange(events []OSVEvent, version string, compare func(v1, v2 string) int) bool {
+	eventVersion := func(e OSVEvent) string {
+		switch {
+		case e.Introduced != "":
+			return e.Introduced
+		case e.Fixed != "":
+			return e.Fixed
+		case e.LastAffected != "":
+			return e.LastAffected
+		}
+		return e.Limit
+	}
+	sorted := append([]OSVEvent{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		vi, vj := eventVersion(sorted[i]), eventVersion(sorted[j])
+		// introduced "0" is before any version
+		if vi == "0" || vj == "0" {
+			return vi == "0" && vj != "0"
+		}
+		return compare(vi, vj) < 0
+	})
+
+	affected := false
+	for _, e := range sorted {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || compare(version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if compare(version, e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if compare(version, e.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// toVulnerability converts the entry without the affected packages
+func toVulnerability(e *OSV) *model.Vulnerability {
+	v := &model.Vulnerability{
+		ID:        e.ID,
+		Source:    model.VulnerabilitySourceOSV,
+		Aliases:   e.Aliases,
+		Summary:   e.Summary,
+		Severity:  severities[strings.ToLower(e.severity())],
+		Published: e.Published,
+		Modified:  e.Modified,
+	}
+	if v.Summary == "" {
+		v.Summary, _, _ = strings.Cut(strings.TrimSpace(e.Details), "\n")
+	}
+	for _, s := range e.Severity {
+		v.Ratings = append(v.Ratings, model.VulnerabilityRating{Method: s.Type, Vector: s.Score})
+	}
+	for _, r := range e.References {
+		v.References = append(v.References, r.URL)
+	}
+	return v
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/apk"
+	"gitee.com/JD-opensource/sbom-tool/pkg/inventory/pckg/collector/rpm"
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestDB_Match(t *testing.T) {
+	db, err := LoadDB("test_material/osv")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, db.Size())
+
+	pkgs := []model.Package{
+		{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{Name: "log4j-core", Version: "2.12.2", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.12.2"},
+		{Name: "log4j-core", Version: "2.0-beta9", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.0-beta9"},
+		{Name: "jinja2", Version: "2.10", PURL: "pkg:pypi/jinja2@2.10"},
+		{Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21"},
+		{Name: "sys", Version: "v0.0.0-20210615035016-665e8c7367d1", PURL: "pkg:golang/golang.org/x/sys@v0.0.0-20210615035016-665e8c7367d1"},
+		{Name: "openssl", Version: "1.1.1n-0+deb10u1", PURL: "pkg:deb/debian/openssl@1.1.1n-0+deb10u1?distro=debian-10"},
+		{Name: "nokogiri", Version: "1.13.3", PURL: "pkg:gem/nokogiri@1.13.3"},
+		{Name: "openssl", Version: "1.1.1k-5.el8_5", PURL: "pkg:rpm/rhel/openssl@1.1.1k-5.el8_5?epoch=1&distro=rhel-8"},
+		{Name: "openssl", Version: "1.1.1k-7.el8_6", PURL: "pkg:rpm/rhel/openssl@1.1.1k-7.el8_6?epoch=1&distro=rhel-8"},
+		{Name: "unknown", Version: "1.0.0", PURL: "pkg:generic/unknown@1.0.0"},
+	}
+	vulns := db.Match(pkgs)
+	ids := make([]string, 0, len(vulns))
+	for _, v := range vulns {
+		ids = append(ids, v.ID)
+	}
+	assert.Equal(t, []string{"GHSA-crjr-9rc5-ghw8", "GHSA-jfh8-c2jp-5v3q", "GO-2022-0493", "PYSEC-2021-66", "RHSA-2022:1066"}, ids)
+
+	log4j := vulns[1]
+	assert.Equal(t, []string{
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.0-beta9",
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+	}, log4j.Affects)
+	assert.ElementsMatch(t, []string{"2.15.0", "2.3.1", "2.12.2"}, log4j.Fixed)
+	assert.Equal(t, model.SeverityCritical, log4j.Severity)
+	assert.Equal(t, model.VulnerabilitySourceOSV, log4j.Source)
+	assert.Equal(t, []model.VulnerabilityRating{
+		{Method: model.RatingCVSSv3, Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"},
+	}, log4j.Ratings)
+	assert.Equal(t, model.SeverityHigh, vulns[4].Severity)
+	assert.Equal(t, []string{"pkg:rpm/rhel/openssl@1.1.1k-5.el8_5?epoch=1&distro=rhel-8"}, vulns[4].Affects)
+	// the summary falls back to the first line of the details
+	assert.Equal(t, "This affects the package jinja2 from 0.0.0 and before 2.11.3.", vulns[3].Summary)
+}
+
+func TestDB_Match_Distro(t *testing.T) {
+	db, err := LoadDB("test_material/osv-distro")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the purls are taken from the collectors of the installed databases, namespaced by the ID of os-release
+	pkgs, err := rpm.NewRPMDBParser().Parse("../pckg/collector/rpm/test_material/centos7/var/lib/rpm/Packages")
+	if !assert.NoError(t, err) {
+		return
+	}
+	vulns := db.Match(pkgs)
+	if assert.Len(t, vulns, 1) {
+		assert.Equal(t, "RHSA-TEST-0007", vulns[0].ID)
+		assert.Equal(t, []string{"pkg:rpm/centos/bash@5.1.8-6.el9?arch=x86_64&distro=centos-7"}, vulns[0].Affects)
+	}
+
+	// fedora and amazon linux are not RHEL rebuilds, their releases never match the Red Hat advisories
+	assert.Empty(t, db.Match([]model.Package{
+		{Name: "bash", Version: "5.1.8-6.fc39", PURL: "pkg:rpm/fedora/bash@5.1.8-6.fc39?distro=fedora-39"},
+		{Name: "bash", Version: "5.1.8-6.amzn2023", PURL: "pkg:rpm/amzn/bash@5.1.8-6.amzn2023?distro=amzn-2023"},
+		{Name: "bash", Version: "5.1.8-6.fc39", PURL: "pkg:rpm/fedora/bash@5.1.8-6.fc39"},
+	}))
+
+	// the releases of the distro qualifiers are normalized to the ones of the ecosystems, v3.18 and 9
+	pkgs, err = apk.NewInstalledDBParser().Parse("../pckg/collector/apk/test_material/rootfs/lib/apk/db/installed")
+	if !assert.NoError(t, err) {
+		return
+	}
+	rhel, err := rpm.NewRPMDBParser().Parse("../pckg/collector/rpm/test_material/rhel9/var/lib/rpm/rpmdb.sqlite")
+	if !assert.NoError(t, err) {
+		return
+	}
+	vulns = db.Match(append(pkgs, rhel...))
+	if assert.Len(t, vulns, 2) {
+		assert.Equal(t, "ALPINE-TEST-0001", vulns[0].ID)
+		assert.Equal(t, []string{"pkg:apk/alpine/libcrypto3@3.1.4-r0?arch=x86_64&distro=alpine-3.18.4"}, vulns[0].Affects)
+		assert.Equal(t, []string{"3.1.4-r1"}, vulns[0].Fixed)
+		assert.Equal(t, "RHSA-TEST-0009", vulns[1].ID)
+		assert.Equal(t, []string{"pkg:rpm/rhel/openssl-libs@3.0.7-24.el9?arch=x86_64&distro=rhel-9.2&epoch=1"}, vulns[1].Affects)
+		assert.Equal(t, []string{"1:3.0.7-25.el9"}, vulns[1].Fixed)
+	}
+}
+
+func TestDistroRelease(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		purl      string
+		want      string
+	}{
+		{EcosystemAlpine, "pkg:apk/alpine/musl@1.2.4-r2?distro=alpine-3.18.4", "v3.18"},
+		{EcosystemRedHat, "pkg:rpm/rhel/bash@5.1.8-6.el9?distro=rhel-9.2", "9"},
+		{EcosystemRocky, "pkg:rpm/rocky/bash@5.1.8-6.el9?distro=rocky-9.2", "9"},
+		{EcosystemDebian, "pkg:deb/debian/bash@5.1-2?distro=debian-11", "11"},
+		{EcosystemUbuntu, "pkg:deb/ubuntu/bash@5.1-6ubuntu1?distro=ubuntu-22.04", "22.04"},
+		{EcosystemOpenSUSE, "pkg:rpm/opensuse-leap/bash@5.1.16?distro=opensuse-leap-15.5", "Leap 15.5"},
+		{EcosystemOpenSUSE, "pkg:rpm/opensuse-tumbleweed/bash@5.2.15?distro=opensuse-tumbleweed-20231010", "Tumbleweed"},
+		{EcosystemSUSE, "pkg:rpm/sles/bash@4.4?distro=sles-15.5", ""},
+		{EcosystemAlpine, "pkg:apk/alpine/musl@1.2.4-r2", ""},
+	}
+	for _, tt := range tests {
+		purl, err := packageurl.FromString(tt.purl)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, tt.want, distroRelease(tt.ecosystem, purl), tt.purl)
+	}
+}
+
+func TestLoadDB_Zip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "all.zip")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, name := range []string{"maven/GHSA-jfh8-c2jp-5v3q.json", "pypi/PYSEC-2021-66.json"} {
+		data, err := os.ReadFile(filepath.Join("test_material/osv", name))
+		assert.NoError(t, err)
+		fw, err := w.Create(filepath.Base(name))
+		assert.NoError(t, err)
+		_, err = fw.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+
+	db, err := LoadDB(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, db.Size())
+	vulns := db.Match([]model.Package{{Name: "Jinja2", Version: "2.11.2", PURL: "pkg:pypi/Jinja2@2.11.2"}})
+	assert.Len(t, vulns, 1)
+
+	_, err = LoadDB("test_material/not-exist")
+	assert.Error(t, err)
+}
+
+func TestInRange(t *testing.T) {
+	events := []OSVEvent{{Fixed: "1.5.0"}, {Introduced: "1.0.0"}, {Introduced: "2.0.0"}, {LastAffected: "2.1.0"}}
+	for version, want := range map[string]bool{
+		"0.9.0": false, "1.0.0": true, "1.4.9": true, "1.5.0": false, "2.0.0": true, "2.1.0": true, "2.1.1": false,
+	} {
+		assert.Equalf(t, want, inRange(events, version, CompareSemver), "version %s", version)
+	}
+}
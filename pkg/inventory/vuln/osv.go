@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+// OSV is an entry of the OSV database, see https://ossf.github.io/osv-schema/
+type OSV struct {
+	ID               string         `json:"id"`
+	Modified         string         `json:"modified"`
+	Published        string         `json:"published"`
+	Withdrawn        string         `json:"withdrawn"`
+	Aliases          []string       `json:"aliases"`
+	Summary          string         `json:"summary"`
+	Details          string         `json:"details"`
+	Severity         []OSVSeverity  `json:"severity"`
+	Affected         []OSVAffected  `json:"affected"`
+	References       []OSVReference `json:"references"`
+	DatabaseSpecific map[string]any `json:"database_specific"`
+}
+
+// OSVSeverity is a scored severity of the vulnerability
+type OSVSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// OSVAffected is a package affected by the vulnerability and the affected versions
+type OSVAffected struct {
+	Package          OSVPackage     `json:"package"`
+	Severity         []OSVSeverity  `json:"severity"`
+	Ranges           []OSVRange     `json:"ranges"`
+	Versions         []string       `json:"versions"`
+	DatabaseSpecific map[string]any `json:"database_specific"`
+}
+
+// OSVPackage identifies a package in an ecosystem
+type OSVPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	PURL      string `json:"purl"`
+}
+
+// range types of OSVRange
+const (
+	RangeSemver    = "SEMVER"
+	RangeEcosystem = "ECOSYSTEM"
+	RangeGit       = "GIT"
+)
+
+// OSVRange is a range of affected versions described by events
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Events []OSVEvent `json:"events"`
+}
+
+// OSVEvent is an event of a range, exactly one of the fields is set
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// OSVReference is a reference url of the vulnerability
+type OSVReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// severity returns the qualitative severity given by the database, e.g. GitHub advisories
+func (o *OSV) severity() string {
+	if s, ok := o.DatabaseSpecific["severity"].(string); ok {
+		return s
+	}
+	for i := range o.Affected {
+		if s, ok := o.Affected[i].DatabaseSpecific["severity"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,463 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"pault.ag/go/debian/version"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// CompareSemver compares two semantic versions, a leading "v" is allowed as in Go modules,
+// so Go pseudo-versions are compared as pre-releases. Invalid versions fall back to util.CompareVersion
+func CompareSemver(v1, v2 string) int {
+	s1, ok1 := parseSemver(v1)
+	s2, ok2 := parseSemver(v2)
+	if !ok1 || !ok2 {
+		return util.CompareVersion(v1, v2)
+	}
+	for i := 0; i < 3; i++ {
+		if c := compareNumeric(s1.core[i], s2.core[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(s1.pre) == 0 && len(s2.pre) == 0:
+		return 0
+	case len(s1.pre) == 0:
+		return 1
+	case len(s2.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(s1.pre) && i < len(s2.pre); i++ {
+		n1, n2 := isDigits(s1.pre[i]), isDigits(s2.pre[i])
+		var c int
+		switch {
+		case n1 && n2:
+			c = compareNumeric(s1.pre[i], s2.pre[i])
+		case n1:
+			c = -1
+		case n2:
+			c = 1
+		default:
+			c = strings.Compare(s1.pre[i], s2.pre[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(s1.pre), len(s2.pre))
+}
+
+type semver struct {
+	core [3]string
+	pre  []string
+}
+
+func parseSemver(v string) (semver, bool) {
+	s := semver{core: [3]string{"0", "0", "0"}}
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, hasPre := strings.Cut(v, "-")
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return s, false
+	}
+	for i, p := range parts {
+		if !isDigits(p) {
+			return s, false
+		}
+		s.core[i] = p
+	}
+	if hasPre {
+		s.pre = strings.Split(pre, ".")
+	}
+	return s, true
+}
+
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?(?:(?:([0-9]+)!)?([0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?([0-9]+)?)?` +
+	`(?:-([0-9]+)|[-_.]?(post|rev|r)[-_.]?([0-9]+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?([0-9]+)?)?)(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// pep440 is the sort key of a PEP 440 version
+type pep440 struct {
+	epoch   int
+	release []int
+	preRank int // 0 for dev releases without pre and post release, 1-3 for a, b and rc, 4 for final releases
+	pre     int
+	post    int // -1 without post release
+	dev     int // max int without dev release
+	local   []string
+}
+
+// ComparePEP440Version compares two versions of python packages following PEP 440,
+// invalid versions fall back to util.CompareVersion
+func ComparePEP440Version(v1, v2 string) int {
+	p1, ok1 := parsePEP440(v1)
+	p2, ok2 := parsePEP440(v2)
+	if !ok1 || !ok2 {
+		return util.CompareVersion(v1, v2)
+	}
+	if c := compareInt(p1.epoch, p2.epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(p1.release) || i < len(p2.release); i++ {
+		if c := compareInt(intAt(p1.release, i), intAt(p2.release, i)); c != 0 {
+			return c
+		}
+	}
+	for _, c := range []int{
+		compareInt(p1.preRank, p2.preRank), compareInt(p1.pre, p2.pre),
+		compareInt(p1.post, p2.post), compareInt(p1.dev, p2.dev),
+	} {
+		if c != 0 {
+			return c
+		}
+	}
+	for i := 0; i < len(p1.local) && i < len(p2.local); i++ {
+		if c := compareLocalSegment(p1.local[i], p2.local[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(p1.local), len(p2.local))
+}
+
+func parsePEP440(v string) (pep440, bool) {
+	m := pep440Pattern.FindStringSubmatch(v)
+	if m == nil {
+		return pep440{}, false
+	}
+	p := pep440{epoch: atoi(m[1]), post: -1, dev: int(^uint(0) >> 1), preRank: 4}
+	for _, r := range strings.Split(m[2], ".") {
+		p.release = append(p.release, atoi(r))
+	}
+	switch strings.ToLower(m[3]) {
+	case "a", "alpha":
+		p.preRank, p.pre = 1, atoi(m[4])
+	case "b", "beta":
+		p.preRank, p.pre = 2, atoi(m[4])
+	case "c", "rc", "pre", "preview":
+		p.preRank, p.pre = 3, atoi(m[4])
+	}
+	if m[5] != "" {
+		p.post = atoi(m[5])
+	} else if m[6] != "" {
+		p.post = atoi(m[7])
+	}
+	if m[8] != "" {
+		p.dev = atoi(m[9])
+		if m[3] == "" && p.post < 0 {
+			p.preRank = 0
+		}
+	}
+	if m[10] != "" {
+		p.local = strings.FieldsFunc(strings.ToLower(m[10]), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	return p, true
+}
+
+// compareLocalSegment compares segments of local versions, numeric segments are newer than alphabetic ones
+func compareLocalSegment(s1, s2 string) int {
+	n1, n2 := isDigits(s1), isDigits(s2)
+	switch {
+	case n1 && n2:
+		return compareNumeric(s1, s2)
+	case n1:
+		return 1
+	case n2:
+		return -1
+	}
+	return strings.Compare(s1, s2)
+}
+
+// mavenQualifiers orders the well-known qualifiers of maven versions, unknown qualifiers are newer and
+// compared lexically
+var mavenQualifiers = map[string]int{
+	"alpha":     0,
+	"a":         0,
+	"beta":      1,
+	"b":         1,
+	"milestone": 2,
+	"m":         2,
+	"rc":        3,
+	"cr":        3,
+	"snapshot":  4,
+	"":          5,
+	"ga":        5,
+	"final":     5,
+	"release":   5,
+	"sp":        6,
+}
+
+// CompareMavenVersion compares two versions of maven artifacts following the rules of ComparableVersion:
+// versions are split into numbers and qualifiers at ".", "-" and transitions between digits and letters,
+// numbers are newer than qualifiers, and alpha < beta < milestone < rc < snapshot < release < sp
+func CompareMavenVersion(v1, v2 string) int {
+	i1, i2 := mavenItems(v1), mavenItems(v2)
+	for i := 0; i < len(i1) || i < len(i2); i++ {
+		var c int
+		switch {
+		case i >= len(i1):
+			c = -compareMavenItem(i2[i], "")
+		case i >= len(i2):
+			c = compareMavenItem(i1[i], "")
+		default:
+			c = compareMavenItem(i1[i], i2[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// mavenItems splits a maven version into items, null items (0, release qualifiers) ending a group are dropped
+func mavenItems(v string) []string {
+	items := make([]string, 0)
+	group := make([]string, 0)
+	flush := func() {
+		for len(group) > 0 && isMavenNull(group[len(group)-1]) {
+			group = group[:len(group)-1]
+		}
+		items = append(items, group...)
+		group = group[:0]
+	}
+	v = strings.ToLower(strings.TrimSpace(v))
+	start := 0
+	for i := 0; i <= len(v); i++ {
+		switch {
+		case i == len(v) || v[i] == '.' || v[i] == '-':
+			if i > start {
+				group = append(group, v[start:i])
+			}
+			if i == len(v) || v[i] == '-' {
+				flush()
+			}
+			start = i + 1
+		case i > start && isDigit(v[i]) != isDigit(v[i-1]):
+			group = append(group, v[start:i])
+			// a transition between digits and letters starts a new group like "-"
+			flush()
+			start = i
+		}
+	}
+	return items
+}
+
+func isMavenNull(item string) bool {
+	if isDigits(item) {
+		return strings.TrimLeft(item, "0") == ""
+	}
+	return mavenQualifiers[item] == mavenQualifiers[""] && item != "sp"
+}
+
+// compareMavenItem compares two items, an empty item is the end of a shorter version
+func compareMavenItem(item1, item2 string) int {
+	n1, n2 := isDigits(item1), isDigits(item2)
+	switch {
+	case n1 && n2:
+		return compareNumeric(item1, item2)
+	case n1:
+		if item2 == "" {
+			return compareNumeric(item1, "0")
+		}
+		return 1
+	case n2:
+		if item1 == "" {
+			return compareNumeric("0", item2)
+		}
+		return -1
+	}
+	r1, ok1 := mavenQualifiers[item1]
+	r2, ok2 := mavenQualifiers[item2]
+	switch {
+	case ok1 && ok2:
+		return compareInt(r1, r2)
+	case ok1:
+		return -1
+	case ok2:
+		return 1
+	}
+	return strings.Compare(item1, item2)
+}
+
+// CompareDebianVersion compares two versions of debian packages as dpkg does,
+// invalid versions fall back to util.CompareVersion
+func CompareDebianVersion(v1, v2 string) int {
+	d1, err1 := version.Parse(v1)
+	d2, err2 := version.Parse(v2)
+	if err1 != nil || err2 != nil {
+		return util.CompareVersion(v1, v2)
+	}
+	return compareSign(version.Compare(d1, d2))
+}
+
+// CompareRPMVersion compares two [epoch:]version[-release] of rpm packages as rpm does
+func CompareRPMVersion(v1, v2 string) int {
+	e1, ver1, rel1 := parseEVR(v1)
+	e2, ver2, rel2 := parseEVR(v2)
+	if c := compareNumeric(e1, e2); c != 0 {
+		return c
+	}
+	if c := rpmvercmp(ver1, ver2); c != 0 {
+		return c
+	}
+	if rel1 == "" || rel2 == "" {
+		return 0
+	}
+	return rpmvercmp(rel1, rel2)
+}
+
+func parseEVR(evr string) (epoch, ver, rel string) {
+	epoch = "0"
+	if e, rest, ok := strings.Cut(evr, ":"); ok && isDigits(e) {
+		epoch, evr = e, rest
+	}
+	if i := strings.LastIndex(evr, "-"); i > -1 {
+		return epoch, evr[:i], evr[i+1:]
+	}
+	return epoch, evr, ""
+}
+
+// rpmvercmp is the version segment comparison of rpm, "~" sorts before anything and "^" after the version
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isAlnum := func(c byte) bool { return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+	for len(a) > 0 || len(b) > 0 {
+		for len(a) > 0 && !isAlnum(a[0]) && a[0] != '~' && a[0] != '^' {
+			a = a[1:]
+		}
+		for len(b) > 0 && !isAlnum(b[0]) && b[0] != '~' && b[0] != '^' {
+			b = b[1:]
+		}
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+		numeric := isDigit(a[0])
+		seg := func(s string) (string, string) {
+			i := 0
+			for i < len(s) && isAlnum(s[i]) && isDigit(s[i]) == numeric {
+				i++
+			}
+			return s[:i], s[i:]
+		}
+		var s1, s2 string
+		s1, a = seg(a)
+		s2, b = seg(b)
+		if s2 == "" {
+			// segments of different types, the numeric one is newer
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		var c int
+		if numeric {
+			c = compareNumeric(s1, s2)
+		} else {
+			c = strings.Compare(s1, s2)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+// compareNumeric compares two strings of digits of any length by value
+func compareNumeric(n1, n2 string) int {
+	n1, n2 = strings.TrimLeft(n1, "0"), strings.TrimLeft(n2, "0")
+	if c := compareInt(len(n1), len(n2)); c != 0 {
+		return c
+	}
+	return strings.Compare(n1, n2)
+}
+
+func compareInt(i1, i2 int) int {
+	switch {
+	case i1 < i2:
+		return -1
+	case i1 > i2:
+		return 1
+	}
+	return 0
+}
+
+func compareSign(c int) int {
+	return compareInt(c, 0)
+}
+
+func intAt(s []int, i int) int {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCompare(t *testing.T, compare func(v1, v2 string) int, cases [][3]any) {
+	for _, c := range cases {
+		v1, v2, want := c[0].(string), c[1].(string), c[2].(int)
+		assert.Equalf(t, want, compare(v1, v2), "compare(%s, %s)", v1, v2)
+		assert.Equalf(t, -want, compare(v2, v1), "compare(%s, %s)", v2, v1)
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	testCompare(t, CompareSemver, [][3]any{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.0.0+build.1", "1.0.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{"0.0.0-20220412211240-33da011f77ad", "0.0.0-20210615035016-665e8c7367d1", 1},
+		{"v0.1.0", "0.0.0-20220412211240-33da011f77ad", 1},
+		{"4.17.20", "4.17.21", -1},
+	})
+}
+
+func TestComparePEP440Version(t *testing.T) {
+	testCompare(t, ComparePEP440Version, [][3]any{
+		{"1.0", "1.0.0", 0},
+		{"1.0.dev1", "1.0a1", -1},
+		{"1.0a1", "1.0b1", -1},
+		{"1.0b2", "1.0rc1", -1},
+		{"1.0rc1", "1.0", -1},
+		{"1.0", "1.0.post1", -1},
+		{"1.0.post1.dev1", "1.0.post1", -1},
+		{"1.0", "1.0+local.1", -1},
+		{"1.0+local.1", "1.0+local.a", 1},
+		{"1!0.1", "2.0", 1},
+		{"2.11.2", "2.11.3", -1},
+		{"1.0-1", "1.0.post1", 0},
+		{"1.0.ALPHA1", "1.0a1", 0},
+	})
+}
+
+func TestCompareMavenVersion(t *testing.T) {
+	testCompare(t, CompareMavenVersion, [][3]any{
+		{"1.0", "1.0.0", 0},
+		{"1.0-final", "1.0", 0},
+		{"1.0-ga", "1", 0},
+		{"1.0-alpha1", "1-alpha1", 0},
+		{"1.0-alpha-1", "1.0-beta-1", -1},
+		{"1.0-beta-1", "1.0-m1", -1},
+		{"1.0-rc1", "1.0-cr1", 0},
+		{"1.0-rc1", "1.0-SNAPSHOT", -1},
+		{"1.0-SNAPSHOT", "1.0", -1},
+		{"1.0", "1.0-sp1", -1},
+		{"1.0-sp1", "1.0-foo", -1},
+		{"1.0-foo", "1.0.1", -1},
+		{"2.0-beta9", "2.0", -1},
+		{"2.12.1", "2.12.2", -1},
+		{"2.14.1", "2.13.0", 1},
+	})
+}
+
+func TestCompareDebianVersion(t *testing.T) {
+	testCompare(t, CompareDebianVersion, [][3]any{
+		{"1.1.1n-0+deb11u1", "1.1.1n-0+deb11u1", 0},
+		{"1.1.1k-1+deb11u1", "1.1.1n-0+deb11u1", -1},
+		{"1:1.0", "2.0", 1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0-1", "1.0-1.1", -1},
+	})
+}
+
+func TestCompareRPMVersion(t *testing.T) {
+	testCompare(t, CompareRPMVersion, [][3]any{
+		{"1.1.1k-5.el8_5", "1.1.1k-6.el8_5", -1},
+		{"1:1.1.1k-5.el8_5", "1.1.1k-6.el8_5", 1},
+		{"1:1.1.1k-6.el8_5", "1:1.1.1k-6.el8_5", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0.1", -1},
+		{"1.0a", "1.0", 1},
+		{"1.0a", "1.0.1", -1},
+		{"2.0", "10.0", -1},
+		{"1.0", "1.0-1", 0},
+	})
+}
@@ -33,6 +33,7 @@ type Artifact struct {
 	Package
 	Build Build  `json:"build"`
 	Files []File `json:"files"`
+	Image *Image `json:"image,omitempty"` // the container image when the artifact is an image
 }
 
 // Build represents the build information of the artifact
@@ -44,9 +45,34 @@ type Build struct {
 	Compiler string `json:"compiler"`
 }
 
+// Image represents the container image of the artifact
+type Image struct {
+	Reference  string            `json:"reference"`
+	Digest     string            `json:"digest"` // digest of the image manifest
+	Config     string            `json:"config"` // digest of the image config, aka the image id
+	OS         string            `json:"os"`
+	Arch       string            `json:"arch"`
+	Variant    string            `json:"variant,omitempty"`
+	Created    string            `json:"created,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	BaseName   string            `json:"baseName,omitempty"`   // name of the base image
+	BaseDigest string            `json:"baseDigest,omitempty"` // digest of the base image
+	Layers     []Layer           `json:"layers"`
+}
+
+// Layer represents a layer of the container image
+type Layer struct {
+	Digest    string `json:"digest"` // digest of the layer blob
+	DiffID    string `json:"diffID"` // digest of the uncompressed layer
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
 // File represents the file in the artifact
 type File struct {
 	Name      string         `json:"name"`
 	Type      FileType       `json:"type"`
 	Checksums []FileChecksum `json:"checksums"`
+	Compilers []string       `json:"compilers,omitempty"` // compilers recorded in a binary, e.g. the .comment of an ELF file
 }
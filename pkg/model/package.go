@@ -34,13 +34,55 @@ var (
 	PkgTypePyPi      PkgType = packageurl.TypePyPi
 	PkgTypeRPM       PkgType = packageurl.TypeRPM
 	PkgTypeDEB       PkgType = packageurl.TypeDebian
+	PkgTypeAPK       PkgType = packageurl.TypeAlpine
 	PkgTypeSwift     PkgType = packageurl.TypeSwift
+	PkgTypeOCI       PkgType = packageurl.TypeOCI
 	PkgTypeDylib     PkgType = "dylib"
 	PkgTypeCarthage  PkgType = "carthage"
 	PkgTypeBower     PkgType = "bower"
 	PkgTypeLua       PkgType = "lua"
+	PkgTypeVcpkg     PkgType = "vcpkg"
 )
 
+// Scope is the dependency scope of a package, an empty scope means the scope is unknown
+type Scope = string
+
+const (
+	ScopeRuntime  Scope = "runtime"
+	ScopeDev      Scope = "dev"
+	ScopeTest     Scope = "test"
+	ScopeOptional Scope = "optional"
+	ScopeProvided Scope = "provided"
+)
+
+// scopeRanks orders the scopes, a package needed by several scopes takes the widest one
+var scopeRanks = map[Scope]int{
+	ScopeDev:      1,
+	ScopeTest:     2,
+	ScopeOptional: 3,
+	ScopeProvided: 4,
+	ScopeRuntime:  5,
+}
+
+// AllScopes returns all known scopes
+func AllScopes() []Scope {
+	return []Scope{ScopeRuntime, ScopeDev, ScopeTest, ScopeOptional, ScopeProvided}
+}
+
+// IsValidScope returns true if the scope is a known scope
+func IsValidScope(scope Scope) bool {
+	_, ok := scopeRanks[scope]
+	return ok
+}
+
+// MergeScope returns the wider one of two scopes, e.g. a package required by both runtime and test is a runtime package
+func MergeScope(s1, s2 Scope) Scope {
+	if scopeRanks[s2] > scopeRanks[s1] {
+		return s2
+	}
+	return s1
+}
+
 // Package is the info of a package
 type Package struct {
 	Name             string   `json:"name"` // required
@@ -48,12 +90,17 @@ type Package struct {
 	Type             PkgType  `json:"type"` // required
 	PURL             string   `json:"purl"` // required, the Package URL (see https://github.com/package-url/purl-spec)
 	Supplier         string   `json:"supplier"`
+	Copyright        string   `json:"copyright,omitempty"`
 	FilesAnalyzed    bool     `json:"filesAnalyzed"`
 	VerificationCode string   `json:"verificationCode"`
 	LicenseConcluded []string `json:"licenseConcluded"`
 	LicenseDeclared  []string `json:"licenseDeclared"`
-	Dependencies     []string `json:"dependencies"` // purl of dependencies
+	Dependencies     []string `json:"dependencies"`       // purl of dependencies
+	Contains         []string `json:"contains,omitempty"` // purl of the packages nested in the package, e.g. the jars of a fat jar
 	SourceLocation   string   `json:"sourceLocation"`
+	Scope            Scope    `json:"scope,omitempty"`
+	Files            []string `json:"files,omitempty"` // files owned by an installed package
+	Layer            string   `json:"layer,omitempty"` // diff id of the image layer introducing the package
 }
 
 func (p *Package) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -17,6 +17,7 @@ type RelationType string
 
 const (
 	DependencyOf RelationType = "DependencyOf" // Is to be used when SPDXRef-A is dependency of SPDXRef-B.	A is explicitly stated as a dependency of B in a machine-readable file. Use when a package manager does not define scopes.
+	Contains     RelationType = "Contains"     // Is to be used when SPDXRef-A contains SPDXRef-B. The ids of the relationship are the PURLs of the packages.
 )
 
 // A Relationship is a relationship between two elements of sbom.
@@ -33,3 +34,14 @@ func (r *Relationship) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("to", r.ToID)
 	return nil
 }
+
+// ContainedPackages returns the PURLs of the packages the package of the given PURL contains
+func ContainedPackages(rels []Relationship, purl string) map[string]struct{} {
+	contained := make(map[string]struct{})
+	for _, rel := range rels {
+		if rel.Type == Contains && rel.FromID == purl {
+			contained[rel.ToID] = struct{}{}
+		}
+	}
+	return contained
+}
@@ -14,12 +14,13 @@ type Metadata map[string]string
 
 // SBOM represents the software bill of materials
 type SBOM struct {
-	NamespaceURI  string
-	Source        Source         `json:"source"`
-	Artifact      Artifact       `json:"artifact"`
-	Packages      []Package      `json:"packages"`
-	Relationships []Relationship `json:"relationships"`
-	CreationInfo  CreationInfo   `json:"creationInfo"`
+	NamespaceURI    string
+	Source          Source          `json:"source"`
+	Artifact        Artifact        `json:"artifact"`
+	Packages        []Package       `json:"packages"`
+	Relationships   []Relationship  `json:"relationships"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+	CreationInfo    CreationInfo    `json:"creationInfo"`
 }
 
 // CreationInfo represents the creation info of the SBOM
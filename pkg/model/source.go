@@ -32,15 +32,38 @@ const (
 )
 
 type Source struct {
-	Repository  string      `json:"repository,omitempty"`
-	Branch      string      `json:"branch,omitempty"`
-	Revision    string      `json:"revision,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+	// Tag is the nearest tag of the revision in the git describe form, e.g. v1.2.0-3-g1a2b3c4
+	Tag        string `json:"tag,omitempty"`
+	Detached   bool   `json:"detached,omitempty"`
+	CommitTime string `json:"commitTime,omitempty"`
+	Committer  string `json:"committer,omitempty"`
+	// Dirty is true if the working tree has uncommitted changes of the tracked files listed in Modified
+	Dirty       bool        `json:"dirty,omitempty"`
+	Modified    []string    `json:"modified,omitempty"`
+	Remotes     []Remote    `json:"remotes,omitempty"`
+	Submodules  []Submodule `json:"submodules,omitempty"`
 	TotalSize   int64       `json:"totalSize,omitempty"`
 	TotalFile   int64       `json:"totalFile,omitempty"`
 	TotalLine   int64       `json:"totalLine,omitempty"`
 	Language    []string    `json:"language,omitempty"`
 	Fingerprint Fingerprint `json:"fingerprint,omitempty"`
 }
+
+// Remote is a git remote of the source repository
+type Remote struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Submodule is a git submodule of the source repository pinned at a revision
+type Submodule struct {
+	Path       string `json:"path"`
+	Repository string `json:"repository,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+}
 type FingerprintVendor struct {
 	Name      string `json:"name,omitempty"`
 	Tool      string `json:"tool,omitempty"`
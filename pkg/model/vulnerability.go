@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package model
+
+// Severity is the qualitative severity of a vulnerability
+type Severity = string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// VulnerabilitySourceOSV is the source of the vulnerabilities from the OSV database
+const VulnerabilitySourceOSV = "OSV"
+
+// Vulnerability represents a known vulnerability affecting packages of the sbom
+type Vulnerability struct {
+	ID         string                `json:"id"`
+	Source     string                `json:"source"` // database the vulnerability comes from, e.g. OSV
+	Aliases    []string              `json:"aliases,omitempty"`
+	Summary    string                `json:"summary,omitempty"`
+	Severity   Severity              `json:"severity,omitempty"`
+	Ratings    []VulnerabilityRating `json:"ratings,omitempty"`
+	Affects    []string              `json:"affects"`         // PURLs of the affected packages
+	Fixed      []string              `json:"fixed,omitempty"` // versions fixing the vulnerability
+	References []string              `json:"references,omitempty"`
+	Published  string                `json:"published,omitempty"`
+	Modified   string                `json:"modified,omitempty"`
+}
+
+// rating methods of VulnerabilityRating, named as the severity types of OSV
+const (
+	RatingCVSSv2 = "CVSS_V2"
+	RatingCVSSv3 = "CVSS_V3"
+	RatingCVSSv4 = "CVSS_V4"
+)
+
+// VulnerabilityRating is a scored rating of a vulnerability, e.g. a CVSS vector
+type VulnerabilityRating struct {
+	Method string `json:"method"`
+	Vector string `json:"vector"`
+}
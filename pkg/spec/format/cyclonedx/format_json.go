@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"fmt"
+	"io"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+)
+
+// JSONFormat is the json format of cyclonedx
+type JSONFormat struct {
+	spec *Spec
+}
+
+func (f *JSONFormat) Spec() format.Specification {
+	return f.spec
+}
+
+func (f *JSONFormat) Load(reader io.Reader) error {
+	bom := &cdx.BOM{}
+	err := cdx.NewBOMDecoder(reader, cdx.BOMFileFormatJSON).Decode(bom)
+	if err != nil {
+		return fmt.Errorf("read error: %w", err)
+	}
+	f.spec.doc = bom
+	return nil
+}
+
+func (f *JSONFormat) Dump(writer io.Writer) error {
+	err := cdx.NewBOMEncoder(writer, cdx.BOMFileFormatJSON).SetPretty(true).Encode(f.spec.doc)
+	if err != nil {
+		return fmt.Errorf("dump error: %w", err)
+	}
+	return nil
+}
+
+func (f *JSONFormat) Type() string {
+	return "json"
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONFormat_Load(t *testing.T) {
+	spec := &Spec{}
+	format := JSONFormat{spec: spec}
+	file, err := os.Open("test_material/example-v1.5.cdx.json")
+	assert.NoError(t, err)
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	err = format.Load(file)
+	assert.NoError(t, err)
+	assert.NoError(t, spec.Validate())
+
+	assert.Equal(t, cdx.BOMFormat, spec.doc.BOMFormat)
+	assert.Equal(t, cdx.SpecVersion1_5, spec.doc.SpecVersion)
+	assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", spec.doc.SerialNumber)
+	assert.Equal(t, "demo", spec.doc.Metadata.Component.Name)
+	assert.Equal(t, 3, len(*spec.doc.Components))
+	assert.Equal(t, 2, len(*spec.doc.Dependencies))
+
+	sbomDoc := spec.ToModel()
+	assert.Equal(t, 3, len(sbomDoc.Packages))
+	assert.Equal(t, []string{"EPL-1.0 OR LGPL-2.1-only"}, sbomDoc.Packages[2].LicenseDeclared)
+	assert.Equal(t, []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}, sbomDoc.Packages[2].Dependencies)
+}
+
+func TestJSONFormat_Dump(t *testing.T) {
+	spec := &Spec{}
+	format := JSONFormat{spec: spec}
+	spec.FromModel(newSbomDoc())
+
+	buf := &bytes.Buffer{}
+	err := format.Dump(buf)
+	assert.NoError(t, err)
+
+	loaded := &Spec{}
+	err = (&JSONFormat{spec: loaded}).Load(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Validate())
+	assert.Equal(t, spec.doc.SerialNumber, loaded.doc.SerialNumber)
+	assert.Equal(t, len(*spec.doc.Components), len(*loaded.doc.Components))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"fmt"
+	"io"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+)
+
+// XMLFormat is the xml format of cyclonedx
+type XMLFormat struct {
+	spec *Spec
+}
+
+func (f *XMLFormat) Spec() format.Specification {
+	return f.spec
+}
+
+func (f *XMLFormat) Load(reader io.Reader) error {
+	bom := &cdx.BOM{}
+	err := cdx.NewBOMDecoder(reader, cdx.BOMFileFormatXML).Decode(bom)
+	if err != nil {
+		return fmt.Errorf("read error: %w", err)
+	}
+	// bomFormat only exists in json, a known xml namespace identifies the document instead
+	if bom.SpecVersion != 0 {
+		bom.BOMFormat = cdx.BOMFormat
+	}
+	f.spec.doc = bom
+	return nil
+}
+
+func (f *XMLFormat) Dump(writer io.Writer) error {
+	err := cdx.NewBOMEncoder(writer, cdx.BOMFileFormatXML).SetPretty(true).Encode(f.spec.doc)
+	if err != nil {
+		return fmt.Errorf("dump error: %w", err)
+	}
+	return nil
+}
+
+func (f *XMLFormat) Type() string {
+	return "xml"
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"errors"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+)
+
+var ErrDocumentEmpty = errors.New("document empty")
+var ErrBOMFormatInvalid = errors.New("bomFormat is not CycloneDX")
+var ErrSpecVersionInvalid = errors.New("specVersion is not supported")
+
+// Spec is the specification of CycloneDX
+// see https://cyclonedx.org/docs/1.5/json/
+type Spec struct {
+	doc      *cdx.BOM
+	formats  []format.Format
+	updaters []format.Updater
+}
+
+func NewSpecification() format.Specification {
+	s := &Spec{}
+	s.formats = []format.Format{
+		&JSONFormat{spec: s},
+		&XMLFormat{spec: s},
+	}
+	s.updaters = newSpecUpdaters(s)
+	return s
+}
+
+func (s *Spec) Name() string {
+	return "cyclonedx"
+}
+
+func (s *Spec) Version() string {
+	return cdx.SpecVersion1_5.String()
+}
+
+func (s *Spec) Formats() []format.Format {
+	return s.formats
+}
+
+func (s *Spec) Validate() error {
+	if s.doc == nil {
+		return ErrDocumentEmpty
+	}
+	if s.doc.BOMFormat != cdx.BOMFormat {
+		return ErrBOMFormatInvalid
+	}
+	if s.doc.SpecVersion < cdx.SpecVersion1_0 || s.doc.SpecVersion > cdx.SpecVersion1_5 {
+		return ErrSpecVersionInvalid
+	}
+
+	refs := make(map[string]struct{})
+	var err error
+	walkComponents(s.doc, func(c *cdx.Component) {
+		if err != nil {
+			return
+		}
+		if c.Name == "" {
+			err = fmt.Errorf("component name is empty (bom-ref: %s)", c.BOMRef)
+			return
+		}
+		if c.BOMRef == "" {
+			return
+		}
+		if _, ok := refs[c.BOMRef]; ok {
+			err = fmt.Errorf("duplicate bom-ref: %s", c.BOMRef)
+			return
+		}
+		refs[c.BOMRef] = struct{}{}
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.doc.Dependencies != nil {
+		for _, dep := range *s.doc.Dependencies {
+			if _, ok := refs[dep.Ref]; !ok {
+				return fmt.Errorf("dependency ref not found: %s", dep.Ref)
+			}
+			if dep.Dependencies == nil {
+				continue
+			}
+			for _, ref := range *dep.Dependencies {
+				if _, ok := refs[ref]; !ok {
+					return fmt.Errorf("dependency ref not found: %s", ref)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Spec) Metadata() model.Metadata {
+	meta := make(map[string]string)
+	if s.doc != nil {
+		meta["BOMFormat"] = s.doc.BOMFormat
+		meta["SpecVersion"] = s.doc.SpecVersion.String()
+		meta["SerialNumber"] = s.doc.SerialNumber
+		if s.doc.Metadata != nil {
+			meta["CreatedAt"] = s.doc.Metadata.Timestamp
+			if s.doc.Metadata.Tools != nil {
+				for _, t := range *s.doc.Metadata.Tools {
+					meta["Tool"] = t.Name
+				}
+			}
+			if s.doc.Metadata.Authors != nil {
+				for _, a := range *s.doc.Metadata.Authors {
+					meta["Person"] = a.Name
+				}
+			}
+			if s.doc.Metadata.Supplier != nil {
+				meta["Organization"] = s.doc.Metadata.Supplier.Name
+			}
+		}
+	}
+	return meta
+}
+
+func (s *Spec) Updaters() []format.Updater {
+	return s.updaters
+}
+
+// walkComponents visits the metadata component and all components of the document, including nested ones
+func walkComponents(bom *cdx.BOM, visit func(c *cdx.Component)) {
+	var walk func(components *[]cdx.Component)
+	walk = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for i := range *components {
+			c := &(*components)[i]
+			visit(c)
+			walk(c.Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		visit(bom.Metadata.Component)
+		walk(bom.Metadata.Component.Components)
+	}
+	walk(bom.Components)
+}
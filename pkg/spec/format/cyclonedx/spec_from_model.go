@@ -0,0 +1,343 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+func (s *Spec) FromModel(sbomDoc *model.SBOM) {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = SerialNumber(sbomDoc.NamespaceURI + sbomDoc.Artifact.Name + "@" + sbomDoc.Artifact.Version)
+	bom.Metadata = fromCreationInfo(&sbomDoc.CreationInfo)
+
+	// the packages take their refs first, the artifact and the files are renamed if they collide
+	refs := make(bomRefs)
+	pkgRefs := make([]string, 0, len(sbomDoc.Packages))
+	for i := range sbomDoc.Packages {
+		pkgRefs = append(pkgRefs, refs.unique(BOMRef(&sbomDoc.Packages[i])))
+	}
+	artifactRef := refs.unique(BOMRef(&sbomDoc.Artifact.Package))
+	bom.Metadata.Component = fromArtifact(&sbomDoc.Artifact, artifactRef, refs)
+
+	components := make([]cdx.Component, 0, len(sbomDoc.Packages)+len(sbomDoc.Source.Fingerprint.Files))
+	for i := range sbomDoc.Packages {
+		component := fromPackage(sbomDoc.Packages[i])
+		component.BOMRef = pkgRefs[i]
+		components = append(components, component)
+	}
+	for _, ffp := range sbomDoc.Source.Fingerprint.Files {
+		component := fromFileFingerprint(ffp)
+		component.BOMRef = refs.unique(component.BOMRef)
+		components = append(components, component)
+	}
+	if len(components) > 0 {
+		bom.Components = &components
+	}
+
+	dependencies := toDependencies(sbomDoc.Packages, pkgRefs, artifactRef)
+	if len(dependencies) > 0 {
+		bom.Dependencies = &dependencies
+	}
+
+	if len(sbomDoc.Vulnerabilities) > 0 {
+		vulns := fromVulnerabilities(sbomDoc.Vulnerabilities, sbomDoc.Packages, pkgRefs)
+		bom.Vulnerabilities = &vulns
+	}
+
+	props := appendProperty(nil, propNamespace, sbomDoc.NamespaceURI)
+	props = append(props, fromSource(&sbomDoc.Source)...)
+	bom.Properties = propertiesOrNil(props)
+	s.doc = bom
+}
+
+func fromCreationInfo(info *model.CreationInfo) *cdx.Metadata {
+	metadata := &cdx.Metadata{
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	tools := make([]cdx.Tool, 0)
+	authors := make([]cdx.OrganizationalContact, 0)
+	for _, c := range info.Creators {
+		if c.Creator == "" {
+			continue
+		}
+		switch c.CreatorType {
+		case "Tool":
+			tools = append(tools, cdx.Tool{Name: c.Creator})
+		case "Person":
+			authors = append(authors, cdx.OrganizationalContact{Name: c.Creator})
+		case "Organization":
+			if metadata.Supplier == nil {
+				metadata.Supplier = &cdx.OrganizationalEntity{Name: c.Creator}
+			}
+		}
+	}
+	if len(tools) > 0 {
+		metadata.Tools = &tools
+	}
+	if len(authors) > 0 {
+		metadata.Authors = &authors
+	}
+	return metadata
+}
+
+// toDependencies builds the dependency graph, the artifact depends on all packages which no other package depends on.
+// refs are the bom-refs of the packages in order
+func toDependencies(pkgs []model.Package, refs []string, rootRef string) []cdx.Dependency {
+	purlRefs := make(map[string]string)
+	allDeps := make(map[string]struct{})
+	for i := 0; i < len(pkgs); i++ {
+		if _, ok := purlRefs[pkgs[i].PURL]; !ok {
+			purlRefs[pkgs[i].PURL] = refs[i]
+		}
+		for j := 0; j < len(pkgs[i].Dependencies); j++ {
+			allDeps[pkgs[i].Dependencies[j]] = struct{}{}
+		}
+	}
+
+	rootDeps := make([]string, 0)
+	deps := make([]cdx.Dependency, 0, len(pkgs)+1)
+	for i := range pkgs {
+		ref := refs[i]
+		if _, isDep := allDeps[pkgs[i].PURL]; !isDep {
+			rootDeps = append(rootDeps, ref)
+		}
+		dependsOn := make([]string, 0)
+		for _, dep := range pkgs[i].Dependencies {
+			if depRef, ok := purlRefs[dep]; ok {
+				dependsOn = append(dependsOn, depRef)
+			}
+		}
+		dependency := cdx.Dependency{Ref: ref}
+		if len(dependsOn) > 0 {
+			dependency.Dependencies = &dependsOn
+		}
+		deps = append(deps, dependency)
+	}
+
+	root := cdx.Dependency{Ref: rootRef}
+	if len(rootDeps) > 0 {
+		root.Dependencies = &rootDeps
+	}
+	return append([]cdx.Dependency{root}, deps...)
+}
+
+func fromPackage(pkg model.Package) cdx.Component {
+	component := cdx.Component{
+		BOMRef:     BOMRef(&pkg),
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       pkg.Name,
+		Version:    pkg.Version,
+		PackageURL: pkg.PURL,
+		Scope:      componentScopes[pkg.Scope],
+		Licenses:   toLicenses(pkg.LicenseDeclared),
+		Copyright:  pkg.Copyright,
+	}
+	if pkg.Supplier != "" {
+		component.Supplier = &cdx.OrganizationalEntity{Name: pkg.Supplier}
+	}
+	if concluded := toLicenses(pkg.LicenseConcluded); concluded != nil {
+		component.Evidence = &cdx.Evidence{Licenses: concluded}
+	}
+	component.Properties = propertiesOrNil(fromPackageProperties(&pkg))
+	return component
+}
+
+func fromPackageProperties(pkg *model.Package) []cdx.Property {
+	var props []cdx.Property
+	props = appendProperty(props, propPackageType, pkg.Type)
+	props = appendProperty(props, propPackageSourceLocation, pkg.SourceLocation)
+	if pkg.FilesAnalyzed {
+		props = appendProperty(props, propPackageFilesAnalyzed, strconv.FormatBool(pkg.FilesAnalyzed))
+	}
+	props = appendProperty(props, propPackageVerificationCode, pkg.VerificationCode)
+	props = appendProperty(props, propPackageScope, pkg.Scope)
+	props = appendProperty(props, propPackageLayer, pkg.Layer)
+	for _, nested := range pkg.Contains {
+		props = appendProperty(props, propPackageContains, nested)
+	}
+	return props
+}
+
+func fromArtifact(artifact *model.Artifact, ref string, refs bomRefs) *cdx.Component {
+	component := fromPackage(artifact.Package)
+	component.BOMRef = ref
+	component.Type = cdx.ComponentTypeApplication
+
+	props := fromPackageProperties(&artifact.Package)
+	props = appendProperty(props, propArtifactID, artifact.ID)
+	props = appendProperty(props, propBuildOS, artifact.Build.OS)
+	props = appendProperty(props, propBuildArch, artifact.Build.Arch)
+	props = appendProperty(props, propBuildKernel, artifact.Build.Kernel)
+	props = appendProperty(props, propBuildBuilder, artifact.Build.Builder)
+	props = appendProperty(props, propBuildCompiler, artifact.Build.Compiler)
+	if artifact.Image != nil {
+		component.Type = cdx.ComponentTypeContainer
+		props = append(props, fromImage(artifact.Image)...)
+	}
+	component.Properties = propertiesOrNil(props)
+
+	files := util.SliceMap(artifact.Files, func(file model.File) cdx.Component {
+		c := fromFile(file)
+		c.BOMRef = refs.unique(c.BOMRef)
+		return c
+	})
+	if len(files) > 0 {
+		component.Components = &files
+	}
+	return &component
+}
+
+func fromImage(img *model.Image) []cdx.Property {
+	var props []cdx.Property
+	props = appendProperty(props, propImageReference, img.Reference)
+	props = appendProperty(props, propImageDigest, img.Digest)
+	props = appendProperty(props, propImageConfig, img.Config)
+	props = appendProperty(props, propImageOS, img.OS)
+	props = appendProperty(props, propImageArch, img.Arch)
+	props = appendProperty(props, propImageVariant, img.Variant)
+	props = appendProperty(props, propImageCreated, img.Created)
+	props = appendProperty(props, propImageBaseName, img.BaseName)
+	props = appendProperty(props, propImageBaseDigest, img.BaseDigest)
+	names := maps.Keys(img.Labels)
+	slices.Sort(names)
+	for _, name := range names {
+		props = appendProperty(props, propImageLabel, name+"="+img.Labels[name])
+	}
+	for _, layer := range img.Layers {
+		props = appendProperty(props, propImageLayer, layer.DiffID)
+		props = appendProperty(props, propImageLayerDigest, layer.Digest)
+	}
+	return props
+}
+
+func fromFile(file model.File) cdx.Component {
+	return cdx.Component{
+		BOMRef:     FileBOMRef(file.Name),
+		Type:       cdx.ComponentTypeFile,
+		Name:       file.Name,
+		Hashes:     toHashes(file.Checksums),
+		Properties: propertiesOrNil(appendProperty(nil, propFileType, string(file.Type))),
+	}
+}
+
+func fromSource(src *model.Source) []cdx.Property {
+	var props []cdx.Property
+	props = appendProperty(props, propSourceRepository, src.Repository)
+	props = appendProperty(props, propSourceBranch, src.Branch)
+	props = appendProperty(props, propSourceRevision, src.Revision)
+	props = appendProperty(props, propSourceTag, src.Tag)
+	props = appendProperty(props, propSourceCommitTime, src.CommitTime)
+	props = appendProperty(props, propSourceCommitter, src.Committer)
+	if src.Dirty {
+		props = appendProperty(props, propSourceDirty, strconv.FormatBool(src.Dirty))
+	}
+	props = appendProperty(props, propSourceTotalSize, formatInt(src.TotalSize))
+	props = appendProperty(props, propSourceTotalFile, formatInt(src.TotalFile))
+	props = appendProperty(props, propSourceTotalLine, formatInt(src.TotalLine))
+	props = appendProperty(props, propSourceLanguage, strings.Join(src.Language, ","))
+
+	fp := &src.Fingerprint
+	props = appendProperty(props, propFingerprintTotalCount, formatInt(fp.TotalCount))
+	props = appendProperty(props, propFingerprintCreated, fp.Created)
+	props = appendProperty(props, propFingerprintChecksum, fp.Checksum)
+	props = appendProperty(props, propFingerprintOutputMode, fp.OutputMode)
+	props = appendProperty(props, propFingerprintExternalRef, fp.ExternalRef)
+	props = appendProperty(props, propFingerprintVendorName, fp.Vendor.Name)
+	props = appendProperty(props, propFingerprintVendorTool, fp.Vendor.Tool)
+	props = appendProperty(props, propFingerprintVendorAlgo, fp.Vendor.Algorithm)
+	return props
+}
+
+// fromFileFingerprint converts a source file fingerprint to a file component, simhash values are kept as properties
+func fromFileFingerprint(ffp model.FileFingerprint) cdx.Component {
+	component := cdx.Component{
+		BOMRef: SourceFileBOMRef(ffp.File),
+		Type:   cdx.ComponentTypeFile,
+		Name:   ffp.File,
+		Hashes: toHashes(ffp.Checksums),
+	}
+	if len(ffp.Copyright) > 0 {
+		copyrights := util.SliceMap(ffp.Copyright, func(c string) cdx.Copyright {
+			return cdx.Copyright{Text: c}
+		})
+		component.Evidence = &cdx.Evidence{Copyright: &copyrights}
+	}
+
+	// the fingerprint property is always present, it marks the component as a source file
+	props := []cdx.Property{{Name: propFingerprintFile, Value: ffp.Fingerprint.File}}
+	for _, snippet := range ffp.Fingerprint.Snippet {
+		props = append(props, cdx.Property{Name: propFingerprintSnippet, Value: snippet.Range + " " + snippet.Value})
+	}
+	props = appendProperty(props, propFingerprintSize, formatInt(ffp.Size))
+	props = appendProperty(props, propFingerprintLines, formatInt(ffp.Lines))
+	props = appendProperty(props, propFingerprintCount, formatInt(ffp.Count))
+	props = appendProperty(props, propFingerprintLanguage, ffp.Language)
+	props = appendProperty(props, propFingerprintLicense, ffp.License)
+	component.Properties = &props
+	return component
+}
+
+func toHashes(checksums []model.FileChecksum) *[]cdx.Hash {
+	hashes := make([]cdx.Hash, 0, len(checksums))
+	for _, sum := range checksums {
+		if alg, ok := toHashAlgorithm(sum.Algorithm); ok {
+			hashes = append(hashes, cdx.Hash{Algorithm: alg, Value: sum.Value})
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return &hashes
+}
+
+// toLicenses converts licenses to CycloneDX license choices, expressions can not be mixed with single licenses
+func toLicenses(licenses []string) *cdx.Licenses {
+	licenses = util.SliceFilter(license.NormalizeExpressions(licenses), func(l string) bool {
+		return l != license.NOASSERTION_LICENSE && l != license.NONE_LICENSE
+	})
+	if len(licenses) == 0 {
+		return nil
+	}
+	if util.SliceAny(licenses, isLicenseExpression) {
+		return &cdx.Licenses{{Expression: license.CombineExpression(licenses)}}
+	}
+	choices := util.SliceMap(licenses, func(l string) cdx.LicenseChoice {
+		if license.IsSPDXLicense(l) {
+			return cdx.LicenseChoice{License: &cdx.License{ID: l}}
+		}
+		return cdx.LicenseChoice{License: &cdx.License{Name: l}}
+	})
+	return (*cdx.Licenses)(&choices)
+}
+
+// isLicenseExpression returns true for a compound expression or a license with an exception
+func isLicenseExpression(l string) bool {
+	expr, err := license.ParseExpression(l)
+	return err == nil && (expr.Operator != "" || expr.Exception != "")
+}
+
+func formatInt(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func newCdxDoc() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Tools:     &[]cdx.Tool{{Name: "sbom-tool"}},
+		Component: &cdx.Component{
+			BOMRef: "pkg:maven/com.example/demo@1.0.0", Type: cdx.ComponentTypeApplication,
+			Name: "demo", Version: "1.0.0", PackageURL: "pkg:maven/com.example/demo@1.0.0",
+			Components: &[]cdx.Component{
+				{BOMRef: FileBOMRef("a/b/c/d.java"), Type: cdx.ComponentTypeFile, Name: "a/b/c/d.java"},
+			},
+		},
+	}
+	bom.Components = &[]cdx.Component{
+		{
+			BOMRef: "pkg:maven/com.alibaba/fastjson@1.2.78", Type: cdx.ComponentTypeLibrary,
+			Name: "fastjson", Version: "1.2.78", PackageURL: "pkg:maven/com.alibaba/fastjson@1.2.78",
+		},
+	}
+	bom.Dependencies = &[]cdx.Dependency{
+		{Ref: "pkg:maven/com.example/demo@1.0.0", Dependencies: &[]string{"pkg:maven/com.alibaba/fastjson@1.2.78"}},
+	}
+	return bom
+}
+
+func newSbomDoc() *model.SBOM {
+	return &model.SBOM{
+		NamespaceURI: "http://sbom.jd.com/demo",
+		CreationInfo: model.CreationInfo{
+			Creators: []model.Creator{
+				{Creator: "sbom-tool", CreatorType: "Tool"},
+				{Creator: "Tim", CreatorType: "Person"},
+				{Creator: "JD", CreatorType: "Organization"},
+			},
+		},
+		Source: model.Source{
+			Repository: "https://github.com/example/demo.git",
+			Branch:     "main",
+			TotalFile:  1,
+			Language:   []string{"java", "go"},
+			Fingerprint: model.Fingerprint{
+				TotalCount: 1,
+				Vendor:     model.FingerprintVendor{Name: "JD", Tool: "sbom-tool", Algorithm: "simhash"},
+				Files: []model.FileFingerprint{
+					{
+						File:      "src/Main.java",
+						Size:      1024,
+						Lines:     30,
+						Language:  "java",
+						Copyright: []string{"Copyright (c) 2023 example"},
+						Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}},
+						Fingerprint: model.FingerprintValue{
+							File:    "0f1e2d3c4b5a6978",
+							Snippet: []model.SnippetFingerprint{{Range: "1-10", Value: "1122334455667788"}},
+						},
+					},
+				},
+			},
+		},
+		Packages: []model.Package{
+			{
+				Name: "fastjson", Version: "1.2.78", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.78",
+				LicenseDeclared: []string{"Apache-2.0"}, Scope: model.ScopeTest,
+			},
+			{
+				Name: "logback-classic", Version: "1.2.11", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.11",
+				LicenseDeclared: []string{"EPL-1.0 OR LGPL-2.1-only"},
+				Dependencies:    []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+				Scope:           model.ScopeRuntime,
+			},
+			{
+				Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36",
+				LicenseDeclared: []string{"MIT"}, LicenseConcluded: []string{"MIT"}, Scope: model.ScopeRuntime,
+			},
+		},
+		Vulnerabilities: []model.Vulnerability{
+			{
+				ID: "GHSA-pv7h-hx5h-mgfj", Source: model.VulnerabilitySourceOSV, Aliases: []string{"CVE-2022-25845"},
+				Summary: "Deserialization of Untrusted Data in fastjson", Severity: model.SeverityCritical,
+				Ratings: []model.VulnerabilityRating{
+					{Method: model.RatingCVSSv3, Vector: "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+				},
+				Affects:    []string{"pkg:maven/com.alibaba/fastjson@1.2.78"},
+				Fixed:      []string{"1.2.83"},
+				References: []string{"https://nvd.nist.gov/vuln/detail/CVE-2022-25845"},
+				Published:  "2022-06-11T00:00:20Z",
+				Modified:   "2023-08-18T20:40:43Z",
+			},
+		},
+		Artifact: model.Artifact{
+			ID:      "demo-1.0.0",
+			Package: model.Package{Name: "demo", Version: "1.0.0", Type: "maven", PURL: "pkg:maven/com.example/demo@1.0.0"},
+			Build:   model.Build{OS: "linux", Arch: "amd64"},
+			Files: []model.File{
+				{Name: "a/b/c/d.java", Type: model.FileTypeSource,
+					Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA256, Value: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}}},
+			},
+		},
+	}
+}
+
+func TestCycloneDXSpec_ToSBOM(t *testing.T) {
+	bom := newCdxDoc()
+	spec := &Spec{doc: bom}
+
+	sbomDoc := spec.ToModel()
+
+	assert.Equal(t, bom.Metadata.Timestamp, sbomDoc.CreationInfo.Created)
+	assert.Equal(t, 1, len(sbomDoc.Packages))
+	assert.Equal(t, "maven", sbomDoc.Packages[0].Type)
+	assert.Equal(t, "demo", sbomDoc.Artifact.Name)
+	assert.Equal(t, 1, len(sbomDoc.Artifact.Files))
+}
+
+func TestCycloneDXSpec_FromSBOM(t *testing.T) {
+	spec := &Spec{}
+	sbomDoc := newSbomDoc()
+	spec.FromModel(sbomDoc)
+
+	bom := spec.doc
+	assert.NoError(t, spec.Validate())
+	assert.Equal(t, cdx.SpecVersion1_5, bom.SpecVersion)
+	assert.Equal(t, 4, len(*bom.Components))
+	assert.Equal(t, "demo", bom.Metadata.Component.Name)
+	assert.Equal(t, 1, len(*bom.Metadata.Component.Components))
+	assert.Equal(t, "JD", bom.Metadata.Supplier.Name)
+	assert.Equal(t, "EPL-1.0 OR LGPL-2.1-only", (*(*bom.Components)[1].Licenses)[0].Expression)
+	assert.Equal(t, "Apache-2.0", (*(*bom.Components)[0].Licenses)[0].License.ID)
+
+	vuln := (*bom.Vulnerabilities)[0]
+	assert.Equal(t, "GHSA-pv7h-hx5h-mgfj", vuln.ID)
+	assert.Equal(t, "https://osv.dev/vulnerability/GHSA-pv7h-hx5h-mgfj", vuln.Source.URL)
+	assert.Equal(t, []cdx.Affects{{Ref: "pkg:maven/com.alibaba/fastjson@1.2.78"}}, *vuln.Affects)
+	assert.Equal(t, cdx.ScoringMethodCVSSv31, (*vuln.Ratings)[1].Method)
+
+	root := (*bom.Dependencies)[0]
+	assert.Equal(t, "pkg:maven/com.example/demo@1.0.0", root.Ref)
+	assert.Equal(t, []string{"pkg:maven/com.alibaba/fastjson@1.2.78", "pkg:maven/ch.qos.logback/logback-classic@1.2.11"}, *root.Dependencies)
+}
+
+func TestCycloneDXSpec_RoundTrip(t *testing.T) {
+	spec := &Spec{}
+	expected := newSbomDoc()
+	spec.FromModel(expected)
+
+	actual := spec.ToModel()
+	actual.CreationInfo.Created = ""
+	assert.Equal(t, expected, actual)
+}
+
+func TestCycloneDXSpec_NestedRoundTrip(t *testing.T) {
+	spec := &Spec{}
+	expected := newSbomDoc()
+	// a shaded jar containing its dependency
+	expected.Packages[1].Contains = []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}
+	spec.FromModel(expected)
+
+	actual := spec.ToModel()
+	actual.CreationInfo.Created = ""
+	assert.Equal(t, expected, actual)
+}
+
+func TestCycloneDXSpec_ImageRoundTrip(t *testing.T) {
+	spec := &Spec{}
+	expected := newSbomDoc()
+	expected.Packages[0].Layer = "sha256:dd1aacd44dc6aebd995a9a707f4452859b03b460aab072fb51f85bc52cc863eb"
+	expected.Artifact.Files = nil
+	expected.Artifact.Image = &model.Image{
+		Reference:  "oci-archive:demo.tar",
+		Digest:     "sha256:67a8f32ef16a1b61ef3b9e18016afda6caf1fbe7b972bd81f0ea456a6ed46e1f",
+		Config:     "sha256:eca06e1bd2abc8be43253e26b7b6af4ce3466eaaee5b85eb942926f78a70d860",
+		OS:         "linux",
+		Arch:       "amd64",
+		Labels:     map[string]string{"org.opencontainers.image.vendor": "JD", "maintainer": "a=b"},
+		BaseName:   "docker.io/library/debian:bookworm",
+		BaseDigest: "sha256:abab",
+		Layers: []model.Layer{
+			{Digest: "sha256:cc1f", DiffID: "sha256:6e33"},
+			{Digest: "sha256:dd1a", DiffID: "sha256:dd1a"},
+		},
+	}
+	spec.FromModel(expected)
+	assert.Equal(t, cdx.ComponentTypeContainer, spec.doc.Metadata.Component.Type)
+
+	actual := spec.ToModel()
+	actual.CreationInfo.Created = ""
+	assert.Equal(t, expected, actual)
+}
+
+func TestCycloneDXSpec_UniqueRefs(t *testing.T) {
+	spec := &Spec{}
+	sbomDoc := newSbomDoc()
+	// the same path as an artifact file and a source file, a reactor module being the artifact and two same packages
+	sbomDoc.Source.Fingerprint.Files[0].File = sbomDoc.Artifact.Files[0].Name
+	sbomDoc.Packages = append(sbomDoc.Packages, sbomDoc.Artifact.Package, sbomDoc.Packages[2])
+	spec.FromModel(sbomDoc)
+	assert.NoError(t, spec.Validate())
+
+	bom := spec.doc
+	assert.Equal(t, "pkg:maven/com.example/demo@1.0.0~2", bom.Metadata.Component.BOMRef)
+	assert.Equal(t, "pkg:maven/com.example/demo@1.0.0~2", (*bom.Dependencies)[0].Ref)
+	assert.Equal(t, "pkg:maven/com.example/demo@1.0.0", (*bom.Components)[3].BOMRef)
+	assert.Equal(t, "pkg:maven/org.slf4j/slf4j-api@1.7.36~2", (*bom.Components)[4].BOMRef)
+	assert.Equal(t, FileBOMRef("a/b/c/d.java"), (*bom.Metadata.Component.Components)[0].BOMRef)
+	assert.Equal(t, SourceFileBOMRef("a/b/c/d.java"), (*bom.Components)[5].BOMRef)
+	// the dependencies refer to the first package of a purl
+	assert.Equal(t, []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}, *(*bom.Dependencies)[2].Dependencies)
+}
+
+func TestCycloneDXSpec_Validate(t *testing.T) {
+	spec := &Spec{}
+	assert.ErrorIs(t, spec.Validate(), ErrDocumentEmpty)
+
+	spec.doc = newCdxDoc()
+	assert.NoError(t, spec.Validate())
+
+	spec.doc.BOMFormat = "SPDX"
+	assert.ErrorIs(t, spec.Validate(), ErrBOMFormatInvalid)
+
+	spec.doc = newCdxDoc()
+	(*spec.doc.Dependencies)[0].Ref = "pkg:maven/unknown@1.0.0"
+	assert.Error(t, spec.Validate())
+}
+
+func TestCycloneDXSpec_AddCreator(t *testing.T) {
+	spec := NewSpecification().(*Spec)
+	spec.doc = newCdxDoc()
+	updater := spec.Updaters()[0]
+	assert.Equal(t, "add-creator", updater.Name())
+
+	err := updater.Update(`{"creator":"Tim","creatorType":"Person"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tim", (*spec.doc.Metadata.Authors)[0].Name)
+	assert.Error(t, updater.Update(`{"creator":"x","creatorType":"Unknown"}`))
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+func (s *Spec) ToModel() *model.SBOM {
+	if s.doc == nil {
+		return nil
+	}
+	bom := s.doc
+	sbomDoc := &model.SBOM{
+		NamespaceURI: propertyValue(bom.Properties, propNamespace),
+		Source:       toSource(bom.Properties),
+	}
+	if bom.Metadata != nil {
+		sbomDoc.CreationInfo = toCreationInfo(bom.Metadata)
+		if bom.Metadata.Component != nil {
+			sbomDoc.Artifact = toArtifact(bom.Metadata.Component)
+		}
+	}
+
+	// bom-ref to purl, dependencies of the model refer to packages by purl
+	refs := make(map[string]string)
+	pkgRefs := make([]string, 0)
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			c := &(*bom.Components)[i]
+			if isFileFingerprint(c) {
+				sbomDoc.Source.Fingerprint.Files = append(sbomDoc.Source.Fingerprint.Files, toFileFingerprint(c))
+				continue
+			}
+			pkg := toPackage(c)
+			if c.BOMRef != "" {
+				refs[c.BOMRef] = pkg.PURL
+			}
+			pkgRefs = append(pkgRefs, c.BOMRef)
+			sbomDoc.Packages = append(sbomDoc.Packages, pkg)
+		}
+	}
+
+	if bom.Dependencies != nil {
+		depMap := make(map[string][]string)
+		for _, dep := range *bom.Dependencies {
+			if dep.Dependencies == nil {
+				continue
+			}
+			depMap[dep.Ref] = util.SliceFilter(util.SliceMap(*dep.Dependencies, func(ref string) string {
+				return refs[ref]
+			}), func(purl string) bool { return purl != "" })
+		}
+		for i, ref := range pkgRefs {
+			if deps, ok := depMap[ref]; ok && len(deps) > 0 {
+				sbomDoc.Packages[i].Dependencies = deps
+			}
+		}
+	}
+	sbomDoc.Vulnerabilities = toVulnerabilities(bom.Vulnerabilities, refs)
+	return sbomDoc
+}
+
+// isFileFingerprint reports whether the component is a source file carrying a fingerprint
+func isFileFingerprint(c *cdx.Component) bool {
+	return c.Type == cdx.ComponentTypeFile && len(propertyValues(c.Properties, propFingerprintFile)) > 0
+}
+
+func toCreationInfo(metadata *cdx.Metadata) model.CreationInfo {
+	info := model.CreationInfo{Created: metadata.Timestamp}
+	if metadata.Tools != nil {
+		for _, t := range *metadata.Tools {
+			name := t.Name
+			if t.Version != "" {
+				name += "-" + t.Version
+			}
+			info.Creators = append(info.Creators, model.Creator{Creator: name, CreatorType: "Tool"})
+		}
+	}
+	if metadata.Authors != nil {
+		for _, a := range *metadata.Authors {
+			name := a.Name
+			if a.Email != "" {
+				name += " (" + a.Email + ")"
+			}
+			info.Creators = append(info.Creators, model.Creator{Creator: name, CreatorType: "Person"})
+		}
+	}
+	if metadata.Supplier != nil && metadata.Supplier.Name != "" {
+		info.Creators = append(info.Creators, model.Creator{Creator: metadata.Supplier.Name, CreatorType: "Organization"})
+	}
+	return info
+}
+
+func toPackage(c *cdx.Component) model.Package {
+	pkg := model.Package{
+		Name:             c.Name,
+		Version:          c.Version,
+		PURL:             c.PackageURL,
+		Type:             propertyValue(c.Properties, propPackageType),
+		SourceLocation:   propertyValue(c.Properties, propPackageSourceLocation),
+		VerificationCode: propertyValue(c.Properties, propPackageVerificationCode),
+		Scope:            propertyValue(c.Properties, propPackageScope),
+		Layer:            propertyValue(c.Properties, propPackageLayer),
+		LicenseDeclared:  fromLicenses(c.Licenses),
+	}
+	if pkg.Scope == "" {
+		pkg.Scope = fromComponentScope(c.Scope)
+	}
+	if pkg.Type == "" && pkg.PURL != "" {
+		if purl, err := packageurl.FromString(pkg.PURL); err == nil {
+			pkg.Type = purl.Type
+		}
+	}
+	if c.Supplier != nil {
+		pkg.Supplier = c.Supplier.Name
+	}
+	pkg.Copyright = c.Copyright
+	if c.Evidence != nil {
+		pkg.LicenseConcluded = fromLicenses(c.Evidence.Licenses)
+	}
+	pkg.FilesAnalyzed, _ = strconv.ParseBool(propertyValue(c.Properties, propPackageFilesAnalyzed))
+	if nested := propertyValues(c.Properties, propPackageContains); len(nested) > 0 {
+		pkg.Contains = nested
+	}
+	return pkg
+}
+
+func toArtifact(c *cdx.Component) model.Artifact {
+	artifact := model.Artifact{
+		ID:      propertyValue(c.Properties, propArtifactID),
+		Package: toPackage(c),
+		Build: model.Build{
+			OS:       propertyValue(c.Properties, propBuildOS),
+			Arch:     propertyValue(c.Properties, propBuildArch),
+			Kernel:   propertyValue(c.Properties, propBuildKernel),
+			Builder:  propertyValue(c.Properties, propBuildBuilder),
+			Compiler: propertyValue(c.Properties, propBuildCompiler),
+		},
+	}
+	if c.Type == cdx.ComponentTypeContainer {
+		artifact.Image = toImage(c.Properties)
+	}
+	if c.Components != nil {
+		for _, f := range *c.Components {
+			if f.Type != cdx.ComponentTypeFile {
+				continue
+			}
+			artifact.Files = append(artifact.Files, model.File{
+				Name:      f.Name,
+				Type:      model.FileType(propertyValue(f.Properties, propFileType)),
+				Checksums: fromHashes(f.Hashes),
+			})
+		}
+	}
+	return artifact
+}
+
+func toImage(props *[]cdx.Property) *model.Image {
+	img := &model.Image{
+		Reference:  propertyValue(props, propImageReference),
+		Digest:     propertyValue(props, propImageDigest),
+		Config:     propertyValue(props, propImageConfig),
+		OS:         propertyValue(props, propImageOS),
+		Arch:       propertyValue(props, propImageArch),
+		Variant:    propertyValue(props, propImageVariant),
+		Created:    propertyValue(props, propImageCreated),
+		BaseName:   propertyValue(props, propImageBaseName),
+		BaseDigest: propertyValue(props, propImageBaseDigest),
+	}
+	for _, label := range propertyValues(props, propImageLabel) {
+		if name, value, ok := strings.Cut(label, "="); ok {
+			if img.Labels == nil {
+				img.Labels = make(map[string]string)
+			}
+			img.Labels[name] = value
+		}
+	}
+	digests := propertyValues(props, propImageLayerDigest)
+	for i, diffID := range propertyValues(props, propImageLayer) {
+		layer := model.Layer{DiffID: diffID}
+		if i < len(digests) {
+			layer.Digest = digests[i]
+		}
+		img.Layers = append(img.Layers, layer)
+	}
+	return img
+}
+
+func toSource(props *[]cdx.Property) model.Source {
+	src := model.Source{
+		Repository: propertyValue(props, propSourceRepository),
+		Branch:     propertyValue(props, propSourceBranch),
+		Revision:   propertyValue(props, propSourceRevision),
+		Tag:        propertyValue(props, propSourceTag),
+		CommitTime: propertyValue(props, propSourceCommitTime),
+		Committer:  propertyValue(props, propSourceCommitter),
+		Dirty:      propertyValue(props, propSourceDirty) == "true",
+		TotalSize:  parseInt(propertyValue(props, propSourceTotalSize)),
+		TotalFile:  parseInt(propertyValue(props, propSourceTotalFile)),
+		TotalLine:  parseInt(propertyValue(props, propSourceTotalLine)),
+		Fingerprint: model.Fingerprint{
+			TotalCount:  parseInt(propertyValue(props, propFingerprintTotalCount)),
+			Created:     propertyValue(props, propFingerprintCreated),
+			Checksum:    propertyValue(props, propFingerprintChecksum),
+			OutputMode:  propertyValue(props, propFingerprintOutputMode),
+			ExternalRef: propertyValue(props, propFingerprintExternalRef),
+			Vendor: model.FingerprintVendor{
+				Name:      propertyValue(props, propFingerprintVendorName),
+				Tool:      propertyValue(props, propFingerprintVendorTool),
+				Algorithm: propertyValue(props, propFingerprintVendorAlgo),
+			},
+		},
+	}
+	if lang := propertyValue(props, propSourceLanguage); lang != "" {
+		src.Language = strings.Split(lang, ",")
+	}
+	return src
+}
+
+func toFileFingerprint(c *cdx.Component) model.FileFingerprint {
+	ffp := model.FileFingerprint{
+		File:      c.Name,
+		Size:      parseInt(propertyValue(c.Properties, propFingerprintSize)),
+		Lines:     parseInt(propertyValue(c.Properties, propFingerprintLines)),
+		Count:     parseInt(propertyValue(c.Properties, propFingerprintCount)),
+		Language:  propertyValue(c.Properties, propFingerprintLanguage),
+		License:   propertyValue(c.Properties, propFingerprintLicense),
+		Checksums: fromHashes(c.Hashes),
+		Fingerprint: model.FingerprintValue{
+			File: propertyValue(c.Properties, propFingerprintFile),
+		},
+	}
+	for _, v := range propertyValues(c.Properties, propFingerprintSnippet) {
+		r, value, _ := strings.Cut(v, " ")
+		ffp.Fingerprint.Snippet = append(ffp.Fingerprint.Snippet, model.SnippetFingerprint{Range: r, Value: value})
+	}
+	if c.Evidence != nil && c.Evidence.Copyright != nil {
+		ffp.Copyright = util.SliceMap(*c.Evidence.Copyright, func(c cdx.Copyright) string {
+			return c.Text
+		})
+	}
+	return ffp
+}
+
+func fromHashes(hashes *[]cdx.Hash) []model.FileChecksum {
+	if hashes == nil {
+		return nil
+	}
+	checksums := make([]model.FileChecksum, 0, len(*hashes))
+	for _, h := range *hashes {
+		if alg, ok := fromHashAlgorithm(h.Algorithm); ok {
+			checksums = append(checksums, model.FileChecksum{Algorithm: alg, Value: h.Value})
+		}
+	}
+	return checksums
+}
+
+func fromLicenses(licenses *cdx.Licenses) []string {
+	if licenses == nil {
+		return nil
+	}
+	result := make([]string, 0, len(*licenses))
+	for _, l := range *licenses {
+		switch {
+		case l.Expression != "":
+			result = append(result, license.SplitExpression(l.Expression)...)
+		case l.License != nil && l.License.ID != "":
+			result = append(result, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			result = append(result, l.License.Name)
+		}
+	}
+	return result
+}
+
+func parseInt(v string) int64 {
+	i, _ := strconv.ParseInt(v, 10, 64)
+	return i
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var ErrDocumentInvalid = errors.New("document invalid")
+
+func newSpecUpdaters(s *Spec) []format.Updater {
+	return []format.Updater{
+		newAddCreatorUpdater(s),
+	}
+}
+
+func newAddCreatorUpdater(s *Spec) format.Updater {
+	exp, err := json.Marshal(model.Creator{Creator: "Tim (tim@demo.com)", CreatorType: "Person"})
+	if err != nil {
+		log.Warnf("example error: %w", err)
+	}
+	desc := "add creator of document, example: '" + string(exp) + "'"
+	return format.NewUpdater("add-creator", desc, func(value string) error {
+		if s == nil || s.doc == nil {
+			return ErrDocumentInvalid
+		}
+		creator := model.Creator{}
+		err := json.Unmarshal([]byte(value), &creator)
+		if err != nil {
+			return fmt.Errorf("parse input error: %w", err)
+		}
+		if s.doc.Metadata == nil {
+			s.doc.Metadata = &cdx.Metadata{}
+		}
+		metadata := s.doc.Metadata
+		switch creator.CreatorType {
+		case "Tool":
+			tools := append(derefTools(metadata.Tools), cdx.Tool{Name: creator.Creator})
+			metadata.Tools = &tools
+		case "Person":
+			authors := make([]cdx.OrganizationalContact, 0)
+			if metadata.Authors != nil {
+				authors = append(authors, *metadata.Authors...)
+			}
+			authors = append(authors, cdx.OrganizationalContact{Name: creator.Creator})
+			metadata.Authors = &authors
+		case "Organization":
+			metadata.Supplier = &cdx.OrganizationalEntity{Name: creator.Creator}
+		default:
+			return fmt.Errorf("unsupported creator type: %s", creator.CreatorType)
+		}
+		return nil
+	})
+}
+
+func derefTools(tools *[]cdx.Tool) []cdx.Tool {
+	if tools == nil {
+		return nil
+	}
+	return *tools
+}
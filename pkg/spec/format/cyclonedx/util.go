@@ -0,0 +1,221 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"fmt"
+	"strconv"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// property names of the sbom-tool taxonomy, used for the fields CycloneDX has no place for
+const (
+	propPrefix = "sbom-tool:"
+
+	propNamespace = propPrefix + "namespace"
+
+	propSourceRepository = propPrefix + "source:repository"
+	propSourceBranch     = propPrefix + "source:branch"
+	propSourceRevision   = propPrefix + "source:revision"
+	propSourceTag        = propPrefix + "source:tag"
+	propSourceCommitTime = propPrefix + "source:commitTime"
+	propSourceCommitter  = propPrefix + "source:committer"
+	propSourceDirty      = propPrefix + "source:dirty"
+	propSourceTotalSize  = propPrefix + "source:totalSize"
+	propSourceTotalFile  = propPrefix + "source:totalFile"
+	propSourceTotalLine  = propPrefix + "source:totalLine"
+	propSourceLanguage   = propPrefix + "source:language"
+
+	propFingerprintTotalCount  = propPrefix + "fingerprint:totalCount"
+	propFingerprintCreated     = propPrefix + "fingerprint:created"
+	propFingerprintChecksum    = propPrefix + "fingerprint:checksum"
+	propFingerprintOutputMode  = propPrefix + "fingerprint:outputMode"
+	propFingerprintExternalRef = propPrefix + "fingerprint:externalRef"
+	propFingerprintVendorName  = propPrefix + "fingerprint:vendor:name"
+	propFingerprintVendorTool  = propPrefix + "fingerprint:vendor:tool"
+	propFingerprintVendorAlgo  = propPrefix + "fingerprint:vendor:algorithm"
+
+	propFingerprintFile     = propPrefix + "fingerprint:file"
+	propFingerprintSnippet  = propPrefix + "fingerprint:snippet"
+	propFingerprintSize     = propPrefix + "fingerprint:size"
+	propFingerprintLines    = propPrefix + "fingerprint:lines"
+	propFingerprintCount    = propPrefix + "fingerprint:count"
+	propFingerprintLanguage = propPrefix + "fingerprint:language"
+	propFingerprintLicense  = propPrefix + "fingerprint:license"
+
+	propPackageType             = propPrefix + "package:type"
+	propPackageSourceLocation   = propPrefix + "package:sourceLocation"
+	propPackageFilesAnalyzed    = propPrefix + "package:filesAnalyzed"
+	propPackageVerificationCode = propPrefix + "package:verificationCode"
+	propPackageScope            = propPrefix + "package:scope"
+	propPackageLayer            = propPrefix + "package:layer"
+	propPackageContains         = propPrefix + "package:contains" // purl of the nested packages, one property per package
+
+	propArtifactID    = propPrefix + "artifact:id"
+	propBuildOS       = propPrefix + "build:os"
+	propBuildArch     = propPrefix + "build:arch"
+	propBuildKernel   = propPrefix + "build:kernel"
+	propBuildBuilder  = propPrefix + "build:builder"
+	propBuildCompiler = propPrefix + "build:compiler"
+
+	propImageReference   = propPrefix + "image:reference"
+	propImageDigest      = propPrefix + "image:digest"
+	propImageConfig      = propPrefix + "image:config"
+	propImageOS          = propPrefix + "image:os"
+	propImageArch        = propPrefix + "image:arch"
+	propImageVariant     = propPrefix + "image:variant"
+	propImageCreated     = propPrefix + "image:created"
+	propImageBaseName    = propPrefix + "image:baseName"
+	propImageBaseDigest  = propPrefix + "image:baseDigest"
+	propImageLabel       = propPrefix + "image:label"       // name=value
+	propImageLayer       = propPrefix + "image:layer"       // diff id of the layers in order
+	propImageLayerDigest = propPrefix + "image:layerDigest" // digest of the layers in order
+
+	propFileType = propPrefix + "file:type"
+
+	propVulnerabilityFixed = propPrefix + "vulnerability:fixed"
+)
+
+var hashAlgorithms = map[model.ChecksumAlgorithm]cdx.HashAlgorithm{
+	model.ChecksumMD5:    cdx.HashAlgoMD5,
+	model.ChecksumSHA1:   cdx.HashAlgoSHA1,
+	model.ChecksumSHA256: cdx.HashAlgoSHA256,
+}
+
+// componentScopes maps the scopes of packages to the component scopes, which only tell whether a component is shipped
+var componentScopes = map[model.Scope]cdx.Scope{
+	model.ScopeRuntime:  cdx.ScopeRequired,
+	model.ScopeProvided: cdx.ScopeRequired,
+	model.ScopeOptional: cdx.ScopeOptional,
+	model.ScopeDev:      cdx.ScopeExcluded,
+	model.ScopeTest:     cdx.ScopeExcluded,
+}
+
+// BOMRef returns the bom-ref of the package, the PURL is used when present
+func BOMRef(pkg *model.Package) string {
+	if pkg.PURL != "" {
+		return pkg.PURL
+	}
+	ref, _ := util.SHA1SumStr(pkg.Name + "@" + pkg.Version)
+	return "Package-" + ref
+}
+
+// FileBOMRef returns the bom-ref of the file
+func FileBOMRef(name string) string {
+	ref, _ := util.SHA1SumStr(name)
+	return "File-" + ref
+}
+
+// SourceFileBOMRef returns the bom-ref of the source file, namespaced apart from the files of the artifact
+func SourceFileBOMRef(name string) string {
+	ref, _ := util.SHA1SumStr(name)
+	return "Source-" + ref
+}
+
+// bomRefs is the set of the bom-refs taken in a document
+type bomRefs map[string]struct{}
+
+// unique takes the ref, a ref already taken gets a numeric suffix, e.g. "pkg:npm/a@1.0.0~2"
+func (r bomRefs) unique(ref string) string {
+	taken := ref
+	for i := 2; ; i++ {
+		if _, ok := r[taken]; !ok {
+			break
+		}
+		taken = ref + "~" + strconv.Itoa(i)
+	}
+	r[taken] = struct{}{}
+	return taken
+}
+
+// SerialNumber returns a name based (version 5 layout) uuid urn for the given content
+func SerialNumber(content string) string {
+	sum, _ := util.SHA1SumStr(content)
+	b := []byte(sum[:32])
+	// version 5, variant RFC 4122
+	b[12] = '5'
+	b[16] = "89ab"[b[16]%4]
+	return fmt.Sprintf("urn:uuid:%s-%s-%s-%s-%s", b[0:8], b[8:12], b[12:16], b[16:20], b[20:32])
+}
+
+func toHashAlgorithm(alg model.ChecksumAlgorithm) (cdx.HashAlgorithm, bool) {
+	v, ok := hashAlgorithms[alg]
+	return v, ok
+}
+
+func fromHashAlgorithm(alg cdx.HashAlgorithm) (model.ChecksumAlgorithm, bool) {
+	for k, v := range hashAlgorithms {
+		if v == alg {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// fromComponentScope returns the package scope of a component scope, dev and test scopes can not be told apart
+func fromComponentScope(scope cdx.Scope) model.Scope {
+	switch scope {
+	case cdx.ScopeRequired:
+		return model.ScopeRuntime
+	case cdx.ScopeOptional:
+		return model.ScopeOptional
+	case cdx.ScopeExcluded:
+		return model.ScopeDev
+	default:
+		return ""
+	}
+}
+
+// propertyValue returns the first value of the named property
+func propertyValue(props *[]cdx.Property, name string) string {
+	if props == nil {
+		return ""
+	}
+	for _, p := range *props {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// propertyValues returns all values of the named property
+func propertyValues(props *[]cdx.Property, name string) []string {
+	values := make([]string, 0)
+	if props == nil {
+		return values
+	}
+	for _, p := range *props {
+		if p.Name == name {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+// appendProperty appends a property when the value is not empty
+func appendProperty(props []cdx.Property, name, value string) []cdx.Property {
+	if value == "" {
+		return props
+	}
+	return append(props, cdx.Property{Name: name, Value: value})
+}
+
+func propertiesOrNil(props []cdx.Property) *[]cdx.Property {
+	if len(props) == 0 {
+		return nil
+	}
+	return &props
+}
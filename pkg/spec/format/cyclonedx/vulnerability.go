@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package cyclonedx
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// osvURL is the url of the vulnerabilities from OSV
+const osvURL = "https://osv.dev/vulnerability/"
+
+// fromVulnerabilities converts the vulnerabilities, the affected packages are referred to by bom-ref,
+// pkgRefs are the bom-refs of the packages in order
+func fromVulnerabilities(vulns []model.Vulnerability, pkgs []model.Package, pkgRefs []string) []cdx.Vulnerability {
+	refs := make(map[string]string, len(pkgs))
+	for i := range pkgs {
+		if _, ok := refs[pkgs[i].PURL]; !ok {
+			refs[pkgs[i].PURL] = pkgRefs[i]
+		}
+	}
+	return util.SliceMap(vulns, func(v model.Vulnerability) cdx.Vulnerability {
+		vuln := cdx.Vulnerability{
+			BOMRef:      v.ID,
+			ID:          v.ID,
+			Description: v.Summary,
+			Published:   v.Published,
+			Updated:     v.Modified,
+		}
+		if v.Source != "" {
+			vuln.Source = &cdx.Source{Name: v.Source}
+			if v.Source == model.VulnerabilitySourceOSV {
+				vuln.Source.URL = osvURL + v.ID
+			}
+		}
+		if len(v.Aliases) > 0 {
+			aliases := util.SliceMap(v.Aliases, func(alias string) cdx.VulnerabilityReference {
+				return cdx.VulnerabilityReference{ID: alias}
+			})
+			vuln.References = &aliases
+		}
+		ratings := make([]cdx.VulnerabilityRating, 0)
+		if v.Severity != "" {
+			ratings = append(ratings, cdx.VulnerabilityRating{Severity: cdx.Severity(v.Severity)})
+		}
+		for _, r := range v.Ratings {
+			ratings = append(ratings, cdx.VulnerabilityRating{Method: toScoringMethod(r), Vector: r.Vector})
+		}
+		if len(ratings) > 0 {
+			vuln.Ratings = &ratings
+		}
+		if len(v.References) > 0 {
+			advisories := util.SliceMap(v.References, func(url string) cdx.Advisory {
+				return cdx.Advisory{URL: url}
+			})
+			vuln.Advisories = &advisories
+		}
+		if len(v.Affects) > 0 {
+			affects := util.SliceMap(v.Affects, func(purl string) cdx.Affects {
+				if ref, ok := refs[purl]; ok {
+					return cdx.Affects{Ref: ref}
+				}
+				return cdx.Affects{Ref: purl}
+			})
+			vuln.Affects = &affects
+		}
+		if len(v.Fixed) > 0 {
+			vuln.Recommendation = "Upgrade to " + strings.Join(v.Fixed, ", ")
+			props := make([]cdx.Property, 0, len(v.Fixed))
+			for _, fixed := range v.Fixed {
+				props = appendProperty(props, propVulnerabilityFixed, fixed)
+			}
+			vuln.Properties = propertiesOrNil(props)
+		}
+		return vuln
+	})
+}
+
+// toVulnerabilities converts the vulnerabilities, refs maps the bom-refs of components to the PURLs
+func toVulnerabilities(vulns *[]cdx.Vulnerability, refs map[string]string) []model.Vulnerability {
+	if vulns == nil || len(*vulns) == 0 {
+		return nil
+	}
+	return util.SliceMap(*vulns, func(vuln cdx.Vulnerability) model.Vulnerability {
+		v := model.Vulnerability{
+			ID:        vuln.ID,
+			Summary:   vuln.Description,
+			Published: vuln.Published,
+			Modified:  vuln.Updated,
+		}
+		if vuln.Source != nil {
+			v.Source = vuln.Source.Name
+		}
+		if vuln.References != nil {
+			v.Aliases = util.SliceMap(*vuln.References, func(r cdx.VulnerabilityReference) string { return r.ID })
+		}
+		if vuln.Ratings != nil {
+			for _, r := range *vuln.Ratings {
+				if r.Vector == "" {
+					if v.Severity == "" {
+						v.Severity = string(r.Severity)
+					}
+					continue
+				}
+				v.Ratings = append(v.Ratings, model.VulnerabilityRating{Method: fromScoringMethod(r), Vector: r.Vector})
+			}
+		}
+		if vuln.Advisories != nil {
+			v.References = util.SliceMap(*vuln.Advisories, func(a cdx.Advisory) string { return a.URL })
+		}
+		if vuln.Affects != nil {
+			v.Affects = util.SliceMap(*vuln.Affects, func(a cdx.Affects) string {
+				if purl, ok := refs[a.Ref]; ok && purl != "" {
+					return purl
+				}
+				return a.Ref
+			})
+		}
+		if fixed := propertyValues(vuln.Properties, propVulnerabilityFixed); len(fixed) > 0 {
+			v.Fixed = fixed
+		}
+		return v
+	})
+}
+
+func toScoringMethod(r model.VulnerabilityRating) cdx.ScoringMethod {
+	switch {
+	case r.Method == model.RatingCVSSv2:
+		return cdx.ScoringMethodCVSSv2
+	case r.Method == model.RatingCVSSv3 && strings.HasPrefix(r.Vector, "CVSS:3.1/"):
+		return cdx.ScoringMethodCVSSv31
+	case r.Method == model.RatingCVSSv3:
+		return cdx.ScoringMethodCVSSv3
+	}
+	return cdx.ScoringMethodOther
+}
+
+func fromScoringMethod(r cdx.VulnerabilityRating) string {
+	switch {
+	case r.Method == cdx.ScoringMethodCVSSv2:
+		return model.RatingCVSSv2
+	case r.Method == cdx.ScoringMethodCVSSv3 || r.Method == cdx.ScoringMethodCVSSv31:
+		return model.RatingCVSSv3
+	case strings.HasPrefix(r.Vector, "CVSS:4"):
+		return model.RatingCVSSv4
+	}
+	return string(r.Method)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+)
+
+var (
+	randPkgTypes  = []string{"maven", "npm", "golang", "pypi", "cargo"}
+	randLicenses  = []string{"MIT", "Apache-2.0", "BSD-3-Clause", "GPL-2.0-only WITH Classpath-exception-2.0", "EPL-1.0 OR LGPL-2.1-only", "Custom Proprietary License"}
+	randFileTypes = []model.FileType{model.FileTypeSource, model.FileTypeBinary, model.FileTypeArchive, model.FileTypeOther}
+	randScopes    = append([]model.Scope{""}, model.AllScopes()...)
+)
+
+func randSubset[T any](r *rand.Rand, in []T) []T {
+	var out []T
+	for _, v := range in {
+		if r.Intn(3) == 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func randPackage(r *rand.Rand, name string) model.Package {
+	pkgType := randPkgTypes[r.Intn(len(randPkgTypes))]
+	version := fmt.Sprintf("%d.%d.%d", r.Intn(5), r.Intn(20), r.Intn(100))
+	pkg := model.Package{
+		Name:             name,
+		Version:          version,
+		Type:             pkgType,
+		PURL:             "pkg:" + pkgType + "/" + name + "@" + version,
+		LicenseDeclared:  randSubset(r, randLicenses),
+		LicenseConcluded: randSubset(r, randLicenses),
+		SourceLocation:   "/src/" + name + "/manifest",
+	}
+	if r.Intn(2) == 0 {
+		pkg.Supplier = "supplier-" + name
+	}
+	if r.Intn(2) == 0 {
+		pkg.Copyright = "Copyright (c) " + name + " authors"
+	}
+	if r.Intn(2) == 0 {
+		pkg.FilesAnalyzed = true
+		pkg.VerificationCode = fmt.Sprintf("%040x", r.Int63())
+	}
+	return pkg
+}
+
+// randSbom returns a model restricted to the data SPDX 2.3 can carry
+func randSbom(r *rand.Rand) *model.SBOM {
+	sbomDoc := &model.SBOM{
+		NamespaceURI: fmt.Sprintf("https://example.com/sbom/%d", r.Int63()),
+		CreationInfo: model.CreationInfo{
+			Creators: []model.Creator{{Creator: "sbom-tool", CreatorType: "Tool"}, {Creator: "JD", CreatorType: "Organization"}},
+		},
+		Artifact: model.Artifact{Package: randPackage(r, "artifact")},
+	}
+	for i := 0; i < 1+r.Intn(8); i++ {
+		pkg := randPackage(r, fmt.Sprintf("pkg%d", i))
+		// the scope is carried by the relationships to the package, the root package has none
+		pkg.Scope = randScopes[r.Intn(len(randScopes))]
+		sbomDoc.Packages = append(sbomDoc.Packages, pkg)
+	}
+	// dependencies only point to later packages, the graph stays acyclic
+	for i := range sbomDoc.Packages {
+		for j := i + 1; j < len(sbomDoc.Packages); j++ {
+			if r.Intn(3) == 0 {
+				sbomDoc.Packages[i].Dependencies = append(sbomDoc.Packages[i].Dependencies, sbomDoc.Packages[j].PURL)
+			}
+		}
+	}
+	// nested packages only point to later packages as well
+	for i := range sbomDoc.Packages {
+		for j := i + 1; j < len(sbomDoc.Packages); j++ {
+			if r.Intn(5) == 0 {
+				sbomDoc.Packages[i].Contains = append(sbomDoc.Packages[i].Contains, sbomDoc.Packages[j].PURL)
+			}
+		}
+	}
+	for i := range sbomDoc.Packages {
+		if r.Intn(4) > 0 {
+			continue
+		}
+		// the artifact contains the package instead of depending on it, no scope is carried
+		sbomDoc.Packages[i].Scope = ""
+		sbomDoc.Relationships = append(sbomDoc.Relationships, model.Relationship{
+			Type:   model.Contains,
+			FromID: sbomDoc.Artifact.PURL,
+			ToID:   sbomDoc.Packages[i].PURL,
+		})
+	}
+	for i := 0; i < r.Intn(4); i++ {
+		file := model.File{
+			Name: fmt.Sprintf("bin/file%d", i),
+			Type: randFileTypes[r.Intn(len(randFileTypes))],
+			Checksums: []model.FileChecksum{
+				{Algorithm: model.ChecksumSHA1, Value: fmt.Sprintf("%040x", r.Int63())},
+			},
+		}
+		if file.Type == model.FileTypeBinary && r.Intn(2) == 0 {
+			file.Compilers = []string{"GCC: (GNU) 12.2.0", fmt.Sprintf("clang version %d.0.1", r.Intn(20))}
+		}
+		sbomDoc.Artifact.Files = append(sbomDoc.Artifact.Files, file)
+	}
+	return sbomDoc
+}
+
+func TestSpdxSpec_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		expected := randSbom(r)
+		spec := &Spec{}
+		for _, f := range []format.Format{&JSONFormat{spec: spec}, &TagValueFormat{spec: spec}} {
+			spec.FromModel(expected)
+			assert.NoError(t, spec.Validate())
+
+			buf := &bytes.Buffer{}
+			assert.NoError(t, f.Dump(buf))
+			assert.NoError(t, f.Load(buf))
+
+			actual := spec.ToModel()
+			actual.CreationInfo.Created = ""
+			// the tagvalue writer orders elements by id
+			assert.ElementsMatch(t, expected.Packages, actual.Packages)
+			assert.ElementsMatch(t, expected.Artifact.Files, actual.Artifact.Files)
+			assert.ElementsMatch(t, expected.Relationships, actual.Relationships)
+			actual.Packages, actual.Artifact.Files = expected.Packages, expected.Artifact.Files
+			actual.Relationships = expected.Relationships
+			if !assert.Equal(t, expected, actual, "case %d, format %s", i, f.Type()) {
+				return
+			}
+		}
+	}
+}
+
+func TestParseLicenseExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{expression: "NOASSERTION", want: nil},
+		{expression: "NONE", want: nil},
+		{expression: "MIT", want: []string{"MIT"}},
+		{expression: "MIT AND Apache-2.0", want: []string{"MIT", "Apache-2.0"}},
+		{expression: "(MIT OR Apache-2.0)", want: []string{"MIT OR Apache-2.0"}},
+		{expression: "(MIT OR Apache-2.0) AND BSD-3-Clause", want: []string{"MIT OR Apache-2.0", "BSD-3-Clause"}},
+		{expression: "(MIT OR Apache-2.0) AND (BSD-3-Clause OR ISC)", want: []string{"MIT OR Apache-2.0", "BSD-3-Clause OR ISC"}},
+		{expression: "MIT OR Apache-2.0 AND ISC", want: []string{"MIT OR (Apache-2.0 AND ISC)"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, ParseLicenseExpression(test.expression), test.expression)
+	}
+}
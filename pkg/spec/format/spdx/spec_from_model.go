@@ -11,7 +11,6 @@
 package spdx
 
 import (
-	"strings"
 	"time"
 
 	"github.com/spdx/tools-golang/spdx"
@@ -41,19 +40,30 @@ func (s *Spec) FromModel(sbomDoc *model.SBOM) {
 				Creator:     c.Creator,
 			}
 		}),
-		Created: time.Now().Format(time.RFC3339),
+		Created:        time.Now().Format(time.RFC3339),
+		CreatorComment: sbomDoc.CreationInfo.CreatorComment,
 	}
 
 	spdxDoc.Packages = util.SliceMap(sbomDoc.Packages, toSpdxPackage)
 	spdxDoc.Packages = append(spdxDoc.Packages, toSpdxPackage(sbomDoc.Artifact.Package))
+	AddSecurityReferences(spdxDoc.Packages, sbomDoc.Vulnerabilities)
+
+	spdxDoc.OtherLicenses = OtherLicenses(append([]model.Package{sbomDoc.Artifact.Package}, sbomDoc.Packages...))
 
 	spdxDoc.Files = util.SliceMap(sbomDoc.Artifact.Files, toSpdxFile)
-	spdxDoc.Relationships = toRelationships(sbomDoc.Packages, &sbomDoc.Artifact.Package)
+	spdxDoc.Relationships = append([]*spdx.Relationship{
+		{
+			RefA:         spdx.DocElementID{ElementRefID: "DOCUMENT"},
+			RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(&sbomDoc.Artifact.Package)},
+			Relationship: spdx.RelationshipDescribes,
+		},
+	}, toRelationships(sbomDoc.Packages, &sbomDoc.Artifact.Package, sbomDoc.Relationships)...)
 	s.doc = spdxDoc
 }
 
-func toRelationships(pkgs []model.Package, mainPkg *model.Package) []*spdx.Relationship {
+func toRelationships(pkgs []model.Package, mainPkg *model.Package, modelRels []model.Relationship) []*spdx.Relationship {
 	mainPkgID := PackageSPDXID(mainPkg)
+	contained := model.ContainedPackages(modelRels, mainPkg.PURL)
 	pkgMap := make(map[string]*model.Package)
 	allDeps := make(map[string]struct{})
 	for i := 0; i < len(pkgs); i++ {
@@ -66,26 +76,32 @@ func toRelationships(pkgs []model.Package, mainPkg *model.Package) []*spdx.Relat
 	rels := make([]*spdx.Relationship, 0)
 	for i := range pkgs {
 		pkgID := PackageSPDXID(&pkgs[i])
-		if _, isDep := allDeps[pkgs[i].PURL]; !isDep {
-			rel := &spdx.Relationship{
+		if _, isContained := contained[pkgs[i].PURL]; isContained {
+			rels = append(rels, &spdx.Relationship{
 				RefA:         spdx.DocElementID{ElementRefID: mainPkgID},
 				RefB:         spdx.DocElementID{ElementRefID: pkgID},
-				Relationship: spdx.RelationshipDependsOn,
-			}
-			rels = append(rels, rel)
+				Relationship: spdx.RelationshipContains,
+			})
+		} else if _, isDep := allDeps[pkgs[i].PURL]; !isDep {
+			rels = append(rels, DependencyRelationship(mainPkgID, pkgID, pkgs[i].Scope))
 		}
 
 		if len(pkgs[i].Dependencies) > 0 {
 			for _, dep := range pkgs[i].Dependencies {
 				if pkg, ok := pkgMap[dep]; ok {
-					rels = append(rels, &spdx.Relationship{
-						RefA:         spdx.DocElementID{ElementRefID: pkgID},
-						RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(pkg)},
-						Relationship: spdx.RelationshipDependsOn,
-					})
+					rels = append(rels, DependencyRelationship(pkgID, PackageSPDXID(pkg), pkg.Scope))
 				}
 			}
 		}
+		for _, nested := range pkgs[i].Contains {
+			if pkg, ok := pkgMap[nested]; ok {
+				rels = append(rels, &spdx.Relationship{
+					RefA:         spdx.DocElementID{ElementRefID: pkgID},
+					RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(pkg)},
+					Relationship: spdx.RelationshipContains,
+				})
+			}
+		}
 	}
 	return rels
 }
@@ -98,6 +114,16 @@ func toSpdxPackage(pkg model.Package) *spdx.Package {
 		PackageDownloadLocation: "NONE",
 		PackageLicenseDeclared:  license.NOASSERTION_LICENSE,
 		PackageLicenseConcluded: license.NOASSERTION_LICENSE,
+		PackageSourceInfo:       pkg.SourceLocation,
+		FilesAnalyzed:           pkg.FilesAnalyzed,
+		// the tag is written, otherwise the spec defaults FilesAnalyzed to true
+		IsFilesAnalyzedTagPresent: true,
+	}
+	if pkg.FilesAnalyzed && len(pkg.VerificationCode) > 0 {
+		spdxPkg.PackageVerificationCode = &spdx.PackageVerificationCode{Value: pkg.VerificationCode}
+	}
+	if len(pkg.Copyright) > 0 {
+		spdxPkg.PackageCopyrightText = pkg.Copyright
 	}
 	if len(pkg.Supplier) > 0 {
 		spdxPkg.PackageSupplier = &spdx.Supplier{
@@ -116,11 +142,11 @@ func toSpdxPackage(pkg model.Package) *spdx.Package {
 	}
 
 	if len(pkg.LicenseConcluded) > 0 {
-		spdxPkg.PackageLicenseConcluded = licenseExpressionForSpdx(pkg.LicenseConcluded)
+		spdxPkg.PackageLicenseConcluded = LicenseExpression(pkg.LicenseConcluded)
 	}
 
 	if len(pkg.LicenseDeclared) > 0 {
-		spdxPkg.PackageLicenseDeclared = licenseExpressionForSpdx(pkg.LicenseDeclared)
+		spdxPkg.PackageLicenseDeclared = LicenseExpression(pkg.LicenseDeclared)
 	}
 	return spdxPkg
 }
@@ -130,6 +156,7 @@ func toSpdxFile(file model.File) *spdx.File {
 		FileSPDXIdentifier: FileSPDXID(&file),
 		FileName:           file.Name,
 		FileTypes:          []string{string(file.Type)},
+		FileComment:        FileComment(&file),
 		Checksums: util.SliceMap(file.Checksums, func(sum model.FileChecksum) spdx.Checksum {
 			return spdx.Checksum{
 				Algorithm: spdx.ChecksumAlgorithm(sum.Algorithm),
@@ -138,8 +165,3 @@ func toSpdxFile(file model.File) *spdx.File {
 		}),
 	}
 }
-
-func licenseExpressionForSpdx(licenses []string) string {
-	licenseExpression := strings.Join(licenses, " AND ")
-	return licenseExpression
-}
@@ -72,7 +72,8 @@ func TestSpdxSpec_ToSBOM(t *testing.T) {
 	sbomDoc := spec.ToModel()
 
 	assert.Equal(t, spdxDoc.CreationInfo.Created, sbomDoc.CreationInfo.Created)
-	assert.Equal(t, 2, len(sbomDoc.Packages))
+	assert.Equal(t, 1, len(sbomDoc.Packages))
+	assert.Equal(t, "demo", sbomDoc.Artifact.Name)
 	assert.Equal(t, 1, len(sbomDoc.Artifact.Files))
 }
 
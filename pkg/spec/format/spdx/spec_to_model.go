@@ -11,11 +11,15 @@
 package spdx
 
 import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
 	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 func (s *Spec) ToModel() *model.SBOM {
@@ -25,56 +29,233 @@ func (s *Spec) ToModel() *model.SBOM {
 	spdxDoc := s.doc
 	sbomDoc := &model.SBOM{
 		NamespaceURI: spdxDoc.DocumentNamespace,
-		CreationInfo: model.CreationInfo{
-			Created: spdxDoc.CreationInfo.Created,
+	}
+	if spdxDoc.CreationInfo != nil {
+		sbomDoc.CreationInfo = model.CreationInfo{
+			Created:        spdxDoc.CreationInfo.Created,
+			CreatorComment: spdxDoc.CreationInfo.CreatorComment,
 			Creators: util.SliceMap(spdxDoc.CreationInfo.Creators, func(c common.Creator) model.Creator {
 				return model.Creator{Creator: c.Creator, CreatorType: c.CreatorType}
 			}),
-		},
+		}
 	}
 
-	sbomDoc.Packages = util.SliceMap(spdxDoc.Packages, toPackage)
-
-	sbomDoc.Artifact = model.Artifact{
-		Files: util.SliceMap(spdxDoc.Files, toFile),
+	rootID := RootPackageID(spdxDoc)
+	purls := make(map[spdx.ElementID]string)
+	for _, pkg := range spdxDoc.Packages {
+		purls[pkg.PackageSPDXIdentifier] = PackageURL(pkg)
 	}
-	index := util.SliceFirst(spdxDoc.Packages, func(p *spdx.Package) bool {
-		return p.PackageSPDXIdentifier == "SPDXRef-RootPackage"
-	})
-	if index > -1 {
-		sbomDoc.Artifact.Package = toPackage(spdxDoc.Packages[index])
+	deps := DependencyMap(spdxDoc.Relationships)
+	scopes := ScopeMap(spdxDoc.Relationships)
+	sbomDoc.Relationships = ContainsRelationships(spdxDoc.Relationships, rootID, purls)
+	nested := NestedMap(spdxDoc.Relationships, rootID)
+
+	for _, pkg := range spdxDoc.Packages {
+		sbomPkg := toPackage(pkg)
+		sbomPkg.LicenseDeclared = RestoreLicenses(sbomPkg.LicenseDeclared, spdxDoc.OtherLicenses)
+		sbomPkg.LicenseConcluded = RestoreLicenses(sbomPkg.LicenseConcluded, spdxDoc.OtherLicenses)
+		sbomPkg.Dependencies = dependencyPURLs(deps[pkg.PackageSPDXIdentifier], purls)
+		sbomPkg.Scope = scopes[pkg.PackageSPDXIdentifier]
+		sbomPkg.Contains = dependencyPURLs(nested[pkg.PackageSPDXIdentifier], purls)
+		if rootID != "" && pkg.PackageSPDXIdentifier == rootID {
+			// dependencies of the root are the top level packages, they are derived when writing
+			sbomPkg.Dependencies = nil
+			sbomDoc.Artifact.Package = sbomPkg
+			continue
+		}
+		sbomDoc.Packages = append(sbomDoc.Packages, sbomPkg)
 	}
 
+	sbomDoc.Artifact.Files = util.SliceMap(spdxDoc.Files, toFile)
+	sbomDoc.Vulnerabilities = Vulnerabilities(spdxDoc.Packages)
 	return sbomDoc
 }
 
-func toPackage(pkg *spdx.Package) model.Package {
-	purl := ""
-	if len(pkg.PackageExternalReferences) > 0 {
-		for _, ref := range pkg.PackageExternalReferences {
-			if ref.RefType == "" {
-				purl = ref.Locator
+// RootPackageID returns the id of the package the document describes
+func RootPackageID(doc *spdx.Document) spdx.ElementID {
+	isPackage := func(id spdx.ElementID) bool {
+		return util.SliceAny(doc.Packages, func(p *spdx.Package) bool {
+			return p.PackageSPDXIdentifier == id
+		})
+	}
+	for _, rel := range doc.Relationships {
+		if rel == nil {
+			continue
+		}
+		switch {
+		case rel.Relationship == common.TypeRelationshipDescribe && rel.RefA.ElementRefID == "DOCUMENT" && isPackage(rel.RefB.ElementRefID):
+			return rel.RefB.ElementRefID
+		case rel.Relationship == common.TypeRelationshipDescribeBy && rel.RefB.ElementRefID == "DOCUMENT" && isPackage(rel.RefA.ElementRefID):
+			return rel.RefA.ElementRefID
+		}
+	}
+	// documents written by earlier versions mark the root package by id
+	for _, id := range []spdx.ElementID{"RootPackage", "SPDXRef-RootPackage"} {
+		if isPackage(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// scopeRelationships maps the scopes of packages to the relationships from a dependency to the package depending on it
+var scopeRelationships = map[model.Scope]string{
+	model.ScopeRuntime:  common.TypeRelationshipRuntimeDependencyOf,
+	model.ScopeDev:      common.TypeRelationshipDevDependencyOf,
+	model.ScopeTest:     common.TypeRelationshipTestDependencyOf,
+	model.ScopeOptional: common.TypeRelationshipOptionalDependencyOf,
+	model.ScopeProvided: common.TypeRelationshipProvidedDependencyOf,
+}
+
+// DependencyRelationship returns the relationship of a package depending on a dependency,
+// it is DEPENDS_ON when the scope of the dependency is unknown, otherwise the scoped *_DEPENDENCY_OF from the dependency
+func DependencyRelationship(pkgID, depID spdx.ElementID, scope model.Scope) *spdx.Relationship {
+	if relType, ok := scopeRelationships[scope]; ok {
+		return &spdx.Relationship{
+			RefA:         spdx.DocElementID{ElementRefID: depID},
+			RefB:         spdx.DocElementID{ElementRefID: pkgID},
+			Relationship: relType,
+		}
+	}
+	return &spdx.Relationship{
+		RefA:         spdx.DocElementID{ElementRefID: pkgID},
+		RefB:         spdx.DocElementID{ElementRefID: depID},
+		Relationship: spdx.RelationshipDependsOn,
+	}
+}
+
+// DependencyMap returns the dependencies of each element, built from DEPENDS_ON and (scoped) DEPENDENCY_OF relationships
+func DependencyMap(rels []*spdx.Relationship) map[spdx.ElementID][]spdx.ElementID {
+	deps := make(map[spdx.ElementID][]spdx.ElementID)
+	for _, rel := range rels {
+		if rel == nil {
+			continue
+		}
+		switch {
+		case rel.Relationship == common.TypeRelationshipDependsOn:
+			deps[rel.RefA.ElementRefID] = append(deps[rel.RefA.ElementRefID], rel.RefB.ElementRefID)
+		case rel.Relationship == common.TypeRelationshipDependencyOf || relationshipScope(rel.Relationship) != "":
+			deps[rel.RefB.ElementRefID] = append(deps[rel.RefB.ElementRefID], rel.RefA.ElementRefID)
+		}
+	}
+	return deps
+}
+
+// ContainsRelationships returns the relationships of the packages the root package contains, identified by PURLs
+func ContainsRelationships(rels []*spdx.Relationship, rootID spdx.ElementID, purls map[spdx.ElementID]string) []model.Relationship {
+	ret := make([]model.Relationship, 0)
+	for _, rel := range rels {
+		if rel == nil || rel.Relationship != common.TypeRelationshipContains || rel.RefA.ElementRefID != rootID {
+			continue
+		}
+		from, to := purls[rel.RefA.ElementRefID], purls[rel.RefB.ElementRefID]
+		if from != "" && to != "" {
+			ret = append(ret, model.Relationship{Type: model.Contains, FromID: from, ToID: to})
+		}
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+// NestedMap returns the elements each package contains, the packages the root contains are kept by ContainsRelationships
+func NestedMap(rels []*spdx.Relationship, rootID spdx.ElementID) map[spdx.ElementID][]spdx.ElementID {
+	nested := make(map[spdx.ElementID][]spdx.ElementID)
+	for _, rel := range rels {
+		if rel == nil || rel.Relationship != common.TypeRelationshipContains || rel.RefA.ElementRefID == rootID {
+			continue
+		}
+		nested[rel.RefA.ElementRefID] = append(nested[rel.RefA.ElementRefID], rel.RefB.ElementRefID)
+	}
+	return nested
+}
+
+// ScopeMap returns the scopes of elements, built from scoped DEPENDENCY_OF relationships
+func ScopeMap(rels []*spdx.Relationship) map[spdx.ElementID]model.Scope {
+	scopes := make(map[spdx.ElementID]model.Scope)
+	for _, rel := range rels {
+		if rel == nil {
+			continue
+		}
+		if scope := relationshipScope(rel.Relationship); scope != "" {
+			scopes[rel.RefA.ElementRefID] = model.MergeScope(scopes[rel.RefA.ElementRefID], scope)
+		}
+	}
+	return scopes
+}
+
+func relationshipScope(relType string) model.Scope {
+	for scope, t := range scopeRelationships {
+		if t == relType {
+			return scope
+		}
+	}
+	return ""
+}
+
+func dependencyPURLs(ids []spdx.ElementID, purls map[spdx.ElementID]string) []string {
+	deps := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if purl := purls[id]; purl != "" && !util.SliceContains(deps, purl) {
+			deps = append(deps, purl)
+		}
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	return deps
+}
 
-				break
-			}
+// PackageURL returns the purl of the package from its external references
+func PackageURL(pkg *spdx.Package) string {
+	for _, ref := range pkg.PackageExternalReferences {
+		if ref == nil {
+			continue
+		}
+		// purl is the reference type defined by the spec, an empty type is kept for documents written by older versions
+		if strings.EqualFold(ref.RefType, common.TypePackageManagerPURL) || ref.RefType == "" {
+			return ref.Locator
 		}
 	}
+	return ""
+}
+
+func toPackage(pkg *spdx.Package) model.Package {
 	sbomPkg := model.Package{
-		Name:    pkg.PackageName,
-		Version: pkg.PackageVersion,
+		Name:             pkg.PackageName,
+		Version:          pkg.PackageVersion,
+		PURL:             PackageURL(pkg),
+		FilesAnalyzed:    pkg.FilesAnalyzed,
+		SourceLocation:   pkg.PackageSourceInfo,
+		LicenseConcluded: ParseLicenseExpression(pkg.PackageLicenseConcluded),
+		LicenseDeclared:  ParseLicenseExpression(pkg.PackageLicenseDeclared),
+	}
+	if purl, err := packageurl.FromString(sbomPkg.PURL); err == nil {
+		sbomPkg.Type = purl.Type
 	}
-	if purl != "" {
-		sbomPkg.PURL = purl
+	if pkg.PackageCopyrightText != license.NOASSERTION_LICENSE && pkg.PackageCopyrightText != "NONE" {
+		sbomPkg.Copyright = pkg.PackageCopyrightText
+	}
+	if pkg.PackageSupplier != nil && pkg.PackageSupplier.Supplier != license.NOASSERTION_LICENSE {
+		sbomPkg.Supplier = pkg.PackageSupplier.Supplier
+	}
+	if pkg.PackageVerificationCode != nil {
+		sbomPkg.VerificationCode = pkg.PackageVerificationCode.Value
 	}
-	// TODO license
 	return sbomPkg
 }
 
 func toFile(file *spdx.File) model.File {
-	return model.File{
+	sbomFile := model.File{
 		Name: file.FileName,
 		Checksums: util.SliceMap(file.Checksums, func(sum spdx.Checksum) model.FileChecksum {
 			return model.FileChecksum{Algorithm: model.ChecksumAlgorithm(sum.Algorithm), Value: sum.Value}
 		}),
+		Compilers: FileCompilers(file.FileComment),
+	}
+	if len(file.FileTypes) > 0 {
+		sbomFile.Type = model.FileType(file.FileTypes[0])
 	}
+	return sbomFile
 }
@@ -11,10 +11,13 @@
 package spdx
 
 import (
+	"strings"
+
 	"github.com/spdx/tools-golang/spdx"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 // SPDXID returns the spdx id of the content
@@ -32,3 +35,73 @@ func PackageSPDXID(pkg *model.Package) spdx.ElementID {
 func FileSPDXID(file *model.File) spdx.ElementID {
 	return spdx.ElementID("File-" + SPDXID(file.Name))
 }
+
+// fileCompilerPrefix prefixes a compiler of a binary in the comment of the file
+const fileCompilerPrefix = "compiler: "
+
+// FileComment returns the comment of the file recording its compilers one per line, SPDX 2 has no field for them
+func FileComment(file *model.File) string {
+	return strings.Join(util.SliceMap(file.Compilers, func(compiler string) string {
+		return fileCompilerPrefix + compiler
+	}), "\n")
+}
+
+// FileCompilers returns the compilers recorded in the comment of a file, see FileComment
+func FileCompilers(comment string) []string {
+	var compilers []string
+	for _, line := range strings.Split(comment, "\n") {
+		if compiler := strings.TrimPrefix(line, fileCompilerPrefix); compiler != line {
+			compilers = append(compilers, compiler)
+		}
+	}
+	return compilers
+}
+
+// ParseLicenseExpression splits a license expression into the licenses combined by AND,
+// NOASSERTION and NONE mean no license
+func ParseLicenseExpression(expression string) []string {
+	return license.SplitExpression(expression)
+}
+
+// LicenseExpression returns the SPDX expression of the licenses combined by AND, NOASSERTION if there is no license
+func LicenseExpression(licenses []string) string {
+	if expression := license.CombineExpression(licenses); expression != "" {
+		return expression
+	}
+	return license.NOASSERTION_LICENSE
+}
+
+// OtherLicenses returns the licenses not in the SPDX license list referenced by the license expressions of the packages
+func OtherLicenses(pkgs []model.Package) []*spdx.OtherLicense {
+	others := make([]*spdx.OtherLicense, 0)
+	ids := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		for _, licenses := range [][]string{pkg.LicenseDeclared, pkg.LicenseConcluded} {
+			_, extracted := license.SPDXExpression(licenses)
+			for _, e := range extracted {
+				if _, ok := ids[e.ID]; ok {
+					continue
+				}
+				ids[e.ID] = struct{}{}
+				others = append(others, &spdx.OtherLicense{LicenseIdentifier: e.ID, ExtractedText: e.Text, LicenseName: e.Name})
+			}
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return others
+}
+
+// RestoreLicenses replaces the LicenseRef ids created from license names by the names, see OtherLicenses
+func RestoreLicenses(licenses []string, others []*spdx.OtherLicense) []string {
+	if len(others) == 0 || len(licenses) == 0 {
+		return licenses
+	}
+	extracted := util.SliceMap(others, func(o *spdx.OtherLicense) license.ExtractedLicense {
+		return license.ExtractedLicense{ID: o.LicenseIdentifier, Name: o.LicenseName, Text: o.ExtractedText}
+	})
+	return util.SliceMap(licenses, func(l string) string {
+		return license.RestoreLicenseRefs(l, extracted)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx
+
+import (
+	"path"
+	"strings"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+const (
+	// refTypeAdvisory is the type of the security external references to vulnerability advisories
+	refTypeAdvisory = "advisory"
+	// osvURL is the url of the vulnerabilities from OSV
+	osvURL = "https://osv.dev/vulnerability/"
+)
+
+// AddSecurityReferences adds an advisory security external reference of each vulnerability to the affected packages,
+// the comment of the reference is the id of the vulnerability
+func AddSecurityReferences(pkgs []*spdx.Package, vulns []model.Vulnerability) {
+	for _, pkg := range pkgs {
+		purl := PackageURL(pkg)
+		if purl == "" {
+			continue
+		}
+		for _, v := range vulns {
+			locator := advisoryURL(&v)
+			if locator == "" || !util.SliceContains(v.Affects, purl) {
+				continue
+			}
+			pkg.PackageExternalReferences = append(pkg.PackageExternalReferences, &spdx.PackageExternalReference{
+				Category:           common.CategorySecurity,
+				RefType:            refTypeAdvisory,
+				Locator:            locator,
+				ExternalRefComment: v.ID,
+			})
+		}
+	}
+}
+
+// advisoryURL returns the url of the vulnerability, the first reference is used unless it comes from OSV
+func advisoryURL(v *model.Vulnerability) string {
+	if v.Source == model.VulnerabilitySourceOSV {
+		return osvURL + v.ID
+	}
+	if len(v.References) > 0 {
+		return v.References[0]
+	}
+	return ""
+}
+
+// Vulnerabilities returns the vulnerabilities of the advisory security external references of the packages
+func Vulnerabilities(pkgs []*spdx.Package) []model.Vulnerability {
+	vulns := make([]model.Vulnerability, 0)
+	index := make(map[string]int)
+	for _, pkg := range pkgs {
+		purl := PackageURL(pkg)
+		for _, ref := range pkg.PackageExternalReferences {
+			if ref == nil || ref.Category != common.CategorySecurity || ref.RefType != refTypeAdvisory || purl == "" {
+				continue
+			}
+			id := ref.ExternalRefComment
+			if id == "" {
+				id = path.Base(ref.Locator)
+			}
+			i, ok := index[id]
+			if !ok {
+				v := model.Vulnerability{ID: id}
+				if strings.HasPrefix(ref.Locator, osvURL) {
+					v.Source = model.VulnerabilitySourceOSV
+				} else {
+					v.References = []string{ref.Locator}
+				}
+				i = len(vulns)
+				index[id] = i
+				vulns = append(vulns, v)
+			}
+			vulns[i].Affects = util.SliceUnique(append(vulns[i].Affects, purl))
+		}
+	}
+	if len(vulns) == 0 {
+		return nil
+	}
+	return vulns
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+func TestSecurityReferences(t *testing.T) {
+	pkgs := []model.Package{
+		{Name: "fastjson", Version: "1.2.78", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.78"},
+		{Name: "log4j-core", Version: "2.14.1", Type: "maven", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+	}
+	vulns := []model.Vulnerability{
+		{ID: "GHSA-jfh8-c2jp-5v3q", Source: model.VulnerabilitySourceOSV, Summary: "Remote code injection in Log4j",
+			Affects: []string{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}},
+		{ID: "CVE-2022-25845", References: []string{"https://nvd.nist.gov/vuln/detail/CVE-2022-25845"},
+			Affects: []string{"pkg:maven/com.alibaba/fastjson@1.2.78"}},
+		{ID: "NO-ADVISORY", Affects: []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+	}
+	spec := &Spec{}
+	spec.FromModel(&model.SBOM{
+		Artifact:        model.Artifact{Package: model.Package{Name: "demo", Version: "1.0.0", PURL: "pkg:generic/demo@1.0.0"}},
+		Packages:        pkgs,
+		Vulnerabilities: vulns,
+	})
+	assert.NoError(t, spec.Validate())
+
+	refs := spec.doc.Packages[1].PackageExternalReferences
+	assert.Len(t, refs, 2)
+	assert.Equal(t, "SECURITY", refs[1].Category)
+	assert.Equal(t, "https://osv.dev/vulnerability/GHSA-jfh8-c2jp-5v3q", refs[1].Locator)
+	assert.Len(t, spec.doc.Packages[2].PackageExternalReferences, 1)
+
+	assert.Equal(t, []model.Vulnerability{
+		{ID: "CVE-2022-25845", References: []string{"https://nvd.nist.gov/vuln/detail/CVE-2022-25845"},
+			Affects: []string{"pkg:maven/com.alibaba/fastjson@1.2.78"}},
+		{ID: "GHSA-jfh8-c2jp-5v3q", Source: model.VulnerabilitySourceOSV,
+			Affects: []string{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}},
+	}, spec.ToModel().Vulnerabilities)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+)
+
+// JSONLDFormat is the json-ld serialization of spdx 3
+type JSONLDFormat struct {
+	spec *Spec
+}
+
+func (f *JSONLDFormat) Spec() format.Specification {
+	return f.spec
+}
+
+func (f *JSONLDFormat) Load(reader io.Reader) error {
+	doc := &spdx3Model.Document{}
+	err := json.NewDecoder(reader).Decode(doc)
+	if err != nil {
+		return fmt.Errorf("read error: %w", err)
+	}
+	f.spec.doc = doc
+	return nil
+}
+
+func (f *JSONLDFormat) Dump(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(f.spec.doc)
+	if err != nil {
+		return fmt.Errorf("dump error: %w", err)
+	}
+	return nil
+}
+
+func (f *JSONLDFormat) Type() string {
+	return "jsonld"
+}
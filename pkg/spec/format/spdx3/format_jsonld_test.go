@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+)
+
+func TestJSONLDFormat_Load(t *testing.T) {
+	spec := &Spec{}
+	format := JSONLDFormat{spec: spec}
+	file, err := os.Open("test_material/example-v3.0.1.spdx3.jsonld")
+	assert.NoError(t, err)
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	err = format.Load(file)
+	assert.NoError(t, err)
+	assert.NoError(t, spec.Validate())
+
+	assert.Equal(t, spdx3Model.Context, spec.doc.Context)
+	assert.Equal(t, 3, len(spec.doc.ElementsOfType(spdx3Model.TypePackage)))
+
+	sbomDoc := spec.ToModel()
+	assert.Equal(t, "https://example.com/sbom/demo", sbomDoc.NamespaceURI)
+	assert.Equal(t, "demo", sbomDoc.Artifact.Name)
+	assert.Equal(t, "linux", sbomDoc.Artifact.Build.OS)
+	assert.Equal(t, 2, len(sbomDoc.Packages))
+	assert.Equal(t, []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}, sbomDoc.Packages[0].Dependencies)
+	assert.Equal(t, []string{"EPL-1.0 OR LGPL-2.1-only"}, sbomDoc.Packages[0].LicenseDeclared)
+}
+
+func TestJSONLDFormat_Dump(t *testing.T) {
+	spec := &Spec{}
+	format := JSONLDFormat{spec: spec}
+	spec.FromModel(newSbomDoc())
+
+	buf := &bytes.Buffer{}
+	err := format.Dump(buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "\"@context\": \""+spdx3Model.Context+"\"")
+
+	loaded := &Spec{}
+	err = (&JSONLDFormat{spec: loaded}).Load(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Validate())
+	assert.Equal(t, len(spec.doc.Graph), len(loaded.doc.Graph))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package model
+
+// Context is the JSON-LD context of SPDX 3.0
+const Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+// SpecVersion is the SPDX version written to CreationInfo
+const SpecVersion = "3.0.1"
+
+// DataLicense is the license of the document data, the IRI of CC0-1.0 in the SPDX license list
+const DataLicense = "https://spdx.org/licenses/CC0-1.0"
+
+// element types of the Core, Software, Build and SimpleLicensing profiles
+const (
+	TypeCreationInfo       = "CreationInfo"
+	TypeSpdxDocument       = "SpdxDocument"
+	TypeRelationship       = "Relationship"
+	TypeScopedRelationship = "LifecycleScopedRelationship"
+	TypePerson             = "Person"
+	TypeOrganization       = "Organization"
+	TypeTool               = "Tool"
+	TypeSbom               = "software_Sbom"
+	TypePackage            = "software_Package"
+	TypeFile               = "software_File"
+	TypeBuild              = "build_Build"
+	TypeLicenseExpression  = "simplelicensing_LicenseExpression"
+)
+
+// relationship types used by sbom-tool
+const (
+	RelationshipDependsOn           = "dependsOn"
+	RelationshipContains            = "contains"
+	RelationshipHasOutput           = "hasOutput"
+	RelationshipHasDeclaredLicense  = "hasDeclaredLicense"
+	RelationshipHasConcludedLicense = "hasConcludedLicense"
+)
+
+// lifecycle scopes of LifecycleScopedRelationship
+const (
+	LifecycleScopeDesign      = "design"
+	LifecycleScopeDevelopment = "development"
+	LifecycleScopeBuild       = "build"
+	LifecycleScopeTest        = "test"
+	LifecycleScopeRuntime     = "runtime"
+	LifecycleScopeOther       = "other"
+)
+
+// Document is the JSON-LD serialization of an SPDX 3.0 document
+type Document struct {
+	Context string     `json:"@context"`
+	Graph   []*Element `json:"@graph"`
+}
+
+// Element is a node of the graph, it holds the union of the properties of all element types used by sbom-tool
+type Element struct {
+	Type         string `json:"type"`
+	ID           string `json:"@id,omitempty"`
+	SpdxID       string `json:"spdxId,omitempty"`
+	CreationInfo string `json:"creationInfo,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Comment      string `json:"comment,omitempty"`
+
+	// CreationInfo
+	SpecVersion  string   `json:"specVersion,omitempty"`
+	Created      string   `json:"created,omitempty"`
+	CreatedBy    []string `json:"createdBy,omitempty"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+
+	// SpdxDocument, software_Sbom
+	DataLicense        string   `json:"dataLicense,omitempty"`
+	ProfileConformance []string `json:"profileConformance,omitempty"`
+	RootElement        []string `json:"rootElement,omitempty"`
+	Element            []string `json:"element,omitempty"`
+	SbomType           []string `json:"software_sbomType,omitempty"`
+
+	// software_Package, software_File
+	SuppliedBy         string               `json:"suppliedBy,omitempty"`
+	PackageVersion     string               `json:"software_packageVersion,omitempty"`
+	PackageURL         string               `json:"software_packageUrl,omitempty"`
+	DownloadLocation   string               `json:"software_downloadLocation,omitempty"`
+	SourceInfo         string               `json:"software_sourceInfo,omitempty"`
+	PrimaryPurpose     string               `json:"software_primaryPurpose,omitempty"`
+	ExternalIdentifier []ExternalIdentifier `json:"externalIdentifier,omitempty"`
+	VerifiedUsing      []Hash               `json:"verifiedUsing,omitempty"`
+
+	// build_Build
+	BuildType        string            `json:"build_buildType,omitempty"`
+	BuildID          string            `json:"build_buildId,omitempty"`
+	BuildEnvironment []DictionaryEntry `json:"build_environment,omitempty"`
+
+	// Relationship
+	From             string   `json:"from,omitempty"`
+	To               []string `json:"to,omitempty"`
+	RelationshipType string   `json:"relationshipType,omitempty"`
+	Scope            string   `json:"scope,omitempty"`
+
+	// simplelicensing_LicenseExpression
+	LicenseExpression string `json:"simplelicensing_licenseExpression,omitempty"`
+}
+
+// ExternalIdentifier identifies an element outside of the document, such as a package url
+type ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+}
+
+// Hash is the integrity method of an element
+type Hash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+// DictionaryEntry is a key value pair
+type DictionaryEntry struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// Get returns the element with the given spdxId
+func (d *Document) Get(spdxID string) *Element {
+	for _, e := range d.Graph {
+		if e.SpdxID == spdxID {
+			return e
+		}
+	}
+	return nil
+}
+
+// IsRelationship returns true if the element is a relationship of any kind
+func (e *Element) IsRelationship() bool {
+	return e.Type == TypeRelationship || e.Type == TypeScopedRelationship
+}
+
+// ElementsOfType returns all elements of the given type
+func (d *Document) ElementsOfType(typ string) []*Element {
+	elements := make([]*Element, 0)
+	for _, e := range d.Graph {
+		if e.Type == typ {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
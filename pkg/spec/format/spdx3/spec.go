@@ -0,0 +1,130 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"errors"
+	"fmt"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+)
+
+var ErrDocumentEmpty = errors.New("document is empty")
+var ErrContextInvalid = errors.New("@context is not spdx 3")
+var ErrSpdxDocumentMissing = errors.New("SpdxDocument element is missing")
+
+// Spec is the specification of SPDX 3.0
+// see https://spdx.github.io/spdx-spec/v3.0.1/
+type Spec struct {
+	doc      *spdx3Model.Document
+	formats  []format.Format
+	updaters []format.Updater
+}
+
+func NewSpecification() format.Specification {
+	s := &Spec{}
+	s.formats = []format.Format{
+		&JSONLDFormat{spec: s},
+	}
+	s.updaters = newSpecUpdaters(s)
+	return s
+}
+
+func (s *Spec) Name() string {
+	return "spdx3"
+}
+
+func (s *Spec) Version() string {
+	return spdx3Model.SpecVersion
+}
+
+func (s *Spec) Formats() []format.Format {
+	return s.formats
+}
+
+func (s *Spec) Validate() error {
+	if s.doc == nil {
+		return ErrDocumentEmpty
+	}
+	if s.doc.Context != spdx3Model.Context {
+		return ErrContextInvalid
+	}
+	if len(s.doc.ElementsOfType(spdx3Model.TypeSpdxDocument)) == 0 {
+		return ErrSpdxDocumentMissing
+	}
+
+	ids := make(map[string]struct{})
+	for _, e := range s.doc.Graph {
+		if e.Type == "" {
+			return fmt.Errorf("element type is empty (spdxId: %s)", e.SpdxID)
+		}
+		if e.Type == spdx3Model.TypeCreationInfo {
+			if e.SpecVersion == "" || e.Created == "" {
+				return fmt.Errorf("creation info is incomplete (@id: %s)", e.ID)
+			}
+			ids[e.ID] = struct{}{}
+			continue
+		}
+		if e.SpdxID == "" {
+			return fmt.Errorf("spdxId is empty (type: %s, name: %s)", e.Type, e.Name)
+		}
+		if _, ok := ids[e.SpdxID]; ok {
+			return fmt.Errorf("duplicate spdxId: %s", e.SpdxID)
+		}
+		ids[e.SpdxID] = struct{}{}
+	}
+
+	for _, e := range s.doc.Graph {
+		if e.Type == spdx3Model.TypeCreationInfo {
+			continue
+		}
+		if _, ok := ids[e.CreationInfo]; !ok {
+			return fmt.Errorf("creation info not found: %s (spdxId: %s)", e.CreationInfo, e.SpdxID)
+		}
+		refs := append([]string{}, e.RootElement...)
+		if e.IsRelationship() {
+			refs = append(refs, e.From)
+			refs = append(refs, e.To...)
+		}
+		for _, ref := range refs {
+			if _, ok := ids[ref]; !ok {
+				return fmt.Errorf("element not found: %s (referenced by %s)", ref, e.SpdxID)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Spec) Metadata() model.Metadata {
+	meta := make(map[string]string)
+	if s.doc == nil {
+		return meta
+	}
+	for _, e := range s.doc.Graph {
+		switch e.Type {
+		case spdx3Model.TypeSpdxDocument:
+			meta["DocumentName"] = e.Name
+			meta["DataLicense"] = e.DataLicense
+		case spdx3Model.TypeCreationInfo:
+			meta["SpecVersion"] = e.SpecVersion
+			meta["CreatedAt"] = e.Created
+		case spdx3Model.TypeTool, spdx3Model.TypePerson, spdx3Model.TypeOrganization:
+			meta[e.Type] = e.Name
+		}
+	}
+	return meta
+}
+
+func (s *Spec) Updaters() []format.Updater {
+	return s.updaters
+}
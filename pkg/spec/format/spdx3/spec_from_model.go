@@ -0,0 +1,266 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"strings"
+	"time"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+// graphBuilder collects the elements of the document, all elements share one creation info
+type graphBuilder struct {
+	prefix   string
+	elements []*spdx3Model.Element
+	ids      map[string]struct{}
+}
+
+func (b *graphBuilder) add(e *spdx3Model.Element) string {
+	if _, ok := b.ids[e.SpdxID]; ok {
+		return e.SpdxID
+	}
+	e.CreationInfo = creationInfoID
+	b.ids[e.SpdxID] = struct{}{}
+	b.elements = append(b.elements, e)
+	return e.SpdxID
+}
+
+func (b *graphBuilder) relate(from, relType string, to ...string) {
+	b.relateScoped(from, relType, "", to...)
+}
+
+// relateScoped adds a LifecycleScopedRelationship, or a plain Relationship when the scope is empty
+func (b *graphBuilder) relateScoped(from, relType, scope string, to ...string) {
+	if len(to) == 0 {
+		return
+	}
+	e := &spdx3Model.Element{
+		Type:             spdx3Model.TypeRelationship,
+		SpdxID:           b.prefix + "SPDXRef-Relationship-" + SPDXID(from+relType+scope+strings.Join(to, ",")),
+		From:             from,
+		RelationshipType: relType,
+		To:               to,
+	}
+	if scope != "" {
+		e.Type = spdx3Model.TypeScopedRelationship
+		e.Scope = scope
+	}
+	b.add(e)
+}
+
+// relateDependencies adds the dependsOn relationships of a package, grouped by the scopes of the dependencies
+func (b *graphBuilder) relateDependencies(from string, to []string, scopes map[string]model.Scope) {
+	for _, scope := range append([]model.Scope{""}, model.AllScopes()...) {
+		ids := util.SliceFilter(to, func(id string) bool {
+			return scopes[id] == scope
+		})
+		b.relateScoped(from, spdx3Model.RelationshipDependsOn, lifecycleScopes[scope], ids...)
+	}
+}
+
+func (b *graphBuilder) agent(typ, name string) string {
+	return b.add(&spdx3Model.Element{
+		Type:   typ,
+		SpdxID: b.prefix + "SPDXRef-" + typ + "-" + SPDXID(name),
+		Name:   name,
+	})
+}
+
+func (b *graphBuilder) license(expression string) string {
+	return b.add(&spdx3Model.Element{
+		Type:              spdx3Model.TypeLicenseExpression,
+		SpdxID:            b.prefix + "SPDXRef-License-" + SPDXID(expression),
+		LicenseExpression: expression,
+	})
+}
+
+func (s *Spec) FromModel(sbomDoc *model.SBOM) {
+	b := &graphBuilder{prefix: idPrefix(sbomDoc.NamespaceURI), ids: make(map[string]struct{})}
+
+	creationInfo := &spdx3Model.Element{
+		Type:        spdx3Model.TypeCreationInfo,
+		ID:          creationInfoID,
+		SpecVersion: spdx3Model.SpecVersion,
+		Created:     time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, c := range sbomDoc.CreationInfo.Creators {
+		if c.Creator == "" || !util.SliceContains(creatorTypes, c.CreatorType) {
+			continue
+		}
+		id := b.agent(c.CreatorType, c.Creator)
+		if c.CreatorType == spdx3Model.TypeTool {
+			creationInfo.CreatedUsing = append(creationInfo.CreatedUsing, id)
+		} else {
+			creationInfo.CreatedBy = append(creationInfo.CreatedBy, id)
+		}
+	}
+	// createdBy is mandatory, fall back to the tool itself
+	if len(creationInfo.CreatedBy) == 0 {
+		creationInfo.CreatedBy = append(creationInfo.CreatedBy, b.agent(spdx3Model.TypeOrganization, "sbom-tool"))
+	}
+
+	mainPkgID := b.add(toSpdx3Package(b, &sbomDoc.Artifact.Package, "application"))
+	scopes := make(map[string]model.Scope, len(sbomDoc.Packages))
+	for i := range sbomDoc.Packages {
+		scopes[b.add(toSpdx3Package(b, &sbomDoc.Packages[i], "library"))] = sbomDoc.Packages[i].Scope
+	}
+	for i := range sbomDoc.Packages {
+		pkg := &sbomDoc.Packages[i]
+		b.relateDependencies(PackageSPDXID(b.prefix, pkg), dependencyIDs(b.prefix, sbomDoc.Packages, pkg.Dependencies), scopes)
+		b.relate(PackageSPDXID(b.prefix, pkg), spdx3Model.RelationshipContains, dependencyIDs(b.prefix, sbomDoc.Packages, pkg.Contains)...)
+	}
+	contained := model.ContainedPackages(sbomDoc.Relationships, sbomDoc.Artifact.PURL)
+	b.relateDependencies(mainPkgID, rootDependencyIDs(b.prefix, sbomDoc.Packages, contained), scopes)
+	b.relate(mainPkgID, spdx3Model.RelationshipContains, util.SliceMap(util.SliceFilter(sbomDoc.Packages, func(p model.Package) bool {
+		_, ok := contained[p.PURL]
+		return ok
+	}), func(p model.Package) string {
+		return PackageSPDXID(b.prefix, &p)
+	})...)
+
+	fileIDs := make([]string, 0, len(sbomDoc.Artifact.Files))
+	for i := range sbomDoc.Artifact.Files {
+		fileIDs = append(fileIDs, b.add(toSpdx3File(b.prefix, &sbomDoc.Artifact.Files[i])))
+	}
+	b.relate(mainPkgID, spdx3Model.RelationshipContains, fileIDs...)
+
+	if build := toSpdx3Build(b.prefix, &sbomDoc.Artifact); build != nil {
+		b.relate(b.add(build), spdx3Model.RelationshipHasOutput, mainPkgID)
+	}
+
+	elementIDs := util.SliceMap(b.elements, func(e *spdx3Model.Element) string {
+		return e.SpdxID
+	})
+	sbom := &spdx3Model.Element{
+		Type:         spdx3Model.TypeSbom,
+		SpdxID:       b.prefix + "SPDXRef-Sbom",
+		CreationInfo: creationInfoID,
+		RootElement:  []string{mainPkgID},
+		Element:      elementIDs,
+		SbomType:     []string{"build"},
+	}
+	document := &spdx3Model.Element{
+		Type:               spdx3Model.TypeSpdxDocument,
+		SpdxID:             b.prefix + "SPDXRef-DOCUMENT",
+		CreationInfo:       creationInfoID,
+		Name:               sbomDoc.Artifact.Name + "-" + sbomDoc.Artifact.Version,
+		DataLicense:        spdx3Model.DataLicense,
+		ProfileConformance: []string{"core", "software", "build", "simpleLicensing"},
+		RootElement:        []string{sbom.SpdxID},
+		Element:            append([]string{sbom.SpdxID}, elementIDs...),
+	}
+	s.doc = &spdx3Model.Document{
+		Context: spdx3Model.Context,
+		Graph:   append([]*spdx3Model.Element{creationInfo, document, sbom}, b.elements...),
+	}
+}
+
+func toSpdx3Package(b *graphBuilder, pkg *model.Package, purpose string) *spdx3Model.Element {
+	e := &spdx3Model.Element{
+		Type:           spdx3Model.TypePackage,
+		SpdxID:         PackageSPDXID(b.prefix, pkg),
+		Name:           pkg.Name,
+		PackageVersion: pkg.Version,
+		PackageURL:     pkg.PURL,
+		SourceInfo:     pkg.SourceLocation,
+		PrimaryPurpose: purpose,
+	}
+	if pkg.PURL != "" {
+		e.ExternalIdentifier = []spdx3Model.ExternalIdentifier{
+			{Type: "ExternalIdentifier", ExternalIdentifierType: "packageUrl", Identifier: pkg.PURL},
+		}
+	}
+	if pkg.Supplier != "" {
+		e.SuppliedBy = b.agent(spdx3Model.TypeOrganization, pkg.Supplier)
+	}
+	if expression := license.CombineExpression(pkg.LicenseDeclared); expression != "" {
+		b.relate(e.SpdxID, spdx3Model.RelationshipHasDeclaredLicense, b.license(expression))
+	}
+	if expression := license.CombineExpression(pkg.LicenseConcluded); expression != "" {
+		b.relate(e.SpdxID, spdx3Model.RelationshipHasConcludedLicense, b.license(expression))
+	}
+	return e
+}
+
+func toSpdx3File(prefix string, file *model.File) *spdx3Model.Element {
+	e := &spdx3Model.Element{
+		Type:           spdx3Model.TypeFile,
+		SpdxID:         FileSPDXID(prefix, file),
+		Name:           file.Name,
+		PrimaryPurpose: fileTypePurposes[file.Type],
+	}
+	for _, sum := range file.Checksums {
+		if alg, ok := hashAlgorithms[sum.Algorithm]; ok {
+			e.VerifiedUsing = append(e.VerifiedUsing, spdx3Model.Hash{Type: "Hash", Algorithm: alg, HashValue: sum.Value})
+		}
+	}
+	return e
+}
+
+// toSpdx3Build describes the build environment of the artifact, returns nil when nothing is known about the build
+func toSpdx3Build(prefix string, artifact *model.Artifact) *spdx3Model.Element {
+	env := make([]spdx3Model.DictionaryEntry, 0)
+	for _, kv := range [][2]string{
+		{"os", artifact.Build.OS},
+		{"arch", artifact.Build.Arch},
+		{"kernel", artifact.Build.Kernel},
+		{"builder", artifact.Build.Builder},
+		{"compiler", artifact.Build.Compiler},
+	} {
+		if kv[1] != "" {
+			env = append(env, spdx3Model.DictionaryEntry{Type: "DictionaryEntry", Key: kv[0], Value: kv[1]})
+		}
+	}
+	if len(env) == 0 && artifact.ID == "" {
+		return nil
+	}
+	return &spdx3Model.Element{
+		Type:             spdx3Model.TypeBuild,
+		SpdxID:           prefix + "SPDXRef-Build",
+		BuildType:        BuildType,
+		BuildID:          artifact.ID,
+		BuildEnvironment: env,
+	}
+}
+
+func dependencyIDs(prefix string, pkgs []model.Package, deps []string) []string {
+	ids := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if i := util.SliceFirst(pkgs, func(p model.Package) bool { return p.PURL == dep }); i > -1 {
+			ids = append(ids, PackageSPDXID(prefix, &pkgs[i]))
+		}
+	}
+	return ids
+}
+
+// rootDependencyIDs returns the packages which no other package depends on, the artifact depends on them directly
+// unless it contains them
+func rootDependencyIDs(prefix string, pkgs []model.Package, contained map[string]struct{}) []string {
+	allDeps := make(map[string]struct{})
+	for i := range pkgs {
+		for _, dep := range pkgs[i].Dependencies {
+			allDeps[dep] = struct{}{}
+		}
+	}
+	ids := make([]string, 0)
+	for i := range pkgs {
+		_, isDep := allDeps[pkgs[i].PURL]
+		_, isContained := contained[pkgs[i].PURL]
+		if !isDep && !isContained {
+			ids = append(ids, PackageSPDXID(prefix, &pkgs[i]))
+		}
+	}
+	return ids
+}
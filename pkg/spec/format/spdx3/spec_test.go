@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+)
+
+func newSbomDoc() *model.SBOM {
+	return &model.SBOM{
+		NamespaceURI: "https://example.com/sbom/demo",
+		CreationInfo: model.CreationInfo{
+			Creators: []model.Creator{
+				{Creator: "sbom-tool", CreatorType: "Tool"},
+				{Creator: "JD", CreatorType: "Organization"},
+			},
+		},
+		Packages: []model.Package{
+			{
+				Name: "fastjson", Version: "1.2.78", Type: "maven", PURL: "pkg:maven/com.alibaba/fastjson@1.2.78",
+				Supplier: "alibaba", LicenseDeclared: []string{"Apache-2.0"}, Scope: model.ScopeTest,
+			},
+			{
+				Name: "logback-classic", Version: "1.2.11", Type: "maven", PURL: "pkg:maven/ch.qos.logback/logback-classic@1.2.11",
+				LicenseDeclared: []string{"EPL-1.0 OR LGPL-2.1-only"},
+				Dependencies:    []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+				Scope:           model.ScopeRuntime,
+			},
+			{
+				Name: "slf4j-api", Version: "1.7.36", Type: "maven", PURL: "pkg:maven/org.slf4j/slf4j-api@1.7.36",
+				LicenseDeclared: []string{"MIT"}, LicenseConcluded: []string{"MIT"}, Scope: model.ScopeRuntime,
+			},
+		},
+		Artifact: model.Artifact{
+			ID:      "demo-1.0.0",
+			Package: model.Package{Name: "demo", Version: "1.0.0", Type: "maven", PURL: "pkg:maven/com.example/demo@1.0.0"},
+			Build:   model.Build{OS: "linux", Arch: "amd64", Compiler: "javac 17"},
+			Files: []model.File{
+				{Name: "demo.jar", Type: model.FileTypeArchive,
+					Checksums: []model.FileChecksum{{Algorithm: model.ChecksumSHA256, Value: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}}},
+			},
+		},
+	}
+}
+
+func TestSpdx3Spec_FromSBOM(t *testing.T) {
+	spec := &Spec{}
+	spec.FromModel(newSbomDoc())
+
+	doc := spec.doc
+	assert.NoError(t, spec.Validate())
+	assert.Equal(t, spdx3Model.Context, doc.Context)
+	assert.Equal(t, 4, len(doc.ElementsOfType(spdx3Model.TypePackage)))
+	assert.Equal(t, 1, len(doc.ElementsOfType(spdx3Model.TypeFile)))
+	assert.Equal(t, 3, len(doc.ElementsOfType(spdx3Model.TypeLicenseExpression)))
+	documents := doc.ElementsOfType(spdx3Model.TypeSpdxDocument)
+	if assert.Len(t, documents, 1) {
+		assert.Equal(t, "https://spdx.org/licenses/CC0-1.0", documents[0].DataLicense)
+	}
+
+	builds := doc.ElementsOfType(spdx3Model.TypeBuild)
+	assert.Equal(t, 1, len(builds))
+	assert.Equal(t, "demo-1.0.0", builds[0].BuildID)
+	assert.Equal(t, 3, len(builds[0].BuildEnvironment))
+
+	relTypes := make(map[string]int)
+	for _, rel := range doc.ElementsOfType(spdx3Model.TypeRelationship) {
+		relTypes[rel.RelationshipType]++
+	}
+	assert.Equal(t, map[string]int{
+		spdx3Model.RelationshipContains:            1,
+		spdx3Model.RelationshipHasOutput:           1,
+		spdx3Model.RelationshipHasDeclaredLicense:  3,
+		spdx3Model.RelationshipHasConcludedLicense: 1,
+	}, relTypes)
+
+	scopes := make(map[string]int)
+	for _, rel := range doc.ElementsOfType(spdx3Model.TypeScopedRelationship) {
+		assert.Equal(t, spdx3Model.RelationshipDependsOn, rel.RelationshipType)
+		scopes[rel.Scope] += len(rel.To)
+	}
+	assert.Equal(t, map[string]int{
+		spdx3Model.LifecycleScopeRuntime: 2,
+		spdx3Model.LifecycleScopeTest:    1,
+	}, scopes)
+
+	root := doc.Get("https://example.com/sbom/demo#SPDXRef-Package-" + SPDXID("demo@1.0.0"))
+	assert.NotNil(t, root)
+	assert.Equal(t, "application", root.PrimaryPurpose)
+}
+
+func TestSpdx3Spec_RoundTrip(t *testing.T) {
+	spec := &Spec{}
+	expected := newSbomDoc()
+	spec.FromModel(expected)
+
+	actual := spec.ToModel()
+	assert.NotEmpty(t, actual.CreationInfo.Created)
+	actual.CreationInfo.Created = ""
+	assert.Equal(t, expected, actual)
+}
+
+func TestSpdx3Spec_Validate(t *testing.T) {
+	spec := &Spec{}
+	assert.ErrorIs(t, spec.Validate(), ErrDocumentEmpty)
+
+	spec.FromModel(newSbomDoc())
+	assert.NoError(t, spec.Validate())
+
+	spec.doc.Context = "https://example.com/context.jsonld"
+	assert.ErrorIs(t, spec.Validate(), ErrContextInvalid)
+
+	spec.FromModel(newSbomDoc())
+	rels := spec.doc.ElementsOfType(spdx3Model.TypeRelationship)
+	rels[0].To = []string{"https://example.com/sbom/demo#SPDXRef-Unknown"}
+	assert.Error(t, spec.Validate())
+}
+
+func TestSpdx3Spec_AddCreator(t *testing.T) {
+	spec := NewSpecification().(*Spec)
+	spec.FromModel(newSbomDoc())
+	updater := spec.Updaters()[0]
+	assert.Equal(t, "add-creator", updater.Name())
+
+	err := updater.Update(`{"creator":"Tim","creatorType":"Person"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, spec.Validate())
+	assert.Contains(t, spec.ToModel().CreationInfo.Creators, model.Creator{Creator: "Tim", CreatorType: "Person"})
+	assert.Error(t, updater.Update(`{"creator":"x","creatorType":"Unknown"}`))
+}
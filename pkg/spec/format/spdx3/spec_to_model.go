@@ -0,0 +1,200 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
+)
+
+func (s *Spec) ToModel() *model.SBOM {
+	if s.doc == nil {
+		return nil
+	}
+	doc := s.doc
+	sbomDoc := &model.SBOM{}
+
+	elements := make(map[string]*spdx3Model.Element)
+	// relationships indexed by from and relationship type
+	rels := make(map[string][]string)
+	// scopes of packages, taken from the lifecycle scoped dependsOn relationships to them
+	scopes := make(map[string]model.Scope)
+	for _, e := range doc.Graph {
+		if e.SpdxID != "" {
+			elements[e.SpdxID] = e
+		}
+		if e.IsRelationship() {
+			rels[e.From+"|"+e.RelationshipType] = append(rels[e.From+"|"+e.RelationshipType], e.To...)
+		}
+		if e.Type == spdx3Model.TypeScopedRelationship && e.RelationshipType == spdx3Model.RelationshipDependsOn {
+			for _, to := range e.To {
+				scopes[to] = model.MergeScope(scopes[to], fromLifecycleScope(e.Scope))
+			}
+		}
+	}
+	related := func(from, relType string) []*spdx3Model.Element {
+		return util.SliceFilter(util.SliceMap(rels[from+"|"+relType], func(id string) *spdx3Model.Element {
+			return elements[id]
+		}), func(e *spdx3Model.Element) bool { return e != nil })
+	}
+
+	for _, e := range doc.ElementsOfType(spdx3Model.TypeSpdxDocument) {
+		sbomDoc.NamespaceURI = strings.TrimSuffix(strings.TrimSuffix(e.SpdxID, "SPDXRef-DOCUMENT"), "#")
+		if strings.HasPrefix(sbomDoc.NamespaceURI, "urn:spdx:") {
+			sbomDoc.NamespaceURI = ""
+		}
+	}
+	for _, e := range doc.ElementsOfType(spdx3Model.TypeCreationInfo) {
+		sbomDoc.CreationInfo = toCreationInfo(e, elements)
+	}
+
+	rootID := ""
+	for _, e := range doc.ElementsOfType(spdx3Model.TypeSbom) {
+		if len(e.RootElement) > 0 {
+			rootID = e.RootElement[0]
+		}
+	}
+
+	purls := make(map[string]string)
+	for _, e := range doc.ElementsOfType(spdx3Model.TypePackage) {
+		purls[e.SpdxID] = packageURL(e)
+	}
+	toPackage := func(e *spdx3Model.Element) model.Package {
+		pkg := model.Package{
+			Name:           e.Name,
+			Version:        e.PackageVersion,
+			PURL:           purls[e.SpdxID],
+			SourceLocation: e.SourceInfo,
+		}
+		if purl, err := packageurl.FromString(pkg.PURL); err == nil {
+			pkg.Type = purl.Type
+		}
+		if supplier, ok := elements[e.SuppliedBy]; ok {
+			pkg.Supplier = supplier.Name
+		}
+		for _, l := range related(e.SpdxID, spdx3Model.RelationshipHasDeclaredLicense) {
+			pkg.LicenseDeclared = append(pkg.LicenseDeclared, license.SplitExpression(l.LicenseExpression)...)
+		}
+		for _, l := range related(e.SpdxID, spdx3Model.RelationshipHasConcludedLicense) {
+			pkg.LicenseConcluded = append(pkg.LicenseConcluded, license.SplitExpression(l.LicenseExpression)...)
+		}
+		return pkg
+	}
+
+	for _, e := range doc.ElementsOfType(spdx3Model.TypePackage) {
+		if e.SpdxID == rootID {
+			continue
+		}
+		pkg := toPackage(e)
+		pkg.Scope = scopes[e.SpdxID]
+		for _, dep := range related(e.SpdxID, spdx3Model.RelationshipDependsOn) {
+			if purl := purls[dep.SpdxID]; purl != "" {
+				pkg.Dependencies = append(pkg.Dependencies, purl)
+			}
+		}
+		for _, nested := range related(e.SpdxID, spdx3Model.RelationshipContains) {
+			if purl := purls[nested.SpdxID]; purl != "" && nested.Type == spdx3Model.TypePackage {
+				pkg.Contains = append(pkg.Contains, purl)
+			}
+		}
+		sbomDoc.Packages = append(sbomDoc.Packages, pkg)
+	}
+
+	if root, ok := elements[rootID]; ok {
+		sbomDoc.Artifact.Package = toPackage(root)
+		for _, e := range related(rootID, spdx3Model.RelationshipContains) {
+			switch {
+			case e.Type == spdx3Model.TypeFile:
+				sbomDoc.Artifact.Files = append(sbomDoc.Artifact.Files, toFile(e))
+			case e.Type == spdx3Model.TypePackage && purls[e.SpdxID] != "" && sbomDoc.Artifact.PURL != "":
+				sbomDoc.Relationships = append(sbomDoc.Relationships, model.Relationship{
+					Type:   model.Contains,
+					FromID: sbomDoc.Artifact.PURL,
+					ToID:   purls[e.SpdxID],
+				})
+			}
+		}
+	}
+	for _, e := range doc.ElementsOfType(spdx3Model.TypeBuild) {
+		if !util.SliceContains(rels[e.SpdxID+"|"+spdx3Model.RelationshipHasOutput], rootID) {
+			continue
+		}
+		sbomDoc.Artifact.ID = e.BuildID
+		sbomDoc.Artifact.Build = toBuild(e)
+	}
+	return sbomDoc
+}
+
+func toCreationInfo(e *spdx3Model.Element, elements map[string]*spdx3Model.Element) model.CreationInfo {
+	info := model.CreationInfo{Created: e.Created}
+	for _, id := range append(append([]string{}, e.CreatedUsing...), e.CreatedBy...) {
+		if agent, ok := elements[id]; ok {
+			info.Creators = append(info.Creators, model.Creator{Creator: agent.Name, CreatorType: agent.Type})
+		}
+	}
+	return info
+}
+
+// packageURL returns the package url of the package, the external identifier is used as fallback
+func packageURL(e *spdx3Model.Element) string {
+	if e.PackageURL != "" {
+		return e.PackageURL
+	}
+	for _, ref := range e.ExternalIdentifier {
+		if ref.ExternalIdentifierType == "packageUrl" {
+			return ref.Identifier
+		}
+	}
+	return ""
+}
+
+func toFile(e *spdx3Model.Element) model.File {
+	file := model.File{Name: e.Name}
+	for typ, purpose := range fileTypePurposes {
+		// several file types share the data purpose, text is the closest one
+		if purpose == e.PrimaryPurpose && (purpose != "data" || typ == model.FileTypeText) {
+			file.Type = typ
+		}
+	}
+	for _, h := range e.VerifiedUsing {
+		for alg, name := range hashAlgorithms {
+			if name == h.Algorithm {
+				file.Checksums = append(file.Checksums, model.FileChecksum{Algorithm: alg, Value: h.HashValue})
+			}
+		}
+	}
+	return file
+}
+
+func toBuild(e *spdx3Model.Element) model.Build {
+	build := model.Build{}
+	for _, entry := range e.BuildEnvironment {
+		switch entry.Key {
+		case "os":
+			build.OS = entry.Value
+		case "arch":
+			build.Arch = entry.Value
+		case "kernel":
+			build.Kernel = entry.Value
+		case "builder":
+			build.Builder = entry.Value
+		case "compiler":
+			build.Compiler = entry.Value
+		}
+	}
+	return build
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/log"
+)
+
+var ErrDocumentInvalid = errors.New("document invalid")
+
+func newSpecUpdaters(s *Spec) []format.Updater {
+	return []format.Updater{
+		newAddCreatorUpdater(s),
+	}
+}
+
+func newAddCreatorUpdater(s *Spec) format.Updater {
+	exp, err := json.Marshal(model.Creator{Creator: "Tim (tim@demo.com)", CreatorType: "Person"})
+	if err != nil {
+		log.Warnf("example error: %w", err)
+	}
+	desc := "add creator of document, example: '" + string(exp) + "'"
+	return format.NewUpdater("add-creator", desc, func(value string) error {
+		if s == nil || s.doc == nil {
+			return ErrDocumentInvalid
+		}
+		creationInfos := s.doc.ElementsOfType(spdx3Model.TypeCreationInfo)
+		if len(creationInfos) == 0 {
+			return ErrDocumentInvalid
+		}
+		creator := model.Creator{}
+		err := json.Unmarshal([]byte(value), &creator)
+		if err != nil {
+			return fmt.Errorf("parse input error: %w", err)
+		}
+		if !util.SliceContains(creatorTypes, creator.CreatorType) {
+			return fmt.Errorf("unsupported creator type: %s", creator.CreatorType)
+		}
+
+		creationInfo := creationInfos[0]
+		prefix := ""
+		for _, e := range s.doc.ElementsOfType(spdx3Model.TypeSpdxDocument) {
+			prefix = strings.TrimSuffix(e.SpdxID, "SPDXRef-DOCUMENT")
+		}
+		agent := &spdx3Model.Element{
+			Type:         creator.CreatorType,
+			SpdxID:       prefix + "SPDXRef-" + creator.CreatorType + "-" + SPDXID(creator.Creator),
+			CreationInfo: creationInfo.ID,
+			Name:         creator.Creator,
+		}
+		if s.doc.Get(agent.SpdxID) == nil {
+			s.doc.Graph = append(s.doc.Graph, agent)
+		}
+		if creator.CreatorType == spdx3Model.TypeTool {
+			creationInfo.CreatedUsing = append(creationInfo.CreatedUsing, agent.SpdxID)
+		} else {
+			creationInfo.CreatedBy = append(creationInfo.CreatedBy, agent.SpdxID)
+		}
+		return nil
+	})
+}
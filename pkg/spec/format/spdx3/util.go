@@ -0,0 +1,92 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spdx3
+
+import (
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdx3Model "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3/model"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// BuildType is the build type IRI of builds described by sbom-tool
+const BuildType = "https://gitee.com/JD-opensource/sbom-tool/build"
+
+// creationInfoID is the blank node id of the shared creation info
+const creationInfoID = "_:creationinfo"
+
+// SPDXID returns the spdx id of the content
+func SPDXID(content string) string {
+	ret, _ := util.SHA1SumStr(content)
+	return ret
+}
+
+// idPrefix returns the prefix of all element ids, elements live in the namespace of the document
+func idPrefix(namespace string) string {
+	if namespace == "" {
+		return "urn:spdx:"
+	}
+	return strings.TrimSuffix(namespace, "#") + "#"
+}
+
+// PackageSPDXID returns the spdx id of the package
+func PackageSPDXID(prefix string, pkg *model.Package) string {
+	return prefix + "SPDXRef-Package-" + SPDXID(pkg.Name+"@"+pkg.Version)
+}
+
+// FileSPDXID returns the spdx id of the file
+func FileSPDXID(prefix string, file *model.File) string {
+	return prefix + "SPDXRef-File-" + SPDXID(file.Name)
+}
+
+var hashAlgorithms = map[model.ChecksumAlgorithm]string{
+	model.ChecksumMD5:    "md5",
+	model.ChecksumSHA1:   "sha1",
+	model.ChecksumSHA256: "sha256",
+}
+
+var fileTypePurposes = map[model.FileType]string{
+	model.FileTypeSource:        "source",
+	model.FileTypeBinary:        "executable",
+	model.FileTypeArchive:       "archive",
+	model.FileTypeApplication:   "application",
+	model.FileTypeDocumentation: "documentation",
+	model.FileTypeImage:         "data",
+	model.FileTypeAudio:         "data",
+	model.FileTypeVideo:         "data",
+	model.FileTypeText:          "data",
+	model.FileTypeSPDX:          "bom",
+	model.FileTypeOther:         "other",
+}
+
+// lifecycleScopes maps the scopes of packages to the lifecycle scopes of the relationships to them
+var lifecycleScopes = map[model.Scope]string{
+	model.ScopeRuntime:  spdx3Model.LifecycleScopeRuntime,
+	model.ScopeDev:      spdx3Model.LifecycleScopeDevelopment,
+	model.ScopeTest:     spdx3Model.LifecycleScopeTest,
+	model.ScopeProvided: spdx3Model.LifecycleScopeBuild,
+	model.ScopeOptional: spdx3Model.LifecycleScopeOther,
+}
+
+func fromLifecycleScope(scope string) model.Scope {
+	if scope == spdx3Model.LifecycleScopeDesign {
+		return model.ScopeDev
+	}
+	for k, v := range lifecycleScopes {
+		if v == scope {
+			return k
+		}
+	}
+	return ""
+}
+
+var creatorTypes = []string{"Tool", "Person", "Organization"}
@@ -20,6 +20,7 @@ type Artifact struct {
 	PURL             string `json:"purl"`
 	LicenseConcluded string `json:"licenseConcluded,omitempty"`
 	LicenseDeclared  string `json:"licenseDeclared,omitempty"`
+	Image            *Image `json:"image,omitempty"`
 }
 
 type Build struct {
@@ -29,3 +30,25 @@ type Build struct {
 	Builder  string `json:"builder"`
 	Compiler string `json:"compiler"`
 }
+
+type Image struct {
+	Reference  string            `json:"reference"`
+	Digest     string            `json:"digest"`
+	Config     string            `json:"config"`
+	OS         string            `json:"os"`
+	Arch       string            `json:"arch"`
+	Variant    string            `json:"variant,omitempty"`
+	Created    string            `json:"created,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	BaseName   string            `json:"baseName,omitempty"`
+	BaseDigest string            `json:"baseDigest,omitempty"`
+	Layers     []Layer           `json:"layers"`
+}
+
+type Layer struct {
+	Digest    string `json:"digest"`
+	DiffID    string `json:"diffID"`
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	CreatedBy string `json:"createdBy,omitempty"`
+}
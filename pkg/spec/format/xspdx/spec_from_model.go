@@ -16,6 +16,7 @@ import (
 	"github.com/spdx/tools-golang/spdx"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdxSpec "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx"
 	xspdxModel "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/xspdx/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
@@ -41,15 +42,24 @@ func (s *Spec) FromModel(sbomDoc *model.SBOM) {
 				Creator:     c.Creator,
 			}
 		}),
-		Created: time.Now().Format(time.RFC3339),
+		Created:        time.Now().Format(time.RFC3339),
+		CreatorComment: sbomDoc.CreationInfo.CreatorComment,
 	}
 
 	spdxDoc.Packages = util.SliceMap(sbomDoc.Packages, fromPackage)
 	spdxDoc.Packages = append(spdxDoc.Packages, fromPackage(sbomDoc.Artifact.Package))
+	spdxSpec.AddSecurityReferences(spdxDoc.Packages, sbomDoc.Vulnerabilities)
 
+	spdxDoc.OtherLicenses = spdxSpec.OtherLicenses(append([]model.Package{sbomDoc.Artifact.Package}, sbomDoc.Packages...))
 	spdxDoc.Files = util.SliceMap(sbomDoc.Artifact.Files, fromFile)
 
-	spdxDoc.Relationships = toRelationships(sbomDoc.Packages, &sbomDoc.Artifact.Package)
+	spdxDoc.Relationships = append([]*spdx.Relationship{
+		{
+			RefA:         spdx.DocElementID{ElementRefID: "DOCUMENT"},
+			RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(&sbomDoc.Artifact.Package)},
+			Relationship: spdx.RelationshipDescribes,
+		},
+	}, toRelationships(sbomDoc.Packages, &sbomDoc.Artifact.Package, sbomDoc.Relationships)...)
 	s.doc = &xspdxModel.XSPDXDocument{
 		Document: spdxDoc,
 		Source:   fromSource(sbomDoc.Source),
@@ -57,8 +67,9 @@ func (s *Spec) FromModel(sbomDoc *model.SBOM) {
 	}
 }
 
-func toRelationships(pkgs []model.Package, mainPkg *model.Package) []*spdx.Relationship {
+func toRelationships(pkgs []model.Package, mainPkg *model.Package, modelRels []model.Relationship) []*spdx.Relationship {
 	mainPkgID := PackageSPDXID(mainPkg)
+	contained := model.ContainedPackages(modelRels, mainPkg.PURL)
 	pkgMap := make(map[string]*model.Package)
 	allDeps := make(map[string]struct{})
 	for i := 0; i < len(pkgs); i++ {
@@ -71,26 +82,32 @@ func toRelationships(pkgs []model.Package, mainPkg *model.Package) []*spdx.Relat
 	rels := make([]*spdx.Relationship, 0)
 	for i := range pkgs {
 		pkgID := PackageSPDXID(&pkgs[i])
-		if _, isDep := allDeps[pkgs[i].PURL]; !isDep {
-			rel := &spdx.Relationship{
+		if _, isContained := contained[pkgs[i].PURL]; isContained {
+			rels = append(rels, &spdx.Relationship{
 				RefA:         spdx.DocElementID{ElementRefID: mainPkgID},
 				RefB:         spdx.DocElementID{ElementRefID: pkgID},
-				Relationship: spdx.RelationshipDependsOn,
-			}
-			rels = append(rels, rel)
+				Relationship: spdx.RelationshipContains,
+			})
+		} else if _, isDep := allDeps[pkgs[i].PURL]; !isDep {
+			rels = append(rels, spdxSpec.DependencyRelationship(mainPkgID, pkgID, pkgs[i].Scope))
 		}
 
 		if len(pkgs[i].Dependencies) > 0 {
 			for _, dep := range pkgs[i].Dependencies {
 				if pkg, ok := pkgMap[dep]; ok {
-					rels = append(rels, &spdx.Relationship{
-						RefA:         spdx.DocElementID{ElementRefID: pkgID},
-						RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(pkg)},
-						Relationship: spdx.RelationshipDependsOn,
-					})
+					rels = append(rels, spdxSpec.DependencyRelationship(pkgID, PackageSPDXID(pkg), pkg.Scope))
 				}
 			}
 		}
+		for _, nested := range pkgs[i].Contains {
+			if pkg, ok := pkgMap[nested]; ok {
+				rels = append(rels, &spdx.Relationship{
+					RefA:         spdx.DocElementID{ElementRefID: pkgID},
+					RefB:         spdx.DocElementID{ElementRefID: PackageSPDXID(pkg)},
+					Relationship: spdx.RelationshipContains,
+				})
+			}
+		}
 	}
 	return rels
 }
@@ -103,6 +120,16 @@ func fromPackage(pkg model.Package) *spdx.Package {
 		PackageDownloadLocation: "NONE",
 		PackageLicenseDeclared:  license.NOASSERTION_LICENSE,
 		PackageLicenseConcluded: license.NOASSERTION_LICENSE,
+		PackageSourceInfo:       pkg.SourceLocation,
+		FilesAnalyzed:           pkg.FilesAnalyzed,
+		// the tag is written, otherwise the spec defaults FilesAnalyzed to true
+		IsFilesAnalyzedTagPresent: true,
+	}
+	if pkg.FilesAnalyzed && len(pkg.VerificationCode) > 0 {
+		spdxPkg.PackageVerificationCode = &spdx.PackageVerificationCode{Value: pkg.VerificationCode}
+	}
+	if len(pkg.Copyright) > 0 {
+		spdxPkg.PackageCopyrightText = pkg.Copyright
 	}
 	if len(pkg.Supplier) > 0 {
 		spdxPkg.PackageSupplier = &spdx.Supplier{
@@ -120,11 +147,11 @@ func fromPackage(pkg model.Package) *spdx.Package {
 		}
 	}
 	if len(pkg.LicenseConcluded) > 0 {
-		spdxPkg.PackageLicenseConcluded = license.CreateLicenseExpression(pkg.LicenseConcluded)
+		spdxPkg.PackageLicenseConcluded = spdxSpec.LicenseExpression(pkg.LicenseConcluded)
 	}
 
 	if len(pkg.LicenseDeclared) > 0 {
-		spdxPkg.PackageLicenseDeclared = license.CreateLicenseExpression(pkg.LicenseDeclared)
+		spdxPkg.PackageLicenseDeclared = spdxSpec.LicenseExpression(pkg.LicenseDeclared)
 	}
 	return spdxPkg
 }
@@ -134,6 +161,7 @@ func fromFile(file model.File) *spdx.File {
 		FileSPDXIdentifier: FileSPDXID(&file),
 		FileName:           file.Name,
 		FileTypes:          []string{string(file.Type)},
+		FileComment:        spdxSpec.FileComment(&file),
 		Checksums: util.SliceMap(file.Checksums, func(sum model.FileChecksum) spdx.Checksum {
 			return spdx.Checksum{
 				Algorithm: spdx.ChecksumAlgorithm(sum.Algorithm),
@@ -148,10 +176,22 @@ func fromSource(src model.Source) *xspdxModel.Source {
 		Repository: src.Repository,
 		Branch:     src.Branch,
 		Revision:   src.Revision,
-		TotalSize:  src.TotalSize,
-		TotalLine:  src.TotalLine,
-		TotalFile:  src.TotalFile,
-		Language:   src.Language,
+		Tag:        src.Tag,
+		Detached:   src.Detached,
+		CommitTime: src.CommitTime,
+		Committer:  src.Committer,
+		Dirty:      src.Dirty,
+		Modified:   src.Modified,
+		Remotes: util.SliceMap(src.Remotes, func(r model.Remote) xspdxModel.Remote {
+			return xspdxModel.Remote{Name: r.Name, URL: r.URL}
+		}),
+		Submodules: util.SliceMap(src.Submodules, func(m model.Submodule) xspdxModel.Submodule {
+			return xspdxModel.Submodule{Path: m.Path, Repository: m.Repository, Revision: m.Revision}
+		}),
+		TotalSize: src.TotalSize,
+		TotalLine: src.TotalLine,
+		TotalFile: src.TotalFile,
+		Language:  src.Language,
 		Fingerprint: xspdxModel.Fingerprint{
 			TotalCount:  src.Fingerprint.TotalCount,
 			Created:     src.Fingerprint.Created,
@@ -182,12 +222,33 @@ func fromArtifact(artifact model.Artifact) *xspdxModel.Artifact {
 	}
 
 	if len(artifact.LicenseDeclared) > 0 {
-		xspdxArtifact.LicenseDeclared = license.CreateLicenseExpression(artifact.LicenseDeclared)
+		xspdxArtifact.LicenseDeclared = spdxSpec.LicenseExpression(artifact.LicenseDeclared)
+	}
+	if artifact.Image != nil {
+		xspdxArtifact.Image = fromArtifactImage(artifact.Image)
 	}
 
 	return xspdxArtifact
 }
 
+func fromArtifactImage(img *model.Image) *xspdxModel.Image {
+	return &xspdxModel.Image{
+		Reference:  img.Reference,
+		Digest:     img.Digest,
+		Config:     img.Config,
+		OS:         img.OS,
+		Arch:       img.Arch,
+		Variant:    img.Variant,
+		Created:    img.Created,
+		Labels:     img.Labels,
+		BaseName:   img.BaseName,
+		BaseDigest: img.BaseDigest,
+		Layers: util.SliceMap(img.Layers, func(l model.Layer) xspdxModel.Layer {
+			return xspdxModel.Layer{Digest: l.Digest, DiffID: l.DiffID, MediaType: l.MediaType, Size: l.Size, CreatedBy: l.CreatedBy}
+		}),
+	}
+}
+
 func fromArtifactBuild(build *model.Build) *xspdxModel.Build {
 	return &xspdxModel.Build{
 		OS:       build.OS,
@@ -49,6 +49,11 @@ func newXSPDXDoc() *xspdxModel.XSPDXDocument {
 		Repository: "https://gitub.com/test/demo.git",
 		Branch:     "release-v1.0.0",
 		Revision:   "f88cc32b",
+		Tag:        "v1.0.0-2-gf88cc32",
+		Dirty:      true,
+		Modified:   []string{"pom.xml"},
+		Remotes:    []xspdxModel.Remote{{Name: "origin", URL: "https://gitub.com/test/demo.git"}},
+		Submodules: []xspdxModel.Submodule{{Path: "lib/dep", Repository: "https://gitub.com/test/dep.git", Revision: "0a1b2c3d"}},
 		TotalLine:  11,
 		TotalFile:  111,
 		Fingerprint: xspdxModel.Fingerprint{
@@ -85,6 +90,11 @@ func newSbomDoc() *model.SBOM {
 			Repository: "https://gitub.com/test/demo.git",
 			Branch:     "release-v1.0.0",
 			Revision:   "f88cc32b",
+			Tag:        "v1.0.0",
+			CommitTime: "2023-06-01T08:00:00Z",
+			Committer:  "sha256:0123456789abcdef",
+			Remotes:    []model.Remote{{Name: "origin", URL: "https://gitub.com/test/demo.git"}},
+			Submodules: []model.Submodule{{Path: "lib/dep", Repository: "https://gitub.com/test/dep.git", Revision: "0a1b2c3d"}},
 			TotalLine:  11,
 			TotalFile:  111,
 			Fingerprint: model.Fingerprint{
@@ -119,6 +129,12 @@ func TestXSPDXSpec_ToSBOM(t *testing.T) {
 	assert.Equal(t, xspdxDoc.Source.Repository, sbomDoc.Source.Repository)
 	assert.Equal(t, xspdxDoc.Source.Branch, sbomDoc.Source.Branch)
 	assert.Equal(t, xspdxDoc.Source.Revision, sbomDoc.Source.Revision)
+	assert.Equal(t, xspdxDoc.Source.Tag, sbomDoc.Source.Tag)
+	assert.True(t, sbomDoc.Source.Dirty)
+	assert.Equal(t, []string{"pom.xml"}, sbomDoc.Source.Modified)
+	assert.Equal(t, []model.Remote{{Name: "origin", URL: "https://gitub.com/test/demo.git"}}, sbomDoc.Source.Remotes)
+	assert.Equal(t, []model.Submodule{{Path: "lib/dep", Repository: "https://gitub.com/test/dep.git", Revision: "0a1b2c3d"}},
+		sbomDoc.Source.Submodules)
 	assert.Equal(t, xspdxDoc.Artifact.Name, sbomDoc.Artifact.Name)
 	assert.Equal(t, xspdxDoc.Artifact.Version, sbomDoc.Artifact.Version)
 	assert.Equal(t, xspdxDoc.Artifact.Build.OS, sbomDoc.Artifact.Build.OS)
@@ -140,6 +156,12 @@ func TestXSPDXSpec_FromSBOM(t *testing.T) {
 	assert.Equal(t, sbomDoc.Source.Repository, xspdxDoc.Source.Repository)
 	assert.Equal(t, sbomDoc.Source.Branch, xspdxDoc.Source.Branch)
 	assert.Equal(t, sbomDoc.Source.Revision, xspdxDoc.Source.Revision)
+	assert.Equal(t, sbomDoc.Source.Tag, xspdxDoc.Source.Tag)
+	assert.Equal(t, sbomDoc.Source.CommitTime, xspdxDoc.Source.CommitTime)
+	assert.Equal(t, sbomDoc.Source.Committer, xspdxDoc.Source.Committer)
+	assert.Equal(t, []xspdxModel.Remote{{Name: "origin", URL: "https://gitub.com/test/demo.git"}}, xspdxDoc.Source.Remotes)
+	assert.Equal(t, []xspdxModel.Submodule{{Path: "lib/dep", Repository: "https://gitub.com/test/dep.git", Revision: "0a1b2c3d"}},
+		xspdxDoc.Source.Submodules)
 	assert.Equal(t, sbomDoc.Artifact.Name, xspdxDoc.Artifact.Name)
 	assert.Equal(t, sbomDoc.Artifact.Version, xspdxDoc.Artifact.Version)
 	assert.Equal(t, sbomDoc.Artifact.Build.OS, xspdxDoc.Artifact.Build.OS)
@@ -152,6 +174,23 @@ func TestXSPDXSpec_FromSBOM(t *testing.T) {
 	assert.Equal(t, 1, len(xspdxDoc.Files))
 }
 
+func TestXSPDXSpec_Image(t *testing.T) {
+	xspdxSpec := &Spec{}
+	sbomDoc := newSbomDoc()
+	sbomDoc.Artifact.Image = &model.Image{
+		Reference: "docker-archive:demo.tar",
+		Config:    "sha256:eca06e1bd2abc8be43253e26b7b6af4ce3466eaaee5b85eb942926f78a70d860",
+		OS:        "linux",
+		Arch:      "amd64",
+		Labels:    map[string]string{"org.opencontainers.image.vendor": "JD"},
+		Layers: []model.Layer{{Digest: "sha256:6e33", DiffID: "sha256:6e33", Size: 10240,
+			CreatedBy: "/bin/sh -c #(nop) ADD file:debian in / "}},
+	}
+	xspdxSpec.FromModel(sbomDoc)
+	assert.Equal(t, sbomDoc.Artifact.Image.Config, xspdxSpec.doc.Artifact.Image.Config)
+	assert.Equal(t, sbomDoc.Artifact.Image, xspdxSpec.ToModel().Artifact.Image)
+}
+
 func TestToRelationships(t *testing.T) {
 	type args struct {
 		pkgs   []model.Package
@@ -192,12 +231,42 @@ func TestToRelationships(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "scopes",
+			args: args{
+				pkgs: []model.Package{
+					{Name: "pkg1", Version: "1.0", Type: model.PkgTypeGeneric, PURL: "pkg:generic://pkg1@1.0", Scope: model.ScopeRuntime},
+					{Name: "pkg2", Version: "1.0", Type: model.PkgTypeGeneric, PURL: "pkg:generic://pkg2@1.0", Scope: model.ScopeTest, Dependencies: []string{
+						"pkg:generic://pkg21@1.0",
+					}},
+					{Name: "pkg21", Version: "1.0", Type: model.PkgTypeGeneric, PURL: "pkg:generic://pkg21@1.0", Scope: model.ScopeTest},
+				},
+				refPkg: model.Package{Name: "Root", Version: "1.0"},
+			},
+			want: []*spdx.Relationship{
+				{
+					RefA:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("pkg1@1.0"))},
+					RefB:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("Root@1.0"))},
+					Relationship: spdx.RelationshipRuntimeDependencyOf,
+				},
+				{
+					RefA:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("pkg2@1.0"))},
+					RefB:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("Root@1.0"))},
+					Relationship: spdx.RelationshipTestDependencyOf,
+				},
+				{
+					RefA:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("pkg21@1.0"))},
+					RefB:         spdx.DocElementID{ElementRefID: spdx.ElementID("Package-" + SPDXID("pkg2@1.0"))},
+					Relationship: spdx.RelationshipTestDependencyOf,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := toRelationships(tt.args.pkgs, &tt.args.refPkg)
-			assert.Equalf(t, len(tt.want), len(got), "toFlatPackages(%v)", tt.args)
+			got := toRelationships(tt.args.pkgs, &tt.args.refPkg, nil)
+			assert.Equalf(t, tt.want, got, "toRelationships(%v)", tt.args)
 		})
 	}
 }
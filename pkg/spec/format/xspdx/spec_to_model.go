@@ -11,88 +11,237 @@
 package xspdx
 
 import (
+	"github.com/anchore/packageurl-go"
 	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+	spdxSpec "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx"
 	xspdxModel "gitee.com/JD-opensource/sbom-tool/pkg/spec/format/xspdx/model"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+	"gitee.com/JD-opensource/sbom-tool/pkg/util/license"
 )
 
 func (s *Spec) ToModel() *model.SBOM {
+	if s.doc == nil || s.doc.Document == nil {
+		return nil
+	}
 	spdxDoc := s.doc
 	sbomDoc := &model.SBOM{
 		NamespaceURI: spdxDoc.DocumentNamespace,
 	}
 
-	sbomDoc.CreationInfo = model.CreationInfo{
-		Created: spdxDoc.CreationInfo.Created,
-		Creators: util.SliceMap(spdxDoc.CreationInfo.Creators, func(c common.Creator) model.Creator {
-			return model.Creator{Creator: c.Creator, CreatorType: c.CreatorType}
-		}),
+	if spdxDoc.CreationInfo != nil {
+		sbomDoc.CreationInfo = model.CreationInfo{
+			Created:        spdxDoc.CreationInfo.Created,
+			CreatorComment: spdxDoc.CreationInfo.CreatorComment,
+			Creators: util.SliceMap(spdxDoc.CreationInfo.Creators, func(c common.Creator) model.Creator {
+				return model.Creator{Creator: c.Creator, CreatorType: c.CreatorType}
+			}),
+		}
 	}
 	if spdxDoc.Source != nil {
 		sbomDoc.Source = toSource(spdxDoc.Source)
 	}
-	if len(spdxDoc.Packages) > 0 {
-		sbomDoc.Packages = util.SliceMap(spdxDoc.Packages, toPackage)
+
+	rootID := spdxSpec.RootPackageID(spdxDoc.Document)
+	purls := make(map[spdx.ElementID]string)
+	for _, pkg := range spdxDoc.Packages {
+		purls[pkg.PackageSPDXIdentifier] = spdxSpec.PackageURL(pkg)
 	}
-	if spdxDoc.Artifact != nil {
-		sbomDoc.Artifact = model.Artifact{
-			Package: model.Package{Name: spdxDoc.Artifact.Name, Version: spdxDoc.Artifact.Version},
-			Files:   util.SliceMap(spdxDoc.Files, toFile),
-			Build:   toArtifactBuild(spdxDoc.Artifact.Build),
+	deps := spdxSpec.DependencyMap(spdxDoc.Relationships)
+	scopes := spdxSpec.ScopeMap(spdxDoc.Relationships)
+	sbomDoc.Relationships = spdxSpec.ContainsRelationships(spdxDoc.Relationships, rootID, purls)
+	nested := spdxSpec.NestedMap(spdxDoc.Relationships, rootID)
+	for _, pkg := range spdxDoc.Packages {
+		sbomPkg := toPackage(pkg)
+		sbomPkg.LicenseDeclared = spdxSpec.RestoreLicenses(sbomPkg.LicenseDeclared, spdxDoc.OtherLicenses)
+		sbomPkg.LicenseConcluded = spdxSpec.RestoreLicenses(sbomPkg.LicenseConcluded, spdxDoc.OtherLicenses)
+		sbomPkg.Scope = scopes[pkg.PackageSPDXIdentifier]
+		for _, id := range deps[pkg.PackageSPDXIdentifier] {
+			if purl := purls[id]; purl != "" && !util.SliceContains(sbomPkg.Dependencies, purl) {
+				sbomPkg.Dependencies = append(sbomPkg.Dependencies, purl)
+			}
+		}
+		for _, id := range nested[pkg.PackageSPDXIdentifier] {
+			if purl := purls[id]; purl != "" && !util.SliceContains(sbomPkg.Contains, purl) {
+				sbomPkg.Contains = append(sbomPkg.Contains, purl)
+			}
 		}
+		if rootID != "" && pkg.PackageSPDXIdentifier == rootID {
+			// dependencies of the root are the top level packages, they are derived when writing
+			sbomPkg.Dependencies = nil
+			sbomDoc.Artifact.Package = sbomPkg
+			continue
+		}
+		sbomDoc.Packages = append(sbomDoc.Packages, sbomPkg)
 	}
 
-	if s.doc == nil {
-		return nil
+	sbomDoc.Artifact.Files = util.SliceMap(spdxDoc.Files, toFile)
+	sbomDoc.Vulnerabilities = spdxSpec.Vulnerabilities(spdxDoc.Packages)
+	if spdxDoc.Artifact != nil {
+		toArtifact(spdxDoc.Artifact, &sbomDoc.Artifact)
+		sbomDoc.Artifact.LicenseDeclared = spdxSpec.RestoreLicenses(sbomDoc.Artifact.LicenseDeclared, spdxDoc.OtherLicenses)
 	}
 	return sbomDoc
 }
 
+// toArtifact fills the artifact with the xspdx artifact property, which takes precedence over the root package
+func toArtifact(artifact *xspdxModel.Artifact, sbomArtifact *model.Artifact) {
+	if artifact.Name != "" {
+		sbomArtifact.Name = artifact.Name
+	}
+	if artifact.Version != "" {
+		sbomArtifact.Version = artifact.Version
+	}
+	if artifact.Type != "" {
+		sbomArtifact.Type = artifact.Type
+	}
+	if artifact.PURL != "" {
+		sbomArtifact.PURL = artifact.PURL
+	}
+	if artifact.Supplier != "" {
+		sbomArtifact.Supplier = artifact.Supplier
+	}
+	if artifact.Checksum != "" {
+		sbomArtifact.VerificationCode = artifact.Checksum
+	}
+	if licenses := spdxSpec.ParseLicenseExpression(artifact.LicenseDeclared); len(licenses) > 0 {
+		sbomArtifact.LicenseDeclared = licenses
+	}
+	if artifact.Build != nil {
+		sbomArtifact.Build = toArtifactBuild(artifact.Build)
+	}
+	if artifact.Image != nil {
+		sbomArtifact.Image = toArtifactImage(artifact.Image)
+	}
+}
+
+func toArtifactImage(img *xspdxModel.Image) *model.Image {
+	return &model.Image{
+		Reference:  img.Reference,
+		Digest:     img.Digest,
+		Config:     img.Config,
+		OS:         img.OS,
+		Arch:       img.Arch,
+		Variant:    img.Variant,
+		Created:    img.Created,
+		Labels:     img.Labels,
+		BaseName:   img.BaseName,
+		BaseDigest: img.BaseDigest,
+		Layers: util.SliceMap(img.Layers, func(l xspdxModel.Layer) model.Layer {
+			return model.Layer{Digest: l.Digest, DiffID: l.DiffID, MediaType: l.MediaType, Size: l.Size, CreatedBy: l.CreatedBy}
+		}),
+	}
+}
+
 func toSource(src *xspdxModel.Source) model.Source {
+	var files []model.FileFingerprint
+	if len(src.Fingerprint.Files) > 0 {
+		files = util.SliceMap(src.Fingerprint.Files, toFileFingerprint)
+	}
+	var remotes []model.Remote
+	if len(src.Remotes) > 0 {
+		remotes = util.SliceMap(src.Remotes, func(r xspdxModel.Remote) model.Remote {
+			return model.Remote{Name: r.Name, URL: r.URL}
+		})
+	}
+	var submodules []model.Submodule
+	if len(src.Submodules) > 0 {
+		submodules = util.SliceMap(src.Submodules, func(m xspdxModel.Submodule) model.Submodule {
+			return model.Submodule{Path: m.Path, Repository: m.Repository, Revision: m.Revision}
+		})
+	}
 	return model.Source{
-		Repository:  src.Repository,
-		Branch:      src.Branch,
-		Revision:    src.Revision,
-		TotalFile:   src.TotalFile,
-		TotalLine:   src.TotalLine,
-		TotalSize:   src.TotalSize,
-		Language:    src.Language,
-		Fingerprint: model.Fingerprint{},
+		Repository: src.Repository,
+		Branch:     src.Branch,
+		Revision:   src.Revision,
+		Tag:        src.Tag,
+		Detached:   src.Detached,
+		CommitTime: src.CommitTime,
+		Committer:  src.Committer,
+		Dirty:      src.Dirty,
+		Modified:   src.Modified,
+		Remotes:    remotes,
+		Submodules: submodules,
+		TotalFile:  src.TotalFile,
+		TotalLine:  src.TotalLine,
+		TotalSize:  src.TotalSize,
+		Language:   src.Language,
+		Fingerprint: model.Fingerprint{
+			TotalCount:  src.Fingerprint.TotalCount,
+			Created:     src.Fingerprint.Created,
+			Checksum:    src.Fingerprint.Checksum,
+			OutputMode:  src.Fingerprint.OutputMode,
+			ExternalRef: src.Fingerprint.ExternalRef,
+			Vendor: model.FingerprintVendor{
+				Name:      src.Fingerprint.Vendor.Name,
+				Tool:      src.Fingerprint.Vendor.Tool,
+				Algorithm: src.Fingerprint.Vendor.Algorithm,
+			},
+			Files: files,
+		},
 	}
 }
 
-func toPackage(pkg *spdx.Package) model.Package {
-	purl := ""
-	if len(pkg.PackageExternalReferences) > 0 {
-		for _, ref := range pkg.PackageExternalReferences {
-			if ref.RefType == "" {
-				purl = ref.Locator
-
-				break
-			}
-		}
+func toFileFingerprint(fileFP xspdxModel.FileFingerprint) model.FileFingerprint {
+	return model.FileFingerprint{
+		File:      fileFP.File,
+		Size:      fileFP.Size,
+		Lines:     fileFP.Lines,
+		Count:     fileFP.Count,
+		Language:  fileFP.Language,
+		Copyright: fileFP.Copyright,
+		License:   fileFP.License,
+		Checksums: util.SliceMap(fileFP.Checksums, func(sum xspdxModel.FileChecksum) model.FileChecksum {
+			return model.FileChecksum{Algorithm: model.ChecksumAlgorithm(sum.Algorithm), Value: sum.Value}
+		}),
+		Fingerprint: model.FingerprintValue{
+			File: fileFP.Fingerprint.File,
+			Snippet: util.SliceMap(fileFP.Fingerprint.Snippet, func(sfp xspdxModel.SnippetFingerprint) model.SnippetFingerprint {
+				return model.SnippetFingerprint{Range: sfp.Range, Value: sfp.Value}
+			}),
+		},
 	}
+}
+
+func toPackage(pkg *spdx.Package) model.Package {
 	sbomPkg := model.Package{
-		Name:    pkg.PackageName,
-		Version: pkg.PackageVersion,
+		Name:             pkg.PackageName,
+		Version:          pkg.PackageVersion,
+		PURL:             spdxSpec.PackageURL(pkg),
+		FilesAnalyzed:    pkg.FilesAnalyzed,
+		SourceLocation:   pkg.PackageSourceInfo,
+		LicenseConcluded: spdxSpec.ParseLicenseExpression(pkg.PackageLicenseConcluded),
+		LicenseDeclared:  spdxSpec.ParseLicenseExpression(pkg.PackageLicenseDeclared),
+	}
+	if purl, err := packageurl.FromString(sbomPkg.PURL); err == nil {
+		sbomPkg.Type = purl.Type
+	}
+	if pkg.PackageCopyrightText != license.NOASSERTION_LICENSE && pkg.PackageCopyrightText != "NONE" {
+		sbomPkg.Copyright = pkg.PackageCopyrightText
 	}
-	if purl != "" {
-		sbomPkg.PURL = purl
+	if pkg.PackageSupplier != nil && pkg.PackageSupplier.Supplier != license.NOASSERTION_LICENSE {
+		sbomPkg.Supplier = pkg.PackageSupplier.Supplier
+	}
+	if pkg.PackageVerificationCode != nil {
+		sbomPkg.VerificationCode = pkg.PackageVerificationCode.Value
 	}
-	// TODO license
 	return sbomPkg
 }
 
 func toFile(file *spdx.File) model.File {
-	return model.File{
+	sbomFile := model.File{
 		Name: file.FileName,
 		Checksums: util.SliceMap(file.Checksums, func(sum spdx.Checksum) model.FileChecksum {
 			return model.FileChecksum{Algorithm: model.ChecksumAlgorithm(sum.Algorithm), Value: sum.Value}
 		}),
+		Compilers: spdxSpec.FileCompilers(file.FileComment),
+	}
+	if len(file.FileTypes) > 0 {
+		sbomFile.Type = model.FileType(file.FileTypes[0])
 	}
+	return sbomFile
 }
 
 func toArtifactBuild(build *xspdxModel.Build) model.Build {
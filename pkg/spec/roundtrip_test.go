@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package spec
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/model"
+)
+
+// project clears the data the target format can not carry
+func project(sbomDoc model.SBOM, formatName string) model.SBOM {
+	sbomDoc.CreationInfo.Created = ""
+	sbomDoc.Artifact.ID = ""
+	if formatName != "xspdx-json" {
+		sbomDoc.Source = model.Source{}
+		sbomDoc.Artifact.Build = model.Build{}
+		// the artifact type is only kept by xspdx, spdx derives it from the purl
+		sbomDoc.Artifact.Type = ""
+	}
+	return sbomDoc
+}
+
+func TestRoundTrip(t *testing.T) {
+	files := []string{
+		"format/spdx/test_material/example-v2.3.spdx",
+		"format/spdx/test_material/example-v2.3.spdx.json",
+		"format/xspdx/test_material/sbom.json",
+	}
+	targets := []string{"spdx-json", "spdx-tagvalue", "xspdx-json"}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err, "read file %s", file)
+		source, err := DetectFormat(bytes.NewReader(data))
+		if !assert.NoError(t, err, "detect format %s", file) {
+			continue
+		}
+		expected := source.Spec().ToModel()
+
+		for _, target := range targets {
+			dumper := GetFormat(target)
+			dumper.Spec().FromModel(expected)
+			buf := &bytes.Buffer{}
+			assert.NoError(t, dumper.Dump(buf), "%s -> %s", file, target)
+
+			loader := GetFormat(target)
+			assert.NoError(t, loader.Load(buf), "%s -> %s", file, target)
+			actual := loader.Spec().ToModel()
+
+			want, got := project(*expected, target), project(*actual, target)
+			assert.ElementsMatch(t, want.Packages, got.Packages, "%s -> %s", file, target)
+			assert.ElementsMatch(t, want.Artifact.Files, got.Artifact.Files, "%s -> %s", file, target)
+			got.Packages, got.Artifact.Files = want.Packages, want.Artifact.Files
+			assert.Equal(t, want, got, "%s -> %s", file, target)
+		}
+	}
+}
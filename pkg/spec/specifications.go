@@ -18,7 +18,9 @@ import (
 	"strings"
 
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format/cyclonedx"
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx"
+	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format/spdx3"
 	"gitee.com/JD-opensource/sbom-tool/pkg/spec/format/xspdx"
 	"gitee.com/JD-opensource/sbom-tool/pkg/util"
 )
@@ -29,6 +31,8 @@ func AllSpecifications() []format.Specification {
 	return []format.Specification{
 		xspdx.NewSpecification(),
 		spdx.NewSpecification(),
+		spdx3.NewSpecification(),
+		cyclonedx.NewSpecification(),
 	}
 }
 
@@ -40,6 +40,21 @@ func TestDetectFormat(t *testing.T) {
 			file:   "format/spdx/test_material/example-v2.3.spdx.json",
 			format: "spdx-json",
 		},
+		{
+			name:   "spdx3-jsonld",
+			file:   "format/spdx3/test_material/example-v3.0.1.spdx3.jsonld",
+			format: "spdx3-jsonld",
+		},
+		{
+			name:   "cyclonedx-json",
+			file:   "format/cyclonedx/test_material/example-v1.5.cdx.json",
+			format: "cyclonedx-json",
+		},
+		{
+			name:   "cyclonedx-xml",
+			file:   "format/cyclonedx/test_material/example-v1.5.cdx.xml",
+			format: "cyclonedx-xml",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,413 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package license
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// license expression operators, see https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/
+const (
+	OperatorAnd  = "AND"
+	OperatorOr   = "OR"
+	OperatorWith = "WITH"
+)
+
+// prefixes of license ids not in the SPDX license list
+const (
+	LicenseRefPrefix  = "LicenseRef-"
+	DocumentRefPrefix = "DocumentRef-"
+)
+
+var (
+	// licenseIDIndex maps the lowercase license ids to the SPDX license ids
+	licenseIDIndex = lowercaseIndex(licenseIDs)
+	// exceptionIDIndex maps the lowercase exception ids to the SPDX exception ids
+	exceptionIDIndex = lowercaseIndex(exceptionIDs)
+)
+
+func lowercaseIndex(ids []string) map[string]string {
+	index := make(map[string]string, len(ids))
+	for _, id := range ids {
+		index[strings.ToLower(id)] = id
+	}
+	return index
+}
+
+// Expression is a parsed SPDX license expression,
+// a license(with an optional exception) if Operator is empty, otherwise an AND/OR of the operands
+type Expression struct {
+	Operator  string
+	License   string
+	Exception string
+	Operands  []*Expression
+}
+
+// ParseExpression parses a SPDX license expression.
+// Operators are case-insensitive, AND binds tighter than OR and WITH binds tighter than AND
+func ParseExpression(s string) (*Expression, error) {
+	p := &expressionParser{tokens: tokenizeExpression(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parse license expression %q error: %w", s, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("parse license expression %q error: unexpected %q", s, p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// tokenizeExpression splits the expression by whitespaces and parentheses
+func tokenizeExpression(s string) []string {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+	return strings.Fields(s)
+}
+
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *expressionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *expressionParser) parseOr() (*Expression, error) {
+	return p.parseCompound(OperatorOr, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (*Expression, error) {
+	return p.parseCompound(OperatorAnd, p.parseWith)
+}
+
+// parseCompound parses the operands joined by the operator
+func (p *expressionParser) parseCompound(operator string, operand func() (*Expression, error)) (*Expression, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(p.peek(), operator) {
+		return first, nil
+	}
+	expr := &Expression{Operator: operator, Operands: []*Expression{first}}
+	for strings.EqualFold(p.peek(), operator) {
+		p.next()
+		e, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		expr.Operands = append(expr.Operands, e)
+	}
+	return expr, nil
+}
+
+func (p *expressionParser) parseWith() (*Expression, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(p.peek(), OperatorWith) {
+		return expr, nil
+	}
+	p.next()
+	if expr.Operator != "" || expr.Exception != "" {
+		return nil, errors.New("WITH must follow a license id")
+	}
+	exception := p.next()
+	if !isIDToken(exception) {
+		return nil, fmt.Errorf("expect exception id after WITH, got %q", exception)
+	}
+	expr.Exception = exception
+	return expr, nil
+}
+
+func (p *expressionParser) parsePrimary() (*Expression, error) {
+	token := p.next()
+	if token == "(" {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("unbalanced parentheses")
+		}
+		return expr, nil
+	}
+	if !isIDToken(token) {
+		return nil, fmt.Errorf("expect license id, got %q", token)
+	}
+	return &Expression{License: token}, nil
+}
+
+// isIDToken returns true if the token is not empty, a parenthesis or an operator
+func isIDToken(token string) bool {
+	if token == "" || token == "(" || token == ")" {
+		return false
+	}
+	return !isOperator(token)
+}
+
+func isOperator(token string) bool {
+	return strings.EqualFold(token, OperatorAnd) || strings.EqualFold(token, OperatorOr) || strings.EqualFold(token, OperatorWith)
+}
+
+// String returns the expression with uppercase operators, compound operands are parenthesized
+func (e *Expression) String() string {
+	if e.Operator == "" {
+		if e.Exception != "" {
+			return e.License + " " + OperatorWith + " " + e.Exception
+		}
+		return e.License
+	}
+	parts := make([]string, 0, len(e.Operands))
+	for _, o := range e.Operands {
+		if o.Operator != "" {
+			parts = append(parts, "("+o.String()+")")
+		} else {
+			parts = append(parts, o.String())
+		}
+	}
+	return strings.Join(parts, " "+e.Operator+" ")
+}
+
+// Licenses returns the unique license ids of the expression
+func (e *Expression) Licenses() []string {
+	licenses := make([]string, 0)
+	seen := make(map[string]struct{})
+	e.walk(func(l *Expression) {
+		if _, ok := seen[l.License]; !ok {
+			seen[l.License] = struct{}{}
+			licenses = append(licenses, l.License)
+		}
+	})
+	return licenses
+}
+
+// walk calls fn for each license of the expression
+func (e *Expression) walk(fn func(l *Expression)) {
+	if e.Operator == "" {
+		fn(e)
+		return
+	}
+	for _, o := range e.Operands {
+		o.walk(fn)
+	}
+}
+
+// Normalize returns a copy of the expression with the ids in the case of the SPDX lists
+// and the deprecated license ids replaced, unknown ids are kept
+func (e *Expression) Normalize() *Expression {
+	if e.Operator != "" {
+		n := &Expression{Operator: strings.ToUpper(e.Operator), Operands: make([]*Expression, 0, len(e.Operands))}
+		for _, o := range e.Operands {
+			n.Operands = append(n.Operands, o.Normalize())
+		}
+		return n
+	}
+	n := normalizeLicenseID(e.License)
+	if e.Exception != "" {
+		if n.Operator != "" {
+			// never happens for the deprecated ids, a license with an exception replaced by a compound expression
+			return &Expression{License: e.License, Exception: normalizeExceptionID(e.Exception)}
+		}
+		n.Exception = normalizeExceptionID(e.Exception)
+	}
+	return n
+}
+
+// normalizeLicenseID returns the license id in the case of the SPDX license list, deprecated ids are replaced.
+// "<id>+" is kept as the or-later operator unless it is a deprecated id
+func normalizeLicenseID(id string) *Expression {
+	lower := strings.ToLower(id)
+	switch {
+	case strings.HasPrefix(lower, strings.ToLower(LicenseRefPrefix)):
+		return &Expression{License: LicenseRefPrefix + id[len(LicenseRefPrefix):]}
+	case strings.HasPrefix(lower, strings.ToLower(DocumentRefPrefix)):
+		return &Expression{License: DocumentRefPrefix + id[len(DocumentRefPrefix):]}
+	}
+	canonical, ok := licenseIDIndex[lower]
+	if !ok {
+		if v, exists := licenseLowercaseKeys[lower]; exists && IsSPDXLicense(v) {
+			canonical, ok = licenseIDIndex[strings.ToLower(v)]
+		}
+	}
+	if !ok {
+		if base := strings.TrimSuffix(id, "+"); base != id {
+			if n := normalizeLicenseID(base); n.Operator == "" && n.Exception == "" && IsSPDXLicense(n.License) {
+				n.License += "+"
+				return n
+			}
+		}
+		return &Expression{License: id}
+	}
+	if replacement, deprecated := deprecatedLicenseIDs[canonical]; deprecated {
+		if expr, err := ParseExpression(replacement); err == nil {
+			return expr
+		}
+	}
+	return &Expression{License: canonical}
+}
+
+// normalizeExceptionID returns the exception id in the case of the SPDX exception list
+func normalizeExceptionID(id string) string {
+	if canonical, ok := exceptionIDIndex[strings.ToLower(id)]; ok {
+		return canonical
+	}
+	return id
+}
+
+// Simplify returns a copy of the expression with nested operands of the same operator flattened,
+// duplicated operands removed and absorbed operands removed, e.g. "MIT AND (MIT OR Apache-2.0)" is "MIT"
+func (e *Expression) Simplify() *Expression {
+	if e.Operator == "" {
+		c := *e
+		return &c
+	}
+	operands := make([]*Expression, 0, len(e.Operands))
+	for _, o := range e.Operands {
+		o = o.Simplify()
+		if o.Operator == e.Operator {
+			operands = append(operands, o.Operands...)
+		} else {
+			operands = append(operands, o)
+		}
+	}
+
+	keys := make([]string, 0, len(operands))
+	unique := make([]*Expression, 0, len(operands))
+	for _, o := range operands {
+		key := o.key()
+		if !containsString(keys, key) {
+			keys = append(keys, key)
+			unique = append(unique, o)
+		}
+	}
+
+	// A AND (A OR B) is A, A OR (A AND B) is A
+	simplified := make([]*Expression, 0, len(unique))
+	for _, o := range unique {
+		absorbed := false
+		if o.Operator != "" {
+			for _, inner := range o.Operands {
+				if containsString(keys, inner.key()) {
+					absorbed = true
+					break
+				}
+			}
+		}
+		if !absorbed {
+			simplified = append(simplified, o)
+		}
+	}
+	if len(simplified) == 1 {
+		return simplified[0]
+	}
+	return &Expression{Operator: e.Operator, Operands: simplified}
+}
+
+// key returns a key of the expression ignoring the case of the ids
+func (e *Expression) key() string {
+	return strings.ToLower(e.String())
+}
+
+// Equivalent returns true if the simplified expressions only differ in the case of the ids and the order of operands
+func (e *Expression) Equivalent(other *Expression) bool {
+	return e.Simplify().unorderedKey() == other.Simplify().unorderedKey()
+}
+
+// unorderedKey returns a key of the expression ignoring the case of the ids and the order of operands
+func (e *Expression) unorderedKey() string {
+	if e.Operator == "" {
+		return e.key()
+	}
+	keys := make([]string, 0, len(e.Operands))
+	for _, o := range e.Operands {
+		keys = append(keys, "("+o.unorderedKey()+")")
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " "+strings.ToUpper(e.Operator)+" ")
+}
+
+func containsString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if any license id is not in the SPDX license list and is not a LicenseRef,
+// or any exception is not in the SPDX exception list
+func (e *Expression) Validate() error {
+	invalid := make([]string, 0)
+	e.walk(func(l *Expression) {
+		if !isValidLicenseID(l.License) {
+			invalid = append(invalid, "license "+l.License)
+		}
+		if l.Exception != "" {
+			if _, ok := exceptionIDIndex[strings.ToLower(l.Exception)]; !ok {
+				invalid = append(invalid, "exception "+l.Exception)
+			}
+		}
+	})
+	if len(invalid) > 0 {
+		return fmt.Errorf("unknown %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// isValidLicenseID returns true for a SPDX license id(with an optional "+"), a LicenseRef or a DocumentRef
+func isValidLicenseID(id string) bool {
+	if strings.HasPrefix(id, DocumentRefPrefix) {
+		i := strings.Index(id, ":")
+		return i > len(DocumentRefPrefix) && isIDString(id[len(DocumentRefPrefix):i]) &&
+			strings.HasPrefix(id[i+1:], LicenseRefPrefix) && isIDString(id[i+1+len(LicenseRefPrefix):])
+	}
+	if strings.HasPrefix(id, LicenseRefPrefix) {
+		return isIDString(id[len(LicenseRefPrefix):])
+	}
+	return IsSPDXLicense(id) || IsSPDXLicense(strings.TrimSuffix(id, "+"))
+}
+
+// IsSPDXLicense returns true if the id is in the SPDX license list, case-insensitive
+func IsSPDXLicense(id string) bool {
+	_, ok := licenseIDIndex[strings.ToLower(id)]
+	return ok
+}
+
+// isIDString returns true if the id only contains letters, numbers, "." and "-"
+func isIDString(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
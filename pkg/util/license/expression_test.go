@@ -0,0 +1,183 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    bool
+	}{
+		{expression: "MIT", want: "MIT"},
+		{expression: "MIT OR Apache-2.0", want: "MIT OR Apache-2.0"},
+		{expression: "(MIT OR Apache-2.0)", want: "MIT OR Apache-2.0"},
+		{expression: "mit or (apache-2.0 and bsd-3-clause)", want: "mit OR (apache-2.0 AND bsd-3-clause)"},
+		{expression: "MIT OR Apache-2.0 AND ISC", want: "MIT OR (Apache-2.0 AND ISC)"},
+		{expression: "GPL-2.0-only WITH Classpath-exception-2.0", want: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{expression: "GPL-2.0+ AND LicenseRef-foo", want: "GPL-2.0+ AND LicenseRef-foo"},
+		{expression: "", wantErr: true},
+		{expression: "MIT OR", wantErr: true},
+		{expression: "MIT Apache-2.0", wantErr: true},
+		{expression: "(MIT", wantErr: true},
+		{expression: "MIT)", wantErr: true},
+		{expression: "(MIT OR ISC) WITH Classpath-exception-2.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := ParseExpression(tt.expression)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestExpression_NormalizeSimplify(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{expression: "mit", want: "MIT"},
+		{expression: "GPL-2.0", want: "GPL-2.0-only"},
+		{expression: "GPL-2.0+", want: "GPL-2.0-or-later"},
+		{expression: "Apache-2.0+", want: "Apache-2.0+"},
+		{expression: "GPL-2.0-with-classpath-exception", want: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{expression: "gpl-2.0-only with classpath-exception-2.0", want: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{expression: "licenseref-foo", want: "LicenseRef-foo"},
+		{expression: "MIT AND (Apache-2.0 AND MIT)", want: "MIT AND Apache-2.0"},
+		{expression: "MIT AND (MIT OR Apache-2.0)", want: "MIT"},
+		{expression: "MIT OR (MIT AND Apache-2.0)", want: "MIT"},
+		{expression: "(MIT OR ISC) AND (ISC OR MIT) AND BSD-3-Clause", want: "(MIT OR ISC) AND (ISC OR MIT) AND BSD-3-Clause"},
+		{expression: "Foo OR mit", want: "Foo OR MIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Normalize().Simplify().String())
+		})
+	}
+}
+
+func TestExpression_Equivalent(t *testing.T) {
+	tests := []struct {
+		e1, e2 string
+		want   bool
+	}{
+		{e1: "MIT", e2: "mit", want: true},
+		{e1: "MIT AND Apache-2.0", e2: "apache-2.0 and MIT", want: true},
+		{e1: "(MIT OR ISC) AND BSD-3-Clause", e2: "BSD-3-Clause AND (ISC OR MIT)", want: true},
+		{e1: "MIT AND (Apache-2.0 AND ISC)", e2: "ISC AND MIT AND Apache-2.0", want: true},
+		{e1: "GPL-2.0-only WITH Classpath-exception-2.0", e2: "GPL-2.0-only", want: false},
+		{e1: "MIT AND Apache-2.0", e2: "MIT OR Apache-2.0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.e1+" "+tt.e2, func(t *testing.T) {
+			e1, err := ParseExpression(tt.e1)
+			assert.NoError(t, err)
+			e2, err := ParseExpression(tt.e2)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, e1.Equivalent(e2))
+		})
+	}
+}
+
+func TestExpression_Validate(t *testing.T) {
+	for _, valid := range []string{
+		"MIT", "GPL-2.0+", "GPL-2.0-or-later WITH Bison-exception-2.2", "LicenseRef-foo.bar-1",
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", "MIT OR (Apache-2.0 AND BSD-3-Clause)",
+	} {
+		expr, err := ParseExpression(valid)
+		assert.NoError(t, err)
+		assert.NoError(t, expr.Validate(), valid)
+	}
+	for _, invalid := range []string{
+		"Foo", "MIT OR Foo", "GPL-2.0-only WITH Foo-exception", "LicenseRef-foo_bar", "DocumentRef-x:Foo",
+	} {
+		expr, err := ParseExpression(invalid)
+		assert.NoError(t, err)
+		assert.Error(t, expr.Validate(), invalid)
+	}
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		license string
+		want    string
+	}{
+		{license: "", want: ""},
+		{license: "noassertion", want: "NOASSERTION"},
+		{license: "(MIT OR Apache-2.0)", want: "MIT OR Apache-2.0"},
+		{license: " AND MIT AND ", want: "MIT"},
+		{license: "mit or apache-2.0", want: "MIT OR Apache-2.0"},
+		{license: "Apache License, Version 2.0", want: "Apache-2.0"},
+		{license: "The MIT License", want: "MIT"},
+		{license: "LGPL-2.1", want: "LGPL-2.1-only"},
+		{license: "CDDL+GPL_1_1", want: "CDDL-1.1 OR GPL-1.0-or-later"},
+		{license: "My Custom License", want: "My Custom License"},
+		{license: "Custom OR MIT", want: "Custom OR MIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeExpression(tt.license))
+		})
+	}
+}
+
+func TestSplitExpression(t *testing.T) {
+	assert.Nil(t, SplitExpression("NOASSERTION"))
+	assert.Nil(t, SplitExpression("NONE"))
+	assert.Equal(t, []string{"MIT OR Apache-2.0"}, SplitExpression("(MIT OR Apache-2.0)"))
+	assert.Equal(t, []string{"MIT OR Apache-2.0", "ISC"}, SplitExpression("(MIT OR Apache-2.0) AND ISC"))
+	assert.Equal(t, []string{"MIT OR (Apache-2.0 AND ISC)"}, SplitExpression("MIT OR Apache-2.0 AND ISC"))
+	assert.Equal(t, []string{"not an expression ("}, SplitExpression("not an expression ("))
+}
+
+func TestSPDXExpression(t *testing.T) {
+	expression, extracted := SPDXExpression([]string{"MIT OR Apache-2.0"})
+	assert.Equal(t, "MIT OR Apache-2.0", expression)
+	assert.Nil(t, extracted)
+
+	expression, extracted = SPDXExpression([]string{"MIT OR Apache-2.0", "BSD-3-Clause", "NOASSERTION", "GPL-2.0"})
+	assert.Equal(t, "(MIT OR Apache-2.0) AND BSD-3-Clause AND GPL-2.0-only", expression)
+	assert.Nil(t, extracted)
+
+	expression, extracted = SPDXExpression([]string{"My Custom License", "Foo OR MIT", "GPL-2.0-only WITH Foo-exception"})
+	assert.Equal(t, "LicenseRef-My-Custom-License AND (LicenseRef-Foo OR MIT) AND LicenseRef-GPL-2.0-only-WITH-Foo-exception", expression)
+	assert.Equal(t, []ExtractedLicense{
+		{ID: "LicenseRef-My-Custom-License", Name: "My Custom License", Text: "My Custom License"},
+		{ID: "LicenseRef-Foo", Name: "Foo", Text: "Foo"},
+		{ID: "LicenseRef-GPL-2.0-only-WITH-Foo-exception", Name: "GPL-2.0-only WITH Foo-exception", Text: "GPL-2.0-only WITH Foo-exception"},
+	}, extracted)
+	for _, l := range SplitExpression(expression) {
+		restored := RestoreLicenseRefs(l, extracted)
+		assert.Contains(t, []string{"My Custom License", "Foo OR MIT", "GPL-2.0-only WITH Foo-exception"}, restored)
+	}
+
+	expression, extracted = SPDXExpression([]string{"NONE", ""})
+	assert.Equal(t, "", expression)
+	assert.Nil(t, extracted)
+}
+
+func TestLicenseRefID(t *testing.T) {
+	assert.Equal(t, "LicenseRef-Foo-Bar-1.0", LicenseRefID(" Foo (Bar) 1.0 "))
+	id := LicenseRefID("a very long license name which can not be used as a license ref id directly because of the length")
+	assert.Len(t, id, len(LicenseRefPrefix)+40)
+}
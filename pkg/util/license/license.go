@@ -71,7 +71,7 @@ func ParseLicenseURL(key string) (value, other string, exists bool) {
 	}
 
 	if strings.Contains(strings.ToLower(key), CDDL1AndGPL1) {
-		value := fmt.Sprintf("%s;%s", licenseLowercaseKeys["cddl-1.1"], licenseLowercaseKeys["gpl-1+"])
+		value := fmt.Sprintf("%s %s %s", licenseLowercaseKeys["cddl-1.1"], OperatorOr, licenseLowercaseKeys["gpl-1+"])
 		return value, "", true
 	}
 
@@ -110,7 +110,7 @@ func ParseLicenseName(key string) (value, other string, exists bool) {
 	}
 
 	if strings.Contains(strings.ToLower(key), CDDL1AndGPL1) {
-		value := fmt.Sprintf("%s;%s", licenseLowercaseKeys["cddl-1.1"], licenseLowercaseKeys["gpl-1+"])
+		value := fmt.Sprintf("%s %s %s", licenseLowercaseKeys["cddl-1.1"], OperatorOr, licenseLowercaseKeys["gpl-1+"])
 		return value, "", true
 	}
 
@@ -219,11 +219,9 @@ func ParseLicenseFromContent(content string) (value, other string, exists bool)
 	return licenseGuessList[0].Name, "", true
 }
 
-// SplitLicense splits the license string and returns the license ID.
+// SplitLicense appends the licenses combined by AND in the expression to the license list.
 func SplitLicense(key string, licenseList []string) []string {
-	arr := strings.Split(key, ";")
-	licenseList = append(licenseList, arr...)
-	return licenseList
+	return append(licenseList, SplitExpression(key)...)
 }
 
 // UniqueStrings returns the unique strings.
@@ -274,19 +272,6 @@ func GetLicenseByZipFile(filePath string) []string {
 	return UniqueStrings(results)
 }
 
-func CreateLicenseExpression(licenses []string) string {
-	filteredLicenses := make([]string, 0)
-	for _, license := range licenses {
-		if len(strings.TrimSpace(license)) != 0 {
-			filteredLicenses = append(filteredLicenses, license)
-		}
-	}
-	licenseExpression := strings.Join(filteredLicenses, " AND ")
-	licenseExpression = strings.TrimPrefix(licenseExpression, " AND ")
-	licenseExpression = strings.TrimSuffix(licenseExpression, " AND ")
-	return licenseExpression
-}
-
 func EnsureSingleLicense(name string) string {
 	licenseName := strings.TrimSpace(name)
 	if strings.Contains(licenseName, " or ") || strings.Contains(licenseName, " and ") {
@@ -24,7 +24,7 @@ var licenseURLItem = []struct {
 	},
 	{
 		"http://glassfish.dev.java.net/public/CDDL+GPL_1_1",
-		"CDDL-1.1;GPL-1.0-or-later",
+		"CDDL-1.1 OR GPL-1.0-or-later",
 	},
 	{
 		"http://repository.jboss.org/licenses/gpl-2.0-ce",
@@ -0,0 +1,203 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package license
+
+import (
+	"regexp"
+	"strings"
+
+	"gitee.com/JD-opensource/sbom-tool/pkg/util"
+)
+
+// maxLicenseRefLength is the max length of an id string of a LicenseRef created from a license name
+const maxLicenseRefLength = 64
+
+var regInvalidIDChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// ExtractedLicense is a license not in the SPDX license list referenced by a LicenseRef id
+type ExtractedLicense struct {
+	ID   string
+	Name string
+	Text string
+}
+
+// isNoLicense returns true for an empty license, NOASSERTION or NONE
+func isNoLicense(license string) bool {
+	return license == "" || strings.EqualFold(license, NOASSERTION_LICENSE) || strings.EqualFold(license, NONE_LICENSE)
+}
+
+// NormalizeExpression returns the normalized and simplified SPDX expression of a declared license.
+// License names and urls known by ParseLicenseName are converted to license ids,
+// a license which is neither an expression nor a known name is returned as it is
+func NormalizeExpression(s string) string {
+	s = trimOperators(s)
+	if isNoLicense(s) {
+		return strings.ToUpper(s)
+	}
+	expr, err := ParseExpression(s)
+	if err == nil {
+		expr = expr.Normalize().Simplify()
+		if expr.Validate() == nil {
+			return expr.String()
+		}
+	}
+	if value, _, _ := ParseLicenseName(s); value != "" && value != s {
+		if e, parseErr := ParseExpression(value); parseErr == nil {
+			return e.Normalize().Simplify().String()
+		}
+	}
+	if err == nil {
+		return expr.String()
+	}
+	return s
+}
+
+// trimOperators removes the whitespaces and the dangling operators around the license, e.g. " AND MIT AND " is "MIT"
+func trimOperators(s string) string {
+	tokens := strings.Fields(s)
+	for len(tokens) > 0 && isOperator(tokens[0]) {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && isOperator(tokens[len(tokens)-1]) {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return strings.Join(tokens, " ")
+}
+
+// NormalizeExpressions normalizes the licenses, removes the empty and duplicated ones
+func NormalizeExpressions(licenses []string) []string {
+	result := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		if l = NormalizeExpression(l); l != "" && !util.SliceContains(result, l) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// SplitExpression splits an expression into the operands of the top-level AND,
+// an empty expression, NOASSERTION and NONE have no license
+func SplitExpression(expression string) []string {
+	expression = strings.TrimSpace(expression)
+	if isNoLicense(expression) {
+		return nil
+	}
+	expr, err := ParseExpression(expression)
+	if err != nil {
+		return []string{expression}
+	}
+	if expr.Operator != OperatorAnd {
+		return []string{expr.String()}
+	}
+	licenses := make([]string, 0, len(expr.Operands))
+	for _, o := range expr.Operands {
+		licenses = append(licenses, o.String())
+	}
+	return licenses
+}
+
+// CombineExpression combines the licenses with AND into a valid SPDX expression, empty if there is no license.
+// Licenses not in the SPDX license list are replaced by LicenseRef ids
+func CombineExpression(licenses []string) string {
+	expression, _ := SPDXExpression(licenses)
+	return expression
+}
+
+// SPDXExpression combines the licenses with AND into a valid SPDX expression, empty if there is no license.
+// Licenses not in the SPDX license list are replaced by LicenseRef ids, which are returned with the extracted licenses
+func SPDXExpression(licenses []string) (string, []ExtractedLicense) {
+	combined := &Expression{Operator: OperatorAnd}
+	extracted := make([]ExtractedLicense, 0)
+	extract := func(name string) *Expression {
+		ref := ExtractedLicense{ID: LicenseRefID(name), Name: name, Text: name}
+		if util.SliceFirst(extracted, func(e ExtractedLicense) bool { return e.ID == ref.ID }) < 0 {
+			extracted = append(extracted, ref)
+		}
+		return &Expression{License: ref.ID}
+	}
+	for _, l := range licenses {
+		l = NormalizeExpression(l)
+		if isNoLicense(l) {
+			continue
+		}
+		expr, err := ParseExpression(l)
+		if err != nil {
+			combined.Operands = append(combined.Operands, extract(l))
+			continue
+		}
+		combined.Operands = append(combined.Operands, expr.replaceLicenses(func(e *Expression) *Expression {
+			if e.Validate() == nil {
+				return e
+			}
+			return extract(e.String())
+		}))
+	}
+	if len(combined.Operands) == 0 {
+		return "", nil
+	}
+	if len(extracted) == 0 {
+		extracted = nil
+	}
+	return combined.Simplify().String(), extracted
+}
+
+// replaceLicenses returns a copy of the expression with each license replaced by fn
+func (e *Expression) replaceLicenses(fn func(l *Expression) *Expression) *Expression {
+	if e.Operator == "" {
+		return fn(e)
+	}
+	r := &Expression{Operator: e.Operator, Operands: make([]*Expression, 0, len(e.Operands))}
+	for _, o := range e.Operands {
+		r.Operands = append(r.Operands, o.replaceLicenses(fn))
+	}
+	return r
+}
+
+// LicenseRefID returns the LicenseRef id of a license not in the SPDX license list,
+// characters not allowed in an id are replaced by "-", a long name is replaced by its hash
+func LicenseRefID(name string) string {
+	id := strings.Trim(regInvalidIDChars.ReplaceAllString(strings.TrimSpace(name), "-"), "-")
+	if id == "" || len(id) > maxLicenseRefLength {
+		sum, _ := util.SHA1SumStr(name)
+		id = sum
+	}
+	return LicenseRefPrefix + id
+}
+
+// RestoreLicenseRefs replaces the LicenseRef ids of the expression created from the extracted license names by the names
+func RestoreLicenseRefs(expression string, extracted []ExtractedLicense) string {
+	names := make(map[string]string)
+	for _, e := range extracted {
+		if e.Name != "" && LicenseRefID(e.Name) == e.ID {
+			names[e.ID] = e.Name
+		}
+	}
+	if len(names) == 0 {
+		return expression
+	}
+	expr, err := ParseExpression(expression)
+	if err != nil {
+		return expression
+	}
+	if name, ok := names[expr.License]; ok && expr.Operator == "" && expr.Exception == "" {
+		return name
+	}
+	return expr.replaceLicenses(func(l *Expression) *Expression {
+		name, ok := names[l.License]
+		if !ok || l.Exception != "" {
+			return l
+		}
+		if named, err := ParseExpression(name); err == nil && named.Operator == "" {
+			return named
+		}
+		return l
+	}).String()
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CompareVersion compares two versions and returns -1, 0 or 1,
+// versions are split into numeric and alphabetic segments, numeric segments are compared by value,
+// a release is newer than its pre-releases, e.g. 1.0.0 > 1.0.0-rc1 > 1.0.0-beta, build metadata after "+" is ignored
+func CompareVersion(v1, v2 string) int {
+	s1, s2 := versionSegments(v1), versionSegments(v2)
+	for i := 0; i < len(s1) || i < len(s2); i++ {
+		var c int
+		switch {
+		case i >= len(s1):
+			c = -compareMissingSegment(s2[i])
+		case i >= len(s2):
+			c = compareMissingSegment(s1[i])
+		default:
+			c = compareVersionSegment(s1[i], s2[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// versionSegments splits a version into numeric and alphabetic segments, separators are dropped
+func versionSegments(version string) []string {
+	version = strings.TrimSpace(version)
+	version, _, _ = strings.Cut(version, "+")
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && unicode.IsDigit(rune(version[1])) {
+		version = version[1:]
+	}
+	segments := make([]string, 0)
+	start := -1
+	for i, r := range version {
+		if !unicode.IsDigit(r) && !unicode.IsLetter(r) {
+			if start >= 0 {
+				segments = append(segments, version[start:i])
+			}
+			start = -1
+			continue
+		}
+		if start >= 0 && unicode.IsDigit(r) != unicode.IsDigit(rune(version[start])) {
+			segments = append(segments, version[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		segments = append(segments, version[start:])
+	}
+	return segments
+}
+
+// compareMissingSegment compares a segment with the end of a shorter version,
+// a numeric segment makes the version newer (1.0.1 > 1.0), an alphabetic one older (1.0-beta < 1.0),
+// zero segments are padding and the following segments decide, e.g. 1.0.0.1 > 1.0 and 1.0.0-beta < 1.0
+func compareMissingSegment(segment string) int {
+	if isNumericSegment(segment) {
+		if strings.TrimLeft(segment, "0") == "" {
+			return 0
+		}
+		return 1
+	}
+	return -1
+}
+
+func compareVersionSegment(s1, s2 string) int {
+	n1, n2 := isNumericSegment(s1), isNumericSegment(s2)
+	switch {
+	case n1 && n2:
+		s1, s2 = strings.TrimLeft(s1, "0"), strings.TrimLeft(s2, "0")
+		if len(s1) != len(s2) {
+			return compareInt(len(s1), len(s2))
+		}
+		return strings.Compare(s1, s2)
+	case n1:
+		return 1
+	case n2:
+		return -1
+	default:
+		return strings.Compare(strings.ToLower(s1), strings.ToLower(s2))
+	}
+}
+
+func isNumericSegment(segment string) bool {
+	return segment != "" && unicode.IsDigit(rune(segment[0]))
+}
+
+func compareInt(i1, i2 int) int {
+	switch {
+	case i1 < i2:
+		return -1
+	case i1 > i2:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Jingdong Technology Information Technology Co., Ltd.
+// SBOM-TOOL is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//          http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND,
+// EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT,
+// MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersion(t *testing.T) {
+	tests := []struct {
+		v1   string
+		v2   string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.0.0+build.1", "1.0.0", 0},
+		{"1.2.10", "1.2.9", 1},
+		{"1.10", "1.9.9", 1},
+		{"2.0.0", "10.0.0", -1},
+		{"1.0.1", "1.0", 1},
+		{"1.0.0.1", "1.0", 1},
+		{"1.0.0-beta", "1.0", -1},
+		{"1.0.0.0", "1", 0},
+		{"1.0.0", "1.0.0-rc1", 1},
+		{"1.0.0-rc1", "1.0.0-beta", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"2.17.1", "2.17.1.Final", 1},
+		{"1.2.3-1", "1.2.3", 1},
+		{"0.10.4", "0.10.04", 0},
+		{"65911d9", "65911d9", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v1+" "+tt.v2, func(t *testing.T) {
+			assert.Equal(t, tt.want, CompareVersion(tt.v1, tt.v2))
+			assert.Equal(t, -tt.want, CompareVersion(tt.v2, tt.v1))
+		})
+	}
+}